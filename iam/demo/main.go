@@ -154,6 +154,27 @@ func loadConfigFromEnvironment() (*auth.Config, error) {
 		MaxRetries:   3,
 	}
 
+	// Override defaults with optional settings, using the same parsing as the provider
+	if scopes := os.Getenv(auth.EnvScopes); scopes != "" {
+		config.Scopes = auth.ParseScopes(scopes)
+	}
+
+	if timeout := os.Getenv(auth.EnvTimeoutSeconds); timeout != "" {
+		parsed, err := auth.ParseTimeoutSeconds(auth.EnvTimeoutSeconds, timeout)
+		if err != nil {
+			return nil, err
+		}
+		config.Timeout = parsed
+	}
+
+	if retries := os.Getenv(auth.EnvMaxRetries); retries != "" {
+		parsed, err := auth.ParseMaxRetries(auth.EnvMaxRetries, retries)
+		if err != nil {
+			return nil, err
+		}
+		config.MaxRetries = parsed
+	}
+
 	// Override with explicit URLs if provided
 	if authURL := os.Getenv("HIIRETAIL_AUTH_URL"); authURL != "" {
 		config.AuthURL = authURL
@@ -0,0 +1,86 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+)
+
+// ApplyGroupRoles moves the roles a caller manages on a group from previous
+// to desired with the fewest changes, as a unit.
+//
+// The group's current V2 roles are diffed against desired: desired roles that
+// are missing are added, those whose bindings differ are re-posted, and roles
+// in previous that are no longer desired are removed. Roles on the group that
+// appear in neither list are left alone, so other role bindings on the same
+// group are not touched. Additions run before removals.
+//
+// Operations stop at the first failure and the ones already applied are
+// reverted in reverse order. The failure is returned as a *GroupRolesError
+// whose RolledBack field reports whether the revert succeeded; when it did
+// not, Applied lists the operations still in effect.
+func (s *Service) ApplyGroupRoles(ctx context.Context, groupID string, previous, desired []RoleBindingDto) error {
+	groupID = s.normalizeID(ctx, "group", groupID)
+
+	current, err := s.ListGroupRoles(ctx, groupID)
+	if err != nil {
+		return err
+	}
+	before := make(map[string]RoleBindingDto, len(current))
+	for _, role := range current {
+		before[groupRoleKey(role)] = role
+	}
+	managed := make(map[string]bool, len(previous))
+	for _, role := range previous {
+		managed[groupRoleKey(role)] = true
+	}
+
+	var applied []RoleOperation
+	for _, op := range diffGroupRoles(current, desired) {
+		if op.Op == RoleOperationRemove && !managed[groupRoleKey(op.Role)] {
+			continue
+		}
+
+		err := ctx.Err()
+		if err == nil {
+			err = s.applyRoleOperation(ctx, groupID, op)
+		}
+		if err != nil {
+			result := &GroupRolesError{GroupID: groupID, Failed: []RoleOperation{op}, Errs: []error{err}}
+			s.rollbackRoleOperations(ctx, groupID, applied, before, result)
+			return result
+		}
+		applied = append(applied, op)
+	}
+	return nil
+}
+
+// rollbackRoleOperations reverts applied, newest first, restoring the roles
+// in before. Reverts run even when ctx is done. Operations that cannot be
+// reverted are recorded on result as still applied.
+func (s *Service) rollbackRoleOperations(ctx context.Context, groupID string, applied []RoleOperation, before map[string]RoleBindingDto, result *GroupRolesError) {
+	ctx = context.WithoutCancel(ctx)
+
+	var remaining []RoleOperation
+	for i := len(applied) - 1; i >= 0; i-- {
+		op := applied[i]
+		if err := s.applyRoleOperation(ctx, groupID, inverseRoleOperation(op, before)); err != nil {
+			remaining = append([]RoleOperation{op}, remaining...)
+			result.Errs = append(result.Errs, fmt.Errorf("rollback of %s role %s: %w", op.Op, op.Role.RoleID, err))
+		}
+	}
+	result.Applied = remaining
+	result.RolledBack = len(remaining) == 0
+}
+
+// inverseRoleOperation returns the operation that undoes op, given the roles
+// bound before op was applied
+func inverseRoleOperation(op RoleOperation, before map[string]RoleBindingDto) RoleOperation {
+	switch op.Op {
+	case RoleOperationAdd:
+		return RoleOperation{Op: RoleOperationRemove, Role: op.Role}
+	case RoleOperationRemove:
+		return RoleOperation{Op: RoleOperationAdd, Role: op.Role}
+	default:
+		return RoleOperation{Op: RoleOperationUpdate, Role: before[groupRoleKey(op.Role)]}
+	}
+}
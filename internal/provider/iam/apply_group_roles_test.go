@@ -0,0 +1,142 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_ApplyGroupRoles(t *testing.T) {
+	cashier := RoleBindingDto{RoleID: "pos.cashier", Bindings: []string{"bu:001"}}
+	manager := RoleBindingDto{RoleID: "pos.manager", Bindings: []string{"bu:001"}}
+	other := RoleBindingDto{RoleID: "pos.viewer", Bindings: []string{"*"}}
+
+	cases := []struct {
+		name      string
+		current   []RoleBindingDto
+		previous  []RoleBindingDto
+		desired   []RoleBindingDto
+		wantCalls []string
+	}{
+		{
+			name:      "add two roles",
+			current:   []RoleBindingDto{other},
+			desired:   []RoleBindingDto{cashier, manager},
+			wantCalls: []string{"POST pos.cashier bu:001", "POST pos.manager bu:001"},
+		},
+		{
+			name:      "remove one of two",
+			current:   []RoleBindingDto{cashier, manager, other},
+			previous:  []RoleBindingDto{cashier, manager},
+			desired:   []RoleBindingDto{cashier},
+			wantCalls: []string{"DELETE pos.manager"},
+		},
+		{
+			name:      "update bindings only",
+			current:   []RoleBindingDto{cashier},
+			previous:  []RoleBindingDto{cashier},
+			desired:   []RoleBindingDto{{RoleID: "pos.cashier", Bindings: []string{"bu:002"}}},
+			wantCalls: []string{"POST pos.cashier bu:002"},
+		},
+		{
+			name:     "already in place",
+			current:  []RoleBindingDto{cashier, manager},
+			previous: []RoleBindingDto{cashier, manager},
+			desired:  []RoleBindingDto{manager, cashier},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			svc := &Service{rawClient: groupRolesMock(t, tc.current, nil, &calls), tenantID: "t"}
+
+			if err := svc.ApplyGroupRoles(context.Background(), "g1", tc.previous, tc.desired); err != nil {
+				t.Fatalf("ApplyGroupRoles failed: %v", err)
+			}
+			if !reflect.DeepEqual(calls, tc.wantCalls) {
+				t.Errorf("calls = %v, want %v", calls, tc.wantCalls)
+			}
+		})
+	}
+}
+
+func TestService_ApplyGroupRoles_MidSequenceFailureRollsBack(t *testing.T) {
+	current := []RoleBindingDto{
+		{RoleID: "pos.cashier", Bindings: []string{"bu:001"}},
+		{RoleID: "pos.auditor", Bindings: []string{"*"}},
+	}
+	previous := current
+	desired := []RoleBindingDto{
+		{RoleID: "pos.cashier", Bindings: []string{"bu:002"}},
+		{RoleID: "pos.manager", Bindings: []string{"bu:001"}},
+		{RoleID: "pos.supervisor", Bindings: []string{"bu:001"}},
+	}
+
+	var calls []string
+	svc := &Service{rawClient: groupRolesMock(t, current, map[string]bool{"pos.supervisor": true}, &calls), tenantID: "t"}
+
+	err := svc.ApplyGroupRoles(context.Background(), "g1", previous, desired)
+	var rolesErr *GroupRolesError
+	if !errors.As(err, &rolesErr) {
+		t.Fatalf("expected *GroupRolesError, got %v", err)
+	}
+	if !rolesErr.RolledBack || len(rolesErr.Applied) != 0 {
+		t.Errorf("RolledBack = %v, Applied = %v, want a full rollback", rolesErr.RolledBack, rolesErr.Applied)
+	}
+	if len(rolesErr.Failed) != 1 || rolesErr.Failed[0].Role.RoleID != "pos.supervisor" {
+		t.Errorf("Failed = %v, want the supervisor add", rolesErr.Failed)
+	}
+
+	// The removal of pos.auditor never ran; the manager add and cashier
+	// update are reverted newest first
+	want := []string{
+		"POST pos.cashier bu:002",
+		"POST pos.manager bu:001",
+		"POST pos.supervisor bu:001",
+		"DELETE pos.manager",
+		"POST pos.cashier bu:001",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+	if !strings.Contains(err.Error(), "rolled back") {
+		t.Errorf("error %q does not mention the rollback", err)
+	}
+}
+
+func TestService_ApplyGroupRoles_ReportsPartialApplication(t *testing.T) {
+	var calls []string
+	base := groupRolesMock(t, nil, map[string]bool{"pos.supervisor": true}, &calls)
+	// Removing the manager role again fails, so it stays applied
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method == "DELETE" && strings.HasSuffix(req.Path, "/pos.manager") {
+			calls = append(calls, "DELETE pos.manager")
+			return &client.Response{StatusCode: 500, Body: []byte(`{"message":"unavailable"}`)}, nil
+		}
+		return base.Do(ctx, req)
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	err := svc.ApplyGroupRoles(context.Background(), "g1", nil, []RoleBindingDto{
+		{RoleID: "pos.manager", Bindings: []string{"bu:001"}},
+		{RoleID: "pos.supervisor", Bindings: []string{"bu:001"}},
+	})
+	var rolesErr *GroupRolesError
+	if !errors.As(err, &rolesErr) {
+		t.Fatalf("expected *GroupRolesError, got %v", err)
+	}
+	if rolesErr.RolledBack {
+		t.Error("RolledBack = true, want false when the revert failed")
+	}
+	if len(rolesErr.Applied) != 1 || rolesErr.Applied[0].Role.RoleID != "pos.manager" {
+		t.Errorf("Applied = %v, want the manager add", rolesErr.Applied)
+	}
+	if !strings.Contains(err.Error(), "partially applied (add pos.manager)") {
+		t.Errorf("error %q does not report the partial application", err)
+	}
+}
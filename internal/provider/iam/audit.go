@@ -0,0 +1,115 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// Audit entity types
+const (
+	AuditEntityGroup       = "group"
+	AuditEntityCustomRole  = "custom_role"
+	AuditEntityResource    = "resource"
+	AuditEntityRoleBinding = "role_binding"
+)
+
+// Audit actions
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// RequestIDHeader is the response header carrying the API request ID
+const RequestIDHeader = "X-Request-Id"
+
+// AuditEvent describes one successful mutating API operation.
+// Before and After are JSON-shaped summaries with secret fields redacted;
+// Before is nil for creates and After is nil for deletes.
+type AuditEvent struct {
+	EntityType string
+	EntityID   string
+	Action     string
+	Before     map[string]interface{}
+	After      map[string]interface{}
+	RequestID  string
+	Timestamp  time.Time
+}
+
+// AuditSink receives an AuditEvent after each successful mutation made through the Service
+type AuditSink interface {
+	RecordAuditEvent(ctx context.Context, event AuditEvent)
+}
+
+// NoopAuditSink discards audit events. It is the Service default.
+type NoopAuditSink struct{}
+
+// RecordAuditEvent implements AuditSink
+func (NoopAuditSink) RecordAuditEvent(context.Context, AuditEvent) {}
+
+// SetAuditSink sets the sink that receives audit events; nil restores the no-op default.
+// While a sink is set, updates and deletes fetch the entity first to summarize its prior state.
+func (s *Service) SetAuditSink(sink AuditSink) {
+	if _, noop := sink.(NoopAuditSink); noop {
+		sink = nil
+	}
+	s.auditSink = sink
+}
+
+// auditEnabled reports whether a sink is configured, so callers can skip fetching before state
+func (s *Service) auditEnabled() bool {
+	return s.auditSink != nil
+}
+
+// recordAudit sends an event for a successful mutation to the configured sink
+func (s *Service) recordAudit(ctx context.Context, entityType, entityID, action string, resp *client.Response, before, after interface{}) {
+	if !s.auditEnabled() {
+		return
+	}
+
+	event := AuditEvent{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Before:     auditSummary(before),
+		After:      auditSummary(after),
+		Timestamp:  time.Now().UTC(),
+	}
+	if resp != nil {
+		event.RequestID = resp.Headers.Get(RequestIDHeader)
+	}
+	s.auditSink.RecordAuditEvent(ctx, event)
+}
+
+// auditSummary converts an entity or request body to a redacted JSON-shaped map.
+// It returns nil for nil values and anything that does not encode as a JSON object.
+func auditSummary(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var summary map[string]interface{}
+	if err := json.Unmarshal(data, &summary); err != nil || summary == nil {
+		return nil
+	}
+	return client.RedactFields(summary)
+}
+
+// auditBefore fetches the current state of an entity for an update or delete event.
+// It returns nil when no sink is configured or the entity cannot be read.
+func (s *Service) auditBefore(get func() (interface{}, error)) interface{} {
+	if !s.auditEnabled() {
+		return nil
+	}
+	v, err := get()
+	if err != nil {
+		return nil
+	}
+	return v
+}
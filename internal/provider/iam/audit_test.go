@@ -0,0 +1,118 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+type recordingAuditSink struct {
+	events []AuditEvent
+}
+
+func (r *recordingAuditSink) RecordAuditEvent(_ context.Context, event AuditEvent) {
+	r.events = append(r.events, event)
+}
+
+// auditMock serves group g1 and resource r1 and counts the requests it sees
+func auditMock(requests *int) *MockClient {
+	headers := http.Header{}
+	headers.Set(RequestIDHeader, "req-123")
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		*requests++
+		switch req.Method + " " + req.Path {
+		case "POST /api/v1/tenants/t/groups":
+			return &client.Response{StatusCode: 201, Headers: headers, Body: []byte(`{"id":"g1","name":"ops"}`)}, nil
+		case "GET /api/v1/tenants/t/groups/g1":
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"g1","name":"ops"}`)}, nil
+		case "PUT /api/v1/tenants/t/groups/g1":
+			return &client.Response{StatusCode: 200, Headers: headers, Body: []byte(`{"id":"g1","name":"ops-renamed"}`)}, nil
+		case "DELETE /api/v1/tenants/t/groups/g1":
+			return &client.Response{StatusCode: 204, Headers: headers}, nil
+		case "GET /api/v1/tenants/t/resources/r1":
+			return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+		case "PUT /api/v1/tenants/t/resources/r1":
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"r1","name":"store","props":{"api_key":"k","region":"eu"}}`)}, nil
+		}
+		return nil, errors.New("unexpected request " + req.Method + " " + req.Path)
+	}}
+}
+
+func TestService_AuditEvents(t *testing.T) {
+	var requests int
+	sink := &recordingAuditSink{}
+	svc := &Service{rawClient: auditMock(&requests), tenantID: "t"}
+	svc.SetAuditSink(sink)
+	ctx := context.Background()
+
+	if _, err := svc.CreateGroup(ctx, &Group{Name: "ops"}); err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	if _, err := svc.UpdateGroup(ctx, "g1", &Group{Name: "ops-renamed"}); err != nil {
+		t.Fatalf("UpdateGroup failed: %v", err)
+	}
+	if err := svc.DeleteGroup(ctx, "g1"); err != nil {
+		t.Fatalf("DeleteGroup failed: %v", err)
+	}
+	if _, err := svc.SetResource(ctx, "r1", &SetResourceDto{Name: "store"}); err != nil {
+		t.Fatalf("SetResource failed: %v", err)
+	}
+
+	want := []struct {
+		entity, id, action    string
+		beforeName, afterName interface{}
+	}{
+		{AuditEntityGroup, "g1", AuditActionCreate, nil, "ops"},
+		{AuditEntityGroup, "g1", AuditActionUpdate, "ops", "ops-renamed"},
+		{AuditEntityGroup, "g1", AuditActionDelete, "ops", nil},
+		{AuditEntityResource, "r1", AuditActionCreate, nil, "store"},
+	}
+	if len(sink.events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(sink.events), len(want), sink.events)
+	}
+	for i, w := range want {
+		e := sink.events[i]
+		if e.EntityType != w.entity || e.EntityID != w.id || e.Action != w.action {
+			t.Errorf("event %d = %s %s %s, want %s %s %s", i, e.EntityType, e.EntityID, e.Action, w.entity, w.id, w.action)
+		}
+		if got := summaryName(e.Before); got != w.beforeName {
+			t.Errorf("event %d before name = %v, want %v", i, got, w.beforeName)
+		}
+		if got := summaryName(e.After); got != w.afterName {
+			t.Errorf("event %d after name = %v, want %v", i, got, w.afterName)
+		}
+		if e.Timestamp.IsZero() {
+			t.Errorf("event %d has no timestamp", i)
+		}
+	}
+
+	if sink.events[0].RequestID != "req-123" {
+		t.Errorf("RequestID = %q, want req-123", sink.events[0].RequestID)
+	}
+	props := sink.events[3].After["props"].(map[string]interface{})
+	if props["api_key"] != "[REDACTED]" || props["region"] != "eu" {
+		t.Errorf("expected api_key to be redacted and region kept, got %v", props)
+	}
+}
+
+func TestService_AuditEvents_NoopByDefault(t *testing.T) {
+	var requests int
+	svc := &Service{rawClient: auditMock(&requests), tenantID: "t"}
+
+	if err := svc.DeleteGroup(context.Background(), "g1"); err != nil {
+		t.Fatalf("DeleteGroup failed: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("expected no extra requests without a sink, got %d requests", requests)
+	}
+}
+
+func summaryName(summary map[string]interface{}) interface{} {
+	if summary == nil {
+		return nil
+	}
+	return summary["name"]
+}
@@ -0,0 +1,247 @@
+package iam
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultReadCacheTTL is the default lifetime of a cached read when the read
+// cache is enabled without an explicit TTL. It is intentionally short so the
+// cache only deduplicates reads within a single apply.
+const DefaultReadCacheTTL = 30 * time.Second
+
+// Entity kinds used as the first half of read cache keys
+const (
+	cacheKindGroup      = "group"
+	cacheKindCustomRole = "custom_role"
+	cacheKindRole       = "role"
+	cacheKindResource   = "resource"
+)
+
+// readCache is a small in-memory cache of entities read during a single run.
+// Entries are keyed by entity kind and ID and expire after a fixed TTL.
+type readCache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	now        func() time.Time
+	entries    map[readCacheKey]readCacheEntry
+	generation uint64 // Incremented by every invalidation, see readCacheFill
+}
+
+type readCacheKey struct {
+	kind string
+	id   string
+}
+
+type readCacheEntry struct {
+	value     interface{}
+	expiresAt time.Time
+}
+
+func newReadCache(ttl time.Duration) *readCache {
+	if ttl <= 0 {
+		ttl = DefaultReadCacheTTL
+	}
+	return &readCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[readCacheKey]readCacheEntry),
+	}
+}
+
+// get returns the cached value for kind/id if present and not expired
+func (c *readCache) get(kind, id string) (interface{}, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := readCacheKey{kind: kind, id: id}
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return cloneCacheValue(entry.value), true
+}
+
+// readCacheFill stores the result of one read. It remembers the cache
+// generation from before the request, so a read that overlaps a write and
+// finishes after the write's invalidation does not cache what the write
+// replaced.
+type readCacheFill struct {
+	cache      *readCache
+	generation uint64
+}
+
+// startFill returns the fill for a read about to be sent
+func (c *readCache) startFill() readCacheFill {
+	if c == nil {
+		return readCacheFill{}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return readCacheFill{cache: c, generation: c.generation}
+}
+
+// set stores value for kind/id unless the cache was invalidated since the
+// fill started
+func (f readCacheFill) set(kind, id string, value interface{}) {
+	f.cache.set(kind, id, value, f.generation)
+}
+
+// set stores value for kind/id for the configured TTL, if nothing was
+// invalidated since generation
+func (c *readCache) set(kind, id string, value interface{}, generation uint64) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation != generation {
+		return
+	}
+	c.entries[readCacheKey{kind: kind, id: id}] = readCacheEntry{
+		value:     cloneCacheValue(value),
+		expiresAt: c.now().Add(c.ttl),
+	}
+}
+
+// invalidate removes the cached value for kind/id
+func (c *readCache) invalidate(kind, id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, readCacheKey{kind: kind, id: id})
+	c.generation++
+}
+
+// clear removes every cached value
+func (c *readCache) clear() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[readCacheKey]readCacheEntry)
+	c.generation++
+}
+
+// cloneCacheValue returns a deep copy of a cached entity, so callers that
+// modify the slices or maps of a returned value do not change the cache
+func cloneCacheValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case Group:
+		v.Members = slices.Clone(v.Members)
+		return v
+	case CustomRole:
+		if v.Permissions != nil {
+			permissions := make([]Permission, len(v.Permissions))
+			for i, permission := range v.Permissions {
+				if permission.Attributes != nil {
+					permission.Attributes = cloneJSONValue(permission.Attributes).(map[string]interface{})
+				}
+				permissions[i] = permission
+			}
+			v.Permissions = permissions
+		}
+		return v
+	case Resource:
+		v.Props = cloneJSONValue(v.Props)
+		return v
+	default:
+		return value
+	}
+}
+
+// cloneJSONValue deep copies the objects and arrays of a decoded JSON value
+func cloneJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		clone := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			clone[key] = cloneJSONValue(elem)
+		}
+		return clone
+	case []interface{}:
+		clone := make([]interface{}, len(v))
+		for i, elem := range v {
+			clone[i] = cloneJSONValue(elem)
+		}
+		return clone
+	default:
+		return value
+	}
+}
+
+// EnableReadCache turns on the per-run read cache for GetGroup, GetRole,
+// GetCustomRole, CustomRoleExists and GetResource. Writes through this Service
+// invalidate the affected entry once the API has answered. A ttl of zero uses
+// DefaultReadCacheTTL. It is safe to call while requests are in flight.
+func (s *Service) EnableReadCache(ttl time.Duration) {
+	s.cache.Store(newReadCache(ttl))
+}
+
+// DisableReadCache turns off the read cache and drops any cached entries
+func (s *Service) DisableReadCache() {
+	s.cache.Swap(nil).clear()
+}
+
+// invalidateGroup drops the cached reads of group id, from the read cache and
+// from the operation cache of ctx
+func (s *Service) invalidateGroup(ctx context.Context, id string) {
+	s.cache.Load().invalidate(cacheKindGroup, id)
+	operationCacheFrom(ctx).invalidateGroup(id)
+}
+
+// cachedGroup returns a copy of a cached group
+func (s *Service) cachedGroup(id string) (*Group, bool) {
+	v, ok := s.cache.Load().get(cacheKindGroup, id)
+	if !ok {
+		return nil, false
+	}
+	group := v.(Group)
+	return &group, true
+}
+
+// cachedCustomRole returns a copy of a cached custom role
+func (s *Service) cachedCustomRole(id string) (*CustomRole, bool) {
+	v, ok := s.cache.Load().get(cacheKindCustomRole, id)
+	if !ok {
+		return nil, false
+	}
+	role := v.(CustomRole)
+	return &role, true
+}
+
+// cachedRole returns a copy of a cached role
+func (s *Service) cachedRole(id string) (*Role, bool) {
+	v, ok := s.cache.Load().get(cacheKindRole, id)
+	if !ok {
+		return nil, false
+	}
+	role := v.(Role)
+	return &role, true
+}
+
+// cachedResource returns a copy of a cached resource
+func (s *Service) cachedResource(id string) (*Resource, bool) {
+	v, ok := s.cache.Load().get(cacheKindResource, id)
+	if !ok {
+		return nil, false
+	}
+	resource := v.(Resource)
+	return &resource, true
+}
@@ -0,0 +1,288 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_ReadCache_RepeatedReadsHitNetworkOnce(t *testing.T) {
+	body, _ := json.Marshal(Group{ID: "g1", Name: "Group1"})
+	var gets int32
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method == "GET" {
+			atomic.AddInt32(&gets, 1)
+		}
+		return &client.Response{StatusCode: 200, Body: body}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.EnableReadCache(time.Minute)
+
+	var wg sync.WaitGroup
+	// Prime the cache so concurrent readers all observe a hit
+	if _, err := svc.GetGroup(context.Background(), "g1"); err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g, err := svc.GetGroup(context.Background(), "g1")
+			if err != nil {
+				t.Errorf("GetGroup failed: %v", err)
+				return
+			}
+			if g.Name != "Group1" {
+				t.Errorf("got name %q want Group1", g.Name)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&gets); got != 1 {
+		t.Fatalf("expected 1 GET, got %d", got)
+	}
+}
+
+func TestService_ReadCache_WriteInvalidates(t *testing.T) {
+	var gets int32
+	var name atomic.Value
+	name.Store("Before")
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch req.Method {
+		case "GET":
+			atomic.AddInt32(&gets, 1)
+			body, _ := json.Marshal(Group{ID: "g1", Name: name.Load().(string)})
+			return &client.Response{StatusCode: 200, Body: body}, nil
+		case "PUT":
+			name.Store("After")
+			body, _ := json.Marshal(Group{ID: "g1", Name: "After"})
+			return &client.Response{StatusCode: 200, Body: body}, nil
+		}
+		return &client.Response{StatusCode: 204}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.EnableReadCache(time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = svc.GetGroup(context.Background(), "g1")
+		}()
+	}
+	wg.Wait()
+	before := atomic.LoadInt32(&gets)
+
+	if _, err := svc.UpdateGroup(context.Background(), "g1", &Group{Name: "After"}); err != nil {
+		t.Fatalf("UpdateGroup failed: %v", err)
+	}
+
+	g, err := svc.GetGroup(context.Background(), "g1")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if g.Name != "After" {
+		t.Fatalf("expected fresh read after write, got %q", g.Name)
+	}
+	if got := atomic.LoadInt32(&gets); got != before+1 {
+		t.Fatalf("expected one extra GET after write, got %d (before %d)", got, before)
+	}
+
+	// Delete must invalidate as well
+	if err := svc.DeleteGroup(context.Background(), "g1"); err != nil {
+		t.Fatalf("DeleteGroup failed: %v", err)
+	}
+	if _, ok := svc.cachedGroup("g1"); ok {
+		t.Fatalf("expected cache entry to be removed after delete")
+	}
+}
+
+func TestService_ReadCache_ExpiresAndDisabled(t *testing.T) {
+	var gets int32
+	body, _ := json.Marshal(CustomRole{ID: "cr1"})
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		atomic.AddInt32(&gets, 1)
+		return &client.Response{StatusCode: 200, Body: body}, nil
+	}}
+
+	// Disabled by default
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	_, _ = svc.GetCustomRole(context.Background(), "cr1")
+	_, _ = svc.GetCustomRole(context.Background(), "cr1")
+	if got := atomic.LoadInt32(&gets); got != 2 {
+		t.Fatalf("expected 2 GETs without cache, got %d", got)
+	}
+
+	// Expired entries are refetched
+	svc.EnableReadCache(time.Minute)
+	now := time.Now()
+	svc.cache.Load().now = func() time.Time { return now }
+	_, _ = svc.GetCustomRole(context.Background(), "cr1")
+	now = now.Add(2 * time.Minute)
+	_, _ = svc.GetCustomRole(context.Background(), "cr1")
+	if got := atomic.LoadInt32(&gets); got != 4 {
+		t.Fatalf("expected expired entry to be refetched, got %d GETs", got)
+	}
+
+	svc.DisableReadCache()
+	_, _ = svc.GetCustomRole(context.Background(), "cr1")
+	if got := atomic.LoadInt32(&gets); got != 5 {
+		t.Fatalf("expected GET after disabling cache, got %d", got)
+	}
+}
+
+func TestService_ReadCache_ReadDuringWriteIsNotKept(t *testing.T) {
+	var name atomic.Value
+	name.Store("Before")
+	var svc *Service
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch req.Method {
+		case "GET":
+			body, _ := json.Marshal(Group{ID: "g1", Name: name.Load().(string)})
+			return &client.Response{StatusCode: 200, Body: body}, nil
+		case "PUT":
+			// A concurrent read caches the group before the write is applied
+			if _, err := svc.GetGroup(context.Background(), "g1"); err != nil {
+				t.Errorf("GetGroup during write failed: %v", err)
+			}
+			name.Store("After")
+			body, _ := json.Marshal(Group{ID: "g1", Name: "After"})
+			return &client.Response{StatusCode: 200, Body: body}, nil
+		}
+		return &client.Response{StatusCode: 204}, nil
+	}}
+	svc = &Service{rawClient: mock, tenantID: "t"}
+	svc.EnableReadCache(time.Minute)
+
+	if _, err := svc.UpdateGroup(context.Background(), "g1", &Group{Name: "After"}); err != nil {
+		t.Fatalf("UpdateGroup failed: %v", err)
+	}
+	g, err := svc.GetGroup(context.Background(), "g1")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if g.Name != "After" {
+		t.Fatalf("expected the group read during the write to be dropped, got %q", g.Name)
+	}
+}
+
+func TestService_ReadCache_ReadOverlappingWriteIsNotKept(t *testing.T) {
+	var name atomic.Value
+	name.Store("Before")
+	readSent := make(chan struct{})
+	releaseRead := make(chan struct{})
+	var gets int32
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch req.Method {
+		case "GET":
+			body, _ := json.Marshal(Group{ID: "g1", Name: name.Load().(string)})
+			if atomic.AddInt32(&gets, 1) == 1 {
+				// The first read answers with the old group, but only after
+				// the write below has finished and invalidated the cache
+				close(readSent)
+				<-releaseRead
+			}
+			return &client.Response{StatusCode: 200, Body: body}, nil
+		case "PUT":
+			name.Store("After")
+			body, _ := json.Marshal(Group{ID: "g1", Name: "After"})
+			return &client.Response{StatusCode: 200, Body: body}, nil
+		}
+		return &client.Response{StatusCode: 204}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.EnableReadCache(time.Minute)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := svc.GetGroup(context.Background(), "g1"); err != nil {
+			t.Errorf("GetGroup failed: %v", err)
+		}
+	}()
+	<-readSent
+	if _, err := svc.UpdateGroup(context.Background(), "g1", &Group{Name: "After"}); err != nil {
+		t.Fatalf("UpdateGroup failed: %v", err)
+	}
+	close(releaseRead)
+	<-done
+
+	g, err := svc.GetGroup(context.Background(), "g1")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if g.Name != "After" {
+		t.Fatalf("expected the read that overlapped the write not to be cached, got %q", g.Name)
+	}
+}
+
+func TestService_ReadCache_ReturnsCopies(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		var body []byte
+		if strings.Contains(req.Path, "/groups/") {
+			body, _ = json.Marshal(Group{ID: "g1", Members: []string{"user:a"}})
+		} else {
+			body, _ = json.Marshal(CustomRole{ID: "cr1", Permissions: []Permission{
+				{ID: "pos.payment.create", Attributes: map[string]interface{}{"limit": []interface{}{"1"}}},
+			}})
+		}
+		return &client.Response{StatusCode: 200, Body: body}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.EnableReadCache(time.Minute)
+
+	group, err := svc.GetGroup(context.Background(), "g1")
+	if err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	group.Members[0] = "user:changed"
+	cachedGroup, _ := svc.cachedGroup("g1")
+	cachedGroup.Members[0] = "user:changed-again"
+	if again, _ := svc.GetGroup(context.Background(), "g1"); again.Members[0] != "user:a" {
+		t.Fatalf("cached group members changed through a returned value: %v", again.Members)
+	}
+
+	role, err := svc.GetCustomRole(context.Background(), "cr1")
+	if err != nil {
+		t.Fatalf("GetCustomRole failed: %v", err)
+	}
+	role.Permissions[0].ID = "changed"
+	role.Permissions[0].Attributes["limit"].([]interface{})[0] = "changed"
+	again, _ := svc.GetCustomRole(context.Background(), "cr1")
+	if again.Permissions[0].ID != "pos.payment.create" || again.Permissions[0].Attributes["limit"].([]interface{})[0] != "1" {
+		t.Fatalf("cached custom role changed through a returned value: %+v", again.Permissions[0])
+	}
+}
+
+func TestService_ReadCache_EnableDuringReads(t *testing.T) {
+	body, _ := json.Marshal(Group{ID: "g1", Name: "Group1"})
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		return &client.Response{StatusCode: 200, Body: body}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := svc.GetGroup(context.Background(), "g1"); err != nil {
+				t.Errorf("GetGroup failed: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			svc.EnableReadCache(time.Minute)
+			svc.DisableReadCache()
+		}()
+	}
+	wg.Wait()
+}
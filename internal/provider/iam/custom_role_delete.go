@@ -0,0 +1,114 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// DeleteOptions controls DeleteCustomRole
+type DeleteOptions struct {
+	// Cascade removes the role from every group bound to it before deleting
+	// the role definition. The role is only deleted once every removal has
+	// succeeded; failures are returned together as a *CustomRoleBindingsError.
+	Cascade bool
+}
+
+// CustomRoleBindingsError reports the groups a cascading DeleteCustomRole
+// could not remove the role from, keyed by group ID. The role itself was not
+// deleted.
+type CustomRoleBindingsError struct {
+	RoleID string
+	Failed map[string]error
+}
+
+func (e *CustomRoleBindingsError) Error() string {
+	ids := sortedKeys(e.Failed)
+	causes := make([]string, len(ids))
+	for i, id := range ids {
+		causes[i] = fmt.Sprintf("%s: %v", id, e.Failed[id])
+	}
+	return fmt.Sprintf("custom role %s was not deleted: failed to remove it from %d group(s): %s",
+		e.RoleID, len(ids), strings.Join(causes, "; "))
+}
+
+func (e *CustomRoleBindingsError) Unwrap() []error {
+	ids := sortedKeys(e.Failed)
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		errs[i] = e.Failed[id]
+	}
+	return errs
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]error) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// groupsWithCustomRole returns the IDs of the groups bound to custom role
+// roleID, reading the roles of every group through the V2 endpoints
+func (s *Service) groupsWithCustomRole(ctx context.Context, roleID string) ([]string, error) {
+	var groupIDs []string
+	err := s.EachGroup(ctx, &ListGroupsRequest{}, func(g Group) error {
+		roles, err := s.ListGroupRoles(ctx, g.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list roles of group %s: %w", g.ID, err)
+		}
+		for _, role := range roles {
+			if role.IsCustom && plainRoleID(role) == roleID {
+				groupIDs = append(groupIDs, g.ID)
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groupIDs, nil
+}
+
+// removeCustomRoleBindings removes custom role roleID from every group bound
+// to it. Every removal is attempted; failures are aggregated.
+func (s *Service) removeCustomRoleBindings(ctx context.Context, roleID string) error {
+	groupIDs, err := s.groupsWithCustomRole(ctx, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to find groups bound to custom role %s: %w", roleID, err)
+	}
+
+	failed := make(map[string]error)
+	for _, groupID := range groupIDs {
+		if err := s.RemoveRoleFromGroup(ctx, groupID, roleID, true); err != nil {
+			failed[groupID] = err
+		}
+	}
+	if len(failed) > 0 {
+		return &CustomRoleBindingsError{RoleID: roleID, Failed: failed}
+	}
+	return nil
+}
+
+// customRoleInUseError rewrites the 409 the API returns for a custom role
+// still bound to groups, naming the groups when they can be listed. It stays
+// a *client.Error so IsConflictError still matches it.
+func (s *Service) customRoleInUseError(ctx context.Context, roleID string, apiErr *client.Error) error {
+	message := fmt.Sprintf("custom role %s is still bound to groups", roleID)
+	if groupIDs, err := s.groupsWithCustomRole(ctx, roleID); err == nil && len(groupIDs) > 0 {
+		message = fmt.Sprintf("custom role %s is still bound to group(s) %s", roleID, strings.Join(groupIDs, ", "))
+	}
+	return &client.Error{
+		StatusCode: apiErr.StatusCode,
+		Message:    message + "; remove those role bindings first or delete with cascade",
+		Code:       apiErr.Code,
+		Details:    apiErr.Message,
+	}
+}
@@ -0,0 +1,108 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// customRoleBindingsMock serves three groups, g1 and g2 bound to the custom
+// role Auditor, and records every write. failRemoval makes removing the role
+// from that group fail; roleDeleteStatus is the status of the role DELETE.
+type customRoleBindingsMock struct {
+	failRemoval      string
+	roleDeleteStatus int
+	writes           []string
+}
+
+func (m *customRoleBindingsMock) client() *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method != "GET" {
+			m.writes = append(m.writes, req.Method+" "+req.Path)
+		}
+		switch {
+		case req.Method == "GET" && req.Path == "/api/v1/tenants/t/groups":
+			return &client.Response{StatusCode: 200, Body: []byte(`{"groups":[{"id":"g1","name":"cashiers"},{"id":"g2","name":"auditors"},{"id":"g3","name":"managers"}]}`)}, nil
+		case req.Method == "GET" && req.Path == "/api/v2/tenants/t/groups/g1/roles":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"roleId":"custom.Auditor","isCustom":true,"bindings":["bu:001"]},{"roleId":"pos.admin","isCustom":false}]`)}, nil
+		case req.Method == "GET" && req.Path == "/api/v2/tenants/t/groups/g2/roles":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"roleId":"custom-roles/custom.Auditor","isCustom":true}]`)}, nil
+		case req.Method == "GET" && req.Path == "/api/v2/tenants/t/groups/g3/roles":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"roleId":"Auditor","isCustom":false},{"roleId":"custom.Other","isCustom":true}]`)}, nil
+		case req.Method == "DELETE" && strings.HasPrefix(req.Path, "/api/v2/tenants/t/groups/"):
+			if m.failRemoval != "" && strings.Contains(req.Path, "/groups/"+m.failRemoval+"/") {
+				return &client.Response{StatusCode: 500, Body: []byte(`{"message":"boom"}`)}, nil
+			}
+			return &client.Response{StatusCode: 204}, nil
+		case req.Method == "DELETE" && req.Path == "/api/v1/tenants/t/roles/Auditor":
+			if m.roleDeleteStatus != 0 {
+				return &client.Response{StatusCode: m.roleDeleteStatus, Body: []byte(`{"message":"role is in use","code":"FAILED_PRECONDITION"}`)}, nil
+			}
+			return &client.Response{StatusCode: 204}, nil
+		}
+		return nil, errors.New("unexpected request " + req.Method + " " + req.Path)
+	}}
+}
+
+func TestService_DeleteCustomRole_Cascade(t *testing.T) {
+	mock := &customRoleBindingsMock{}
+	svc := &Service{rawClient: mock.client(), tenantID: "t"}
+
+	if err := svc.DeleteCustomRole(context.Background(), "Auditor", DeleteOptions{Cascade: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		"DELETE /api/v2/tenants/t/groups/g1/roles/Auditor",
+		"DELETE /api/v2/tenants/t/groups/g2/roles/Auditor",
+		"DELETE /api/v1/tenants/t/roles/Auditor",
+	}
+	if !reflect.DeepEqual(mock.writes, want) {
+		t.Errorf("writes = %v, want %v", mock.writes, want)
+	}
+}
+
+func TestService_DeleteCustomRole_CascadeFailure(t *testing.T) {
+	mock := &customRoleBindingsMock{failRemoval: "g1"}
+	svc := &Service{rawClient: mock.client(), tenantID: "t"}
+
+	err := svc.DeleteCustomRole(context.Background(), "Auditor", DeleteOptions{Cascade: true})
+	var bindingsErr *CustomRoleBindingsError
+	if !errors.As(err, &bindingsErr) {
+		t.Fatalf("expected a *CustomRoleBindingsError, got %v", err)
+	}
+	if len(bindingsErr.Failed) != 1 || bindingsErr.Failed["g1"] == nil {
+		t.Errorf("failed = %v, want only g1", bindingsErr.Failed)
+	}
+	if !client.IsServerError(bindingsErr.Failed["g1"]) {
+		t.Errorf("g1 error = %v, want the API error", bindingsErr.Failed["g1"])
+	}
+	for _, write := range mock.writes {
+		if write == "DELETE /api/v1/tenants/t/roles/Auditor" {
+			t.Error("the role was deleted although a binding could not be removed")
+		}
+	}
+	if len(mock.writes) != 2 {
+		t.Errorf("writes = %v, want both removals attempted", mock.writes)
+	}
+}
+
+func TestService_DeleteCustomRole_BlockedWithoutCascade(t *testing.T) {
+	mock := &customRoleBindingsMock{roleDeleteStatus: 409}
+	svc := &Service{rawClient: mock.client(), tenantID: "t"}
+
+	err := svc.DeleteCustomRole(context.Background(), "Auditor", DeleteOptions{})
+	if !client.IsConflictError(err) {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "still bound to group(s) g1, g2") || !strings.Contains(err.Error(), "cascade") {
+		t.Errorf("error = %q, want it to name the groups and suggest cascade", err)
+	}
+	if want := []string{"DELETE /api/v1/tenants/t/roles/Auditor"}; !reflect.DeepEqual(mock.writes, want) {
+		t.Errorf("writes = %v, want only the role delete", mock.writes)
+	}
+}
@@ -0,0 +1,55 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// CustomRoleExists reports whether the custom role exists in the tenant.
+// With the read cache enabled, a role found by an earlier check or read within
+// its TTL is answered without a request. A missing role is not cached, so a
+// role created meanwhile, through this Service or not, is found by the next
+// check.
+func (s *Service) CustomRoleExists(ctx context.Context, id string) (bool, error) {
+	id = s.normalizeID(ctx, "custom role", id)
+	cache := operationCacheFrom(ctx)
+	if cache.hasCustomRole(id) {
+		return true, nil
+	}
+	if _, ok := s.cachedCustomRole(id); ok {
+		cache.setCustomRole(id)
+		return true, nil
+	}
+
+	// GetCustomRole caches the role it finds
+	_, err := s.GetCustomRole(ctx, id)
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	cache.setCustomRole(id)
+	return true, nil
+}
+
+// invalidateCustomRole drops every cached read for the custom role, from the
+// read cache and from the operation cache of ctx
+func (s *Service) invalidateCustomRole(ctx context.Context, id string) {
+	s.cache.Load().invalidate(cacheKindCustomRole, id)
+	operationCacheFrom(ctx).invalidateCustomRole(id)
+}
+
+// requireCustomRole returns an error unless the custom role exists
+func (s *Service) requireCustomRole(ctx context.Context, id string) error {
+	exists, err := s.CustomRoleExists(ctx, id)
+	if err != nil {
+		return fmt.Errorf("custom role %s not found or inaccessible: %w", id, err)
+	}
+	if !exists {
+		return fmt.Errorf("custom role %s not found or inaccessible: %w", id, &client.Error{StatusCode: 404, Message: "custom role not found"})
+	}
+	return nil
+}
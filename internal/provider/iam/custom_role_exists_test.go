@@ -0,0 +1,147 @@
+package iam
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_CustomRoleExists_ServedFromCache(t *testing.T) {
+	var gets int32
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method == "GET" {
+			atomic.AddInt32(&gets, 1)
+			if strings.HasSuffix(req.Path, "/roles/missing") {
+				return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+			}
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"cr1","permissions":[]}`)}, nil
+		}
+		return &client.Response{StatusCode: 201, Body: []byte(`{}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.EnableReadCache(time.Minute)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		exists, err := svc.CustomRoleExists(ctx, "cr1")
+		if err != nil || !exists {
+			t.Fatalf("CustomRoleExists(cr1) = %v, %v; want true", exists, err)
+		}
+		exists, err = svc.CustomRoleExists(ctx, "missing")
+		if err != nil || exists {
+			t.Fatalf("CustomRoleExists(missing) = %v, %v; want false", exists, err)
+		}
+	}
+	// The existing role is fetched once, the missing one on every check
+	if got := atomic.LoadInt32(&gets); got != 3 {
+		t.Fatalf("expected 3 GETs, repeated checks of cr1 should be cached; got %d", got)
+	}
+
+	// Adding many roles validates each custom role once
+	for i := 0; i < 3; i++ {
+		if err := svc.AddRoleToGroup(ctx, "g1", "cr1", true, nil); err != nil {
+			t.Fatalf("AddRoleToGroup failed: %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&gets); got != 3 {
+		t.Fatalf("AddRoleToGroup should reuse the cached check; got %d GETs", got)
+	}
+	if err := svc.AddRoleToGroup(ctx, "g1", "missing", true, nil); !client.IsNotFoundError(err) {
+		t.Fatalf("AddRoleToGroup(missing) error = %v, want not found", err)
+	}
+}
+
+func TestService_CustomRoleExists_InvalidatedByWrites(t *testing.T) {
+	var gets int32
+	deleted := false
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch req.Method {
+		case "GET":
+			atomic.AddInt32(&gets, 1)
+			if deleted {
+				return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+			}
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"cr1","permissions":[]}`)}, nil
+		case "DELETE":
+			deleted = true
+			return &client.Response{StatusCode: 204}, nil
+		case "POST":
+			deleted = false
+			return &client.Response{StatusCode: 201, Body: []byte(`{"id":"cr1","permissions":[]}`)}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"cr1","permissions":[]}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.EnableReadCache(time.Minute)
+	ctx := context.Background()
+
+	check := func(want bool) {
+		t.Helper()
+		exists, err := svc.CustomRoleExists(ctx, "cr1")
+		if err != nil || exists != want {
+			t.Fatalf("CustomRoleExists(cr1) = %v, %v; want %v", exists, err, want)
+		}
+	}
+
+	check(true)
+	if err := svc.DeleteCustomRole(ctx, "cr1", DeleteOptions{}); err != nil {
+		t.Fatalf("DeleteCustomRole failed: %v", err)
+	}
+	check(false)
+	check(false)
+	if _, err := svc.CreateCustomRole(ctx, &CustomRole{ID: "cr1"}); err != nil {
+		t.Fatalf("CreateCustomRole failed: %v", err)
+	}
+	check(true)
+
+	// One GET before the delete, one per check of the deleted role and one
+	// after the create
+	if got := atomic.LoadInt32(&gets); got != 4 {
+		t.Fatalf("expected 4 GETs, got %d", got)
+	}
+}
+
+func TestService_CustomRoleExists_NoCache(t *testing.T) {
+	var gets int32
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		atomic.AddInt32(&gets, 1)
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"cr1"}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	for i := 0; i < 2; i++ {
+		if exists, err := svc.CustomRoleExists(context.Background(), "cr1"); err != nil || !exists {
+			t.Fatalf("CustomRoleExists = %v, %v", exists, err)
+		}
+	}
+	if got := atomic.LoadInt32(&gets); got != 2 {
+		t.Fatalf("without the read cache every check should fetch, got %d", got)
+	}
+}
+
+func TestService_CustomRoleExists_MissingRoleNotCached(t *testing.T) {
+	var created atomic.Bool
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if !created.Load() {
+			return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"cr1","permissions":[]}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.EnableReadCache(time.Minute)
+	ctx := context.Background()
+
+	if exists, err := svc.CustomRoleExists(ctx, "cr1"); err != nil || exists {
+		t.Fatalf("CustomRoleExists(cr1) = %v, %v; want false", exists, err)
+	}
+
+	// Created by another provider instance, not through svc
+	created.Store(true)
+	if exists, err := svc.CustomRoleExists(ctx, "cr1"); err != nil || !exists {
+		t.Fatalf("CustomRoleExists(cr1) = %v, %v; want the role created meanwhile to be found", exists, err)
+	}
+}
@@ -0,0 +1,149 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	// MaxPOSPermissions is the number of pos.* permissions allowed per custom role
+	MaxPOSPermissions = 500
+	// MaxGeneralPermissions is the number of non-POS permissions allowed per custom role
+	MaxGeneralPermissions = 100
+)
+
+// PermissionIDRegexp is the permission id format enforced by the IAM API
+var PermissionIDRegexp = regexp.MustCompile(`^[a-z][-a-z]{2}\.[a-z][-a-z]{1,15}\.[a-z][-a-z]{1,15}$`)
+
+// InvalidPermissionIDError reports a permission id that does not match PermissionIDRegexp
+type InvalidPermissionIDError struct {
+	Index int // Position of the id in the validated ids
+	ID    string
+}
+
+func (e *InvalidPermissionIDError) Error() string {
+	return fmt.Sprintf("Permission id %q must follow the pattern {systemPrefix}.{resource}.{action} "+
+		"(lowercase a-z letters and hyphens, a 3 character system prefix and 2-16 character resource and action), e.g. \"pos.payment.create\".", e.ID)
+}
+
+// PermissionLimitError reports more POS or general permissions than a custom role may have
+type PermissionLimitError struct {
+	POS   bool // The pos.* limit rather than the general one
+	Count int
+	Limit int
+}
+
+func (e *PermissionLimitError) Error() string {
+	if e.POS {
+		return fmt.Sprintf("A custom role may have at most %d pos permissions, got %d.", e.Limit, e.Count)
+	}
+	return fmt.Sprintf("A custom role may have at most %d general (non-pos) permissions, got %d.", e.Limit, e.Count)
+}
+
+// ValidatePermissionIDs checks the permission ids of a custom role at plan
+// time. It returns an *InvalidPermissionIDError for each malformed id, in
+// order, followed by a *PermissionLimitError for each exceeded limit.
+func ValidatePermissionIDs(ids []string) []error {
+	var errs []error
+	posCount, generalCount := 0, 0
+	for i, id := range ids {
+		if !PermissionIDRegexp.MatchString(id) {
+			errs = append(errs, &InvalidPermissionIDError{Index: i, ID: id})
+		}
+		if strings.HasPrefix(id, "pos.") {
+			posCount++
+		} else {
+			generalCount++
+		}
+	}
+
+	if posCount > MaxPOSPermissions {
+		errs = append(errs, &PermissionLimitError{POS: true, Count: posCount, Limit: MaxPOSPermissions})
+	}
+	if generalCount > MaxGeneralPermissions {
+		errs = append(errs, &PermissionLimitError{Count: generalCount, Limit: MaxGeneralPermissions})
+	}
+	return errs
+}
+
+// customRoleLocks serializes permission read-modify-writes per tenant/role
+// within the process, shared at package level like groupLocks
+var customRoleLocks = newKeyedMutex()
+
+// AddPermissions adds perms to a custom role without replacing its existing
+// permissions. The role is read, the new permissions are appended after the
+// current ones and the result is written back, so attributes and fields set
+// by the server are kept. Permissions already on the role are left as they
+// are. The POS and general limits are checked before anything is sent.
+func (s *Service) AddPermissions(ctx context.Context, roleID string, perms []Permission) (*CustomRole, error) {
+	return s.modifyPermissions(ctx, roleID, func(current []Permission) []Permission {
+		for _, perm := range perms {
+			if !slices.ContainsFunc(current, func(p Permission) bool { return p.ID == perm.ID }) {
+				current = append(current, perm)
+			}
+		}
+		return current
+	})
+}
+
+// RemovePermissions removes the permissions with the given ids from a custom
+// role, keeping the others and their attributes. Ids not on the role are
+// ignored.
+func (s *Service) RemovePermissions(ctx context.Context, roleID string, permIDs []string) (*CustomRole, error) {
+	return s.modifyPermissions(ctx, roleID, func(current []Permission) []Permission {
+		return slices.DeleteFunc(current, func(p Permission) bool {
+			return slices.Contains(permIDs, p.ID)
+		})
+	})
+}
+
+// modifyPermissions reads the custom role, applies modify to a copy of its
+// permissions and writes the role back when they changed
+func (s *Service) modifyPermissions(ctx context.Context, roleID string, modify func([]Permission) []Permission) (*CustomRole, error) {
+	roleID = s.normalizeID(ctx, "custom role", roleID)
+
+	unlock := customRoleLocks.Lock(s.tenantID + "/" + roleID)
+	defer unlock()
+
+	// Read past the cache so the write starts from the current permissions
+	s.invalidateCustomRole(ctx, roleID)
+	role, err := s.GetCustomRole(ctx, roleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read custom role %s: %w", roleID, err)
+	}
+
+	permissions := modify(slices.Clone(role.Permissions))
+	if slices.EqualFunc(permissions, role.Permissions, func(a, b Permission) bool { return a.ID == b.ID }) {
+		return role, nil
+	}
+	if err := checkPermissionLimits(roleID, permissions); err != nil {
+		return nil, err
+	}
+
+	updated := *role
+	updated.Permissions = permissions
+	return s.UpdateCustomRole(ctx, roleID, &updated)
+}
+
+// checkPermissionLimits returns an error when permissions exceed the POS or
+// general permission limits of a custom role
+func checkPermissionLimits(roleID string, permissions []Permission) error {
+	posCount, generalCount := 0, 0
+	for _, perm := range permissions {
+		if strings.HasPrefix(perm.ID, "pos.") {
+			posCount++
+		} else {
+			generalCount++
+		}
+	}
+	if posCount > MaxPOSPermissions {
+		return fmt.Errorf("custom role %s would have %d pos permissions, at most %d are allowed", roleID, posCount, MaxPOSPermissions)
+	}
+	if generalCount > MaxGeneralPermissions {
+		return fmt.Errorf("custom role %s would have %d general permissions, at most %d are allowed", roleID, generalCount, MaxGeneralPermissions)
+	}
+	return nil
+}
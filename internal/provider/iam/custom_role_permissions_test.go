@@ -0,0 +1,179 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// customRoleStore serves a single custom role, applying PUTs to it and
+// counting the writes
+type customRoleStore struct {
+	role   CustomRole
+	writes int
+}
+
+func (m *customRoleStore) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	if !strings.HasSuffix(req.Path, "/roles/"+m.role.ID) {
+		return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+	}
+	switch req.Method {
+	case "GET":
+	case "PUT":
+		m.writes++
+		body, err := json.Marshal(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		var update CustomRole
+		if err := json.Unmarshal(body, &update); err != nil {
+			return nil, err
+		}
+		m.role.Name = update.Name
+		m.role.Permissions = update.Permissions
+	default:
+		return nil, fmt.Errorf("unexpected %s", req.Method)
+	}
+	body, _ := json.Marshal(m.role)
+	return &client.Response{StatusCode: 200, Body: body}, nil
+}
+
+func newCustomRoleStore(perms ...Permission) *customRoleStore {
+	return &customRoleStore{role: CustomRole{
+		ID:          "cashier",
+		Name:        "Cashier",
+		Permissions: perms,
+		CreatedAt:   "2024-01-01T00:00:00Z",
+	}}
+}
+
+func permissionIDs(perms []Permission) []string {
+	ids := make([]string, len(perms))
+	for i, p := range perms {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestService_AddPermissions(t *testing.T) {
+	store := newCustomRoleStore(
+		Permission{ID: "pos.payment.create", Attributes: map[string]interface{}{"limit": "100"}},
+		Permission{ID: "iam.group.list"},
+	)
+	svc := &Service{rawClient: store, tenantID: "t"}
+
+	role, err := svc.AddPermissions(context.Background(), "cashier", []Permission{
+		{ID: "pos.refund.create"},
+		{ID: "iam.group.list"}, // already present
+	})
+	if err != nil {
+		t.Fatalf("AddPermissions() error = %v", err)
+	}
+
+	want := []string{"pos.payment.create", "iam.group.list", "pos.refund.create"}
+	if got := permissionIDs(role.Permissions); !reflect.DeepEqual(got, want) {
+		t.Errorf("permissions = %v, want %v", got, want)
+	}
+	if got := store.role.Permissions[0].Attributes["limit"]; got != "100" {
+		t.Errorf("existing attributes were not preserved, limit = %v", got)
+	}
+	if store.role.Name != "Cashier" {
+		t.Errorf("name = %q, want the existing name kept", store.role.Name)
+	}
+
+	// Adding only permissions the role already has does not write
+	if _, err := svc.AddPermissions(context.Background(), "cashier", []Permission{{ID: "pos.refund.create"}}); err != nil {
+		t.Fatalf("AddPermissions() error = %v", err)
+	}
+	if store.writes != 1 {
+		t.Errorf("writes = %d, want 1", store.writes)
+	}
+}
+
+func TestService_RemovePermissions(t *testing.T) {
+	store := newCustomRoleStore(
+		Permission{ID: "pos.payment.create", Attributes: map[string]interface{}{"limit": "100"}},
+		Permission{ID: "pos.refund.create"},
+		Permission{ID: "iam.group.list"},
+	)
+	svc := &Service{rawClient: store, tenantID: "t"}
+
+	role, err := svc.RemovePermissions(context.Background(), "cashier", []string{"pos.refund.create", "iam.group.list", "ccc.unknown.get"})
+	if err != nil {
+		t.Fatalf("RemovePermissions() error = %v", err)
+	}
+
+	if got := permissionIDs(role.Permissions); !reflect.DeepEqual(got, []string{"pos.payment.create"}) {
+		t.Errorf("permissions = %v, want [pos.payment.create]", got)
+	}
+	if got := store.role.Permissions[0].Attributes["limit"]; got != "100" {
+		t.Errorf("remaining attributes were not preserved, limit = %v", got)
+	}
+}
+
+func TestService_AddPermissions_Limits(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		limit   int
+		wantErr string
+	}{
+		{"pos", "pos", MaxPOSPermissions, "would have 501 pos permissions, at most 500 are allowed"},
+		{"general", "iam", MaxGeneralPermissions, "would have 101 general permissions, at most 100 are allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := make([]Permission, tt.limit)
+			for i := range existing {
+				existing[i] = Permission{ID: fmt.Sprintf("%s.resource.action%d", tt.prefix, i)}
+			}
+			store := newCustomRoleStore(existing...)
+			svc := &Service{rawClient: store, tenantID: "t"}
+
+			_, err := svc.AddPermissions(context.Background(), "cashier", []Permission{{ID: tt.prefix + ".extra.create"}})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("AddPermissions() error = %v, want %q", err, tt.wantErr)
+			}
+			if store.writes != 0 {
+				t.Errorf("writes = %d, want nothing sent over the limit", store.writes)
+			}
+		})
+	}
+}
+
+func TestValidatePermissionIDs(t *testing.T) {
+	if errs := ValidatePermissionIDs([]string{"pos.payment.create", "ccc.config.read"}); len(errs) != 0 {
+		t.Fatalf("expected valid ids to pass, got %v", errs)
+	}
+
+	errs := ValidatePermissionIDs([]string{"pos.payment.create", "POS.Payment", "pos.payment.delete", "x"})
+	var indexes []int
+	for _, err := range errs {
+		idErr, ok := err.(*InvalidPermissionIDError)
+		if !ok {
+			t.Fatalf("expected only *InvalidPermissionIDError, got %T: %v", err, err)
+		}
+		indexes = append(indexes, idErr.Index)
+	}
+	if !reflect.DeepEqual(indexes, []int{1, 3}) {
+		t.Errorf("invalid id indexes = %v, want [1 3]", indexes)
+	}
+
+	var general []string
+	for i := 0; i <= MaxGeneralPermissions; i++ {
+		general = append(general, fmt.Sprintf("ccc.config.read%s", strings.Repeat("a", i%10)))
+	}
+	errs = ValidatePermissionIDs(general)
+	if len(errs) != 1 {
+		t.Fatalf("expected a single limit error, got %v", errs)
+	}
+	limitErr, ok := errs[0].(*PermissionLimitError)
+	if !ok || limitErr.POS || limitErr.Count != MaxGeneralPermissions+1 || limitErr.Limit != MaxGeneralPermissions {
+		t.Errorf("got %#v, want the general limit exceeded by one", errs[0])
+	}
+}
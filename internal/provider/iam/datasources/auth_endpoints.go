@@ -0,0 +1,118 @@
+package datasources
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &AuthEndpointsDataSource{}
+
+// AuthEndpointsDataSource resolves the OAuth2 and API endpoints for a tenant.
+// It makes no API calls and does not need a configured client.
+type AuthEndpointsDataSource struct{}
+
+// AuthEndpointsDataSourceModel describes the data source data model
+type AuthEndpointsDataSourceModel struct {
+	ID                types.String `tfsdk:"id"`
+	TenantID          types.String `tfsdk:"tenant_id"`
+	Environment       types.String `tfsdk:"environment"`
+	AuthURL           types.String `tfsdk:"auth_url"`
+	APIURL            types.String `tfsdk:"api_url"`
+	IsTestEnvironment types.Bool   `tfsdk:"is_test_environment"`
+}
+
+// NewAuthEndpointsDataSource creates a new auth endpoints data source
+func NewAuthEndpointsDataSource() datasource.DataSource {
+	return &AuthEndpointsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *AuthEndpointsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_auth_endpoints"
+}
+
+// Schema defines the schema for the data source
+func (d *AuthEndpointsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Resolves the OAuth2 and API endpoints the provider uses for a tenant and environment.",
+		MarkdownDescription: "Resolves the OAuth2 and API endpoints the provider uses for a tenant and environment. Useful to check a misconfigured environment in plan output without running an apply.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Unique identifier for the data source.",
+				MarkdownDescription: "Unique identifier for the data source.",
+				Computed:            true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description:         "Tenant ID to resolve endpoints for.",
+				MarkdownDescription: "Tenant ID to resolve endpoints for.",
+				Required:            true,
+			},
+			"environment": schema.StringAttribute{
+				Description:         "Environment to resolve (dev, test, staging or production). Detected from the tenant ID when omitted.",
+				MarkdownDescription: "Environment to resolve (`dev`, `test`, `staging` or `production`). Detected from the tenant ID when omitted.",
+				Optional:            true,
+			},
+			"auth_url": schema.StringAttribute{
+				Description:         "Resolved OAuth2 token endpoint.",
+				MarkdownDescription: "Resolved OAuth2 token endpoint.",
+				Computed:            true,
+			},
+			"api_url": schema.StringAttribute{
+				Description:         "Resolved API base URL.",
+				MarkdownDescription: "Resolved API base URL.",
+				Computed:            true,
+			},
+			"is_test_environment": schema.BoolAttribute{
+				Description:         "Whether the resolved environment is a non-production environment.",
+				MarkdownDescription: "Whether the resolved environment is a non-production environment.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Read resolves the endpoints into the Terraform state
+func (d *AuthEndpointsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config AuthEndpointsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tenantID := config.TenantID.ValueString()
+	environment := config.Environment.ValueString()
+
+	authURL, apiURL, err := auth.ResolveEndpoints(tenantID, environment)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("environment"),
+			"Unable to Resolve Endpoints",
+			err.Error(),
+		)
+		return
+	}
+
+	config.ID = types.StringValue(tenantID)
+	if environment != "" {
+		config.ID = types.StringValue(tenantID + "/" + environment)
+	}
+	config.AuthURL = types.StringValue(authURL)
+	config.APIURL = types.StringValue(apiURL)
+	config.IsTestEnvironment = types.BoolValue(auth.NewEndpointResolver(tenantID, environment).IsTestEnvironment())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+
+	tflog.Trace(ctx, "read auth endpoints data source")
+}
@@ -0,0 +1,84 @@
+package datasources
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readAuthEndpoints(t *testing.T, tenantID string, environment *string) (*datasource.ReadResponse, AuthEndpointsDataSourceModel) {
+	t.Helper()
+	ctx := context.Background()
+	ds := NewAuthEndpointsDataSource()
+
+	schemaResp := &datasource.SchemaResponse{}
+	ds.Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	objType := schemaResp.Schema.Type().TerraformType(ctx).(tftypes.Object)
+	var env tftypes.Value
+	if environment != nil {
+		env = tftypes.NewValue(tftypes.String, *environment)
+	} else {
+		env = tftypes.NewValue(tftypes.String, nil)
+	}
+	raw := tftypes.NewValue(objType, map[string]tftypes.Value{
+		"id":                  tftypes.NewValue(tftypes.String, nil),
+		"tenant_id":           tftypes.NewValue(tftypes.String, tenantID),
+		"environment":         env,
+		"auth_url":            tftypes.NewValue(tftypes.String, nil),
+		"api_url":             tftypes.NewValue(tftypes.String, nil),
+		"is_test_environment": tftypes.NewValue(tftypes.Bool, nil),
+	})
+
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw}}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objType, nil)}}
+	ds.Read(ctx, req, resp)
+
+	var state AuthEndpointsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		require.False(t, resp.State.Get(ctx, &state).HasError())
+	}
+	return resp, state
+}
+
+func TestAuthEndpointsDataSource_Metadata(t *testing.T) {
+	resp := &datasource.MetadataResponse{}
+	NewAuthEndpointsDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "hiiretail"}, resp)
+	assert.Equal(t, "hiiretail_auth_endpoints", resp.TypeName)
+}
+
+func TestAuthEndpointsDataSource_Read_Test(t *testing.T) {
+	resp, state := readAuthEndpoints(t, "acme-test", nil)
+	require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+
+	assert.True(t, strings.HasPrefix(state.AuthURL.ValueString(), "https://auth.retailsvc-test.com"))
+	assert.True(t, strings.HasPrefix(state.APIURL.ValueString(), "https://iam-api.retailsvc-test.com"))
+	assert.True(t, state.IsTestEnvironment.ValueBool())
+	assert.Equal(t, "acme-test", state.ID.ValueString())
+}
+
+func TestAuthEndpointsDataSource_Read_Production(t *testing.T) {
+	env := "production"
+	resp, state := readAuthEndpoints(t, "acme-test", &env)
+	require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics)
+
+	assert.True(t, strings.HasPrefix(state.AuthURL.ValueString(), "https://auth.retailsvc.com"))
+	assert.True(t, strings.HasPrefix(state.APIURL.ValueString(), "https://iam-api.retailsvc.com"))
+	assert.False(t, state.IsTestEnvironment.ValueBool(), "an explicit environment overrides the tenant name")
+	assert.Equal(t, "acme-test/production", state.ID.ValueString())
+}
+
+func TestAuthEndpointsDataSource_Read_InvalidEnvironment(t *testing.T) {
+	env := "qa"
+	resp, _ := readAuthEndpoints(t, "acme", &env)
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Unable to Resolve Endpoints", resp.Diagnostics.Errors()[0].Summary())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "unsupported environment: qa")
+}
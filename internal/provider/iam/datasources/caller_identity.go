@@ -0,0 +1,143 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &CallerIdentityDataSource{}
+
+// CallerIdentityDataSource reports the principal and scopes the provider
+// authenticates as
+type CallerIdentityDataSource struct {
+	client     *client.Client
+	iamService *iam.Service
+}
+
+// CallerIdentityDataSourceModel describes the data source data model
+type CallerIdentityDataSourceModel struct {
+	ID             types.String `tfsdk:"id"`
+	Subject        types.String `tfsdk:"subject"`
+	ClientID       types.String `tfsdk:"client_id"`
+	TenantID       types.String `tfsdk:"tenant_id"`
+	Scopes         types.List   `tfsdk:"scopes"`
+	ScopesReported types.Bool   `tfsdk:"scopes_reported"`
+}
+
+// NewCallerIdentityDataSource creates a new caller identity data source
+func NewCallerIdentityDataSource() datasource.DataSource {
+	return &CallerIdentityDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *CallerIdentityDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_caller_identity"
+}
+
+// Schema defines the schema for the data source
+func (d *CallerIdentityDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Retrieves the principal, tenant and scopes the provider authenticates as.",
+		MarkdownDescription: "Retrieves the principal, tenant and scopes the provider authenticates as. Useful when debugging permission errors.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Unique identifier for the data source, the tenant and subject.",
+				MarkdownDescription: "Unique identifier for the data source, the tenant and subject.",
+				Computed:            true,
+			},
+			"subject": schema.StringAttribute{
+				Description:         "Subject of the access token, the client ID when the API does not report one.",
+				MarkdownDescription: "Subject of the access token, the client ID when the API does not report one.",
+				Computed:            true,
+			},
+			"client_id": schema.StringAttribute{
+				Description:         "OAuth2 client ID of the access token.",
+				MarkdownDescription: "OAuth2 client ID of the access token.",
+				Computed:            true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Description:         "Tenant the access token belongs to.",
+				MarkdownDescription: "Tenant the access token belongs to.",
+				Computed:            true,
+			},
+			"scopes": schema.ListAttribute{
+				Description:         "Scopes granted to the access token, sorted.",
+				MarkdownDescription: "Scopes granted to the access token, sorted. See `scopes_reported`.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"scopes_reported": schema.BoolAttribute{
+				Description:         "Whether scopes were reported by the API. When false, scopes are the configured scopes, which the token may not all have been granted.",
+				MarkdownDescription: "Whether `scopes` were reported by the API. When false, `scopes` are the configured scopes, which the token may not all have been granted.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *CallerIdentityDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.iamService = providerData.Service
+
+	tflog.Info(ctx, "Configured Caller Identity Data Source")
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *CallerIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
+	principal, err := d.iamService.WhoAmI(ctx)
+	if err != nil {
+		detail := err.Error()
+		if client.IsForbiddenError(err) {
+			detail += ". Grant the provider credential a scope that can read its own user, or check client_id and scopes."
+		}
+		resp.Diagnostics.AddError("Unable to Read Caller Identity", detail)
+		return
+	}
+
+	scopes, diags := types.ListValueFrom(ctx, types.StringType, principal.Scopes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := CallerIdentityDataSourceModel{
+		ID:             types.StringValue(principal.TenantID + "/" + principal.Subject),
+		Subject:        types.StringValue(principal.Subject),
+		ClientID:       types.StringValue(principal.ClientID),
+		TenantID:       types.StringValue(principal.TenantID),
+		Scopes:         scopes,
+		ScopesReported: types.BoolValue(principal.ScopesReported),
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+
+	tflog.Trace(ctx, "read caller identity data source")
+}
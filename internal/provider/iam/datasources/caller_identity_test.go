@@ -0,0 +1,91 @@
+package datasources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// readCallerIdentity runs Read against a mock self endpoint answering with
+// status and body
+func readCallerIdentity(t *testing.T, status int, body string) (*datasource.ReadResponse, CallerIdentityDataSourceModel) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/user", r.URL.Path)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cfg := client.DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.MaxRetries = 0
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "acme"}, cfg)
+	require.NoError(t, err)
+
+	ds := NewCallerIdentityDataSource().(*CallerIdentityDataSource)
+	var configureResp datasource.ConfigureResponse
+	ds.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: &iam.ProviderData{Client: apiClient, Service: iam.NewService(apiClient, apiClient.TenantID())}}, &configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	var schemaResp datasource.SchemaResponse
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	schemaType := schemaResp.Schema.Type().TerraformType(context.Background())
+
+	req := datasource.ReadRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+	}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+	}
+	ds.Read(context.Background(), req, resp)
+
+	var state CallerIdentityDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	}
+	return resp, state
+}
+
+func TestCallerIdentityDataSource_Metadata(t *testing.T) {
+	resp := &datasource.MetadataResponse{}
+	NewCallerIdentityDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "hiiretail"}, resp)
+	assert.Equal(t, "hiiretail_caller_identity", resp.TypeName)
+}
+
+func TestCallerIdentityDataSource_Read(t *testing.T) {
+	resp, state := readCallerIdentity(t, http.StatusOK, `{"id":"user-1","client_id":"api-client","scopes":["iam:read","iam:admin"]}`)
+	require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics.Errors())
+
+	assert.Equal(t, "acme/user-1", state.ID.ValueString())
+	assert.Equal(t, "user-1", state.Subject.ValueString())
+	assert.Equal(t, "api-client", state.ClientID.ValueString())
+	assert.Equal(t, "acme", state.TenantID.ValueString())
+	assert.True(t, state.ScopesReported.ValueBool())
+
+	var scopes []string
+	require.False(t, state.Scopes.ElementsAs(context.Background(), &scopes, false).HasError())
+	assert.Equal(t, []string{"iam:admin", "iam:read"}, scopes)
+}
+
+func TestCallerIdentityDataSource_Read_InsufficientScope(t *testing.T) {
+	resp, _ := readCallerIdentity(t, http.StatusForbidden, `{"message":"missing scope iam:read"}`)
+	require.True(t, resp.Diagnostics.HasError())
+
+	diag := resp.Diagnostics.Errors()[0]
+	assert.Equal(t, "Unable to Read Caller Identity", diag.Summary())
+	assert.True(t, strings.Contains(diag.Detail(), "insufficient scope"), diag.Detail())
+	assert.True(t, strings.Contains(diag.Detail(), "missing scope iam:read"), diag.Detail())
+}
@@ -0,0 +1,186 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &GroupRoleBindingsDataSource{}
+
+// GroupRoleBindingsDataSource defines the data source implementation for the roles bound to a group
+type GroupRoleBindingsDataSource struct {
+	client     *client.Client
+	iamService *iam.Service
+}
+
+// GroupRoleBindingsDataSourceModel describes the data source data model
+type GroupRoleBindingsDataSourceModel struct {
+	ID      types.String `tfsdk:"id"`
+	GroupID types.String `tfsdk:"group_id"`
+	Roles   types.List   `tfsdk:"roles"`
+}
+
+// groupRoleBindingObjectType is the object type of each entry in roles
+var groupRoleBindingObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"role":      types.StringType,
+		"is_custom": types.BoolType,
+		"bindings":  types.ListType{ElemType: types.StringType},
+	},
+}
+
+// NewGroupRoleBindingsDataSource creates a new group role bindings data source
+func NewGroupRoleBindingsDataSource() datasource.DataSource {
+	return &GroupRoleBindingsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *GroupRoleBindingsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_group_role_bindings"
+}
+
+// Schema defines the schema for the data source
+func (d *GroupRoleBindingsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Lists the roles bound to an IAM group and the bindings of each.",
+		MarkdownDescription: "Lists the roles bound to an IAM group and the bindings of each, for auditing a group's access.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "The group ID.",
+				MarkdownDescription: "The group ID.",
+				Computed:            true,
+			},
+			"group_id": schema.StringAttribute{
+				Description:         "ID of the group whose roles are listed.",
+				MarkdownDescription: "ID of the group whose roles are listed.",
+				Required:            true,
+			},
+			"roles": schema.ListNestedAttribute{
+				Description:         "Roles bound to the group.",
+				MarkdownDescription: "Roles bound to the group.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"role": schema.StringAttribute{
+							Description:         "Role ID, without the 'custom.' prefix for custom roles.",
+							MarkdownDescription: "Role ID, without the `custom.` prefix for custom roles.",
+							Computed:            true,
+						},
+						"is_custom": schema.BoolAttribute{
+							Description:         "Whether the role is a custom role.",
+							MarkdownDescription: "Whether the role is a custom role.",
+							Computed:            true,
+						},
+						"bindings": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Description:         "Resources the role applies to, such as 'bu:001'.",
+							MarkdownDescription: "Resources the role applies to, such as `bu:001`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *GroupRoleBindingsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.iamService = providerData.Service
+
+	tflog.Info(ctx, "Configured IAM Group Role Bindings Data Source")
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *GroupRoleBindingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
+	var config GroupRoleBindingsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groupID := config.GroupID.ValueString()
+	bindings, err := d.iamService.ListRoleBindingsForGroup(ctx, groupID)
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			resp.Diagnostics.AddAttributeError(path.Root("group_id"), "Group Not Found",
+				fmt.Sprintf("IAM group %q does not exist", groupID))
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to Read Group Role Bindings",
+			fmt.Sprintf("Could not list the roles of group %s: %s", groupID, err.Error()),
+		)
+		return
+	}
+
+	roles := make([]attr.Value, 0, len(bindings))
+	for _, binding := range bindings {
+		roleID, isCustom := iam.ParseRole(binding.Role)
+		values := binding.Bindings
+		if values == nil {
+			values = []string{}
+		}
+		resourceBindings, diags := types.ListValueFrom(ctx, types.StringType, values)
+		resp.Diagnostics.Append(diags...)
+
+		role, diags := types.ObjectValue(groupRoleBindingObjectType.AttrTypes, map[string]attr.Value{
+			"role":      types.StringValue(roleID),
+			"is_custom": types.BoolValue(isCustom),
+			"bindings":  resourceBindings,
+		})
+		resp.Diagnostics.Append(diags...)
+		roles = append(roles, role)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	listValue, diags := types.ListValue(groupRoleBindingObjectType, roles)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config.ID = types.StringValue(groupID)
+	config.Roles = listValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+
+	tflog.Trace(ctx, "read group role bindings data source", map[string]interface{}{
+		"group_id": groupID,
+		"roles":    len(roles),
+	})
+}
@@ -0,0 +1,110 @@
+package datasources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// groupRoleBindingEntry is a roles entry read back from state
+type groupRoleBindingEntry struct {
+	Role     string   `tfsdk:"role"`
+	IsCustom bool     `tfsdk:"is_custom"`
+	Bindings []string `tfsdk:"bindings"`
+}
+
+// readGroupRoleBindings runs Read for groupID against a mock API serving
+// the responses in routes, keyed by request path
+func readGroupRoleBindings(t *testing.T, groupID string, routes map[string]string) (*datasource.ReadResponse, GroupRoleBindingsDataSourceModel) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cfg := client.DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.MaxRetries = 0
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "acme"}, cfg)
+	require.NoError(t, err)
+
+	ds := NewGroupRoleBindingsDataSource().(*GroupRoleBindingsDataSource)
+	var configureResp datasource.ConfigureResponse
+	ds.Configure(context.Background(), datasource.ConfigureRequest{ProviderData: &iam.ProviderData{Client: apiClient, Service: iam.NewService(apiClient, apiClient.TenantID())}}, &configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	var schemaResp datasource.SchemaResponse
+	ds.Schema(context.Background(), datasource.SchemaRequest{}, &schemaResp)
+	schemaType := schemaResp.Schema.Type().TerraformType(context.Background())
+	rolesType := schemaType.(tftypes.Object).AttributeTypes["roles"]
+
+	config := tftypes.NewValue(schemaType, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, nil),
+		"group_id": tftypes.NewValue(tftypes.String, groupID),
+		"roles":    tftypes.NewValue(rolesType, tftypes.UnknownValue),
+	})
+	req := datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: config}}
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaType, nil)},
+	}
+	ds.Read(context.Background(), req, resp)
+
+	var state GroupRoleBindingsDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		require.False(t, resp.State.Get(context.Background(), &state).HasError())
+	}
+	return resp, state
+}
+
+func TestGroupRoleBindingsDataSource_Metadata(t *testing.T) {
+	resp := &datasource.MetadataResponse{}
+	NewGroupRoleBindingsDataSource().Metadata(context.Background(), datasource.MetadataRequest{ProviderTypeName: "hiiretail"}, resp)
+	assert.Equal(t, "hiiretail_iam_group_role_bindings", resp.TypeName)
+}
+
+func TestGroupRoleBindingsDataSource_Read(t *testing.T) {
+	resp, state := readGroupRoleBindings(t, "g1", map[string]string{
+		"/api/v1/tenants/acme/groups/g1": `{"id":"g1","name":"cashiers"}`,
+		"/api/v2/tenants/acme/groups/g1/roles": `[
+			{"roleId":"pos.admin","isCustom":false,"bindings":["bu:001"]},
+			{"roleId":"custom.Cashier","isCustom":true,"bindings":["bu:001","bu:002"]},
+			{"roleId":"custom-roles/custom.Auditor","isCustom":true}
+		]`,
+	})
+	require.False(t, resp.Diagnostics.HasError(), "diagnostics: %v", resp.Diagnostics.Errors())
+
+	assert.Equal(t, "g1", state.ID.ValueString())
+	var roles []groupRoleBindingEntry
+	require.False(t, state.Roles.ElementsAs(context.Background(), &roles, false).HasError())
+	assert.Equal(t, []groupRoleBindingEntry{
+		{Role: "pos.admin", Bindings: []string{"bu:001"}},
+		{Role: "Cashier", IsCustom: true, Bindings: []string{"bu:001", "bu:002"}},
+		{Role: "Auditor", IsCustom: true, Bindings: []string{}},
+	}, roles)
+}
+
+func TestGroupRoleBindingsDataSource_Read_GroupNotFound(t *testing.T) {
+	resp, _ := readGroupRoleBindings(t, "missing", nil)
+	require.True(t, resp.Diagnostics.HasError())
+
+	diag := resp.Diagnostics.Errors()[0]
+	assert.Equal(t, "Group Not Found", diag.Summary())
+	assert.Contains(t, diag.Detail(), `"missing"`)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
@@ -25,9 +27,10 @@ type GroupsDataSource struct {
 
 // GroupsDataSourceModel describes the data source data model
 type GroupsDataSourceModel struct {
-	ID     types.String `tfsdk:"id"`
-	Filter types.String `tfsdk:"filter"`
-	Groups types.List   `tfsdk:"groups"`
+	ID       types.String `tfsdk:"id"`
+	Filter   types.String `tfsdk:"filter"`
+	PageSize types.Int64  `tfsdk:"page_size"`
+	Groups   types.List   `tfsdk:"groups"`
 }
 
 // GroupDataModel describes a single group in the data source
@@ -62,10 +65,18 @@ func (d *GroupsDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 				Computed:            true,
 			},
 			"filter": schema.StringAttribute{
-				Description:         "Optional filter to narrow down the groups (e.g., 'name:dev-*').",
-				MarkdownDescription: "Optional filter to narrow down the groups (e.g., `name:dev-*`).",
+				Description:         "Optional filter to narrow down the groups (e.g., 'name:dev-*'). Applied by the API.",
+				MarkdownDescription: "Optional filter to narrow down the groups (e.g., `name:dev-*`). Applied by the API.",
 				Optional:            true,
 			},
+			"page_size": schema.Int64Attribute{
+				Description:         "Number of groups requested per page while listing; the API default when unset. All pages are always read.",
+				MarkdownDescription: "Number of groups requested per page while listing; the API default when unset. All pages are always read.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 1000),
+				},
+			},
 			"groups": schema.ListNestedAttribute{
 				Description:         "List of IAM groups matching the filter criteria.",
 				MarkdownDescription: "List of IAM groups matching the filter criteria.",
@@ -111,23 +122,25 @@ func (d *GroupsDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
-	d.iamService = iam.NewService(client, client.TenantID())
+	d.client = providerData.Client
+	d.iamService = providerData.Service
 
 	tflog.Info(ctx, "Configured IAM Groups Data Source")
 }
 
 // Read refreshes the Terraform state with the latest data
 func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var config GroupsDataSourceModel
 
 	// Read Terraform configuration data into the model
@@ -142,22 +155,23 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		listReq.Filter = config.Filter.ValueString()
 	}
 
-	// Get groups from API
-	listResp, err := d.iamService.ListGroups(ctx, listReq)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Read IAM Groups",
-			err.Error(),
-		)
-		return
+	if !config.PageSize.IsNull() {
+		listReq.PageSize = int(config.PageSize.ValueInt64())
 	}
 
-	// Map API response to data source model
-	config.ID = types.StringValue("groups")
+	objType := types.ObjectType{
+		AttrTypes: map[string]attr.Type{
+			"id":           types.StringType,
+			"name":         types.StringType,
+			"description":  types.StringType,
+			"member_count": types.Int64Type,
+			"created_at":   types.StringType,
+		},
+	}
 
-	// Convert groups to list of objects
-	groupElements := make([]attr.Value, len(listResp.Groups))
-	for i, group := range listResp.Groups {
+	// Get groups from API page by page, converting each page as it arrives
+	var groupElements []attr.Value
+	err := d.iamService.EachGroup(ctx, listReq, func(group iam.Group) error {
 		groupObj := map[string]attr.Value{
 			"id":           types.StringValue(group.ID),
 			"name":         types.StringValue(group.Name),
@@ -166,38 +180,32 @@ func (d *GroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			"created_at":   types.StringValue(group.CreatedAt),
 		}
 
-		objType := types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"id":           types.StringType,
-				"name":         types.StringType,
-				"description":  types.StringType,
-				"member_count": types.Int64Type,
-				"created_at":   types.StringType,
-			},
-		}
-
 		objValue, diags := types.ObjectValue(objType.AttrTypes, groupObj)
 		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
+		if diags.HasError() {
+			return fmt.Errorf("failed to convert group %s", group.ID)
 		}
-
-		groupElements[i] = objValue
+		groupElements = append(groupElements, objValue)
+		return nil
+	})
+	if resp.Diagnostics.HasError() {
+		return
 	}
-
-	listType := types.ListType{
-		ElemType: types.ObjectType{
-			AttrTypes: map[string]attr.Type{
-				"id":           types.StringType,
-				"name":         types.StringType,
-				"description":  types.StringType,
-				"member_count": types.Int64Type,
-				"created_at":   types.StringType,
-			},
-		},
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IAM Groups",
+			err.Error(),
+		)
+		return
 	}
 
-	listValue, diags := types.ListValue(listType.ElemType, groupElements)
+	// Map API response to data source model
+	config.ID = types.StringValue("groups")
+
+	if groupElements == nil {
+		groupElements = []attr.Value{}
+	}
+	listValue, diags := types.ListValue(objType, groupElements)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
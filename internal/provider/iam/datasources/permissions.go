@@ -0,0 +1,166 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces
+var _ datasource.DataSource = &PermissionsDataSource{}
+
+// permissionAttrTypes describes a single permission object in the data source
+var permissionAttrTypes = map[string]attr.Type{
+	"id":    types.StringType,
+	"alias": types.StringType,
+}
+
+// PermissionsDataSource defines the data source implementation for the IAM permissions catalog
+type PermissionsDataSource struct {
+	client     *client.Client
+	iamService *iam.Service
+}
+
+// PermissionsDataSourceModel describes the data source data model
+type PermissionsDataSourceModel struct {
+	ID           types.String `tfsdk:"id"`
+	SystemPrefix types.String `tfsdk:"system_prefix"`
+	Permissions  types.List   `tfsdk:"permissions"`
+}
+
+// NewPermissionsDataSource creates a new permissions data source
+func NewPermissionsDataSource() datasource.DataSource {
+	return &PermissionsDataSource{}
+}
+
+// Metadata returns the data source type name
+func (d *PermissionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_iam_permissions"
+}
+
+// Schema defines the schema for the data source
+func (d *PermissionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description:         "Retrieves the IAM permissions catalog within HiiRetail.",
+		MarkdownDescription: "Retrieves the IAM permissions catalog within HiiRetail, including the alias of each permission. Can be narrowed down to a single system.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description:         "Unique identifier for the data source.",
+				MarkdownDescription: "Unique identifier for the data source.",
+				Computed:            true,
+			},
+			"system_prefix": schema.StringAttribute{
+				Description:         "Optional system prefix to narrow down the permissions (e.g., 'pos').",
+				MarkdownDescription: "Optional system prefix to narrow down the permissions (e.g., `pos`).",
+				Optional:            true,
+			},
+			"permissions": schema.ListNestedAttribute{
+				Description:         "List of permissions in the catalog.",
+				MarkdownDescription: "List of permissions in the catalog.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description:         "Permission ID in the format {systemPrefix}.{resource}.{action}.",
+							MarkdownDescription: "Permission ID in the format `{systemPrefix}.{resource}.{action}`.",
+							Computed:            true,
+						},
+						"alias": schema.StringAttribute{
+							Description:         "Human-readable alias of the permission.",
+							MarkdownDescription: "Human-readable alias of the permission.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source
+func (d *PermissionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = providerData.Client
+	d.iamService = providerData.Service
+
+	tflog.Info(ctx, "Configured IAM Permissions Data Source")
+}
+
+// Read refreshes the Terraform state with the latest data
+func (d *PermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
+	var config PermissionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	systemPrefix := ""
+	if !config.SystemPrefix.IsNull() {
+		systemPrefix = config.SystemPrefix.ValueString()
+	}
+
+	permissions, err := d.iamService.ListPermissions(ctx, systemPrefix)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read IAM Permissions",
+			err.Error(),
+		)
+		return
+	}
+
+	config.ID = types.StringValue("permissions")
+	if systemPrefix != "" {
+		config.ID = types.StringValue("permissions/" + systemPrefix)
+	}
+
+	elements := make([]attr.Value, len(permissions))
+	for i, p := range permissions {
+		objValue, diags := types.ObjectValue(permissionAttrTypes, map[string]attr.Value{
+			"id":    types.StringValue(p.ID),
+			"alias": types.StringValue(p.Alias),
+		})
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		elements[i] = objValue
+	}
+
+	listValue, diags := types.ListValue(types.ObjectType{AttrTypes: permissionAttrTypes}, elements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	config.Permissions = listValue
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &config)...)
+
+	tflog.Trace(ctx, "read permissions data source")
+}
@@ -2,7 +2,6 @@ package datasources
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
@@ -73,39 +72,23 @@ func (d *ResourceDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	clientData, ok := req.ProviderData.(map[string]interface{})
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected map[string]interface{}, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	client, ok := clientData["client"].(*client.Client)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected Client Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", clientData["client"]),
-		)
-		return
-	}
-
-	iamService, ok := clientData["iam_service"].(*iam.Service)
-	if !ok {
-		resp.Diagnostics.AddError(
-			"Unexpected IAM Service Type",
-			fmt.Sprintf("Expected *iam.Service, got: %T. Please report this issue to the provider developers.", clientData["iam_service"]),
-		)
-		return
-	}
-
-	d.client = client
-	d.iamService = iamService
+	d.client = providerData.Client
+	d.iamService = providerData.Service
 }
 
 // Read refreshes the Terraform state with the latest data
 func (d *ResourceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var config ResourceDataSourceModel
 
 	// Read Terraform configuration data into the model
@@ -135,9 +118,9 @@ func (d *ResourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 	config.ID = types.StringValue(resource.ID)
 	config.Name = types.StringValue(resource.Name)
 
-	// Set properties as JSON string
+	// Set properties as canonical JSON string
 	if resource.Props != nil {
-		propsJSON, err := json.Marshal(resource.Props)
+		propsJSON, err := iam.CanonicalizeProps(resource.Props)
 		if err != nil {
 			resp.Diagnostics.AddWarning(
 				"Unable to serialize properties",
@@ -145,7 +128,7 @@ func (d *ResourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 			)
 			config.Properties = types.StringValue("{}")
 		} else {
-			config.Properties = types.StringValue(string(propsJSON))
+			config.Properties = types.StringValue(propsJSON)
 		}
 	} else {
 		config.Properties = types.StringValue("{}")
@@ -117,10 +117,7 @@ func TestResourceDataSource_Configure(t *testing.T) {
 		mockService := &iam.Service{}
 
 		req := datasource.ConfigureRequest{
-			ProviderData: map[string]interface{}{
-				"client":      mockClient,
-				"iam_service": mockService,
-			},
+			ProviderData: &iam.ProviderData{Client: mockClient, Service: mockService},
 		}
 		resp := &datasource.ConfigureResponse{}
 
@@ -117,23 +117,25 @@ func (d *RolesDataSource) Configure(ctx context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	d.client = client
-	d.iamService = iam.NewService(client, client.TenantID())
+	d.client = providerData.Client
+	d.iamService = providerData.Service
 
 	tflog.Info(ctx, "Configured IAM Roles Data Source")
 }
 
 // Read refreshes the Terraform state with the latest data
 func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var config RolesDataSourceModel
 
 	// Read Terraform configuration data into the model
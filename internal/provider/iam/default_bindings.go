@@ -0,0 +1,38 @@
+package iam
+
+// legacyRoleBindingBindings are the bindings CreateRoleBinding sends when
+// neither the binding nor the provider configures any
+var legacyRoleBindingBindings = []string{"bu:001"}
+
+// allResourcesBindings are the bindings AddRoleToGroup sends when neither the
+// caller nor the provider configures any
+var allResourcesBindings = []string{"*"}
+
+// SetDefaultBindings sets the bindings used by CreateRoleBinding and
+// AddRoleToGroup when a role binding does not specify its own. Empty
+// restores the built-in fallbacks. The slice is copied.
+func (s *Service) SetDefaultBindings(bindings []string) {
+	if len(bindings) == 0 {
+		s.defaultBindings = nil
+		return
+	}
+	s.defaultBindings = append([]string(nil), bindings...)
+}
+
+// resolveBindings returns explicit when it is non-empty, then the provider
+// default bindings, then fallback
+func (s *Service) resolveBindings(explicit, fallback []string) []string {
+	if len(explicit) > 0 {
+		return explicit
+	}
+	if len(s.defaultBindings) > 0 {
+		return s.defaultBindings
+	}
+	return fallback
+}
+
+// ImplicitGroupBindings returns the bindings AddRoleToGroup sends for a role
+// added without bindings of its own
+func (s *Service) ImplicitGroupBindings() []string {
+	return append([]string(nil), s.resolveBindings(nil, allResourcesBindings)...)
+}
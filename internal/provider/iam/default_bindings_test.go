@@ -0,0 +1,107 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// bindingsCaptureMock answers group and role lookups and records the bindings of the
+// last role assignment POST
+func bindingsCaptureMock(sent *[]string) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch {
+		case req.Method == "GET" && strings.Contains(req.Path, "/roles/"):
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"pos.admin","name":"pos.admin"}`)}, nil
+		case req.Method == "GET":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1","name":"cashiers"}]`)}, nil
+		case req.Method == "POST":
+			payload, ok := req.Body.(map[string]interface{})
+			if !ok {
+				return nil, errors.New("unexpected body")
+			}
+			*sent = payload["bindings"].([]string)
+			return &client.Response{StatusCode: 201, Body: []byte(`{}`)}, nil
+		}
+		return nil, errors.New("unexpected request")
+	}}
+}
+
+func TestService_CreateRoleBinding_ProviderDefaultBindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults []string
+		explicit []string
+		want     []string
+	}{
+		{name: "built-in fallback", want: []string{"bu:001"}},
+		{name: "provider default", defaults: []string{"bu:100", "bu:200"}, want: []string{"bu:100", "bu:200"}},
+		{name: "explicit wins", defaults: []string{"bu:100"}, explicit: []string{"bu:300"}, want: []string{"bu:300"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			svc := &Service{rawClient: bindingsCaptureMock(&sent), tenantID: "t"}
+			svc.SetDefaultBindings(tt.defaults)
+
+			out, err := svc.CreateRoleBinding(context.Background(), &RoleBinding{
+				Role:     "roles/pos.admin",
+				Members:  []string{"group:cashiers"},
+				Bindings: tt.explicit,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(sent, tt.want) {
+				t.Errorf("sent bindings = %v, want %v", sent, tt.want)
+			}
+			if !reflect.DeepEqual(out.Bindings, tt.want) {
+				t.Errorf("returned bindings = %v, want %v", out.Bindings, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_AddRoleToGroup_ProviderDefaultBindings(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults []string
+		explicit []string
+		want     []string
+	}{
+		{name: "built-in fallback", want: []string{"*"}},
+		{name: "provider default", defaults: []string{"bu:100"}, want: []string{"bu:100"}},
+		{name: "explicit wins", defaults: []string{"bu:100"}, explicit: []string{"bu:300"}, want: []string{"bu:300"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			svc := &Service{rawClient: bindingsCaptureMock(&sent), tenantID: "t"}
+			svc.SetDefaultBindings(tt.defaults)
+
+			if err := svc.AddRoleToGroup(context.Background(), "g1", "pos.admin", false, tt.explicit); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(sent, tt.want) {
+				t.Errorf("sent bindings = %v, want %v", sent, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_SetDefaultBindings_Copies(t *testing.T) {
+	defaults := []string{"bu:100"}
+	svc := &Service{}
+	svc.SetDefaultBindings(defaults)
+	defaults[0] = "bu:999"
+
+	if got := svc.resolveBindings(nil, allResourcesBindings); !reflect.DeepEqual(got, []string{"bu:100"}) {
+		t.Errorf("resolveBindings = %v, want the bindings as set", got)
+	}
+}
@@ -0,0 +1,103 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// DeleteGroupsParallelism is the number of concurrent deletes made by DeleteGroups
+const DeleteGroupsParallelism = 4
+
+// DeleteGroupsError reports the groups DeleteGroups could not delete, keyed by
+// group ID. Groups not listed were deleted or were already gone.
+type DeleteGroupsError struct {
+	Failed map[string]error
+}
+
+func (e *DeleteGroupsError) Error() string {
+	ids := e.ids()
+	causes := make([]string, len(ids))
+	for i, id := range ids {
+		causes[i] = fmt.Sprintf("%s: %v", id, e.Failed[id])
+	}
+	return fmt.Sprintf("failed to delete %d group(s): %s", len(ids), strings.Join(causes, "; "))
+}
+
+func (e *DeleteGroupsError) Unwrap() []error {
+	ids := e.ids()
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		errs[i] = e.Failed[id]
+	}
+	return errs
+}
+
+// ids returns the failed group IDs in sorted order
+func (e *DeleteGroupsError) ids() []string {
+	ids := make([]string, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// DeleteGroups deletes the given groups with up to DeleteGroupsParallelism
+// requests in flight. Every deletion is attempted even when some fail; a 404
+// counts as success since the group is already gone. Failures are returned
+// together as a *DeleteGroupsError. Deletions not yet started when ctx is done
+// are reported as failed with the context error.
+func (s *Service) DeleteGroups(ctx context.Context, ids []string) error {
+	var (
+		mu     sync.Mutex
+		failed = make(map[string]error)
+		wg     sync.WaitGroup
+		sem    = make(chan struct{}, DeleteGroupsParallelism)
+		seen   = make(map[string]bool, len(ids))
+	)
+
+	fail := func(id string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failed[id] = err
+	}
+
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		select {
+		case <-ctx.Done():
+			fail(id, ctx.Err())
+			continue
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := ctx.Err(); err != nil {
+				fail(id, err)
+				return
+			}
+			if err := s.DeleteGroup(ctx, id); err != nil && !client.IsNotFoundError(err) {
+				fail(id, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	if len(failed) == 0 {
+		return nil
+	}
+	return &DeleteGroupsError{Failed: failed}
+}
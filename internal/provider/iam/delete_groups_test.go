@@ -0,0 +1,108 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_DeleteGroups_PartialFailure(t *testing.T) {
+	var mu sync.Mutex
+	deleted := map[string]int{}
+	var inFlight, maxInFlight int32
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method != "DELETE" {
+			t.Errorf("unexpected request %s %s", req.Method, req.Path)
+			return &client.Response{StatusCode: 400}, nil
+		}
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if n <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		id := req.Path[strings.LastIndex(req.Path, "/")+1:]
+		mu.Lock()
+		deleted[id]++
+		mu.Unlock()
+		switch id {
+		case "gone":
+			return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+		case "broken":
+			return &client.Response{StatusCode: 500, Body: []byte(`{"message":"boom"}`)}, nil
+		}
+		return &client.Response{StatusCode: 204}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	ids := []string{"g1", "g2", "gone", "broken", "g3", "g4", "g5", "g1"}
+	err := svc.DeleteGroups(context.Background(), ids)
+
+	var delErr *DeleteGroupsError
+	if !errors.As(err, &delErr) {
+		t.Fatalf("expected *DeleteGroupsError, got %v", err)
+	}
+	if len(delErr.Failed) != 1 || delErr.Failed["broken"] == nil {
+		t.Fatalf("expected only 'broken' to fail, got %v", delErr.Failed)
+	}
+	if !client.IsServerError(delErr.Failed["broken"]) {
+		t.Errorf("expected the 500 cause to be kept, got %v", delErr.Failed["broken"])
+	}
+	if !strings.Contains(err.Error(), "broken") || strings.Contains(err.Error(), "gone") {
+		t.Errorf("unexpected error message: %v", err)
+	}
+
+	for _, id := range []string{"g1", "g2", "gone", "broken", "g3", "g4", "g5"} {
+		if deleted[id] != 1 {
+			t.Errorf("group %s deleted %d times, want 1", id, deleted[id])
+		}
+	}
+	if got := atomic.LoadInt32(&maxInFlight); got > DeleteGroupsParallelism {
+		t.Errorf("max concurrent deletes = %d, want <= %d", got, DeleteGroupsParallelism)
+	}
+}
+
+func TestService_DeleteGroups_AllSucceed(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		return &client.Response{StatusCode: 204}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	if err := svc.DeleteGroups(context.Background(), []string{"g1", "g2"}); err != nil {
+		t.Fatalf("expected nil error, got %v", err)
+	}
+}
+
+func TestService_DeleteGroups_ContextCanceled(t *testing.T) {
+	var calls int32
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		atomic.AddInt32(&calls, 1)
+		return &client.Response{StatusCode: 204}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := svc.DeleteGroups(ctx, []string{"g1", "g2", "g3"})
+
+	var delErr *DeleteGroupsError
+	if !errors.As(err, &delErr) || len(delErr.Failed) != 3 {
+		t.Fatalf("expected all deletes to fail with the context error, got %v", err)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected errors.Is(err, context.Canceled), got %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 0 {
+		t.Errorf("expected no requests after cancellation, got %d", got)
+	}
+}
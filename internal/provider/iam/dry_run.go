@@ -0,0 +1,61 @@
+package iam
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DryRunIDPrefix marks IDs synthesized by write operations in dry-run mode,
+// so previewed results cannot be mistaken for real API entities
+const DryRunIDPrefix = "dryrun-"
+
+type dryRunContextKey struct{}
+
+// WithDryRun returns a context under which Service write operations log what they
+// would do and return synthesized results instead of issuing HTTP requests.
+// Reads still call the API so diffs such as ReplaceGroupRoles can be computed.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunContextKey{}, true)
+}
+
+// IsDryRun reports whether ctx was created with WithDryRun
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunContextKey{}).(bool)
+	return dryRun
+}
+
+// dryRun logs a skipped write and reports whether the caller should return early
+func dryRun(ctx context.Context, action, entityType, id string) bool {
+	if !IsDryRun(ctx) {
+		return false
+	}
+	tflog.Info(ctx, "Dry run: skipping API call", map[string]interface{}{
+		"action": action,
+		"entity": entityType,
+		"id":     id,
+	})
+	return true
+}
+
+// dryRunRoleBinding synthesizes the result of CreateRoleBinding without resolving
+// the group, so the ID is built from the group name rather than its ID
+func (s *Service) dryRunRoleBinding(binding *RoleBinding) *RoleBinding {
+	var groupName string
+	for _, member := range binding.Members {
+		if name, ok := strings.CutPrefix(member, "group:"); ok {
+			groupName = name
+			break
+		}
+	}
+
+	roleID, isCustom := normalizeRoleID(binding.Role)
+	return &RoleBinding{
+		ID:        DryRunIDPrefix + s.FormatRoleBindingID(groupName, denormalizeRoleID(roleID, isCustom, roleIDFormatAPI)),
+		Name:      binding.Name,
+		Role:      binding.Role,
+		Members:   binding.Members,
+		Condition: binding.Condition,
+	}
+}
@@ -0,0 +1,64 @@
+package iam
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_DryRun(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		t.Errorf("unexpected API call in dry-run mode: %s %s", req.Method, req.Path)
+		return &client.Response{StatusCode: 500}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	ctx := WithDryRun(context.Background())
+
+	group, err := svc.CreateGroup(ctx, &Group{Name: "ops"})
+	if err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	if group.ID != "dryrun-ops" || group.Name != "ops" {
+		t.Errorf("CreateGroup = %+v, want dry-run ID for ops", group)
+	}
+
+	binding, err := svc.CreateRoleBinding(ctx, &RoleBinding{Role: "roles/custom.Auditor", Members: []string{"group:ops"}})
+	if err != nil {
+		t.Fatalf("CreateRoleBinding failed: %v", err)
+	}
+	if binding.ID != "dryrun-ops/custom.Auditor" {
+		t.Errorf("CreateRoleBinding ID = %q, want %q", binding.ID, "dryrun-ops/custom.Auditor")
+	}
+
+	role, err := svc.CreateCustomRole(ctx, &CustomRole{ID: "Auditor"})
+	if err != nil || !strings.HasPrefix(role.ID, DryRunIDPrefix) {
+		t.Errorf("CreateCustomRole = %+v, %v, want dry-run ID", role, err)
+	}
+	resource, err := svc.SetResource(ctx, "bu:001", &SetResourceDto{Name: "store"})
+	if err != nil || resource.ID != "dryrun-bu:001" {
+		t.Errorf("SetResource = %+v, %v, want dry-run ID", resource, err)
+	}
+
+	for name, op := range map[string]func() error{
+		"DeleteRoleBinding": func() error { return svc.DeleteRoleBinding(ctx, "g1/custom.Auditor") },
+		"AddRoleToGroup":    func() error { return svc.AddRoleToGroup(ctx, "g1", "Auditor", true, nil) },
+		"DeleteGroup":       func() error { return svc.DeleteGroup(ctx, "g1") },
+		"DeleteCustomRole":  func() error { return svc.DeleteCustomRole(ctx, "Auditor", DeleteOptions{}) },
+		"DeleteResource":    func() error { return svc.DeleteResource(ctx, "bu:001") },
+	} {
+		if err := op(); err != nil {
+			t.Errorf("%s failed: %v", name, err)
+		}
+	}
+}
+
+func TestIsDryRun(t *testing.T) {
+	if IsDryRun(context.Background()) {
+		t.Error("plain context should not be dry-run")
+	}
+	if !IsDryRun(WithDryRun(context.Background())) {
+		t.Error("WithDryRun context should be dry-run")
+	}
+}
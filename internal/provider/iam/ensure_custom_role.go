@@ -0,0 +1,32 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// EnsureCustomRole creates a custom role, or updates the existing role with
+// the same ID when the API reports a conflict. The update sends the complete
+// desired permissions, so permissions missing from role are removed and the
+// result matches role whatever the prior state. As with UpdateCustomRole,
+// empty name, title and description leave the existing values in place.
+func (s *Service) EnsureCustomRole(ctx context.Context, role *CustomRole) (*CustomRole, error) {
+	unlock := customRoleLocks.Lock(s.tenantID + "/" + s.normalizeID(ctx, "custom role", role.ID))
+	defer unlock()
+
+	created, err := s.CreateCustomRole(ctx, role)
+	if err == nil {
+		return created, nil
+	}
+	if !client.IsConflictError(err) {
+		return nil, err
+	}
+
+	updated, updateErr := s.UpdateCustomRole(ctx, role.ID, role)
+	if updateErr != nil {
+		return nil, fmt.Errorf("custom role %s already exists and could not be updated: %w", role.ID, updateErr)
+	}
+	return updated, nil
+}
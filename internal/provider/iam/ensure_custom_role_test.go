@@ -0,0 +1,136 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// customRolesServer keeps custom roles by ID, answering a POST for an
+// existing role with 409 Conflict and recording each request method
+type customRolesServer struct {
+	roles   map[string]CustomRole
+	methods []string
+}
+
+func (m *customRolesServer) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	m.methods = append(m.methods, req.Method)
+
+	var sent CustomRole
+	if req.Body != nil {
+		body, err := json.Marshal(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(body, &sent); err != nil {
+			return nil, err
+		}
+	}
+
+	id := req.Path[strings.LastIndex(req.Path, "/")+1:]
+	switch {
+	case req.Method == "POST" && strings.HasSuffix(req.Path, "/roles"):
+		if _, ok := m.roles[sent.ID]; ok {
+			return &client.Response{StatusCode: 409, Body: []byte(`{"message":"role already exists"}`)}, nil
+		}
+		m.roles[sent.ID] = sent
+		id = sent.ID
+	case req.Method == "PUT":
+		existing, ok := m.roles[id]
+		if !ok {
+			return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+		}
+		existing.Permissions = sent.Permissions
+		if sent.Name != "" {
+			existing.Name = sent.Name
+		}
+		m.roles[id] = existing
+	case req.Method != "GET":
+		return nil, errors.New("unexpected request")
+	}
+
+	role, ok := m.roles[id]
+	if !ok {
+		return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+	}
+	body, _ := json.Marshal(role)
+	return &client.Response{StatusCode: 200, Body: body}, nil
+}
+
+func TestService_EnsureCustomRole_Creates(t *testing.T) {
+	server := &customRolesServer{roles: map[string]CustomRole{}}
+	svc := &Service{rawClient: server, tenantID: "t"}
+
+	role, err := svc.EnsureCustomRole(context.Background(), &CustomRole{
+		ID:          "cashier",
+		Name:        "Cashier",
+		Permissions: []Permission{{ID: "pos.payment.create"}},
+	})
+	if err != nil {
+		t.Fatalf("EnsureCustomRole() error = %v", err)
+	}
+	if role.ID != "cashier" || !reflect.DeepEqual(permissionIDs(role.Permissions), []string{"pos.payment.create"}) {
+		t.Errorf("EnsureCustomRole() = %+v, want the created role", role)
+	}
+	if !reflect.DeepEqual(server.methods, []string{"POST"}) {
+		t.Errorf("requests = %v, want a single POST", server.methods)
+	}
+}
+
+func TestService_EnsureCustomRole_ConflictUpdates(t *testing.T) {
+	server := &customRolesServer{roles: map[string]CustomRole{
+		"cashier": {ID: "cashier", Name: "Old", Permissions: []Permission{{ID: "pos.payment.create"}}},
+	}}
+	svc := &Service{rawClient: server, tenantID: "t"}
+
+	role, err := svc.EnsureCustomRole(context.Background(), &CustomRole{
+		ID:          "cashier",
+		Name:        "Cashier",
+		Permissions: []Permission{{ID: "pos.payment.create"}, {ID: "pos.refund.create"}},
+	})
+	if err != nil {
+		t.Fatalf("EnsureCustomRole() error = %v", err)
+	}
+	if !reflect.DeepEqual(server.methods, []string{"POST", "PUT"}) {
+		t.Errorf("requests = %v, want POST then PUT", server.methods)
+	}
+	if role.Name != "Cashier" || !reflect.DeepEqual(permissionIDs(role.Permissions), []string{"pos.payment.create", "pos.refund.create"}) {
+		t.Errorf("EnsureCustomRole() = %+v, want the desired name and permissions", role)
+	}
+}
+
+func TestService_EnsureCustomRole_PrunesPermissions(t *testing.T) {
+	server := &customRolesServer{roles: map[string]CustomRole{
+		"cashier": {ID: "cashier", Permissions: []Permission{{ID: "pos.payment.create"}, {ID: "pos.refund.create"}, {ID: "iam.group.list"}}},
+	}}
+	svc := &Service{rawClient: server, tenantID: "t"}
+
+	if _, err := svc.EnsureCustomRole(context.Background(), &CustomRole{
+		ID:          "cashier",
+		Permissions: []Permission{{ID: "pos.payment.create"}},
+	}); err != nil {
+		t.Fatalf("EnsureCustomRole() error = %v", err)
+	}
+	if got := permissionIDs(server.roles["cashier"].Permissions); !reflect.DeepEqual(got, []string{"pos.payment.create"}) {
+		t.Errorf("stored permissions = %v, want only pos.payment.create", got)
+	}
+}
+
+func TestService_EnsureCustomRole_OtherErrors(t *testing.T) {
+	svc := &Service{rawClient: &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method != "POST" {
+			t.Errorf("unexpected %s after a failed create", req.Method)
+		}
+		return &client.Response{StatusCode: 400, Body: []byte(`{"message":"invalid permission"}`)}, nil
+	}}, tenantID: "t"}
+
+	_, err := svc.EnsureCustomRole(context.Background(), &CustomRole{ID: "cashier"})
+	if !client.IsValidationError(err) {
+		t.Errorf("EnsureCustomRole() error = %v, want the create error", err)
+	}
+}
@@ -0,0 +1,55 @@
+package iam
+
+import "sync"
+
+// keyedMutex hands out one mutex per key so that operations on the same key are
+// serialized while operations on different keys run in parallel. Entries are
+// reference counted and dropped once no caller holds or waits for them.
+type keyedMutex struct {
+	mu    sync.Mutex
+	locks map[string]*keyedMutexEntry
+}
+
+type keyedMutexEntry struct {
+	mu   sync.Mutex
+	refs int
+}
+
+func newKeyedMutex() *keyedMutex {
+	return &keyedMutex{locks: make(map[string]*keyedMutexEntry)}
+}
+
+// Lock acquires the mutex for key and returns the function that releases it
+func (k *keyedMutex) Lock(key string) func() {
+	k.mu.Lock()
+	entry, ok := k.locks[key]
+	if !ok {
+		entry = &keyedMutexEntry{}
+		k.locks[key] = entry
+	}
+	entry.refs++
+	k.mu.Unlock()
+
+	entry.mu.Lock()
+
+	return func() {
+		entry.mu.Unlock()
+
+		k.mu.Lock()
+		entry.refs--
+		if entry.refs == 0 {
+			delete(k.locks, key)
+		}
+		k.mu.Unlock()
+	}
+}
+
+// groupLocks serializes role assignment changes per tenant/group within the
+// process. Each resource builds its own Service, so the locks are shared at
+// package level rather than per Service.
+var groupLocks = newKeyedMutex()
+
+// lockGroup serializes role modifications on a single group
+func (s *Service) lockGroup(groupID string) func() {
+	return groupLocks.Lock(s.tenantID + "/" + groupID)
+}
@@ -0,0 +1,101 @@
+package iam
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// racyGroupRolesBackend simulates a backend whose role assignment is a
+// non-atomic read-modify-write, so unserialized concurrent POSTs clobber each other.
+type racyGroupRolesBackend struct {
+	mu    sync.Mutex
+	roles map[string][]string
+}
+
+func (b *racyGroupRolesBackend) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	if req.Method != "POST" || !strings.HasSuffix(req.Path, "/roles") {
+		return &client.Response{StatusCode: 404}, nil
+	}
+	payload := req.Body.(map[string]interface{})
+	roleID := payload["roleId"].(string)
+
+	b.mu.Lock()
+	snapshot := append([]string(nil), b.roles[req.Path]...)
+	b.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	b.mu.Lock()
+	b.roles[req.Path] = append(snapshot, roleID)
+	b.mu.Unlock()
+
+	return &client.Response{StatusCode: 201, Body: []byte(`{}`)}, nil
+}
+
+func TestService_AddRoleToGroup_ConcurrentSameGroupSerialized(t *testing.T) {
+	backend := &racyGroupRolesBackend{roles: make(map[string][]string)}
+	// Two independent Services, as two role binding resources would have
+	svcA := &Service{rawClient: backend, tenantID: "t"}
+	svcB := &Service{rawClient: backend, tenantID: "t"}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for _, tc := range []struct {
+		svc  *Service
+		role string
+	}{{svcA, "RoleA"}, {svcB, "RoleB"}} {
+		wg.Add(1)
+		go func(svc *Service, role string) {
+			defer wg.Done()
+			errs <- svc.AddRoleToGroup(context.Background(), "g1", role, false, []string{"bu:001"})
+		}(tc.svc, tc.role)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("AddRoleToGroup failed: %v", err)
+		}
+	}
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	got := backend.roles["/api/v2/tenants/t/groups/g1/roles"]
+	if len(got) != 2 {
+		t.Fatalf("expected both roles to persist, got %v", got)
+	}
+}
+
+func TestKeyedMutex_ReleasesEntries(t *testing.T) {
+	km := newKeyedMutex()
+	unlockA := km.Lock("a")
+	unlockB := km.Lock("b") // different key must not block
+	unlockA()
+	unlockB()
+
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	if len(km.locks) != 0 {
+		t.Fatalf("expected no lingering entries, got %d", len(km.locks))
+	}
+}
+
+func TestService_RemoveRoleFromGroup_CustomPath(t *testing.T) {
+	var gotPath string
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		gotPath = req.Path
+		return &client.Response{StatusCode: 204}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	if err := svc.RemoveRoleFromGroup(context.Background(), "g1", "cr1", true); err != nil {
+		t.Fatalf("RemoveRoleFromGroup failed: %v", err)
+	}
+	if gotPath != "/api/v2/tenants/t/groups/g1/roles/cr1" {
+		t.Fatalf("unexpected path: %s", gotPath)
+	}
+}
@@ -0,0 +1,71 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+)
+
+// maxGroupPages bounds how many pages EachGroup follows, so a server that
+// keeps returning the same next_page cannot loop forever.
+const maxGroupPages = 1000
+
+// EachGroup calls fn for every group matching req.Filter, requesting pages of
+// req.PageSize groups (the server default when zero) from req.Page onwards
+// until the server stops returning a next_page. Only one page is held in
+// memory at a time. Iteration stops at the first error returned by fn.
+func (s *Service) EachGroup(ctx context.Context, req *ListGroupsRequest, fn func(Group) error) error {
+	pageReq := *req
+	return eachPage("groups", req.Page, maxGroupPages, func(page int) (int, error) {
+		pageReq.Page = page
+		result, err := s.ListGroups(ctx, &pageReq)
+		if err != nil {
+			return 0, err
+		}
+		for _, g := range result.Groups {
+			if err := fn(g); err != nil {
+				return 0, err
+			}
+		}
+		return result.NextPage, nil
+	})
+}
+
+// ListAllGroups returns every group matching req.Filter across all pages
+func (s *Service) ListAllGroups(ctx context.Context, req *ListGroupsRequest) ([]Group, error) {
+	var groups []Group
+	err := s.EachGroup(ctx, req, func(g Group) error {
+		groups = append(groups, g)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// decodeGroupsPage accepts either a plain array of groups or the paginated
+// {"groups": [...], "next_page": n, "total": n} envelope.
+func decodeGroupsPage(body []byte) (*ListGroupsResponse, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return &ListGroupsResponse{}, nil
+	}
+
+	if trimmed[0] == '[' {
+		var groups []Group
+		if err := json.Unmarshal(trimmed, &groups); err != nil {
+			return nil, err
+		}
+		return &ListGroupsResponse{Groups: groups, Total: len(groups)}, nil
+	}
+
+	var result ListGroupsResponse
+	if err := json.Unmarshal(trimmed, &result); err != nil {
+		return nil, err
+	}
+	if result.Total == 0 {
+		result.Total = len(result.Groups)
+	}
+	return &result, nil
+}
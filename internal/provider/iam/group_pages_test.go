@@ -0,0 +1,175 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// pagedGroupsMock serves total groups named group-0..group-(total-1) in pages
+// of the requested page_size (default 100), applying a `name co "x"` filter
+// server-side. It records every query it receives.
+func pagedGroupsMock(total int, queries *[]map[string]string) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if queries != nil {
+			*queries = append(*queries, req.Query)
+		}
+		var names []string
+		contains := ""
+		if f := req.Query["filter"]; f != "" {
+			contains = strings.Trim(strings.TrimPrefix(f, "name co "), `"`)
+		}
+		for i := 0; i < total; i++ {
+			name := fmt.Sprintf("group-%d", i)
+			if strings.Contains(name, contains) {
+				names = append(names, name)
+			}
+		}
+
+		size := 100
+		if v := req.Query["page_size"]; v != "" {
+			size, _ = strconv.Atoi(v)
+		}
+		page, _ := strconv.Atoi(req.Query["page"])
+		start := page * size
+		end := start + size
+		if start > len(names) {
+			start = len(names)
+		}
+		if end > len(names) {
+			end = len(names)
+		}
+
+		out := ListGroupsResponse{Total: len(names)}
+		for _, name := range names[start:end] {
+			out.Groups = append(out.Groups, Group{ID: "id-" + name, Name: name})
+		}
+		if end < len(names) {
+			out.NextPage = page + 1
+		}
+		body, _ := json.Marshal(out)
+		return &client.Response{StatusCode: 200, Body: body}, nil
+	}}
+}
+
+func TestService_EachGroup_FollowsPages(t *testing.T) {
+	var queries []map[string]string
+	svc := &Service{rawClient: pagedGroupsMock(2500, &queries), tenantID: "t"}
+
+	seen := map[string]bool{}
+	err := svc.EachGroup(context.Background(), &ListGroupsRequest{PageSize: 200}, func(g Group) error {
+		if seen[g.ID] {
+			t.Errorf("group %s returned twice", g.ID)
+		}
+		seen[g.ID] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("EachGroup failed: %v", err)
+	}
+	if len(seen) != 2500 {
+		t.Errorf("got %d groups, want 2500", len(seen))
+	}
+	if len(queries) != 13 {
+		t.Errorf("made %d requests, want 13", len(queries))
+	}
+	for _, q := range queries {
+		if q["page_size"] != "200" {
+			t.Fatalf("page_size not forwarded: %v", q)
+		}
+	}
+}
+
+func TestService_ListAllGroups_Filter(t *testing.T) {
+	var queries []map[string]string
+	svc := &Service{rawClient: pagedGroupsMock(1000, &queries), tenantID: "t"}
+
+	groups, err := svc.ListAllGroups(context.Background(), &ListGroupsRequest{Filter: `name co "-99"`, PageSize: 5})
+	if err != nil {
+		t.Fatalf("ListAllGroups failed: %v", err)
+	}
+	// group-99 and group-990..group-999
+	if len(groups) != 11 {
+		t.Fatalf("got %d groups, want 11: %v", len(groups), groups)
+	}
+	for _, g := range groups {
+		if !strings.Contains(g.Name, "-99") {
+			t.Errorf("group %s does not match the filter", g.Name)
+		}
+	}
+	for _, q := range queries {
+		if q["filter"] != `name co "-99"` {
+			t.Fatalf("filter not forwarded: %v", q)
+		}
+	}
+}
+
+func TestService_EachGroup_StopsOnCallbackError(t *testing.T) {
+	var queries []map[string]string
+	svc := &Service{rawClient: pagedGroupsMock(1000, &queries), tenantID: "t"}
+
+	stop := errors.New("stop")
+	count := 0
+	err := svc.EachGroup(context.Background(), &ListGroupsRequest{}, func(g Group) error {
+		count++
+		if count == 150 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) {
+		t.Fatalf("expected callback error, got %v", err)
+	}
+	if len(queries) != 2 {
+		t.Errorf("made %d requests after stopping, want 2", len(queries))
+	}
+}
+
+func TestService_EachGroup_PlainArrayAndLoopGuard(t *testing.T) {
+	t.Run("plain array is one page", func(t *testing.T) {
+		calls := 0
+		mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+			calls++
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1","name":"a"},{"id":"g2","name":"b"}]`)}, nil
+		}}
+		svc := &Service{rawClient: mock, tenantID: "t"}
+
+		groups, err := svc.ListAllGroups(context.Background(), &ListGroupsRequest{})
+		if err != nil || len(groups) != 2 || calls != 1 {
+			t.Fatalf("got %v groups, %d calls, err %v", groups, calls, err)
+		}
+	})
+
+	t.Run("repeated next_page stops", func(t *testing.T) {
+		mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+			return &client.Response{StatusCode: 200, Body: []byte(`{"groups":[{"id":"g1"}],"next_page":1}`)}, nil
+		}}
+		svc := &Service{rawClient: mock, tenantID: "t"}
+
+		groups, err := svc.ListAllGroups(context.Background(), &ListGroupsRequest{})
+		if err != nil || len(groups) != 2 {
+			t.Fatalf("got %v groups, err %v", groups, err)
+		}
+	})
+}
+
+func BenchmarkService_EachGroup(b *testing.B) {
+	svc := &Service{rawClient: pagedGroupsMock(5000, nil), tenantID: "t"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		count := 0
+		err := svc.EachGroup(context.Background(), &ListGroupsRequest{PageSize: 100}, func(g Group) error {
+			count++
+			return nil
+		})
+		if err != nil || count != 5000 {
+			b.Fatalf("got %d groups, err %v", count, err)
+		}
+	}
+}
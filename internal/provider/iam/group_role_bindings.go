@@ -0,0 +1,36 @@
+package iam
+
+import "context"
+
+// ListRoleBindingsForGroup returns every role bound to a group as role
+// bindings, with one V2 request for the group's roles rather than a
+// GetRoleBinding per role. IDs and roles use the same forms as
+// GetRoleBinding, and members name the group as "group:<name>".
+func (s *Service) ListRoleBindingsForGroup(ctx context.Context, groupID string) ([]RoleBinding, error) {
+	groupID = s.normalizeID(ctx, "group", groupID)
+
+	group, err := s.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.ListGroupRoles(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	members := s.normalizeMembers(ctx, []string{"group:" + group.Name})
+	bindings := make([]RoleBinding, 0, len(roles))
+	for _, role := range roles {
+		id := plainRoleID(role)
+		bindings = append(bindings, RoleBinding{
+			ID:        s.FormatRoleBindingID(groupID, denormalizeRoleID(id, role.IsCustom, roleIDFormatAPI)),
+			Role:      denormalizeRoleID(id, role.IsCustom, roleIDFormatTerraform),
+			Members:   append([]string(nil), members...),
+			Bindings:  role.Bindings,
+			CreatedAt: group.CreatedAt,
+			UpdatedAt: group.UpdatedAt,
+		})
+	}
+	return bindings, nil
+}
@@ -0,0 +1,57 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_ListRoleBindingsForGroup(t *testing.T) {
+	var roleRequests int
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch req.Path {
+		case "/api/v1/tenants/t/groups/g1":
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"g1","name":"cashiers"}`)}, nil
+		case "/api/v2/tenants/t/groups/g1/roles":
+			roleRequests++
+			return &client.Response{StatusCode: 200, Body: []byte(`[
+				{"roleId":"pos.admin","isCustom":false,"bindings":["bu:001"]},
+				{"roleId":"custom.Cashier","isCustom":true,"bindings":["bu:001","bu:002"]},
+				{"roleId":"custom-roles/custom.Auditor","isCustom":true,"bindings":[]}
+			]`)}, nil
+		}
+		return nil, errors.New("unexpected request " + req.Path)
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	bindings, err := svc.ListRoleBindingsForGroup(context.Background(), "g1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if roleRequests != 1 {
+		t.Errorf("got %d role requests, want 1", roleRequests)
+	}
+
+	want := []RoleBinding{
+		{ID: "g1/pos.admin", Role: "roles/pos.admin", Members: []string{"group:cashiers"}, Bindings: []string{"bu:001"}},
+		{ID: "g1/custom.Cashier", Role: "roles/custom.Cashier", Members: []string{"group:cashiers"}, Bindings: []string{"bu:001", "bu:002"}},
+		{ID: "g1/custom.Auditor", Role: "roles/custom.Auditor", Members: []string{"group:cashiers"}, Bindings: []string{}},
+	}
+	if !reflect.DeepEqual(bindings, want) {
+		t.Errorf("bindings = %+v, want %+v", bindings, want)
+	}
+}
+
+func TestService_ListRoleBindingsForGroup_GroupNotFound(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	if _, err := svc.ListRoleBindingsForGroup(context.Background(), "missing"); !client.IsNotFoundError(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RoleAssignment describes a role to bind to a group.
+// RoleID is given without the "custom." prefix, as for AddRoleToGroup.
+type RoleAssignment struct {
+	RoleID   string
+	IsCustom bool
+	Bindings []string
+}
+
+// compositeRoleID returns the role ID as used in composite role binding IDs
+func (a RoleAssignment) compositeRoleID() string {
+	return denormalizeRoleID(a.RoleID, a.IsCustom, roleIDFormatAPI)
+}
+
+// GroupRoleBindingError reports role assignments that failed during CreateGroupWithRoles
+type GroupRoleBindingError struct {
+	GroupID    string
+	Failed     []RoleAssignment
+	Errs       []error
+	RolledBack bool // The group was deleted because every assignment failed
+}
+
+func (e *GroupRoleBindingError) Error() string {
+	roles := make([]string, len(e.Failed))
+	for i, a := range e.Failed {
+		roles[i] = a.compositeRoleID()
+	}
+	msg := fmt.Sprintf("failed to bind roles %s to group %s", strings.Join(roles, ", "), e.GroupID)
+	if e.RolledBack {
+		msg += " (group creation rolled back)"
+	}
+	return fmt.Sprintf("%s: %v", msg, errors.Join(e.Errs...))
+}
+
+func (e *GroupRoleBindingError) Unwrap() []error {
+	return e.Errs
+}
+
+// SetGroupRollback controls whether CreateGroupWithRoles deletes the new group
+// when every role assignment fails. Rollback is enabled by default.
+func (s *Service) SetGroupRollback(enabled bool) {
+	s.skipGroupRollback = !enabled
+}
+
+// CreateGroupWithRoles creates a group and binds the given roles to it.
+//
+// When some assignments fail the group and the successful bindings are returned
+// together with a *GroupRoleBindingError. When all assignments fail the group is
+// deleted again, unless disabled with SetGroupRollback, and no group is returned.
+func (s *Service) CreateGroupWithRoles(ctx context.Context, group *Group, roles []RoleAssignment) (*Group, []RoleBinding, error) {
+	created, err := s.CreateGroup(ctx, group)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var bindings []RoleBinding
+	bindErr := &GroupRoleBindingError{GroupID: created.ID}
+	for _, role := range roles {
+		if err := s.AddRoleToGroup(ctx, created.ID, role.RoleID, role.IsCustom, role.Bindings); err != nil {
+			bindErr.Failed = append(bindErr.Failed, role)
+			bindErr.Errs = append(bindErr.Errs, err)
+			continue
+		}
+		bindings = append(bindings, RoleBinding{
+			ID:      s.FormatRoleBindingID(created.ID, role.compositeRoleID()),
+			Role:    denormalizeRoleID(role.RoleID, role.IsCustom, roleIDFormatTerraform),
+			Members: []string{"group:" + created.Name},
+		})
+	}
+
+	if len(bindErr.Failed) == 0 {
+		return created, bindings, nil
+	}
+	if len(bindings) > 0 || s.skipGroupRollback {
+		return created, bindings, bindErr
+	}
+
+	if err := s.DeleteGroup(ctx, created.ID); err != nil {
+		bindErr.Errs = append(bindErr.Errs, fmt.Errorf("failed to roll back group %s: %w", created.ID, err))
+		return created, nil, bindErr
+	}
+	bindErr.RolledBack = true
+	return nil, nil, bindErr
+}
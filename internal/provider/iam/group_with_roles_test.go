@@ -0,0 +1,116 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// groupWithRolesMock creates group g1 and fails role assignments whose roleId is in failing
+func groupWithRolesMock(failing map[string]bool, deleted *bool) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch {
+		case req.Method == "POST" && req.Path == "/api/v1/tenants/t/groups":
+			return &client.Response{StatusCode: 201, Body: []byte(`{"id":"g1","name":"ops"}`)}, nil
+		case req.Method == "POST" && req.Path == "/api/v2/tenants/t/groups/g1/roles":
+			roleID, _ := req.Body.(map[string]interface{})["roleId"].(string)
+			if failing[roleID] {
+				return &client.Response{StatusCode: 400, Body: []byte(`{"message":"bad role"}`)}, nil
+			}
+			return &client.Response{StatusCode: 201}, nil
+		case req.Method == "GET" && req.Path == "/api/v1/tenants/t/roles/Auditor":
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"Auditor"}`)}, nil
+		case req.Method == "DELETE" && req.Path == "/api/v1/tenants/t/groups/g1":
+			*deleted = true
+			return &client.Response{StatusCode: 204}, nil
+		}
+		return nil, errors.New("unexpected request " + req.Method + " " + req.Path)
+	}}
+}
+
+func TestService_CreateGroupWithRoles(t *testing.T) {
+	roles := []RoleAssignment{
+		{RoleID: "pos.cashier", Bindings: []string{"bu:001"}},
+		{RoleID: "Auditor", IsCustom: true},
+	}
+
+	cases := []struct {
+		name            string
+		failing         map[string]bool
+		disableRollback bool
+		wantGroup       bool
+		wantBindingIDs  []string
+		wantFailed      int
+		wantRolledBack  bool
+		wantDeleted     bool
+	}{
+		{name: "success", wantGroup: true, wantBindingIDs: []string{"g1/pos.cashier", "g1/custom.Auditor"}},
+		{name: "partial failure keeps group", failing: map[string]bool{"Auditor": true}, wantGroup: true, wantBindingIDs: []string{"g1/pos.cashier"}, wantFailed: 1},
+		{name: "total failure rolls back", failing: map[string]bool{"pos.cashier": true, "Auditor": true}, wantFailed: 2, wantRolledBack: true, wantDeleted: true},
+		{name: "total failure without rollback", failing: map[string]bool{"pos.cashier": true, "Auditor": true}, disableRollback: true, wantGroup: true, wantFailed: 2},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			deleted := false
+			svc := &Service{rawClient: groupWithRolesMock(tc.failing, &deleted), tenantID: "t"}
+			if tc.disableRollback {
+				svc.SetGroupRollback(false)
+			}
+
+			group, bindings, err := svc.CreateGroupWithRoles(context.Background(), &Group{Name: "ops"}, roles)
+
+			if (group != nil) != tc.wantGroup {
+				t.Errorf("group = %+v, want group returned: %v", group, tc.wantGroup)
+			}
+			if len(bindings) != len(tc.wantBindingIDs) {
+				t.Fatalf("got %d bindings, want %d: %+v", len(bindings), len(tc.wantBindingIDs), bindings)
+			}
+			for i, id := range tc.wantBindingIDs {
+				if bindings[i].ID != id {
+					t.Errorf("binding[%d].ID = %q, want %q", i, bindings[i].ID, id)
+				}
+			}
+			if deleted != tc.wantDeleted {
+				t.Errorf("group deleted = %v, want %v", deleted, tc.wantDeleted)
+			}
+
+			if tc.wantFailed == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var bindErr *GroupRoleBindingError
+			if !errors.As(err, &bindErr) {
+				t.Fatalf("expected *GroupRoleBindingError, got %v", err)
+			}
+			if len(bindErr.Failed) != tc.wantFailed {
+				t.Errorf("failed assignments = %d, want %d", len(bindErr.Failed), tc.wantFailed)
+			}
+			if bindErr.RolledBack != tc.wantRolledBack {
+				t.Errorf("RolledBack = %v, want %v", bindErr.RolledBack, tc.wantRolledBack)
+			}
+		})
+	}
+}
+
+func TestService_CreateGroupWithRoles_GroupCreateFails(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method == "POST" && req.Path == "/api/v1/tenants/t/groups" {
+			return &client.Response{StatusCode: 409, Body: []byte(`{"message":"exists"}`)}, nil
+		}
+		return nil, errors.New("unexpected request " + req.Method + " " + req.Path)
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	group, bindings, err := svc.CreateGroupWithRoles(context.Background(), &Group{Name: "ops"}, []RoleAssignment{{RoleID: "r1"}})
+	if err == nil || !client.IsConflictError(err) {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+	if group != nil || bindings != nil {
+		t.Errorf("expected no results, got %+v %+v", group, bindings)
+	}
+}
@@ -0,0 +1,112 @@
+package iam
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// IDCase selects how IDs are cased by IDNormalization
+type IDCase int
+
+const (
+	// IDCasePreserve leaves the case of IDs unchanged
+	IDCasePreserve IDCase = iota
+	// IDCaseLower lowercases IDs
+	IDCaseLower
+	// IDCaseUpper uppercases IDs
+	IDCaseUpper
+)
+
+// ParseIDCase returns the IDCase named "lower" or "upper"; anything else,
+// including "preserve", is IDCasePreserve
+func ParseIDCase(name string) IDCase {
+	switch name {
+	case "lower":
+		return IDCaseLower
+	case "upper":
+		return IDCaseUpper
+	}
+	return IDCasePreserve
+}
+
+// IDNormalization describes how IDs are cleaned up before they are used in request paths.
+// The zero value leaves IDs untouched.
+type IDNormalization struct {
+	TrimSpace bool   // Strip leading and trailing whitespace
+	Case      IDCase // Case folding; only enable for tenants whose IDs are case-insensitive
+}
+
+// Apply returns id normalized according to n
+func (n IDNormalization) Apply(id string) string {
+	if n.TrimSpace {
+		id = strings.TrimSpace(id)
+	}
+	return n.fold(id)
+}
+
+// applyRole is Apply for role IDs: the "roles/", "custom-roles/" and
+// "custom." prefixes keep their case and only the role ID after them is folded
+func (n IDNormalization) applyRole(id string) string {
+	if n.TrimSpace {
+		id = strings.TrimSpace(id)
+	}
+	plain, _ := normalizeRoleID(id)
+	prefix := id[:len(id)-len(plain)]
+	return prefix + n.fold(plain)
+}
+
+// fold applies the configured case folding to id
+func (n IDNormalization) fold(id string) string {
+	switch n.Case {
+	case IDCaseLower:
+		return strings.ToLower(id)
+	case IDCaseUpper:
+		return strings.ToUpper(id)
+	}
+	return id
+}
+
+// SetIDNormalization configures the normalization applied to group, role, role binding
+// and resource IDs before path construction. Normalization is disabled by default.
+func (s *Service) SetIDNormalization(n IDNormalization) {
+	s.idNormalization = n
+}
+
+// normalizeID applies the configured normalization and logs a warning when it changes the ID,
+// so a pasted ID with stray whitespace shows up in the logs instead of as a confusing 404
+func (s *Service) normalizeID(ctx context.Context, kind, id string) string {
+	var normalized string
+	switch kind {
+	case "role", "custom role":
+		normalized = s.idNormalization.applyRole(id)
+	case "role binding":
+		normalized = s.normalizeRoleBindingID(id)
+	default:
+		normalized = s.idNormalization.Apply(id)
+	}
+	if normalized != id {
+		tflog.Warn(ctx, "Normalized "+kind+" ID before use", map[string]interface{}{
+			"original":   id,
+			"normalized": normalized,
+		})
+	}
+	return normalized
+}
+
+// normalizeRoleBindingID normalizes the group and role parts of a composite
+// role binding ID separately, so the role prefix keeps its case
+func (s *Service) normalizeRoleBindingID(id string) string {
+	if s.idNormalization.TrimSpace {
+		id = strings.TrimSpace(id)
+	}
+	if s.idNormalization.Case == IDCasePreserve {
+		return id
+	}
+	groupID, roleID, err := s.ParseRoleBindingID(id)
+	if err != nil {
+		return s.idNormalization.fold(id)
+	}
+	return s.FormatRoleBindingID(s.idNormalization.fold(groupID), s.idNormalization.applyRole(roleID))
+}
@@ -0,0 +1,84 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestService_IDNormalization(t *testing.T) {
+	cases := []struct {
+		name     string
+		id       string
+		norm     IDNormalization
+		wantPath string
+		wantWarn bool
+	}{
+		{name: "trailing space trimmed", id: "g1  ", norm: IDNormalization{TrimSpace: true}, wantPath: "/api/v1/tenants/t/groups/g1", wantWarn: true},
+		{name: "clean id unchanged", id: "g1", norm: IDNormalization{TrimSpace: true, Case: IDCaseLower}, wantPath: "/api/v1/tenants/t/groups/g1"},
+		{name: "lowercased", id: " G1", norm: IDNormalization{TrimSpace: true, Case: IDCaseLower}, wantPath: "/api/v1/tenants/t/groups/g1", wantWarn: true},
+		{name: "disabled by default", id: "g1 ", wantPath: "/api/v1/tenants/t/groups/g1%20"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotPath string
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				gotPath = req.Path
+				return nil, errors.New("stop")
+			}}
+			svc := &Service{rawClient: mock, tenantID: "t"}
+			svc.SetIDNormalization(tc.norm)
+
+			var logs bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &logs)
+			_ = svc.DeleteGroup(ctx, tc.id)
+
+			if gotPath != tc.wantPath {
+				t.Errorf("path = %q, want %q", gotPath, tc.wantPath)
+			}
+			if warned := strings.Contains(logs.String(), "Normalized group ID"); warned != tc.wantWarn {
+				t.Errorf("warning logged = %v, want %v:\n%s", warned, tc.wantWarn, logs.String())
+			}
+		})
+	}
+}
+
+func TestIDNormalization_RolePrefixKeepsCase(t *testing.T) {
+	upper := IDNormalization{TrimSpace: true, Case: IDCaseUpper}
+	cases := map[string]string{
+		"custom.cashier":        "custom.CASHIER",
+		" roles/custom.cashier": "roles/custom.CASHIER",
+		"custom-roles/cashier":  "custom-roles/CASHIER",
+		"admin":                 "ADMIN",
+	}
+	for id, want := range cases {
+		if got := upper.applyRole(id); got != want {
+			t.Errorf("applyRole(%q) = %q, want %q", id, got, want)
+		}
+	}
+
+	svc := &Service{tenantID: "t"}
+	svc.SetIDNormalization(upper)
+	if got, want := svc.normalizeID(context.Background(), "role binding", "g1/custom.cashier"), "G1/custom.CASHIER"; got != want {
+		t.Errorf("role binding ID = %q, want %q", got, want)
+	}
+}
+
+func TestNewServiceWithOptions_IDNormalization(t *testing.T) {
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, client.DefaultConfig())
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+
+	svc := NewServiceWithOptions(apiClient, "t", Options{IDNormalization: IDNormalization{TrimSpace: true, Case: IDCaseLower}})
+	if got, want := svc.idNormalization, (IDNormalization{TrimSpace: true, Case: IDCaseLower}); got != want {
+		t.Errorf("idNormalization = %+v, want %+v", got, want)
+	}
+}
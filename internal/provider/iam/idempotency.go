@@ -0,0 +1,44 @@
+package iam
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// IdempotencyKeyHeader carries the key that lets the API recognize a retried
+// create and answer it with the original result instead of creating twice
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// idempotencyRunID scopes idempotency keys to this provider process, so an
+// identical create in a later run, e.g. after the entity was deleted, is not
+// answered with the stale result of an earlier one
+var idempotencyRunID = newIdempotencyRunID()
+
+// newIdempotencyRunID returns a random run ID, or an empty one if the system
+// random source fails, which keeps keys deterministic per tenant and body
+func newIdempotencyRunID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// idempotencyKey derives the Idempotency-Key of a create request from the
+// tenant, entity kind and request body. Map keys are marshaled sorted, so
+// identical requests within a run get the same key.
+func (s *Service) idempotencyKey(entity string, body map[string]interface{}) (string, error) {
+	encoded, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive idempotency key: %w", err)
+	}
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(idempotencyRunID), []byte(s.tenantID), []byte(entity), encoded} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
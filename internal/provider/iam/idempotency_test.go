@@ -0,0 +1,102 @@
+package iam
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+var idempotencyKeyPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// recordIdempotencyKeys returns a mock that answers creates with the entity
+// and appends the Idempotency-Key of every POST to keys
+func recordIdempotencyKeys(keys *[]string) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method == "POST" {
+			*keys = append(*keys, req.Headers[IdempotencyKeyHeader])
+		}
+		return &client.Response{StatusCode: 201, Body: []byte(`{"id":"created","name":"created"}`)}, nil
+	}}
+}
+
+func TestService_CreateGroup_IdempotencyKey(t *testing.T) {
+	var keys []string
+	svc := &Service{rawClient: recordIdempotencyKeys(&keys), tenantID: "t"}
+	ctx := context.Background()
+
+	for _, group := range []*Group{
+		{Name: "cashiers", Members: []string{"u1", "u2"}},
+		{Name: "cashiers", Members: []string{"u1", "u2"}},
+		{Name: "cashiers", Members: []string{"u1"}},
+		{Name: "managers", Members: []string{"u1", "u2"}},
+	} {
+		if _, err := svc.CreateGroup(ctx, group); err != nil {
+			t.Fatalf("CreateGroup(%s) error = %v", group.Name, err)
+		}
+	}
+
+	for i, key := range keys {
+		if !idempotencyKeyPattern.MatchString(key) {
+			t.Errorf("request %d Idempotency-Key = %q, want a hex SHA-256", i, key)
+		}
+	}
+	if keys[0] != keys[1] {
+		t.Errorf("identical creates got keys %s and %s, want the same key", keys[0], keys[1])
+	}
+	if keys[0] == keys[2] || keys[0] == keys[3] {
+		t.Errorf("creates with different members or names share key %s", keys[0])
+	}
+
+	other := &Service{rawClient: recordIdempotencyKeys(&keys), tenantID: "other"}
+	if _, err := other.CreateGroup(ctx, &Group{Name: "cashiers", Members: []string{"u1", "u2"}}); err != nil {
+		t.Fatalf("CreateGroup error = %v", err)
+	}
+	if keys[4] == keys[0] {
+		t.Error("identical creates in different tenants share a key")
+	}
+}
+
+func TestService_CreateCustomRole_IdempotencyKey(t *testing.T) {
+	var keys []string
+	svc := &Service{rawClient: recordIdempotencyKeys(&keys), tenantID: "t"}
+	ctx := context.Background()
+
+	role := func() *CustomRole {
+		return &CustomRole{ID: "cashier", Name: "Cashier", Permissions: []Permission{{ID: "pos.payment.create"}}}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := svc.CreateCustomRole(ctx, role()); err != nil {
+			t.Fatalf("CreateCustomRole error = %v", err)
+		}
+	}
+
+	if len(keys) != 2 || !idempotencyKeyPattern.MatchString(keys[0]) || keys[0] != keys[1] {
+		t.Errorf("keys = %v, want the same key for identical creates", keys)
+	}
+
+	groupKey, err := svc.idempotencyKey(AuditEntityGroup, map[string]interface{}{"id": "cashier"})
+	if err != nil {
+		t.Fatalf("idempotencyKey error = %v", err)
+	}
+	roleKey, _ := svc.idempotencyKey(AuditEntityCustomRole, map[string]interface{}{"id": "cashier"})
+	if groupKey == roleKey {
+		t.Error("a group and a custom role with the same body share a key")
+	}
+}
+
+func TestIdempotencyKey_ScopedToRun(t *testing.T) {
+	svc := &Service{tenantID: "t"}
+	body := map[string]interface{}{"name": "cashiers"}
+	first, _ := svc.idempotencyKey(AuditEntityGroup, body)
+
+	previous := idempotencyRunID
+	idempotencyRunID = newIdempotencyRunID()
+	defer func() { idempotencyRunID = previous }()
+
+	second, _ := svc.idempotencyKey(AuditEntityGroup, body)
+	if first == second {
+		t.Error("identical creates in different runs share a key")
+	}
+}
@@ -0,0 +1,86 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_GenerateImportBlocks_Groups(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method != "GET" || req.Path != "/api/v1/tenants/t/groups" {
+			return nil, errors.New("unexpected request " + req.Method + " " + req.Path)
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(`[
+			{"id":"g1","name":"Store Managers"},
+			{"id":"g2","name":"store-managers"},
+			{"id":"g3","name":"42 Cashiers"},
+			{"id":"g4","name":""}
+		]`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	blocks, err := svc.GenerateImportBlocks(context.Background(), ImportEntityGroup)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []ImportBlock{
+		{Address: "hiiretail_iam_group.store_managers", ID: "g1"},
+		{Address: "hiiretail_iam_group.store_managers_2", ID: "g2"},
+		{Address: "hiiretail_iam_group._42_cashiers", ID: "g3"},
+		{Address: "hiiretail_iam_group.g4", ID: "g4"},
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("got %d blocks, want %d: %+v", len(blocks), len(want), blocks)
+	}
+	for i := range want {
+		if blocks[i] != want[i] {
+			t.Errorf("block %d = %+v, want %+v", i, blocks[i], want[i])
+		}
+	}
+
+	hcl := blocks[0].HCL()
+	if !strings.Contains(hcl, "to = hiiretail_iam_group.store_managers") || !strings.Contains(hcl, `id = "g1"`) {
+		t.Errorf("unexpected HCL:\n%s", hcl)
+	}
+}
+
+func TestService_GenerateImportBlocks_CustomRoles(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		return &client.Response{StatusCode: 200, Body: []byte(`{"roles":[
+			{"id":"custom.pos-admin","name":"POS Admin","type":"custom"},
+			{"id":"iam.admin","name":"IAM Admin","type":"basic"},
+			{"id":"auditor","name":"Auditor","type":"custom"}
+		]}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	blocks, err := svc.GenerateImportBlocks(context.Background(), ImportEntityCustomRole)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []ImportBlock{
+		{Address: "hiiretail_iam_custom_role.pos_admin", ID: "pos-admin"},
+		{Address: "hiiretail_iam_custom_role.auditor", ID: "auditor"},
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("got %d blocks, want %d: %+v", len(blocks), len(want), blocks)
+	}
+	for i := range want {
+		if blocks[i] != want[i] {
+			t.Errorf("block %d = %+v, want %+v", i, blocks[i], want[i])
+		}
+	}
+}
+
+func TestService_GenerateImportBlocks_UnsupportedEntity(t *testing.T) {
+	svc := &Service{rawClient: &MockClient{}, tenantID: "t"}
+	if _, err := svc.GenerateImportBlocks(context.Background(), "users"); err == nil || !strings.Contains(err.Error(), "unsupported import entity") {
+		t.Fatalf("expected unsupported entity error, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package iam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// The mocks must keep matching the same interfaces as the real clients
+// asserted in service.go, otherwise this file stops compiling.
+var (
+	_ RawClient     = (*MockClient)(nil)
+	_ clientService = (*MockServiceClient)(nil)
+)
+
+func TestNewService_WiresRealClients(t *testing.T) {
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, client.DefaultConfig())
+	if err != nil {
+		t.Fatalf("client.New failed: %v", err)
+	}
+
+	svc := NewService(apiClient, "t")
+
+	if _, ok := svc.rawClient.(*client.Client); !ok {
+		t.Errorf("rawClient = %T, want *client.Client", svc.rawClient)
+	}
+	if _, ok := svc.client.(*client.ServiceClient); !ok {
+		t.Errorf("client = %T, want *client.ServiceClient", svc.client)
+	}
+	if svc.TenantID() != "t" {
+		t.Errorf("TenantID() = %q, want %q", svc.TenantID(), "t")
+	}
+}
+
+func TestNewService_BasePathFromConfig(t *testing.T) {
+	for _, basePath := range []string{"/api/v1", "/iam/v1"} {
+		t.Run(basePath, func(t *testing.T) {
+			var gotPath string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.Write([]byte(`{"id":"g1","name":"group"}`))
+			}))
+			defer server.Close()
+
+			cfg := client.DefaultConfig()
+			cfg.BaseURL = server.URL
+			cfg.BasePath = basePath
+			apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, cfg)
+			if err != nil {
+				t.Fatalf("client.New failed: %v", err)
+			}
+
+			if _, err := NewService(apiClient, "t").GetGroup(context.Background(), "g1"); err != nil {
+				t.Fatalf("GetGroup failed: %v", err)
+			}
+			if want := basePath + "/tenants/t/groups/g1"; gotPath != want {
+				t.Errorf("request path = %q, want %q", gotPath, want)
+			}
+		})
+	}
+}
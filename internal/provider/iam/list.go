@@ -0,0 +1,100 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// listGetter sends the GET for one page of a list endpoint
+type listGetter func(ctx context.Context, path string, query map[string]string) (*client.Response, error)
+
+// rawGet sends a GET through the raw client, for paths built with apiPath
+func (s *Service) rawGet(ctx context.Context, path string, query map[string]string) (*client.Response, error) {
+	return s.rawClient.Do(ctx, &client.Request{
+		Method: "GET",
+		Path:   path,
+		Query:  query,
+	})
+}
+
+// list fetches one page of a list endpoint and decodes it with extract, which
+// unwraps whatever envelope the endpoint answers with. Transport errors are
+// wrapped as "failed to list <what>", API errors are returned unchanged and
+// extract errors are wrapped as "failed to decode response".
+func list[T any](ctx context.Context, get listGetter, what, path string, query map[string]string, extract func(body []byte) (T, error)) (T, error) {
+	var zero T
+
+	resp, err := get(ctx, path, query)
+	if err != nil {
+		return zero, fmt.Errorf("failed to list %s: %w", what, err)
+	}
+	if err := client.CheckResponse(resp); err != nil {
+		return zero, err
+	}
+
+	result, err := extract(resp.Body)
+	if err != nil {
+		return zero, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result, nil
+}
+
+// eachPage follows a page numbered list from start. fetch requests one page
+// and returns the next page the server reported; iteration stops once the next
+// page does not advance, or fails after maxPages so a server that keeps
+// returning the same next_page cannot loop forever.
+func eachPage(what string, start, maxPages int, fetch func(page int) (next int, err error)) error {
+	page := start
+	for i := 0; i < maxPages; i++ {
+		next, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		if next <= page {
+			return nil
+		}
+		page = next
+	}
+	return fmt.Errorf("failed to list %s: exceeded %d pages", what, maxPages)
+}
+
+// listQuery builds a list query from key, value pairs, leaving out empty values
+func listQuery(pairs ...string) map[string]string {
+	query := make(map[string]string)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if pairs[i+1] != "" {
+			query[pairs[i]] = pairs[i+1]
+		}
+	}
+	return query
+}
+
+// pageParam formats a page number or size for listQuery, empty when not positive
+func pageParam(n int) string {
+	if n <= 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
+
+// envelope returns an extract function for lists wrapped as {"<key>": [...]}.
+// A missing key decodes as an empty list.
+func envelope[T any](key string) func(body []byte) ([]T, error) {
+	return func(body []byte) ([]T, error) {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return nil, err
+		}
+		var items []T
+		if raw, ok := fields[key]; ok {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+}
@@ -0,0 +1,151 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// listCase is one response a list endpoint answers with and the outcome expected
+type listCase struct {
+	name    string
+	resp    *client.Response
+	err     error
+	want    int    // number of items decoded
+	wantErr string // substring of the expected error
+}
+
+// listErrorCases are the failures every list method must surface the same way
+func listErrorCases(what string) []listCase {
+	return []listCase{
+		{name: "transport error", err: errors.New("connection reset"), wantErr: "failed to list " + what + ": connection reset"},
+		{name: "api error", resp: &client.Response{StatusCode: 500, Body: []byte(`{"message":"boom"}`)}, wantErr: "boom"},
+		{name: "undecodable body", resp: &client.Response{StatusCode: 200, Body: []byte(`{`)}, wantErr: "failed to decode response"},
+	}
+}
+
+func runListCases(t *testing.T, cases []listCase, call func(resp *client.Response, err error) (int, error)) {
+	t.Helper()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := call(tc.resp, tc.err)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tc.wantErr)
+				}
+				if tc.resp != nil && tc.resp.StatusCode >= 400 {
+					var apiErr *client.Error
+					if !errors.As(err, &apiErr) || apiErr.StatusCode != tc.resp.StatusCode {
+						t.Errorf("error = %#v, want the API error returned unchanged", err)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("decoded %d items, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func rawListService(resp *client.Response, err error) *Service {
+	return &Service{tenantID: "t", rawClient: &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		return resp, err
+	}}}
+}
+
+func TestService_ListGroups_Envelopes(t *testing.T) {
+	cases := append([]listCase{
+		{name: "plain array", resp: &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1"},{"id":"g2"}]`)}, want: 2},
+		{name: "paginated envelope", resp: &client.Response{StatusCode: 200, Body: []byte(`{"groups":[{"id":"g1"}],"next_page":2}`)}, want: 1},
+		{name: "empty body", resp: &client.Response{StatusCode: 200}, want: 0},
+	}, listErrorCases("groups")...)
+
+	runListCases(t, cases, func(resp *client.Response, err error) (int, error) {
+		page, err := rawListService(resp, err).ListGroups(context.Background(), &ListGroupsRequest{})
+		if err != nil {
+			return 0, err
+		}
+		return len(page.Groups), nil
+	})
+}
+
+func TestService_ListRoles_Envelopes(t *testing.T) {
+	cases := append([]listCase{
+		{name: "roles envelope", resp: &client.Response{StatusCode: 200, Body: []byte(`{"roles":[{"id":"r1"},{"id":"r2"}]}`)}, want: 2},
+		{name: "missing key", resp: &client.Response{StatusCode: 200, Body: []byte(`{}`)}, want: 0},
+	}, listErrorCases("roles")...)
+
+	runListCases(t, cases, func(resp *client.Response, err error) (int, error) {
+		roles, err := rawListService(resp, err).ListRoles(context.Background(), "")
+		return len(roles), err
+	})
+}
+
+func TestService_ListRoleBindings_Envelopes(t *testing.T) {
+	cases := append([]listCase{
+		{name: "bindings envelope", resp: &client.Response{StatusCode: 200, Body: []byte(`{"bindings":[{"id":"b1"}]}`)}, want: 1},
+	}, listErrorCases("role bindings")...)
+
+	runListCases(t, cases, func(resp *client.Response, err error) (int, error) {
+		svc := &Service{tenantID: "t", client: &MockServiceClient{GetFunc: func(ctx context.Context, path string, query map[string]string) (*client.Response, error) {
+			return resp, err
+		}}}
+		bindings, err := svc.ListRoleBindingsWithOptions(context.Background(), nil)
+		return len(bindings), err
+	})
+}
+
+func TestService_GetResources_Envelopes(t *testing.T) {
+	cases := append([]listCase{
+		{name: "plain array", resp: &client.Response{StatusCode: 200, Body: []byte(`[{"id":"bu:001","props":{"n":12345678901234567890}}]`)}, want: 1},
+	}, listErrorCases("resources")...)
+
+	runListCases(t, cases, func(resp *client.Response, err error) (int, error) {
+		result, err := rawListService(resp, err).GetResources(context.Background(), nil)
+		if err != nil {
+			return 0, err
+		}
+		if result.Total != len(result.Resources) {
+			t.Errorf("Total = %d, want %d", result.Total, len(result.Resources))
+		}
+		return len(result.Resources), nil
+	})
+}
+
+func TestListQuery(t *testing.T) {
+	got := listQuery("filter", "a", "page", pageParam(0), "page_size", pageParam(50), "type", "")
+	want := map[string]string{"filter": "a", "page_size": "50"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("listQuery() = %v, want %v", got, want)
+	}
+}
+
+func TestEachPage(t *testing.T) {
+	var pages []int
+	err := eachPage("things", 1, 10, func(page int) (int, error) {
+		pages = append(pages, page)
+		if page < 3 {
+			return page + 1, nil
+		}
+		return 0, nil
+	})
+	if err != nil {
+		t.Fatalf("eachPage() error = %v", err)
+	}
+	if !reflect.DeepEqual(pages, []int{1, 2, 3}) {
+		t.Errorf("visited pages %v, want [1 2 3]", pages)
+	}
+
+	err = eachPage("things", 0, 3, func(page int) (int, error) { return page + 1, nil })
+	if err == nil || err.Error() != "failed to list things: exceeded 3 pages" {
+		t.Errorf("eachPage() error = %v, want the page limit error", err)
+	}
+}
@@ -0,0 +1,70 @@
+package iam
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultMemberType is the member type assumed for a bare member id
+const DefaultMemberType = "user"
+
+// NormalizeMember returns the canonical "type:id" form of a role binding
+// member: surrounding spaces are trimmed, the type is lowercased and a bare
+// id defaults to the user type. "User: alice", " alice" and "user:alice" all
+// normalize to "user:alice". An empty member is returned unchanged.
+func NormalizeMember(member string) string {
+	member = strings.TrimSpace(member)
+	if member == "" {
+		return member
+	}
+	kind, id, ok := strings.Cut(member, ":")
+	if !ok {
+		return DefaultMemberType + ":" + member
+	}
+	return strings.ToLower(strings.TrimSpace(kind)) + ":" + strings.TrimSpace(id)
+}
+
+// NormalizeMembers normalizes each member and drops members that only
+// differed from an earlier one in formatting, keeping the original order.
+func NormalizeMembers(members []string) []string {
+	if members == nil {
+		return nil
+	}
+	normalized := make([]string, 0, len(members))
+	seen := make(map[string]bool, len(members))
+	for _, member := range members {
+		member = NormalizeMember(member)
+		if seen[member] {
+			continue
+		}
+		seen[member] = true
+		normalized = append(normalized, member)
+	}
+	return normalized
+}
+
+// SetMemberNormalization turns role binding member normalization on or off.
+// When on, the default, members are canonicalized with NormalizeMembers
+// before a role binding is created and when it is read back, so members that
+// differ only by prefix or formatting do not create duplicate bindings.
+func (s *Service) SetMemberNormalization(enabled bool) {
+	s.disableMemberNormalization = !enabled
+}
+
+// normalizeMembers applies member normalization unless it is disabled and
+// logs when it changes the members
+func (s *Service) normalizeMembers(ctx context.Context, members []string) []string {
+	if s.disableMemberNormalization {
+		return members
+	}
+	normalized := NormalizeMembers(members)
+	if strings.Join(normalized, ",") != strings.Join(members, ",") {
+		tflog.Debug(ctx, "Normalized role binding members", map[string]interface{}{
+			"original":   members,
+			"normalized": normalized,
+		})
+	}
+	return normalized
+}
@@ -0,0 +1,78 @@
+package iam
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeMember(t *testing.T) {
+	tests := []struct {
+		name   string
+		member string
+		want   string
+	}{
+		{name: "canonical user", member: "user:alice", want: "user:alice"},
+		{name: "bare id", member: "alice", want: "user:alice"},
+		{name: "uppercase type", member: "USER:alice", want: "user:alice"},
+		{name: "mixed case type", member: "User:alice", want: "user:alice"},
+		{name: "surrounding spaces", member: "  user:alice ", want: "user:alice"},
+		{name: "spaces around separator", member: "user : alice", want: "user:alice"},
+		{name: "bare id with spaces", member: " alice ", want: "user:alice"},
+		{name: "group", member: "Group:cashiers", want: "group:cashiers"},
+		{name: "id case is kept", member: "group:Cashiers", want: "group:Cashiers"},
+		{name: "colon in id", member: "group:a:b", want: "group:a:b"},
+		{name: "empty", member: "  ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeMember(tt.member); got != tt.want {
+				t.Errorf("NormalizeMember(%q) = %q, want %q", tt.member, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeMembers_DropsFormattingDuplicates(t *testing.T) {
+	got := NormalizeMembers([]string{"alice", "user:alice", "USER: alice", "group:cashiers", "Group:cashiers"})
+	if want := []string{"user:alice", "group:cashiers"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeMembers = %v, want %v", got, want)
+	}
+	if got := NormalizeMembers(nil); got != nil {
+		t.Errorf("NormalizeMembers(nil) = %v, want nil", got)
+	}
+}
+
+func TestService_CreateRoleBinding_NormalizesMembers(t *testing.T) {
+	t.Run("enabled by default", func(t *testing.T) {
+		posts := 0
+		svc := &Service{rawClient: memberValidationMock(&posts), tenantID: "t"}
+
+		binding := &RoleBinding{Role: "roles/pos.admin", Members: []string{" Group:cashiers", "alice", "user:alice"}}
+		out, err := svc.CreateRoleBinding(context.Background(), binding)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{"group:cashiers", "user:alice"}; !reflect.DeepEqual(out.Members, want) {
+			t.Errorf("members = %v, want %v", out.Members, want)
+		}
+		if want := []string{" Group:cashiers", "alice", "user:alice"}; !reflect.DeepEqual(binding.Members, want) {
+			t.Errorf("input binding was modified: %v", binding.Members)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		posts := 0
+		svc := &Service{rawClient: memberValidationMock(&posts), tenantID: "t"}
+		svc.SetMemberNormalization(false)
+
+		binding := &RoleBinding{Role: "roles/pos.admin", Members: []string{"group:cashiers", "alice"}}
+		out, err := svc.CreateRoleBinding(context.Background(), binding)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{"group:cashiers", "alice"}; !reflect.DeepEqual(out.Members, want) {
+			t.Errorf("members = %v, want %v", out.Members, want)
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// UnresolvedMembersError reports role binding members that do not resolve to an existing subject
+type UnresolvedMembersError struct {
+	Members []string
+}
+
+func (e *UnresolvedMembersError) Error() string {
+	return fmt.Sprintf("role binding members do not resolve to existing subjects: %s", strings.Join(e.Members, ", "))
+}
+
+// SetMemberValidation turns pre-create member validation on or off. When on,
+// CreateRoleBinding fails with an UnresolvedMembersError before any write if
+// a member does not resolve to an existing subject.
+func (s *Service) SetMemberValidation(enabled bool) {
+	s.validateMembers = enabled
+}
+
+// ValidateMembers returns an UnresolvedMembersError listing the members that
+// do not resolve to an existing subject when member validation is on. It
+// returns nil without any request when validation is off.
+func (s *Service) ValidateMembers(ctx context.Context, members []string) error {
+	if !s.validateMembers {
+		return nil
+	}
+	unresolved, err := s.ResolveMembers(ctx, members)
+	if err != nil {
+		return err
+	}
+	if len(unresolved) > 0 {
+		return &UnresolvedMembersError{Members: unresolved}
+	}
+	return nil
+}
+
+// ResolveMembers checks each "group:<name or id>" member against the tenant's
+// groups and returns the members that do not resolve. "user:<id>" members
+// cannot be looked up through the IAM API and are accepted as given; members
+// of any other kind, or without an id, are reported as unresolved.
+func (s *Service) ResolveMembers(ctx context.Context, members []string) ([]string, error) {
+	var groups map[string]bool
+	var unresolved []string
+
+	for _, member := range members {
+		if user, isUser := strings.CutPrefix(member, DefaultMemberType+":"); isUser && user != "" {
+			continue
+		}
+		name, isGroup := strings.CutPrefix(member, "group:")
+		if !isGroup || name == "" {
+			unresolved = append(unresolved, member)
+			continue
+		}
+
+		if groups == nil {
+			groups = make(map[string]bool)
+			err := s.EachGroup(ctx, &ListGroupsRequest{}, func(g Group) error {
+				groups[g.ID] = true
+				groups[g.Name] = true
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list groups to resolve members: %w", err)
+			}
+		}
+
+		if !groups[name] {
+			unresolved = append(unresolved, member)
+		}
+	}
+
+	return unresolved, nil
+}
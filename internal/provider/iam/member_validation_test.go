@@ -0,0 +1,118 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func memberValidationMock(posts *int) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch {
+		case req.Method == "GET" && strings.Contains(req.Path, "/roles/"):
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"pos.admin","name":"pos.admin"}`)}, nil
+		case req.Method == "GET":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1","name":"cashiers"},{"id":"g2","name":"managers"}]`)}, nil
+		case req.Method == "POST":
+			*posts++
+			return &client.Response{StatusCode: 201, Body: []byte(`{}`)}, nil
+		}
+		return nil, errors.New("unexpected request")
+	}}
+}
+
+func TestService_ResolveMembers(t *testing.T) {
+	posts := 0
+	svc := &Service{rawClient: memberValidationMock(&posts), tenantID: "t"}
+
+	unresolved, err := svc.ResolveMembers(context.Background(), []string{"group:cashiers", "group:g2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(unresolved) != 0 {
+		t.Errorf("expected all members to resolve, got unresolved %v", unresolved)
+	}
+
+	unresolved, err = svc.ResolveMembers(context.Background(), []string{"group:cashiers", "group:ghosts", "user:alice", "user:", "robot:r2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"group:ghosts", "user:", "robot:r2"}; !reflect.DeepEqual(unresolved, want) {
+		t.Errorf("unresolved = %v, want %v", unresolved, want)
+	}
+}
+
+func TestService_CreateRoleBinding_MemberValidation(t *testing.T) {
+	t.Run("all resolvable", func(t *testing.T) {
+		posts := 0
+		svc := &Service{rawClient: memberValidationMock(&posts), tenantID: "t"}
+		svc.SetMemberValidation(true)
+
+		binding := &RoleBinding{Role: "roles/pos.admin", Members: []string{"group:cashiers"}}
+		if _, err := svc.CreateRoleBinding(context.Background(), binding); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if posts != 1 {
+			t.Errorf("expected binding to be created, got %d POSTs", posts)
+		}
+	})
+
+	t.Run("user member", func(t *testing.T) {
+		posts := 0
+		svc := &Service{rawClient: memberValidationMock(&posts), tenantID: "t"}
+		svc.SetMemberValidation(true)
+
+		binding := &RoleBinding{Role: "roles/pos.admin", Members: []string{"group:cashiers", "user:alice"}}
+		if _, err := svc.CreateRoleBinding(context.Background(), binding); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if posts != 1 {
+			t.Errorf("expected binding to be created, got %d POSTs", posts)
+		}
+	})
+
+	t.Run("some unresolvable", func(t *testing.T) {
+		posts := 0
+		svc := &Service{rawClient: memberValidationMock(&posts), tenantID: "t"}
+		svc.SetMemberValidation(true)
+
+		binding := &RoleBinding{Role: "roles/pos.admin", Members: []string{"group:cashiers", "group:ghosts"}}
+		_, err := svc.CreateRoleBinding(context.Background(), binding)
+
+		var unresolvedErr *UnresolvedMembersError
+		if !errors.As(err, &unresolvedErr) {
+			t.Fatalf("expected UnresolvedMembersError, got %v", err)
+		}
+		if want := []string{"group:ghosts"}; !reflect.DeepEqual(unresolvedErr.Members, want) {
+			t.Errorf("unresolved = %v, want %v", unresolvedErr.Members, want)
+		}
+		if posts != 0 {
+			t.Errorf("expected no write when members are unresolved, got %d POSTs", posts)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		posts := 0
+		svc := &Service{rawClient: memberValidationMock(&posts), tenantID: "t"}
+
+		binding := &RoleBinding{Role: "roles/pos.admin", Members: []string{"group:cashiers", "user:alice"}}
+		if _, err := svc.CreateRoleBinding(context.Background(), binding); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestService_ValidateMembers_Disabled(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		return nil, errors.New("unexpected request")
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	if err := svc.ValidateMembers(context.Background(), []string{"group:ghosts"}); err != nil {
+		t.Fatalf("expected no validation when disabled, got %v", err)
+	}
+}
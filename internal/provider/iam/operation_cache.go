@@ -0,0 +1,128 @@
+package iam
+
+import (
+	"context"
+	"sync"
+)
+
+type operationCacheContextKey struct{}
+
+// operationCache holds the groups and custom roles read during one logical
+// operation. It lives in the context, so nothing read under it outlives the
+// operation.
+type operationCache struct {
+	mu          sync.Mutex
+	groups      map[string]Group
+	customRoles map[string]bool
+}
+
+// WithOperationCache returns a context under which the group lookup of
+// GetRoleBinding, and of UpdateRoleBinding through it, and the custom role
+// check of AddRoleToGroup are made at most once per group or role, so a read
+// followed by writes in the same flow, such as an ApplyGroupRoles that adds a
+// role and reverts it, does not fetch them again. Writes through the Service
+// drop the cached group or role. A context that already has an operation
+// cache is returned unchanged.
+func WithOperationCache(ctx context.Context) context.Context {
+	if operationCacheFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, operationCacheContextKey{}, &operationCache{
+		groups:      make(map[string]Group),
+		customRoles: make(map[string]bool),
+	})
+}
+
+// operationCacheFrom returns the operation cache of ctx, or nil when ctx was
+// not created with WithOperationCache
+func operationCacheFrom(ctx context.Context) *operationCache {
+	cache, _ := ctx.Value(operationCacheContextKey{}).(*operationCache)
+	return cache
+}
+
+// group returns a copy of the cached group id
+func (c *operationCache) group(id string) (*Group, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	group, ok := c.groups[id]
+	if !ok {
+		return nil, false
+	}
+	return &group, true
+}
+
+// setGroup caches group under id
+func (c *operationCache) setGroup(id string, group Group) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.groups[id] = group
+}
+
+// invalidateGroup removes the cached group id
+func (c *operationCache) invalidateGroup(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.groups, id)
+}
+
+// hasCustomRole reports whether custom role id was found earlier in the
+// operation
+func (c *operationCache) hasCustomRole(id string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.customRoles[id]
+}
+
+// setCustomRole records that custom role id exists
+func (c *operationCache) setCustomRole(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.customRoles[id] = true
+}
+
+// invalidateCustomRole forgets custom role id
+func (c *operationCache) invalidateCustomRole(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.customRoles, id)
+}
+
+// operationGroup is GetGroup, reusing a group already read under the same
+// WithOperationCache context
+func (s *Service) operationGroup(ctx context.Context, id string) (*Group, error) {
+	cache := operationCacheFrom(ctx)
+	id = s.idNormalization.Apply(id)
+	if group, ok := cache.group(id); ok {
+		return group, nil
+	}
+	group, err := s.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cache.setGroup(id, *group)
+	return group, nil
+}
@@ -0,0 +1,90 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// operationCacheMock serves group g1 with one role, counting group GETs
+func operationCacheMock(groupGets *int) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch {
+		case req.Method == "GET" && req.Path == "/api/v1/tenants/t/groups/g1":
+			*groupGets++
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"g1","name":"ops"}`)}, nil
+		case req.Method == "GET" && req.Path == "/api/v2/tenants/t/groups/g1/roles":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"roleId":"pos.cashier","isCustom":false}]`)}, nil
+		case req.Method == "PUT":
+			return &client.Response{StatusCode: 204}, nil
+		}
+		return &client.Response{StatusCode: 404}, nil
+	}}
+}
+
+func TestService_OperationCache_ReadThenUpdate(t *testing.T) {
+	var groupGets int
+	svc := &Service{rawClient: operationCacheMock(&groupGets), tenantID: "t"}
+	id := svc.FormatRoleBindingID("g1", "pos.cashier")
+
+	ctx := WithOperationCache(context.Background())
+	if _, err := svc.GetRoleBinding(ctx, id); err != nil {
+		t.Fatalf("GetRoleBinding() error = %v", err)
+	}
+	if _, err := svc.UpdateRoleBinding(ctx, id, &RoleBinding{Role: "roles/pos.cashier"}); err != nil {
+		t.Fatalf("UpdateRoleBinding() error = %v", err)
+	}
+	if groupGets != 1 {
+		t.Errorf("group fetched %d times, want once within one operation", groupGets)
+	}
+
+	// A new operation reads the group again
+	if _, err := svc.GetRoleBinding(WithOperationCache(context.Background()), id); err != nil {
+		t.Fatalf("GetRoleBinding() error = %v", err)
+	}
+	if groupGets != 2 {
+		t.Errorf("group fetched %d times, want a fresh read for a new operation", groupGets)
+	}
+}
+
+func TestService_OperationCache_Disabled(t *testing.T) {
+	var groupGets int
+	svc := &Service{rawClient: operationCacheMock(&groupGets), tenantID: "t"}
+	id := svc.FormatRoleBindingID("g1", "pos.cashier")
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := svc.GetRoleBinding(ctx, id); err != nil {
+			t.Fatalf("GetRoleBinding() error = %v", err)
+		}
+	}
+	if groupGets != 2 {
+		t.Errorf("group fetched %d times, want every call without an operation cache", groupGets)
+	}
+}
+
+func TestService_OperationCache_GroupWriteInvalidates(t *testing.T) {
+	var groupGets int
+	svc := &Service{rawClient: operationCacheMock(&groupGets), tenantID: "t"}
+	id := svc.FormatRoleBindingID("g1", "pos.cashier")
+
+	ctx := WithOperationCache(context.Background())
+	if _, err := svc.GetRoleBinding(ctx, id); err != nil {
+		t.Fatalf("GetRoleBinding() error = %v", err)
+	}
+	if _, err := svc.UpdateGroup(ctx, "g1", &Group{Name: "ops-renamed"}); err != nil {
+		t.Fatalf("UpdateGroup() error = %v", err)
+	}
+	before := groupGets
+	if _, err := svc.GetRoleBinding(ctx, id); err != nil {
+		t.Fatalf("GetRoleBinding() error = %v", err)
+	}
+	if groupGets != before+1 {
+		t.Errorf("group fetched %d times after the update, want it read again", groupGets-before)
+	}
+
+	if WithOperationCache(ctx) != ctx {
+		t.Error("WithOperationCache() replaced an existing operation cache")
+	}
+}
@@ -0,0 +1,89 @@
+package iam
+
+import (
+	"context"
+	"maps"
+	"time"
+)
+
+// Operation names accepted by SetOperationTimeouts and
+// Options.OperationTimeouts
+const (
+	OperationListGroups          = "list_groups"
+	OperationGetGroup            = "get_group"
+	OperationCreateGroup         = "create_group"
+	OperationUpdateGroup         = "update_group"
+	OperationDeleteGroup         = "delete_group"
+	OperationListRoles           = "list_roles"
+	OperationGetRole             = "get_role"
+	OperationCreateCustomRole    = "create_custom_role"
+	OperationGetCustomRole       = "get_custom_role"
+	OperationUpdateCustomRole    = "update_custom_role"
+	OperationDeleteCustomRole    = "delete_custom_role"
+	OperationListRoleBindings    = "list_role_bindings"
+	OperationGetRoleBinding      = "get_role_binding"
+	OperationCreateRoleBinding   = "create_role_binding"
+	OperationUpdateRoleBinding   = "update_role_binding"
+	OperationDeleteRoleBinding   = "delete_role_binding"
+	OperationListGroupRoles      = "list_group_roles"
+	OperationAddRoleToGroup      = "add_role_to_group"
+	OperationRemoveRoleFromGroup = "remove_role_from_group"
+	OperationSetResource         = "set_resource"
+	OperationGetResource         = "get_resource"
+	OperationDeleteResource      = "delete_resource"
+	OperationListResources       = "list_resources"
+)
+
+// Operations returns the operation names accepted by SetOperationTimeouts,
+// sorted
+func Operations() []string {
+	return []string{
+		OperationAddRoleToGroup,
+		OperationCreateCustomRole,
+		OperationCreateGroup,
+		OperationCreateRoleBinding,
+		OperationDeleteCustomRole,
+		OperationDeleteGroup,
+		OperationDeleteResource,
+		OperationDeleteRoleBinding,
+		OperationGetCustomRole,
+		OperationGetGroup,
+		OperationGetResource,
+		OperationGetRole,
+		OperationGetRoleBinding,
+		OperationListGroupRoles,
+		OperationListGroups,
+		OperationListResources,
+		OperationListRoleBindings,
+		OperationListRoles,
+		OperationRemoveRoleFromGroup,
+		OperationSetResource,
+		OperationUpdateCustomRole,
+		OperationUpdateGroup,
+		OperationUpdateRoleBinding,
+	}
+}
+
+// SetOperationTimeouts bounds individual operations, keyed by the Operation
+// constants. Each call of a listed operation runs under a context with that
+// timeout, covering retries and any nested lookups; operations without an
+// entry keep the caller's context. Non-positive durations are ignored and nil
+// clears all timeouts. The map is copied.
+func (s *Service) SetOperationTimeouts(timeouts map[string]time.Duration) {
+	s.operationTimeouts = maps.Clone(timeouts)
+	maps.DeleteFunc(s.operationTimeouts, func(_ string, timeout time.Duration) bool {
+		return timeout <= 0
+	})
+	if len(s.operationTimeouts) == 0 {
+		s.operationTimeouts = nil
+	}
+}
+
+// withOperationTimeout derives the context for one call of operation, applying
+// its configured timeout. The returned cancel func must always be called.
+func (s *Service) withOperationTimeout(ctx context.Context, operation string) (context.Context, context.CancelFunc) {
+	if timeout, ok := s.operationTimeouts[operation]; ok {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func() {}
+}
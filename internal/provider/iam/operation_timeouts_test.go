@@ -0,0 +1,127 @@
+package iam
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// deadlineMock records the time left before the deadline of each request,
+// keyed by method and path, answering every request with an empty success
+func deadlineMock(remaining map[string]time.Duration) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		key := req.Method + " " + req.Path
+		if deadline, ok := ctx.Deadline(); ok {
+			remaining[key] = time.Until(deadline)
+		} else {
+			remaining[key] = 0
+		}
+		body := `{}`
+		if strings.HasSuffix(req.Path, "/groups") {
+			body = `[]`
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(body)}, nil
+	}}
+}
+
+func TestService_OperationTimeouts(t *testing.T) {
+	remaining := map[string]time.Duration{}
+	svc := &Service{rawClient: deadlineMock(remaining), tenantID: "t"}
+	svc.SetOperationTimeouts(map[string]time.Duration{
+		OperationListGroups:  5 * time.Minute,
+		OperationGetGroup:    10 * time.Second,
+		OperationDeleteGroup: 0,
+	})
+
+	ctx := context.Background()
+	if _, err := svc.ListGroups(ctx, &ListGroupsRequest{}); err != nil {
+		t.Fatalf("ListGroups() error = %v", err)
+	}
+	if _, err := svc.GetGroup(ctx, "g1"); err != nil {
+		t.Fatalf("GetGroup() error = %v", err)
+	}
+	if _, err := svc.GetResource(ctx, "bu:001"); err != nil {
+		t.Fatalf("GetResource() error = %v", err)
+	}
+	if err := svc.DeleteGroup(ctx, "g1"); err != nil {
+		t.Fatalf("DeleteGroup() error = %v", err)
+	}
+
+	tests := []struct {
+		request string
+		min     time.Duration
+		max     time.Duration
+	}{
+		{"GET /api/v1/tenants/t/groups", 4 * time.Minute, 5 * time.Minute},
+		{"GET /api/v1/tenants/t/groups/g1", 9 * time.Second, 10 * time.Second},
+		// Unlisted and non-positive entries keep the caller's context
+		{"GET /api/v1/tenants/t/resources/bu:001", 0, 0},
+		{"DELETE /api/v1/tenants/t/groups/g1", 0, 0},
+	}
+	for _, tt := range tests {
+		got, ok := remaining[tt.request]
+		if !ok {
+			t.Errorf("%s was not sent, got %v", tt.request, remaining)
+			continue
+		}
+		if got < tt.min || got > tt.max {
+			t.Errorf("%s deadline in %v, want between %v and %v", tt.request, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestService_OperationTimeouts_Expire(t *testing.T) {
+	svc := &Service{rawClient: &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}}, tenantID: "t"}
+	svc.SetOperationTimeouts(map[string]time.Duration{OperationListGroupRoles: 10 * time.Millisecond})
+
+	start := time.Now()
+	_, err := svc.ListGroupRoles(context.Background(), "g1")
+	if err == nil || !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Fatalf("ListGroupRoles() error = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("ListGroupRoles() returned after %v, want the operation timeout", elapsed)
+	}
+}
+
+func TestNewServiceWithOptions_OperationTimeouts(t *testing.T) {
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, client.DefaultConfig())
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+
+	opts := Options{OperationTimeouts: map[string]time.Duration{OperationCreateRoleBinding: time.Minute}}
+	svc := NewServiceWithOptions(apiClient, "t", opts)
+	if got := svc.operationTimeouts[OperationCreateRoleBinding]; got != time.Minute {
+		t.Errorf("create_role_binding timeout = %v, want 1m", got)
+	}
+
+	// The service keeps its own copy
+	opts.OperationTimeouts[OperationCreateRoleBinding] = time.Hour
+	if got := svc.operationTimeouts[OperationCreateRoleBinding]; got != time.Minute {
+		t.Errorf("create_role_binding timeout after options change = %v, want 1m", got)
+	}
+}
+
+func TestOperations(t *testing.T) {
+	operations := Operations()
+	if !slices.IsSorted(operations) {
+		t.Errorf("Operations() = %v, want sorted", operations)
+	}
+	if len(slices.Compact(slices.Clone(operations))) != len(operations) {
+		t.Errorf("Operations() = %v, want no repeats", operations)
+	}
+	for _, operation := range []string{OperationListGroups, OperationCreateRoleBinding, OperationListResources} {
+		if !slices.Contains(operations, operation) {
+			t.Errorf("Operations() is missing %s", operation)
+		}
+	}
+}
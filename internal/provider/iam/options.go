@@ -0,0 +1,47 @@
+package iam
+
+import (
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// Options are the provider-level IAM settings. The zero value keeps every
+// Service default.
+type Options struct {
+	// DefaultBindings are used by role bindings that set none; empty keeps
+	// the built-in fallbacks, see SetDefaultBindings
+	DefaultBindings []string
+	// ValidateMembers resolves role binding members before create, see
+	// SetMemberValidation
+	ValidateMembers bool
+	// RoleBindingIDDelimiter separates the parts of composite role binding
+	// IDs; empty uses DefaultRoleBindingIDDelimiter
+	RoleBindingIDDelimiter string
+	// IDNormalization is applied to IDs before they are used in request paths
+	IDNormalization IDNormalization
+	// OperationTimeouts bound individual operations, keyed by the Operation
+	// constants, see SetOperationTimeouts
+	OperationTimeouts map[string]time.Duration
+	// PropsSchemas are JSON Schema documents that resource props must match,
+	// keyed by resource type, the prefix before ":" in the resource ID. Types
+	// without a schema only need valid JSON.
+	PropsSchemas map[string]string
+	// ReadCache serves repeated reads from memory for DefaultReadCacheTTL,
+	// see EnableReadCache
+	ReadCache bool
+}
+
+// NewServiceWithOptions creates a new IAM service client with opts applied
+func NewServiceWithOptions(apiClient *client.Client, tenantID string, opts Options) *Service {
+	svc := NewService(apiClient, tenantID)
+	svc.SetDefaultBindings(opts.DefaultBindings)
+	svc.SetMemberValidation(opts.ValidateMembers)
+	svc.SetRoleBindingIDDelimiter(opts.RoleBindingIDDelimiter)
+	svc.SetIDNormalization(opts.IDNormalization)
+	svc.SetOperationTimeouts(opts.OperationTimeouts)
+	if opts.ReadCache {
+		svc.EnableReadCache(DefaultReadCacheTTL)
+	}
+	return svc
+}
@@ -0,0 +1,45 @@
+package iam
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestNewProviderData(t *testing.T) {
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, client.DefaultConfig())
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+
+	opts := Options{
+		DefaultBindings: []string{"bu:001"},
+		ValidateMembers: true,
+		PropsSchemas:    map[string]string{"bu": `{"type":"object"}`},
+		ReadCache:       true,
+	}
+	data := NewProviderData(apiClient, opts)
+
+	if data.Client != apiClient {
+		t.Error("Client should be the given client")
+	}
+	if !reflect.DeepEqual(data.Options, opts) {
+		t.Errorf("Options = %+v, want %+v", data.Options, opts)
+	}
+	svc := data.Service
+	if svc.tenantID != "t" {
+		t.Errorf("tenantID = %q, want the client tenant", svc.tenantID)
+	}
+	if !reflect.DeepEqual(svc.defaultBindings, opts.DefaultBindings) || !svc.validateMembers {
+		t.Errorf("service settings = %v, %v, want the options applied", svc.defaultBindings, svc.validateMembers)
+	}
+	if svc.cache.Load() == nil {
+		t.Error("read cache should be enabled")
+	}
+
+	if NewProviderData(apiClient, Options{}).Service.cache.Load() != nil {
+		t.Error("read cache should be off by default")
+	}
+}
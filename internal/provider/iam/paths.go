@@ -0,0 +1,69 @@
+package iam
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultV2BasePath is the prefix of the V2 endpoints, which are not under
+// the configured V1 base path, used when no V2 base path is set
+const DefaultV2BasePath = "/api/v2"
+
+// validateTenantID reports why tenantID cannot be used in request paths
+func validateTenantID(tenantID string) error {
+	if strings.TrimSpace(tenantID) == "" {
+		return errors.New("tenant ID is empty: set tenant_id on the provider or HIIRETAIL_TENANT_ID")
+	}
+	if url.PathEscape(tenantID) != tenantID {
+		return fmt.Errorf("tenant ID %q contains characters that are not allowed in a URL path", tenantID)
+	}
+	return nil
+}
+
+// tenantPath returns the V1 path of elems under the service tenant, e.g.
+// tenantPath("groups", id) for "<base path>/tenants/<tenant>/groups/<id>".
+// Each element is path-escaped, so IDs may contain reserved characters.
+func (s *Service) tenantPath(elems ...string) (string, error) {
+	if err := validateTenantID(s.tenantID); err != nil {
+		return "", err
+	}
+	return s.apiPath("tenants/%s", joinPath(append([]string{s.tenantID}, elems...)...)), nil
+}
+
+// tenantV2Path is tenantPath for the V2 endpoints under "<V2 base path>/tenants"
+func (s *Service) tenantV2Path(elems ...string) (string, error) {
+	if err := validateTenantID(s.tenantID); err != nil {
+		return "", err
+	}
+	return s.V2BasePath() + "/tenants/" + joinPath(append([]string{s.tenantID}, elems...)...), nil
+}
+
+// SetV2BasePath sets the prefix of the V2 endpoints used for role bindings,
+// for tenants whose V2 API is mounted somewhere other than /api/v2. Surrounding
+// slashes are normalized and an empty path restores DefaultV2BasePath.
+func (s *Service) SetV2BasePath(basePath string) {
+	basePath = strings.Trim(strings.TrimSpace(basePath), "/")
+	if basePath != "" {
+		basePath = "/" + basePath
+	}
+	s.v2BasePath = basePath
+}
+
+// V2BasePath returns the prefix of the V2 endpoints
+func (s *Service) V2BasePath() string {
+	if s.v2BasePath == "" {
+		return DefaultV2BasePath
+	}
+	return s.v2BasePath
+}
+
+// joinPath joins path-escaped elements with "/"
+func joinPath(elems ...string) string {
+	escaped := make([]string, len(elems))
+	for i, elem := range elems {
+		escaped[i] = url.PathEscape(elem)
+	}
+	return strings.Join(escaped, "/")
+}
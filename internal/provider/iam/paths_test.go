@@ -0,0 +1,167 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_InvalidTenantID(t *testing.T) {
+	tests := []struct {
+		name     string
+		tenantID string
+		wantErr  string
+	}{
+		{name: "empty", tenantID: "", wantErr: "tenant ID is empty"},
+		{name: "whitespace", tenantID: "  ", wantErr: "tenant ID is empty"},
+		{name: "slash", tenantID: "acme/dev", wantErr: "not allowed in a URL path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				requests++
+				return &client.Response{StatusCode: 200, Body: []byte(`{}`)}, nil
+			}}
+			svc := &Service{rawClient: mock, tenantID: tt.tenantID}
+
+			_, getErr := svc.GetGroup(context.Background(), "g1")
+			_, listErr := svc.ListGroupRoles(context.Background(), "g1")
+			deleteErr := svc.DeleteResource(context.Background(), "bu:001")
+			for _, err := range []error{getErr, listErr, deleteErr} {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+			}
+			if requests != 0 {
+				t.Errorf("made %d requests, want none with an invalid tenant", requests)
+			}
+		})
+	}
+}
+
+func TestService_EscapesIDsInPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(svc *Service) error
+		wantPath string
+	}{
+		{
+			name: "group id with a slash",
+			call: func(svc *Service) error {
+				_, err := svc.GetGroup(context.Background(), "ops/team")
+				return err
+			},
+			wantPath: "/api/v1/tenants/t/groups/ops%2Fteam",
+		},
+		{
+			name: "group id with a space",
+			call: func(svc *Service) error {
+				_, err := svc.ListGroupRoles(context.Background(), "ops team")
+				return err
+			},
+			wantPath: "/api/v2/tenants/t/groups/ops%20team/roles",
+		},
+		{
+			name: "custom role id with a slash",
+			call: func(svc *Service) error {
+				return svc.RemoveRoleFromGroup(context.Background(), "g1", "a/b", true)
+			},
+			wantPath: "/api/v2/tenants/t/groups/g1/roles/a%2Fb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				paths = append(paths, req.Path)
+				switch req.Method {
+				case "GET":
+					if strings.HasSuffix(req.Path, "/roles") {
+						return &client.Response{StatusCode: 200, Body: []byte(`[]`)}, nil
+					}
+					return &client.Response{StatusCode: 200, Body: []byte(`{"id":"ops/team"}`)}, nil
+				case "DELETE":
+					return &client.Response{StatusCode: 204}, nil
+				}
+				return nil, errors.New("unexpected request")
+			}}
+			svc := &Service{rawClient: mock, tenantID: "t"}
+
+			if err := tt.call(svc); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(paths) == 0 || paths[0] != tt.wantPath {
+				t.Errorf("paths = %v, want first %s", paths, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestService_V2BasePath(t *testing.T) {
+	svc := &Service{}
+	if got := svc.V2BasePath(); got != DefaultV2BasePath {
+		t.Errorf("default V2 base path = %q, want %q", got, DefaultV2BasePath)
+	}
+	svc.SetV2BasePath(" iam/v2/ ")
+	if got := svc.V2BasePath(); got != "/iam/v2" {
+		t.Errorf("V2 base path = %q, want /iam/v2", got)
+	}
+	svc.SetV2BasePath("")
+	if got := svc.V2BasePath(); got != DefaultV2BasePath {
+		t.Errorf("empty V2 base path = %q, want the default", got)
+	}
+}
+
+func TestService_V2BasePath_RoleBindingPaths(t *testing.T) {
+	var calls []string
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		calls = append(calls, req.Method+" "+req.Path)
+		switch {
+		case req.Method == "GET" && req.Path == "/iam/v2/tenants/t/groups/g1/roles":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"roleId":"pos.cashier","isCustom":false}]`)}, nil
+		case req.Method == "GET" && req.Path == "/api/v1/tenants/t/groups/g1":
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"g1","name":"ops"}`)}, nil
+		case req.Method == "GET" && req.Path == "/api/v1/tenants/t/groups":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1","name":"ops"}]`)}, nil
+		case req.Method == "POST" || req.Method == "DELETE":
+			return &client.Response{StatusCode: 204}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(`{}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.SetV2BasePath("/iam/v2")
+	svc.SetRoleValidation(false)
+	ctx := context.Background()
+
+	if _, err := svc.CreateRoleBinding(ctx, &RoleBinding{Role: "roles/pos.cashier", Members: []string{"group:ops"}}); err != nil {
+		t.Fatalf("CreateRoleBinding() error = %v", err)
+	}
+	if _, err := svc.GetRoleBinding(ctx, svc.FormatRoleBindingID("g1", "pos.cashier")); err != nil {
+		t.Fatalf("GetRoleBinding() error = %v", err)
+	}
+	if err := svc.DeleteRoleBinding(ctx, svc.FormatRoleBindingID("g1", "pos.cashier")); err != nil {
+		t.Fatalf("DeleteRoleBinding() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"POST /iam/v2/tenants/t/groups/g1/roles",
+		"GET /iam/v2/tenants/t/groups/g1/roles",
+		"DELETE /iam/v2/tenants/t/groups/g1/roles/pos.cashier",
+	} {
+		if !slices.Contains(calls, want) {
+			t.Errorf("calls = %q, want %q", calls, want)
+		}
+	}
+	for _, call := range calls {
+		if strings.Contains(call, "/api/v2/") {
+			t.Errorf("request %q used the default V2 base path", call)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// maxPermissionPages bounds how many catalog pages ListPermissions follows, so
+// a server that keeps returning the same next_page cannot loop forever.
+const maxPermissionPages = 100
+
+// listPermissionsResponse is the paginated form of the permissions catalog.
+// Servers that do not paginate return a plain array instead.
+type listPermissionsResponse struct {
+	Permissions []Permission `json:"permissions"`
+	NextPage    int          `json:"next_page,omitempty"`
+}
+
+// ListPermissions returns the permissions catalog for the tenant, including
+// the alias of each permission. When systemPrefix is set (e.g. "pos") only
+// permissions of that system are returned. Pages are followed until the
+// server stops returning a next_page.
+func (s *Service) ListPermissions(ctx context.Context, systemPrefix string) ([]Permission, error) {
+	systemPrefix = strings.TrimSuffix(strings.TrimSpace(systemPrefix), ".")
+
+	var permissions []Permission
+	err := eachPage("permissions", 0, maxPermissionPages, func(page int) (int, error) {
+		query := listQuery(
+			"systemPrefix", systemPrefix,
+			"page", pageParam(page),
+		)
+		path, err := s.tenantPath("permissions")
+		if err != nil {
+			return 0, err
+		}
+		result, err := list(ctx, s.rawGet, "permissions", path, query, decodePermissionsPage)
+		if err != nil {
+			return 0, err
+		}
+
+		// Filter locally as well in case the server ignores systemPrefix
+		for _, p := range result.Permissions {
+			if systemPrefix == "" || strings.HasPrefix(p.ID, systemPrefix+".") {
+				permissions = append(permissions, p)
+			}
+		}
+		return result.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// decodePermissionsPage accepts either a plain array of permissions or the
+// paginated {"permissions": [...], "next_page": n} envelope.
+func decodePermissionsPage(body []byte) (*listPermissionsResponse, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return &listPermissionsResponse{}, nil
+	}
+
+	if trimmed[0] == '[' {
+		var permissions []Permission
+		if err := json.Unmarshal(trimmed, &permissions); err != nil {
+			return nil, err
+		}
+		return &listPermissionsResponse{Permissions: permissions}, nil
+	}
+
+	var result listPermissionsResponse
+	if err := json.Unmarshal(trimmed, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,79 @@
+package iam
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_ListPermissions_FilteredPrefix(t *testing.T) {
+	var pages []string
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method != "GET" || !strings.HasSuffix(req.Path, "/tenants/t/permissions") {
+			t.Fatalf("unexpected request %s %s", req.Method, req.Path)
+		}
+		if got := req.Query["systemPrefix"]; got != "pos" {
+			t.Errorf("systemPrefix query = %q, want %q", got, "pos")
+		}
+		pages = append(pages, req.Query["page"])
+		switch req.Query["page"] {
+		case "":
+			return &client.Response{StatusCode: 200, Body: []byte(`{"permissions":[{"id":"pos.payment.create","alias":"PaymentCreate"},{"id":"ccc.config.read","alias":"ConfigRead"}],"next_page":2}`)}, nil
+		case "2":
+			return &client.Response{StatusCode: 200, Body: []byte(`{"permissions":[{"id":"pos.payment.delete","alias":"PaymentDelete"},{"id":"posx.thing.read"}]}`)}, nil
+		}
+		t.Fatalf("unexpected page %q", req.Query["page"])
+		return nil, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	perms, err := svc.ListPermissions(context.Background(), "pos")
+	if err != nil {
+		t.Fatalf("ListPermissions() error = %v", err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 page requests, got %v", pages)
+	}
+	if len(perms) != 2 {
+		t.Fatalf("expected 2 permissions, got %+v", perms)
+	}
+	if perms[0].ID != "pos.payment.create" || perms[0].Alias != "PaymentCreate" {
+		t.Errorf("unexpected first permission: %+v", perms[0])
+	}
+	if perms[1].ID != "pos.payment.delete" || perms[1].Alias != "PaymentDelete" {
+		t.Errorf("unexpected second permission: %+v", perms[1])
+	}
+}
+
+func TestService_ListPermissions_EmptyCatalog(t *testing.T) {
+	for _, body := range []string{`[]`, `{"permissions":[]}`, ``} {
+		mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+			if _, ok := req.Query["systemPrefix"]; ok {
+				t.Errorf("did not expect systemPrefix query without a prefix")
+			}
+			return &client.Response{StatusCode: 200, Body: []byte(body)}, nil
+		}}
+		svc := &Service{rawClient: mock, tenantID: "t"}
+
+		perms, err := svc.ListPermissions(context.Background(), "")
+		if err != nil {
+			t.Fatalf("ListPermissions(%q) error = %v", body, err)
+		}
+		if len(perms) != 0 {
+			t.Errorf("ListPermissions(%q) = %+v, want empty", body, perms)
+		}
+	}
+}
+
+func TestService_ListPermissions_Error(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		return &client.Response{StatusCode: 500, Body: []byte(`{"message":"boom"}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	if _, err := svc.ListPermissions(context.Background(), "pos"); err == nil {
+		t.Fatal("expected error for 500 response")
+	}
+}
@@ -0,0 +1,127 @@
+package iam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// representationServer answers writes with entity when the request asks for
+// it with Prefer and honor is set, and with 204 otherwise. GETs return
+// entity. It records every request.
+func representationServer(t *testing.T, honor bool, entity string) (*Service, func() []string) {
+	t.Helper()
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		if r.Method != http.MethodGet && !(honor && r.Header.Get(client.PreferHeader) == client.PreferRepresentation) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(entity))
+	}))
+	t.Cleanup(server.Close)
+
+	cfg := client.DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.MaxRetries = 0
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, cfg)
+	if err != nil {
+		t.Fatalf("client.New failed: %v", err)
+	}
+	return NewService(apiClient, "t"), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), calls...)
+	}
+}
+
+func TestService_PreferRepresentation(t *testing.T) {
+	const group = `{"id":"g1","name":"ops","description":"Operations"}`
+	const role = `{"id":"auditor","name":"Auditor","permissions":[{"id":"iam.groups.list"}]}`
+
+	tests := []struct {
+		name   string
+		entity string
+		write  func(ctx context.Context, s *Service) (string, error)
+		path   string
+	}{
+		{
+			name:   "UpdateGroup",
+			entity: group,
+			write: func(ctx context.Context, s *Service) (string, error) {
+				g, err := s.UpdateGroup(ctx, "g1", &Group{Name: "ops", Description: "Operations"})
+				if err != nil {
+					return "", err
+				}
+				return g.Description, nil
+			},
+			path: "/api/v1/tenants/t/groups/g1",
+		},
+		{
+			name:   "UpdateCustomRole",
+			entity: role,
+			write: func(ctx context.Context, s *Service) (string, error) {
+				r, err := s.UpdateCustomRole(ctx, "auditor", &CustomRole{Name: "Auditor", Permissions: []Permission{{ID: "iam.groups.list"}}})
+				if err != nil {
+					return "", err
+				}
+				return r.Name, nil
+			},
+			path: "/api/v1/tenants/t/roles/auditor",
+		},
+		{
+			name:   "CreateCustomRole",
+			entity: role,
+			write: func(ctx context.Context, s *Service) (string, error) {
+				r, err := s.CreateCustomRole(ctx, &CustomRole{ID: "auditor", Name: "Auditor", Permissions: []Permission{{ID: "iam.groups.list"}}})
+				if err != nil {
+					return "", err
+				}
+				return r.Name, nil
+			},
+			path: "/api/v1/tenants/t/roles/auditor",
+		},
+	}
+
+	for _, tt := range tests {
+		for _, honor := range []bool{true, false} {
+			name := tt.name + "/server ignores Prefer"
+			if honor {
+				name = tt.name + "/server returns representation"
+			}
+			t.Run(name, func(t *testing.T) {
+				svc, calls := representationServer(t, honor, tt.entity)
+
+				got, err := tt.write(context.Background(), svc)
+				if err != nil {
+					t.Fatalf("write failed: %v", err)
+				}
+				if got == "" {
+					t.Errorf("write returned an empty entity")
+				}
+
+				followUp := 0
+				for _, call := range calls() {
+					if call == "GET "+tt.path {
+						followUp++
+					}
+				}
+				if honor && followUp != 0 {
+					t.Errorf("calls = %q, want no follow-up GET when the body is returned", calls())
+				}
+				if !honor && followUp != 1 {
+					t.Errorf("calls = %q, want one follow-up GET after a 204", calls())
+				}
+			})
+		}
+	}
+}
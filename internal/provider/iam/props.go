@@ -0,0 +1,120 @@
+package iam
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// maxExactFloatInt is the largest integer a float64 holds exactly, 2^53
+const maxExactFloatInt = 1 << 53
+
+// CanonicalizeProps returns resource props as canonical JSON: object keys
+// sorted, no insignificant whitespace, and numbers written in one form so an
+// integer stays an integer however it was decoded (1, 1.0 and 1e0 are all
+// written as 1). props may be a JSON document as a string, []byte or
+// json.RawMessage, or any value json.Marshal accepts. nil and an empty string
+// canonicalize to "".
+func CanonicalizeProps(props interface{}) (string, error) {
+	var raw []byte
+	switch v := props.(type) {
+	case nil:
+		return "", nil
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	case json.RawMessage:
+		raw = v
+	default:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode props: %w", err)
+		}
+		raw = encoded
+	}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return "", nil
+	}
+
+	value, err := decodeProps(raw)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(canonicalNumbers(value)); err != nil {
+		return "", fmt.Errorf("failed to encode props: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// unmarshalResources decodes resource responses like json.Unmarshal but keeps
+// numbers in props as json.Number, so large integers survive a round-trip
+func unmarshalResources(body []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// decodeProps decodes a JSON document keeping numbers as json.Number, so
+// integers do not become floats
+func decodeProps(raw []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("invalid props JSON: %w", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("invalid props JSON: unexpected data after the document")
+	}
+	return value, nil
+}
+
+// canonicalNumbers rewrites the json.Number values in a decoded document in
+// place to their canonical form
+func canonicalNumbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			v[key] = canonicalNumbers(field)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = canonicalNumbers(v[i])
+		}
+	case json.Number:
+		return canonicalNumber(v)
+	}
+	return value
+}
+
+// canonicalNumber writes integers, including integral floats within the
+// exact float64 range, without a fraction or exponent and other numbers as
+// json.Marshal writes a float64. Integers too large for int64 are kept as
+// written.
+func canonicalNumber(n json.Number) json.Number {
+	if i, err := strconv.ParseInt(string(n), 10, 64); err == nil {
+		return json.Number(strconv.FormatInt(i, 10))
+	} else if !strings.ContainsAny(string(n), ".eE") {
+		return n
+	}
+	f, err := strconv.ParseFloat(string(n), 64)
+	if err != nil || math.IsInf(f, 0) {
+		return n
+	}
+	if f == math.Trunc(f) && math.Abs(f) <= maxExactFloatInt {
+		return json.Number(strconv.FormatInt(int64(f), 10))
+	}
+	encoded, err := json.Marshal(f)
+	if err != nil {
+		return n
+	}
+	return json.Number(encoded)
+}
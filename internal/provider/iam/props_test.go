@@ -0,0 +1,63 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestCanonicalizeProps(t *testing.T) {
+	tests := []struct {
+		name    string
+		props   interface{}
+		want    string
+		wantErr bool
+	}{
+		{name: "nil", props: nil, want: ""},
+		{name: "empty string", props: "  ", want: ""},
+		{name: "sorted keys", props: `{"b": 1, "a": {"d": true, "c": null}}`, want: `{"a":{"c":null,"d":true},"b":1}`},
+		{name: "integral numbers", props: `{"a": 1.0, "b": 1e2, "c": -0, "d": 7}`, want: `{"a":1,"b":100,"c":0,"d":7}`},
+		{name: "fractions", props: `{"a": 1.50, "b": 2.5e-1}`, want: `{"a":1.5,"b":0.25}`},
+		{name: "large integers kept", props: `{"a": 9007199254740993, "b": 123456789012345678901234567890}`, want: `{"a":9007199254740993,"b":123456789012345678901234567890}`},
+		{name: "no HTML escaping", props: `{"expr": "a<b && c>d"}`, want: `{"expr":"a<b && c>d"}`},
+		{name: "bytes", props: []byte(`[2, 1]`), want: `[2,1]`},
+		{name: "raw message", props: json.RawMessage(`{"z":1,"y":2}`), want: `{"y":2,"z":1}`},
+		{name: "map from float decoding", props: map[string]interface{}{"n": float64(42), "m": map[string]interface{}{"k": 1.0}}, want: `{"m":{"k":1},"n":42}`},
+		{name: "invalid JSON", props: `{not json`, wantErr: true},
+		{name: "trailing data", props: `{} {}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CanonicalizeProps(tt.props)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CanonicalizeProps() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CanonicalizeProps() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetResource_PropsRoundTrip(t *testing.T) {
+	props := `{"store":{"id":9007199254740993,"levels":[1,2,3]},"weight":0.5}`
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"r1","name":"R","props":` + props + `}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	resource, err := svc.GetResource(context.Background(), "r1")
+	if err != nil {
+		t.Fatalf("GetResource failed: %v", err)
+	}
+	got, err := CanonicalizeProps(resource.Props)
+	if err != nil {
+		t.Fatalf("CanonicalizeProps failed: %v", err)
+	}
+	if got != props {
+		t.Errorf("props = %s, want %s", got, props)
+	}
+}
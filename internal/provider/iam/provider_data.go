@@ -0,0 +1,23 @@
+package iam
+
+import (
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// ProviderData is what the provider hands to every resource and data source.
+// They all use the same Service, so its read cache, when enabled, serves
+// repeated reads across resources within one run.
+type ProviderData struct {
+	Client  *client.Client
+	Service *Service
+	Options Options // The settings Service was built with
+}
+
+// NewProviderData builds the shared Service for apiClient's tenant from opts
+func NewProviderData(apiClient *client.Client, opts Options) *ProviderData {
+	return &ProviderData{
+		Client:  apiClient,
+		Service: NewServiceWithOptions(apiClient, apiClient.TenantID(), opts),
+		Options: opts,
+	}
+}
@@ -0,0 +1,52 @@
+package iam
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// RenameGroup changes the name of a group and keeps its description and
+// members. Role bindings reference the group by ID, so they are unaffected.
+// When another group already has newName the API's 409 is returned as a
+// *client.Error with a message naming the conflict, so IsConflictError
+// still matches it.
+func (s *Service) RenameGroup(ctx context.Context, id, newName string) (*Group, error) {
+	id = s.normalizeID(ctx, "group", id)
+	newName = strings.TrimSpace(newName)
+	if newName == "" {
+		return nil, errors.New("new group name must not be empty")
+	}
+
+	// Read the group fresh so the PUT does not drop members added since it was cached
+	s.cache.Load().invalidate(cacheKindGroup, id)
+	current, err := s.GetGroup(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if current.Name == newName {
+		return current, nil
+	}
+
+	renamed := *current
+	renamed.Name = newName
+	renamed.Members = append([]string(nil), current.Members...)
+
+	result, err := s.UpdateGroup(ctx, id, &renamed)
+	if err != nil {
+		if client.IsConflictError(err) {
+			apiErr := err.(*client.Error)
+			return nil, &client.Error{
+				StatusCode: apiErr.StatusCode,
+				Message:    fmt.Sprintf("cannot rename group %s to %q: another group already has that name", id, newName),
+				Code:       apiErr.Code,
+				Details:    apiErr.Message,
+			}
+		}
+		return nil, err
+	}
+	return result, nil
+}
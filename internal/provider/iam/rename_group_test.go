@@ -0,0 +1,130 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// renameGroupMock serves one group and its roles, recording the PUT body
+type renameGroupMock struct {
+	name    string
+	putBody map[string]interface{}
+	putErr  *client.Response
+	writes  []string
+}
+
+func (m *renameGroupMock) client() *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		switch {
+		case req.Path == "/api/v1/tenants/t/groups/g1" && req.Method == "GET":
+			body, _ := json.Marshal(Group{ID: "g1", Name: m.name, Description: "Store staff", Members: []string{"u1", "u2"}})
+			return &client.Response{StatusCode: 200, Body: body}, nil
+		case req.Path == "/api/v1/tenants/t/groups/g1" && req.Method == "PUT":
+			m.writes = append(m.writes, req.Method+" "+req.Path)
+			if m.putErr != nil {
+				return m.putErr, nil
+			}
+			m.putBody = req.Body.(map[string]interface{})
+			m.name = m.putBody["name"].(string)
+			return &client.Response{StatusCode: 204}, nil
+		case req.Path == "/api/v2/tenants/t/groups/g1/roles" && req.Method == "GET":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"roleId":"pos.admin","isCustom":false,"bindings":["bu:001"]}]`)}, nil
+		}
+		m.writes = append(m.writes, req.Method+" "+req.Path)
+		return nil, errors.New("unexpected request " + req.Method + " " + req.Path)
+	}}
+}
+
+func TestService_RenameGroup(t *testing.T) {
+	mock := &renameGroupMock{name: "cashiers"}
+	svc := &Service{rawClient: mock.client(), tenantID: "t"}
+	ctx := context.Background()
+
+	before, err := svc.ListRoleBindingsForGroup(ctx, "g1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	group, err := svc.RenameGroup(ctx, "g1", "  tills  ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if group.ID != "g1" || group.Name != "tills" {
+		t.Errorf("group = %+v, want id g1 named tills", group)
+	}
+	if !reflect.DeepEqual(group.Members, []string{"u1", "u2"}) {
+		t.Errorf("members = %v, want [u1 u2]", group.Members)
+	}
+	if mock.putBody["name"] != "tills" || mock.putBody["description"] != "Store staff" {
+		t.Errorf("PUT body = %v, want the new name and the existing description", mock.putBody)
+	}
+	if members, _ := mock.putBody["members"].([]string); !reflect.DeepEqual(members, []string{"u1", "u2"}) {
+		t.Errorf("PUT members = %v, want [u1 u2]", mock.putBody["members"])
+	}
+	if !reflect.DeepEqual(mock.writes, []string{"PUT /api/v1/tenants/t/groups/g1"}) {
+		t.Errorf("writes = %v, want only the group PUT", mock.writes)
+	}
+
+	after, err := svc.ListRoleBindingsForGroup(ctx, "g1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(after) != len(before) {
+		t.Fatalf("got %d bindings after rename, want %d", len(after), len(before))
+	}
+	for i := range before {
+		if after[i].ID != before[i].ID || after[i].Role != before[i].Role || !reflect.DeepEqual(after[i].Bindings, before[i].Bindings) {
+			t.Errorf("binding %d = %+v, want %+v", i, after[i], before[i])
+		}
+	}
+	if got := after[0].Members; !reflect.DeepEqual(got, []string{"group:tills"}) {
+		t.Errorf("members = %v, want the renamed group", got)
+	}
+}
+
+func TestService_RenameGroup_Conflict(t *testing.T) {
+	mock := &renameGroupMock{
+		name:   "cashiers",
+		putErr: &client.Response{StatusCode: 409, Body: []byte(`{"message":"group name already exists","code":"ALREADY_EXISTS"}`)},
+	}
+	svc := &Service{rawClient: mock.client(), tenantID: "t"}
+
+	_, err := svc.RenameGroup(context.Background(), "g1", "managers")
+	if !client.IsConflictError(err) {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), `cannot rename group g1 to "managers"`) {
+		t.Errorf("error = %q, want it to name the group and the new name", err)
+	}
+	if mock.name != "cashiers" {
+		t.Errorf("name = %q, want the group unchanged", mock.name)
+	}
+}
+
+func TestService_RenameGroup_SameName(t *testing.T) {
+	mock := &renameGroupMock{name: "cashiers"}
+	svc := &Service{rawClient: mock.client(), tenantID: "t"}
+
+	group, err := svc.RenameGroup(context.Background(), "g1", "cashiers")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if group.Name != "cashiers" || len(mock.writes) != 0 {
+		t.Errorf("group = %+v, writes = %v, want no write", group, mock.writes)
+	}
+}
+
+func TestService_RenameGroup_EmptyName(t *testing.T) {
+	mock := &renameGroupMock{name: "cashiers"}
+	svc := &Service{rawClient: mock.client(), tenantID: "t"}
+
+	if _, err := svc.RenameGroup(context.Background(), "g1", " "); err == nil {
+		t.Fatal("expected an error for an empty name")
+	}
+}
@@ -0,0 +1,199 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// Role operations applied by ReplaceGroupRoles
+const (
+	RoleOperationAdd    = "add"
+	RoleOperationUpdate = "update"
+	RoleOperationRemove = "remove"
+)
+
+// RoleOperation is a single change ReplaceGroupRoles applies to a group
+type RoleOperation struct {
+	Op   string // RoleOperationAdd, RoleOperationUpdate or RoleOperationRemove
+	Role RoleBindingDto
+}
+
+// GroupRolesError reports the operations that failed during ReplaceGroupRoles
+// or ApplyGroupRoles. For ReplaceGroupRoles, operations not listed were
+// applied. For ApplyGroupRoles, RolledBack reports whether the operations
+// applied before the failure were reverted, and Applied lists the ones that
+// remain in effect.
+type GroupRolesError struct {
+	GroupID    string
+	Failed     []RoleOperation
+	Errs       []error
+	Applied    []RoleOperation
+	RolledBack bool
+}
+
+func (e *GroupRolesError) Error() string {
+	msg := fmt.Sprintf("failed to replace roles on group %s (%s)", e.GroupID, describeRoleOperations(e.Failed))
+	switch {
+	case e.RolledBack:
+		msg += ", changes rolled back"
+	case len(e.Applied) > 0:
+		msg += fmt.Sprintf(", partially applied (%s)", describeRoleOperations(e.Applied))
+	}
+	return fmt.Sprintf("%s: %v", msg, errors.Join(e.Errs...))
+}
+
+// describeRoleOperations lists operations as "op role", comma separated
+func describeRoleOperations(ops []RoleOperation) string {
+	parts := make([]string, len(ops))
+	for i, op := range ops {
+		parts[i] = op.Op + " " + op.Role.RoleID
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (e *GroupRolesError) Unwrap() []error {
+	return e.Errs
+}
+
+// ListGroupRoles returns the roles bound to a group using the V2 API
+func (s *Service) ListGroupRoles(ctx context.Context, groupID string) ([]RoleBindingDto, error) {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationListGroupRoles)
+	defer cancel()
+
+	groupID = s.normalizeID(ctx, "group", groupID)
+
+	path, err := s.tenantV2Path("groups", groupID, "roles")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.rawClient.Do(ctx, &client.Request{
+		Method: "GET",
+		Path:   path,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles for group %s: %w", groupID, err)
+	}
+	if err := client.CheckResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var roles []RoleBindingDto
+	if err := json.Unmarshal(resp.Body, &roles); err != nil {
+		return nil, fmt.Errorf("failed to parse role bindings response: %w", err)
+	}
+	return roles, nil
+}
+
+// ReplaceGroupRoles makes the roles bound to a group match desired.
+//
+// The current V2 roles are diffed against desired: missing roles are added,
+// roles whose bindings differ are re-posted and roles not in desired are removed.
+// Additions run before removals so the group does not lose access midway.
+// A failing operation does not stop the others; failures are returned together
+// as a *GroupRolesError. Operations not yet started when ctx is done are
+// reported as failed with the context error.
+func (s *Service) ReplaceGroupRoles(ctx context.Context, groupID string, desired []RoleBindingDto) error {
+	groupID = s.normalizeID(ctx, "group", groupID)
+
+	current, err := s.ListGroupRoles(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	result := &GroupRolesError{GroupID: groupID}
+	for _, op := range diffGroupRoles(current, desired) {
+		if err := ctx.Err(); err != nil {
+			result.Failed = append(result.Failed, op)
+			result.Errs = append(result.Errs, err)
+			continue
+		}
+
+		if err := s.applyRoleOperation(ctx, groupID, op); err != nil {
+			result.Failed = append(result.Failed, op)
+			result.Errs = append(result.Errs, err)
+		}
+	}
+
+	if len(result.Failed) > 0 {
+		return result
+	}
+	return nil
+}
+
+// applyRoleOperation performs a single role change on a group
+func (s *Service) applyRoleOperation(ctx context.Context, groupID string, op RoleOperation) error {
+	roleID := plainRoleID(op.Role)
+	var err error
+	switch op.Op {
+	case RoleOperationRemove:
+		err = s.RemoveRoleFromGroup(ctx, groupID, roleID, op.Role.IsCustom)
+	default:
+		err = s.AddRoleToGroup(ctx, groupID, roleID, op.Role.IsCustom, op.Role.Bindings)
+	}
+	if err != nil {
+		return fmt.Errorf("%s role %s: %w", op.Op, roleID, err)
+	}
+	return nil
+}
+
+// diffGroupRoles returns the operations that turn current into desired,
+// additions and updates first, then removals
+func diffGroupRoles(current, desired []RoleBindingDto) []RoleOperation {
+	existing := make(map[string]RoleBindingDto, len(current))
+	for _, role := range current {
+		existing[groupRoleKey(role)] = role
+	}
+
+	var ops, removals []RoleOperation
+	wanted := make(map[string]bool, len(desired))
+	for _, role := range desired {
+		key := groupRoleKey(role)
+		wanted[key] = true
+
+		have, ok := existing[key]
+		switch {
+		case !ok:
+			ops = append(ops, RoleOperation{Op: RoleOperationAdd, Role: role})
+		case !sameBindings(have.Bindings, role.Bindings):
+			ops = append(ops, RoleOperation{Op: RoleOperationUpdate, Role: role})
+		}
+	}
+	for _, role := range current {
+		if !wanted[groupRoleKey(role)] {
+			removals = append(removals, RoleOperation{Op: RoleOperationRemove, Role: role})
+		}
+	}
+	return append(ops, removals...)
+}
+
+// plainRoleID strips the prefixes the V2 API may return on custom role IDs
+func plainRoleID(role RoleBindingDto) string {
+	if !role.IsCustom {
+		return role.RoleID
+	}
+	id, _ := normalizeRoleID(role.RoleID)
+	return id
+}
+
+func groupRoleKey(role RoleBindingDto) string {
+	return denormalizeRoleID(plainRoleID(role), role.IsCustom, roleIDFormatAPI)
+}
+
+// sameBindings compares bindings as sets; empty means all resources, as in AddRoleToGroup
+func sameBindings(a, b []string) bool {
+	norm := func(bindings []string) []string {
+		if len(bindings) == 0 {
+			return []string{"*"}
+		}
+		sorted := slices.Clone(bindings)
+		slices.Sort(sorted)
+		return slices.Compact(sorted)
+	}
+	return slices.Equal(norm(a), norm(b))
+}
@@ -0,0 +1,147 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// groupRolesMock serves current as the roles of group g1 and records role changes.
+// Changes to roles in failing return a 400.
+func groupRolesMock(t *testing.T, current []RoleBindingDto, failing map[string]bool, calls *[]string) *MockClient {
+	t.Helper()
+	body, err := json.Marshal(current)
+	if err != nil {
+		t.Fatalf("marshal roles: %v", err)
+	}
+	const rolesPath = "/api/v2/tenants/t/groups/g1/roles"
+
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		var roleID string
+		switch {
+		case req.Method == "GET" && req.Path == rolesPath:
+			return &client.Response{StatusCode: 200, Body: body}, nil
+		case req.Method == "GET" && strings.HasPrefix(req.Path, "/api/v1/tenants/t/roles/"):
+			return &client.Response{StatusCode: 200, Body: []byte(`{}`)}, nil
+		case req.Method == "POST" && req.Path == rolesPath:
+			payload := req.Body.(map[string]interface{})
+			roleID = payload["roleId"].(string)
+			*calls = append(*calls, "POST "+roleID+" "+strings.Join(payload["bindings"].([]string), ","))
+		case req.Method == "DELETE" && strings.HasPrefix(req.Path, rolesPath+"/"):
+			roleID = strings.TrimPrefix(req.Path, rolesPath+"/")
+			*calls = append(*calls, "DELETE "+roleID)
+		default:
+			return nil, errors.New("unexpected request " + req.Method + " " + req.Path)
+		}
+		if failing[roleID] {
+			return &client.Response{StatusCode: 400, Body: []byte(`{"message":"rejected"}`)}, nil
+		}
+		return &client.Response{StatusCode: 204}, nil
+	}}
+}
+
+func TestService_ReplaceGroupRoles(t *testing.T) {
+	current := []RoleBindingDto{
+		{RoleID: "pos.cashier", Bindings: []string{"bu:001"}},
+		{RoleID: "custom-roles/custom.Auditor", IsCustom: true, Bindings: []string{"*"}},
+	}
+
+	cases := []struct {
+		name      string
+		desired   []RoleBindingDto
+		failing   map[string]bool
+		wantCalls []string
+		wantFail  []string
+	}{
+		{
+			name:    "no changes",
+			desired: []RoleBindingDto{{RoleID: "pos.cashier", Bindings: []string{"bu:001"}}, {RoleID: "Auditor", IsCustom: true}},
+		},
+		{
+			name: "add only",
+			desired: []RoleBindingDto{
+				{RoleID: "pos.cashier", Bindings: []string{"bu:001"}},
+				{RoleID: "custom.Auditor", IsCustom: true, Bindings: []string{"*"}},
+				{RoleID: "pos.manager", Bindings: []string{"bu:002"}},
+			},
+			wantCalls: []string{"POST pos.manager bu:002"},
+		},
+		{
+			name:      "remove only",
+			desired:   []RoleBindingDto{{RoleID: "pos.cashier", Bindings: []string{"bu:001"}}},
+			wantCalls: []string{"DELETE Auditor"},
+		},
+		{
+			name: "mixed",
+			desired: []RoleBindingDto{
+				{RoleID: "pos.cashier", Bindings: []string{"bu:001", "bu:002"}},
+				{RoleID: "pos.manager", Bindings: []string{"bu:003"}},
+			},
+			wantCalls: []string{"POST pos.cashier bu:001,bu:002", "POST pos.manager bu:003", "DELETE Auditor"},
+		},
+		{
+			name:      "partial failure continues",
+			desired:   []RoleBindingDto{{RoleID: "pos.manager", Bindings: []string{"bu:003"}}},
+			failing:   map[string]bool{"pos.manager": true},
+			wantCalls: []string{"POST pos.manager bu:003", "DELETE pos.cashier", "DELETE Auditor"},
+			wantFail:  []string{"add pos.manager"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var calls []string
+			svc := &Service{rawClient: groupRolesMock(t, current, tc.failing, &calls), tenantID: "t"}
+
+			err := svc.ReplaceGroupRoles(context.Background(), "g1", tc.desired)
+
+			if !reflect.DeepEqual(calls, tc.wantCalls) {
+				t.Errorf("calls = %q, want %q", calls, tc.wantCalls)
+			}
+			if len(tc.wantFail) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			var rolesErr *GroupRolesError
+			if !errors.As(err, &rolesErr) {
+				t.Fatalf("expected *GroupRolesError, got %v", err)
+			}
+			var failed []string
+			for _, op := range rolesErr.Failed {
+				failed = append(failed, op.Op+" "+op.Role.RoleID)
+			}
+			if !reflect.DeepEqual(failed, tc.wantFail) {
+				t.Errorf("failed = %q, want %q", failed, tc.wantFail)
+			}
+		})
+	}
+}
+
+func TestService_ReplaceGroupRoles_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls []string
+	mock := groupRolesMock(t, nil, nil, &calls)
+	svc := &Service{rawClient: &MockClient{DoFunc: func(c context.Context, req *client.Request) (*client.Response, error) {
+		resp, err := mock.DoFunc(c, req)
+		if req.Method == "POST" {
+			cancel()
+		}
+		return resp, err
+	}}, tenantID: "t"}
+
+	err := svc.ReplaceGroupRoles(ctx, "g1", []RoleBindingDto{{RoleID: "a"}, {RoleID: "b"}})
+
+	if len(calls) != 1 {
+		t.Errorf("expected only the first operation to run, got %q", calls)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
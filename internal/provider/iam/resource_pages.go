@@ -0,0 +1,100 @@
+package iam
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+// maxResourcePages bounds how many pages GetResources follows with FetchAll,
+// so a server that keeps returning the same next_page cannot loop forever.
+const maxResourcePages = 1000
+
+// resourcesPage is one decoded page of the resources listing. Total is nil
+// when the API does not report one.
+type resourcesPage struct {
+	Resources []Resource `json:"resources"`
+	NextPage  int        `json:"next_page,omitempty"`
+	Total     *int       `json:"total,omitempty"`
+}
+
+// response converts the page to a GetResourcesResponse, counting the page's
+// resources when the API reports no total
+func (p *resourcesPage) response() *GetResourcesResponse {
+	total := len(p.Resources)
+	if p.Total != nil {
+		total = *p.Total
+	}
+	return &GetResourcesResponse{Resources: p.Resources, NextPage: p.NextPage, Total: total}
+}
+
+// getResourcesPage requests one page of resources matching req
+func (s *Service) getResourcesPage(ctx context.Context, req *GetResourcesRequest, page int) (*resourcesPage, error) {
+	query := listQuery(
+		"permission", req.Permission,
+		"type", req.Type,
+		"page_size", pageParam(req.PageSize),
+		"page", pageParam(page),
+	)
+	path, err := s.tenantPath("resources")
+	if err != nil {
+		return nil, err
+	}
+	return list(ctx, s.rawGet, "resources", path, query, decodeResourcesPage)
+}
+
+// getAllResources follows every page from req.Page and concatenates them.
+// Total is the latest total reported by the API, raised to the number of
+// resources collected if resources were added while paging, or that number
+// when the API reports no total.
+func (s *Service) getAllResources(ctx context.Context, req *GetResourcesRequest) (*GetResourcesResponse, error) {
+	all := &GetResourcesResponse{Resources: []Resource{}}
+	var reported *int
+	err := eachPage("resources", req.Page, maxResourcePages, func(page int) (int, error) {
+		if err := ctx.Err(); err != nil {
+			return 0, fmt.Errorf("failed to list resources: %w", err)
+		}
+		result, err := s.getResourcesPage(ctx, req, page)
+		if err != nil {
+			return 0, err
+		}
+		all.Resources = append(all.Resources, result.Resources...)
+		if result.Total != nil {
+			reported = result.Total
+		}
+		return result.NextPage, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	all.Total = len(all.Resources)
+	if reported != nil && *reported > all.Total {
+		all.Total = *reported
+	}
+	return all, nil
+}
+
+// decodeResourcesPage accepts either a plain array of resources or the
+// paginated {"resources": [...], "next_page": n, "total": n} envelope,
+// keeping numbers in props exact
+func decodeResourcesPage(body []byte) (*resourcesPage, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 {
+		return &resourcesPage{}, nil
+	}
+
+	if trimmed[0] == '[' {
+		var resources []Resource
+		if err := unmarshalResources(trimmed, &resources); err != nil {
+			return nil, err
+		}
+		return &resourcesPage{Resources: resources}, nil
+	}
+
+	var page resourcesPage
+	if err := unmarshalResources(trimmed, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
@@ -0,0 +1,124 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// pagedResourcesMock serves total resources bu:0..bu:(total-1) in pages of
+// the requested page_size (default 100) with the API total, recording every
+// page requested
+func pagedResourcesMock(total int, pages *[]int) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		size := 100
+		if v := req.Query["page_size"]; v != "" {
+			size, _ = strconv.Atoi(v)
+		}
+		page, _ := strconv.Atoi(req.Query["page"])
+		if pages != nil {
+			*pages = append(*pages, page)
+		}
+		start, end := min(page*size, total), min((page+1)*size, total)
+
+		out := map[string]interface{}{"total": total}
+		resources := []map[string]interface{}{}
+		for i := start; i < end; i++ {
+			resources = append(resources, map[string]interface{}{"id": fmt.Sprintf("bu:%d", i), "name": fmt.Sprintf("Store %d", i)})
+		}
+		out["resources"] = resources
+		if end < total {
+			out["next_page"] = page + 1
+		}
+		body, _ := json.Marshal(out)
+		return &client.Response{StatusCode: 200, Body: body}, nil
+	}}
+}
+
+func TestService_GetResources_FetchAll(t *testing.T) {
+	var pages []int
+	svc := &Service{rawClient: pagedResourcesMock(250, &pages), tenantID: "t"}
+
+	result, err := svc.GetResources(context.Background(), &GetResourcesRequest{PageSize: 100, FetchAll: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Resources) != 250 || result.Total != 250 {
+		t.Fatalf("got %d resources with total %d, want 250 and 250", len(result.Resources), result.Total)
+	}
+	seen := map[string]bool{}
+	for _, r := range result.Resources {
+		if seen[r.ID] {
+			t.Errorf("resource %s returned twice", r.ID)
+		}
+		seen[r.ID] = true
+	}
+	if fmt.Sprint(pages) != "[0 1 2]" {
+		t.Errorf("pages = %v, want [0 1 2]", pages)
+	}
+	if result.NextPage != 0 {
+		t.Errorf("NextPage = %d, want 0 after the last page", result.NextPage)
+	}
+}
+
+func TestService_GetResources_SinglePageReportsAPITotal(t *testing.T) {
+	var pages []int
+	svc := &Service{rawClient: pagedResourcesMock(250, &pages), tenantID: "t"}
+
+	result, err := svc.GetResources(context.Background(), &GetResourcesRequest{PageSize: 100, Page: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Resources) != 100 || result.Resources[0].ID != "bu:100" {
+		t.Errorf("got %d resources from %v, want the 100 of page 1", len(result.Resources), result.Resources[0].ID)
+	}
+	if result.Total != 250 || result.NextPage != 2 {
+		t.Errorf("Total = %d, NextPage = %d, want 250 and 2", result.Total, result.NextPage)
+	}
+	if len(pages) != 1 {
+		t.Errorf("pages = %v, want a single request without FetchAll", pages)
+	}
+}
+
+func TestService_GetResources_FetchAllWithoutReportedTotal(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Query["page"] == "" {
+			return &client.Response{StatusCode: 200, Body: []byte(`{"resources":[{"id":"bu:1"},{"id":"bu:2"}],"next_page":1}`)}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(`{"resources":[{"id":"bu:3"}]}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	result, err := svc.GetResources(context.Background(), &GetResourcesRequest{FetchAll: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Resources) != 3 || result.Total != 3 {
+		t.Errorf("got %d resources with total %d, want 3 and 3", len(result.Resources), result.Total)
+	}
+}
+
+func TestService_GetResources_FetchAllStopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var pages []int
+	inner := pagedResourcesMock(250, &pages)
+	mock := &MockClient{DoFunc: func(c context.Context, req *client.Request) (*client.Response, error) {
+		resp, err := inner.DoFunc(c, req)
+		cancel()
+		return resp, err
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	_, err := svc.GetResources(ctx, &GetResourcesRequest{PageSize: 100, FetchAll: true})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("error = %v, want context.Canceled", err)
+	}
+	if len(pages) != 1 {
+		t.Errorf("pages = %v, want paging to stop after the first page", pages)
+	}
+}
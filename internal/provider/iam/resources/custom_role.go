@@ -2,7 +2,9 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -99,6 +101,9 @@ func (r *CustomRoleResource) Schema(ctx context.Context, req resource.SchemaRequ
 				Description:         "Set of permissions for the custom role.",
 				MarkdownDescription: "Set of permissions for the custom role.",
 				Required:            true,
+				Validators: []validator.Set{
+					permissionsValidator{},
+				},
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
@@ -148,23 +153,25 @@ func (r *CustomRoleResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
-	r.iamService = iam.NewService(client, client.TenantID())
+	r.client = providerData.Client
+	r.iamService = providerData.Service
 
 	tflog.Info(ctx, "Configured IAM Custom Role Resource")
 }
 
 // Create creates the resource and sets the initial Terraform state
 func (r *CustomRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data CustomRoleResourceModel
 
 	// Read Terraform plan data into the model
@@ -323,6 +330,8 @@ func (r *CustomRoleResource) Create(ctx context.Context, req resource.CreateRequ
 
 // Read refreshes the Terraform state with the latest data
 func (r *CustomRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data CustomRoleResourceModel
 
 	// Read Terraform prior state data into the model
@@ -413,6 +422,8 @@ func (r *CustomRoleResource) Read(ctx context.Context, req resource.ReadRequest,
 
 // Update updates the resource and sets the updated Terraform state on success
 func (r *CustomRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data CustomRoleResourceModel
 
 	// Read Terraform plan data into the model
@@ -533,6 +544,8 @@ func (r *CustomRoleResource) Update(ctx context.Context, req resource.UpdateRequ
 
 // Delete deletes the resource and removes the Terraform state on success
 func (r *CustomRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data CustomRoleResourceModel
 
 	// Read Terraform prior state data into the model
@@ -542,7 +555,7 @@ func (r *CustomRoleResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 
 	// Delete the custom role via API
-	err := r.iamService.DeleteCustomRole(ctx, data.Name.ValueString())
+	err := r.iamService.DeleteCustomRole(ctx, data.Name.ValueString(), iam.DeleteOptions{})
 	if err != nil {
 		if client.IsNotFoundError(err) {
 			// Custom role already deleted, nothing to do
@@ -558,8 +571,96 @@ func (r *CustomRoleResource) Delete(ctx context.Context, req resource.DeleteRequ
 	tflog.Trace(ctx, "deleted IAM custom role resource")
 }
 
-// ImportState imports an existing resource into Terraform state
+// ImportState imports an existing resource into Terraform state. The import
+// ID is the role ID or a {tenant}/{roleId} composite whose tenant must match
+// the provider tenant.
 func (r *CustomRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the name as the import identifier (custom roles are identified by name)
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	providerTenant := ""
+	if r.client != nil {
+		providerTenant = r.client.TenantID()
+	}
+
+	roleID, err := parseCustomRoleImportID(req.ID, providerTenant)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
+		return
+	}
+
+	// Read looks the role up by id and fills in the name returned by the API
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(roleID))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), types.StringValue(roleID))...)
+}
+
+// parseCustomRoleImportID returns the role ID of an import ID of the form
+// roleId or tenant/roleId
+func parseCustomRoleImportID(importID, providerTenant string) (string, error) {
+	const usage = "Use: terraform import hiiretail_iam_custom_role.example role-id or tenant/role-id"
+
+	if importID == "" {
+		return "", fmt.Errorf("custom role ID is required for import. %s", usage)
+	}
+
+	tenantID, roleID, composite := strings.Cut(importID, "/")
+	if !composite {
+		return importID, nil
+	}
+	if tenantID == "" || roleID == "" || strings.Contains(roleID, "/") {
+		return "", fmt.Errorf("import ID %q is not of the form tenant/role-id. %s", importID, usage)
+	}
+	if providerTenant != "" && tenantID != providerTenant {
+		return "", fmt.Errorf("import ID tenant %q does not match the provider tenant %q; configure the provider for tenant %q or import with the bare role ID", tenantID, providerTenant, tenantID)
+	}
+	return roleID, nil
+}
+
+var _ validator.Set = permissionsValidator{}
+
+// permissionsValidator checks permission ids and the per-role limits at plan
+// time so violations are reported before the API round trip
+type permissionsValidator struct{}
+
+func (v permissionsValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("permission ids must match %s; at most %d pos and %d general permissions are allowed per role",
+		iam.PermissionIDRegexp.String(), iam.MaxPOSPermissions, iam.MaxGeneralPermissions)
+}
+
+func (v permissionsValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("permission ids must match `%s`; at most %d pos and %d general permissions are allowed per role",
+		iam.PermissionIDRegexp.String(), iam.MaxPOSPermissions, iam.MaxGeneralPermissions)
+}
+
+// ValidateSet reports each malformed id on its own element and the POS and
+// general permission limits on the set
+func (v permissionsValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var ids []string
+	var idPaths []path.Path
+	for _, elem := range req.ConfigValue.Elements() {
+		obj, ok := elem.(types.Object)
+		if !ok || obj.IsNull() || obj.IsUnknown() {
+			continue
+		}
+		id, ok := obj.Attributes()["id"].(types.String)
+		if !ok || id.IsNull() || id.IsUnknown() {
+			continue
+		}
+		ids = append(ids, id.ValueString())
+		idPaths = append(idPaths, req.Path.AtSetValue(elem).AtName("id"))
+	}
+
+	for _, err := range iam.ValidatePermissionIDs(ids) {
+		var idErr *iam.InvalidPermissionIDError
+		var limitErr *iam.PermissionLimitError
+		switch {
+		case errors.As(err, &idErr):
+			resp.Diagnostics.AddAttributeError(idPaths[idErr.Index], "Invalid Permission ID", err.Error())
+		case errors.As(err, &limitErr) && limitErr.POS:
+			resp.Diagnostics.AddAttributeError(req.Path, "Too Many POS Permissions", err.Error())
+		default:
+			resp.Diagnostics.AddAttributeError(req.Path, "Too Many Permissions", err.Error())
+		}
+	}
 }
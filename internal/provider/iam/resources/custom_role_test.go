@@ -0,0 +1,99 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+)
+
+func TestParseCustomRoleImportID(t *testing.T) {
+	tests := []struct {
+		name     string
+		importID string
+		tenant   string
+		want     string
+		wantErr  string
+	}{
+		{name: "bare role id", importID: "cashier", tenant: "my-tenant", want: "cashier"},
+		{name: "composite id", importID: "my-tenant/cashier", tenant: "my-tenant", want: "cashier"},
+		{name: "composite id without provider tenant", importID: "my-tenant/cashier", want: "cashier"},
+		{name: "tenant mismatch", importID: "other/cashier", tenant: "my-tenant", wantErr: "does not match the provider tenant"},
+		{name: "missing role id", importID: "my-tenant/", tenant: "my-tenant", wantErr: "not of the form tenant/role-id"},
+		{name: "too many parts", importID: "my-tenant/a/b", tenant: "my-tenant", wantErr: "not of the form tenant/role-id"},
+		{name: "empty", importID: "", wantErr: "required for import"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCustomRoleImportID(tt.importID, tt.tenant)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func permissionsSet(t *testing.T, ids ...string) types.Set {
+	t.Helper()
+	objectType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"id":         types.StringType,
+		"attributes": types.MapType{ElemType: types.StringType},
+	}}
+
+	elems := make([]attr.Value, len(ids))
+	for i, id := range ids {
+		elems[i] = types.ObjectValueMust(objectType.AttrTypes, map[string]attr.Value{
+			"id":         types.StringValue(id),
+			"attributes": types.MapNull(types.StringType),
+		})
+	}
+	set, diags := types.SetValue(objectType, elems)
+	require.False(t, diags.HasError(), "%v", diags)
+	return set
+}
+
+func TestPermissionsValidator(t *testing.T) {
+	// manyIDs returns n distinct valid ids, n must be below 26*26
+	manyIDs := func(prefix string, n int) []string {
+		ids := make([]string, n)
+		for i := range ids {
+			ids[i] = fmt.Sprintf("%s.res.act%c%c", prefix, 'a'+i/26, 'a'+i%26)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name      string
+		ids       []string
+		wantError []string
+	}{
+		{name: "valid", ids: []string{"pos.payment.create", "iam.group.list"}},
+		{name: "malformed id", ids: []string{"pos.payment.create", "IAM.Groups.List"}, wantError: []string{"Invalid Permission ID"}},
+		{name: "general limit", ids: manyIDs("iam", iam.MaxGeneralPermissions+1), wantError: []string{"Too Many Permissions"}},
+		{name: "pos limit", ids: manyIDs("pos", iam.MaxPOSPermissions+1), wantError: []string{"Too Many POS Permissions"}},
+		{name: "pos within limit", ids: manyIDs("pos", iam.MaxPOSPermissions)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.SetRequest{Path: path.Root("permissions"), ConfigValue: permissionsSet(t, tt.ids...)}
+			resp := &validator.SetResponse{}
+			permissionsValidator{}.ValidateSet(context.Background(), req, resp)
+
+			var got []string
+			for _, d := range resp.Diagnostics.Errors() {
+				got = append(got, d.Summary())
+			}
+			require.Equal(t, tt.wantError, got)
+		})
+	}
+}
@@ -107,23 +107,25 @@ func (r *GroupResource) Configure(ctx context.Context, req resource.ConfigureReq
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 		return
 	}
 
-	r.client = client
-	r.iamService = iam.NewService(client, client.TenantID())
+	r.client = providerData.Client
+	r.iamService = providerData.Service
 
 	tflog.Info(ctx, "Configured IAM Group Resource")
 }
 
 // Create creates the resource and sets the initial Terraform state
 func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data GroupResourceModel
 
 	// Read Terraform plan data into the model
@@ -209,6 +211,8 @@ func (r *GroupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 // Read refreshes the Terraform state with the latest data
 func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data GroupResourceModel
 
 	// Read Terraform prior state data into the model
@@ -262,6 +266,8 @@ func (r *GroupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 // Update updates the resource and sets the updated Terraform state on success
 func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data GroupResourceModel
 
 	// Read Terraform plan data into the model
@@ -326,6 +332,8 @@ func (r *GroupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 // Delete deletes the resource and removes the Terraform state on success
 func (r *GroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data GroupResourceModel
 
 	// Read Terraform prior state data into the model
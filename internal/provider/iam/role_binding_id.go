@@ -0,0 +1,53 @@
+package iam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// DefaultRoleBindingIDDelimiter separates the parts of composite role binding IDs.
+// Group and role IDs may contain hyphens, so the default avoids them.
+const DefaultRoleBindingIDDelimiter = "/"
+
+// LegacyRoleBindingIDDelimiter is the delimiter used by composite role binding IDs
+// before the delimiter became configurable. IDs in this form are still accepted
+// when parsing so existing state keeps working during the transition.
+const LegacyRoleBindingIDDelimiter = "-"
+
+// SetRoleBindingIDDelimiter sets the delimiter used when formatting composite
+// role binding IDs. An empty delimiter restores DefaultRoleBindingIDDelimiter.
+func (s *Service) SetRoleBindingIDDelimiter(delimiter string) {
+	s.idDelimiter = delimiter
+}
+
+// RoleBindingIDDelimiter returns the delimiter used for composite role binding IDs.
+func (s *Service) RoleBindingIDDelimiter() string {
+	if s.idDelimiter == "" {
+		return DefaultRoleBindingIDDelimiter
+	}
+	return s.idDelimiter
+}
+
+// FormatRoleBindingID builds the composite ID for a role bound to a group.
+// roleID must include the "custom." prefix for custom roles.
+func (s *Service) FormatRoleBindingID(groupID, roleID string) string {
+	return groupID + s.RoleBindingIDDelimiter() + roleID
+}
+
+// ParseRoleBindingID splits a composite role binding ID into its group and role
+// parts. IDs using the configured delimiter are tried first; otherwise the legacy
+// hyphen-delimited form is accepted, in which the group is everything up to the
+// first hyphen.
+func (s *Service) ParseRoleBindingID(id string) (groupID, roleID string, err error) {
+	for _, delimiter := range []string{s.RoleBindingIDDelimiter(), LegacyRoleBindingIDDelimiter} {
+		if groupID, roleID, ok := strings.Cut(id, delimiter); ok && groupID != "" && roleID != "" {
+			return groupID, roleID, nil
+		}
+	}
+	return "", "", &client.Error{
+		StatusCode: 400,
+		Message:    fmt.Sprintf("invalid role binding ID format: %s", id),
+	}
+}
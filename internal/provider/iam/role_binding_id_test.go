@@ -0,0 +1,120 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_ParseRoleBindingID(t *testing.T) {
+	tests := []struct {
+		name      string
+		delimiter string
+		id        string
+		wantGroup string
+		wantRole  string
+		wantErr   bool
+	}{
+		{name: "new format", id: "g1/Role1", wantGroup: "g1", wantRole: "Role1"},
+		{name: "new format with hyphens", id: "store-admins/custom.Foo-Bar", wantGroup: "store-admins", wantRole: "custom.Foo-Bar"},
+		{name: "legacy format", id: "g1-Role1", wantGroup: "g1", wantRole: "Role1"},
+		{name: "legacy format custom role with hyphens", id: "g1-custom.Foo-Bar", wantGroup: "g1", wantRole: "custom.Foo-Bar"},
+		{name: "configured delimiter", delimiter: "::", id: "store-admins::Role1", wantGroup: "store-admins", wantRole: "Role1"},
+		{name: "configured delimiter accepts legacy", delimiter: "::", id: "g1-Role1", wantGroup: "g1", wantRole: "Role1"},
+		{name: "no delimiter", id: "invalidformat", wantErr: true},
+		{name: "missing role", id: "g1/", wantErr: true},
+		{name: "missing group", id: "/Role1", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &Service{tenantID: "t"}
+			svc.SetRoleBindingIDDelimiter(tc.delimiter)
+
+			group, role, err := svc.ParseRoleBindingID(tc.id)
+			if tc.wantErr {
+				apiErr, ok := err.(*client.Error)
+				if !ok || apiErr.StatusCode != 400 {
+					t.Fatalf("expected 400 client error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if group != tc.wantGroup || role != tc.wantRole {
+				t.Errorf("got (%q, %q), want (%q, %q)", group, role, tc.wantGroup, tc.wantRole)
+			}
+		})
+	}
+}
+
+func TestService_FormatRoleBindingID(t *testing.T) {
+	svc := &Service{tenantID: "t"}
+	if got := svc.FormatRoleBindingID("g1", "custom.Foo-Bar"); got != "g1/custom.Foo-Bar" {
+		t.Errorf("default delimiter: got %q", got)
+	}
+
+	svc.SetRoleBindingIDDelimiter("::")
+	if got := svc.FormatRoleBindingID("g1", "Role1"); got != "g1::Role1" {
+		t.Errorf("configured delimiter: got %q", got)
+	}
+
+	svc.SetRoleBindingIDDelimiter("")
+	if got := svc.RoleBindingIDDelimiter(); got != DefaultRoleBindingIDDelimiter {
+		t.Errorf("empty delimiter should restore default, got %q", got)
+	}
+}
+
+func TestNewServiceWithOptions_RoleBindingIDDelimiter(t *testing.T) {
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, client.DefaultConfig())
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+
+	if got := NewServiceWithOptions(apiClient, "t", Options{RoleBindingIDDelimiter: "::"}).RoleBindingIDDelimiter(); got != "::" {
+		t.Errorf("RoleBindingIDDelimiter() = %q, want the configured %q", got, "::")
+	}
+}
+
+func TestService_RoleBindingID_BothFormats(t *testing.T) {
+	var paths []string
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		paths = append(paths, req.Method+" "+req.Path)
+		switch req.Method {
+		case "GET":
+			if req.Path == "/api/v2/tenants/t/groups/g-1/roles" {
+				return &client.Response{StatusCode: 200, Body: []byte(`[{"roleId":"Role1","isCustom":false}]`)}, nil
+			}
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"g-1","name":"Group1"}`)}, nil
+		case "DELETE":
+			return &client.Response{StatusCode: 204}, nil
+		}
+		return &client.Response{StatusCode: 404}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	b, err := svc.GetRoleBinding(context.Background(), "g-1/Role1")
+	if err != nil {
+		t.Fatalf("GetRoleBinding new format failed: %v", err)
+	}
+	if b.ID != "g-1/Role1" || b.Role != "roles/Role1" {
+		t.Errorf("unexpected binding: %+v", b)
+	}
+
+	if err := svc.DeleteRoleBinding(context.Background(), "g-1/Role1"); err != nil {
+		t.Fatalf("DeleteRoleBinding new format failed: %v", err)
+	}
+	if want := "DELETE /api/v2/tenants/t/groups/g-1/roles/Role1"; paths[len(paths)-1] != want {
+		t.Errorf("new format delete path = %q, want %q", paths[len(paths)-1], want)
+	}
+
+	if err := svc.DeleteRoleBinding(context.Background(), "g1-custom.Foo-Bar"); err != nil {
+		t.Fatalf("DeleteRoleBinding legacy format failed: %v", err)
+	}
+	if want := "DELETE /api/v2/tenants/t/groups/g1/roles/Foo-Bar"; paths[len(paths)-1] != want {
+		t.Errorf("legacy format delete path = %q, want %q", paths[len(paths)-1], want)
+	}
+}
@@ -0,0 +1,53 @@
+package iam
+
+import "strings"
+
+// Role ID prefixes. Internally a role is its plain ID plus a custom flag;
+// the prefixes only appear where IDs are exchanged with the API or Terraform.
+const (
+	customRolePrefix     = "custom."       // Custom roles in API role IDs and composite binding IDs
+	terraformRolePrefix  = "roles/"        // Role references in Terraform configuration
+	customRolePathPrefix = "custom-roles/" // Custom role paths some V2 responses return
+)
+
+// roleIDFormat selects the representation produced by denormalizeRoleID
+type roleIDFormat int
+
+const (
+	// roleIDFormatAPI is "custom.<id>" for custom roles and "<id>" otherwise,
+	// as sent to the V2 API and used in composite role binding IDs
+	roleIDFormatAPI roleIDFormat = iota
+	// roleIDFormatTerraform is "roles/custom.<id>" or "roles/<id>", as
+	// written in the role attribute of a role binding
+	roleIDFormatTerraform
+)
+
+// normalizeRoleID strips the "roles/", "custom-roles/" and "custom." prefixes
+// from roleID and reports whether any of them marked it as a custom role
+func normalizeRoleID(roleID string) (id string, isCustom bool) {
+	id = strings.TrimPrefix(roleID, terraformRolePrefix)
+	if rest, ok := strings.CutPrefix(id, customRolePathPrefix); ok {
+		id, isCustom = rest, true
+	}
+	if rest, ok := strings.CutPrefix(id, customRolePrefix); ok {
+		id, isCustom = rest, true
+	}
+	return id, isCustom
+}
+
+// denormalizeRoleID formats a plain role ID in the given representation
+func denormalizeRoleID(id string, isCustom bool, format roleIDFormat) string {
+	if isCustom {
+		id = customRolePrefix + id
+	}
+	if format == roleIDFormatTerraform {
+		id = terraformRolePrefix + id
+	}
+	return id
+}
+
+// ParseRole splits a role reference in any of the forms the API or Terraform
+// use, such as "roles/custom.Cashier", into its plain ID and custom flag
+func ParseRole(role string) (id string, isCustom bool) {
+	return normalizeRoleID(role)
+}
@@ -0,0 +1,55 @@
+package iam
+
+import "testing"
+
+func TestNormalizeRoleID(t *testing.T) {
+	tests := []struct {
+		roleID     string
+		wantID     string
+		wantCustom bool
+	}{
+		{roleID: "TerraformTest", wantID: "TerraformTest"},
+		{roleID: "custom.TerraformTest", wantID: "TerraformTest", wantCustom: true},
+		{roleID: "custom-roles/custom.TerraformTest", wantID: "TerraformTest", wantCustom: true},
+		{roleID: "custom-roles/TerraformTest", wantID: "TerraformTest", wantCustom: true},
+		{roleID: "roles/custom.TerraformTest", wantID: "TerraformTest", wantCustom: true},
+		{roleID: "roles/pos.admin", wantID: "pos.admin"},
+		{roleID: "pos.admin", wantID: "pos.admin"},
+		{roleID: "custom.Foo-Bar", wantID: "Foo-Bar", wantCustom: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.roleID, func(t *testing.T) {
+			id, isCustom := normalizeRoleID(tt.roleID)
+			if id != tt.wantID || isCustom != tt.wantCustom {
+				t.Errorf("normalizeRoleID(%q) = %q, %v; want %q, %v", tt.roleID, id, isCustom, tt.wantID, tt.wantCustom)
+			}
+		})
+	}
+}
+
+func TestDenormalizeRoleID(t *testing.T) {
+	tests := []struct {
+		id       string
+		isCustom bool
+		format   roleIDFormat
+		want     string
+	}{
+		{id: "TerraformTest", isCustom: true, format: roleIDFormatAPI, want: "custom.TerraformTest"},
+		{id: "TerraformTest", isCustom: true, format: roleIDFormatTerraform, want: "roles/custom.TerraformTest"},
+		{id: "pos.admin", format: roleIDFormatAPI, want: "pos.admin"},
+		{id: "pos.admin", format: roleIDFormatTerraform, want: "roles/pos.admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := denormalizeRoleID(tt.id, tt.isCustom, tt.format); got != tt.want {
+				t.Errorf("denormalizeRoleID(%q, %v, %d) = %q, want %q", tt.id, tt.isCustom, tt.format, got, tt.want)
+			}
+			// Formatting and normalizing again round-trips to the plain ID
+			if id, isCustom := normalizeRoleID(tt.want); id != tt.id || isCustom != tt.isCustom {
+				t.Errorf("normalizeRoleID(%q) = %q, %v; want %q, %v", tt.want, id, isCustom, tt.id, tt.isCustom)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// SetRoleValidation turns the role lookup before CreateRoleBinding on or off.
+// It is on by default, so an unknown role fails with a "role X not found"
+// error instead of an API error from the V2 POST. Callers that have already
+// validated the role can turn it off to save the round trip.
+func (s *Service) SetRoleValidation(enabled bool) {
+	s.disableRoleValidation = !enabled
+}
+
+// requireRole returns an error unless the role exists, looking up basic roles
+// with GetRole and custom roles with CustomRoleExists. A missing role is
+// reported as a not found error.
+func (s *Service) requireRole(ctx context.Context, roleID string, isCustom bool) error {
+	if s.disableRoleValidation {
+		return nil
+	}
+	if isCustom {
+		return s.requireCustomRole(ctx, roleID)
+	}
+
+	if _, err := s.GetRole(ctx, roleID); err != nil {
+		if client.IsNotFoundError(err) {
+			return fmt.Errorf("role %s not found: %w", roleID, err)
+		}
+		return fmt.Errorf("failed to look up role %s: %w", roleID, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package iam
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// roleValidationMock resolves the group "ops", answers role lookups with
+// roleStatus and records every request
+func roleValidationMock(roleStatus int, calls *[]string) *MockClient {
+	return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		*calls = append(*calls, req.Method+" "+req.Path)
+		switch {
+		case req.Method == "GET" && strings.Contains(req.Path, "/roles/"):
+			return &client.Response{StatusCode: roleStatus, Body: []byte(`{"id":"r","name":"r","permissions":[]}`)}, nil
+		case req.Method == "GET":
+			return &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1","name":"ops"}]`)}, nil
+		}
+		return &client.Response{StatusCode: 201, Body: []byte(`{}`)}, nil
+	}}
+}
+
+func countPosts(calls []string) int {
+	posts := 0
+	for _, call := range calls {
+		if strings.HasPrefix(call, "POST ") {
+			posts++
+		}
+	}
+	return posts
+}
+
+func TestService_CreateRoleBinding_RoleValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		role       string
+		roleStatus int
+		wantErr    string
+		wantLookup []string
+	}{
+		{
+			name:       "missing basic role",
+			role:       "roles/pos.ghost",
+			roleStatus: 404,
+			wantErr:    "role pos.ghost not found",
+			wantLookup: []string{"GET /api/v1/tenants/t/roles/pos.ghost", "GET /api/v1/roles/pos.ghost"},
+		},
+		{
+			name:       "missing custom role",
+			role:       "roles/custom.Ghost",
+			roleStatus: 404,
+			wantErr:    "custom role Ghost not found",
+			wantLookup: []string{"GET /api/v1/tenants/t/roles/Ghost"},
+		},
+		{
+			name:       "existing basic role",
+			role:       "roles/pos.cashier",
+			roleStatus: 200,
+			wantLookup: []string{"GET /api/v1/tenants/t/roles/pos.cashier"},
+		},
+		{
+			name:       "existing custom role",
+			role:       "roles/custom.Auditor",
+			roleStatus: 200,
+			wantLookup: []string{"GET /api/v1/tenants/t/roles/Auditor"},
+		},
+		{
+			name:       "lookup failure",
+			role:       "roles/pos.cashier",
+			roleStatus: 500,
+			wantErr:    "failed to look up role pos.cashier",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			svc := &Service{rawClient: roleValidationMock(tt.roleStatus, &calls), tenantID: "t"}
+
+			_, err := svc.CreateRoleBinding(context.Background(), &RoleBinding{Role: tt.role, Members: []string{"group:ops"}})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				if tt.roleStatus == 404 && !client.IsNotFoundError(err) {
+					t.Errorf("missing role error should be a not found error: %v", err)
+				}
+				if posts := countPosts(calls); posts != 0 {
+					t.Errorf("expected no POST for a failed role check, got %d", posts)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, want := range tt.wantLookup {
+				if !slices.Contains(calls, want) {
+					t.Errorf("calls = %q, want %q", calls, want)
+				}
+			}
+		})
+	}
+}
+
+func TestService_CreateRoleBinding_RoleValidationDisabled(t *testing.T) {
+	var calls []string
+	svc := &Service{rawClient: roleValidationMock(404, &calls), tenantID: "t"}
+	svc.SetRoleValidation(false)
+
+	if _, err := svc.CreateRoleBinding(context.Background(), &RoleBinding{Role: "roles/pos.ghost", Members: []string{"group:ops"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, call := range calls {
+		if strings.Contains(call, "/roles/pos.ghost") {
+			t.Errorf("role lookup made with validation disabled: %s", call)
+		}
+	}
+	if posts := countPosts(calls); posts != 1 {
+		t.Errorf("expected the binding to be posted, got %d POSTs", posts)
+	}
+}
@@ -1,38 +1,99 @@
 package iam
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
 )
 
-// Service provides IAM API operations
-// RawClient is an interface for API clients with Do method
+// RawClient is the interface the Service uses for requests that need full control
+// over the path, such as the V2 group role endpoints. It is implemented by
+// *client.Client; the path is sent as-is without the IAM endpoint prefix.
 type RawClient interface {
 	Do(ctx context.Context, req *client.Request) (*client.Response, error)
 }
 
+// Compile-time checks that the real clients satisfy the interfaces the Service
+// depends on, so signature drift breaks the build instead of only the mocks.
+var (
+	_ RawClient     = (*client.Client)(nil)
+	_ clientService = (*client.ServiceClient)(nil)
+)
+
+// Service provides IAM API operations
 type Service struct {
-	client    clientService
-	rawClient RawClient // For direct API calls that need custom paths (like V2 API)
-	tenantID  string
+	client      clientService
+	rawClient   RawClient // For direct API calls that need custom paths (like V2 API)
+	writeClient RawClient // For mutating calls with a separate write credential, nil uses rawClient
+	tenantID    string
+	basePath    string                    // API prefix for V1 paths, client.DefaultBasePath when empty
+	v2BasePath  string                    // API prefix for V2 paths, DefaultV2BasePath when empty, see SetV2BasePath
+	cache       atomic.Pointer[readCache] // Optional per-run read cache, nil when disabled
+
+	validateMembers bool   // Resolve role binding members before create, see SetMemberValidation
+	idDelimiter     string // Composite role binding ID delimiter, see SetRoleBindingIDDelimiter
+
+	skipGroupRollback bool            // Keep groups whose role assignments all failed, see SetGroupRollback
+	idNormalization   IDNormalization // Cleanup applied to IDs before path construction, see SetIDNormalization
+	auditSink         AuditSink       // Receives an event per successful mutation, nil for no-op, see SetAuditSink
+
+	disableDeleteFallback bool // Return 403 role binding deletes as errors instead of POSTing empty bindings, see SetDeleteFallback
+	strictGet             bool // Report missing role bindings as not found instead of synthesizing them, see SetStrictGet
+
+	disableMemberNormalization bool // Send role binding members as given, see SetMemberNormalization
+
+	defaultBindings []string // Bindings for role bindings that set none, see SetDefaultBindings
+
+	disableRoleValidation bool // Skip the role lookup before CreateRoleBinding, see SetRoleValidation
+
+	operationTimeouts map[string]time.Duration // Per-operation deadlines, see SetOperationTimeouts
 }
 
-// NewService creates a new IAM service client
+// NewService creates a new IAM service client with default settings, see
+// NewServiceWithOptions
 func NewService(apiClient *client.Client, tenantID string) *Service {
-	return &Service{
+	svc := &Service{
 		client:    apiClient.IAMClient(),
 		rawClient: apiClient,
 		tenantID:  tenantID,
+		basePath:  apiClient.BasePath(),
+	}
+	if writeClient := apiClient.WriteClient(); writeClient != apiClient {
+		svc.writeClient = writeClient
+	}
+	return svc
+}
+
+// writer returns the client for mutating calls, which may hold a separate write credential
+func (s *Service) writer() RawClient {
+	if s.writeClient != nil {
+		return s.writeClient
 	}
+	return s.rawClient
+}
+
+// apiPath formats a V1 resource path and prefixes it with the configured base path
+func (s *Service) apiPath(format string, args ...interface{}) string {
+	basePath := s.basePath
+	if basePath == "" {
+		basePath = client.DefaultBasePath
+	}
+	return basePath + "/" + fmt.Sprintf(format, args...)
 }
 
 // clientService defines the minimal interface we use from client.ServiceClient
-// allowing tests to inject a mock implementation.
+// allowing tests to inject a mock implementation. Paths passed to it are
+// relative to the configured IAM endpoint.
 type clientService interface {
 	Get(ctx context.Context, path string, query map[string]string) (*client.Response, error)
 	Post(ctx context.Context, path string, body interface{}) (*client.Response, error)
@@ -70,6 +131,7 @@ type CustomRole struct {
 // Permission represents a permission in a custom role
 type Permission struct {
 	ID         string                 `json:"id"`
+	Alias      string                 `json:"alias,omitempty"`
 	Attributes map[string]interface{} `json:"attributes,omitempty"`
 }
 
@@ -80,6 +142,9 @@ type RoleBinding struct {
 	Role      string   `json:"role"`
 	Members   []string `json:"members"`
 	Condition string   `json:"condition,omitempty"`
+	// Bindings are the resources the role applies to; empty uses the
+	// provider default bindings, see SetDefaultBindings
+	Bindings  []string `json:"bindings,omitempty"`
 	CreatedAt string   `json:"created_at,omitempty"`
 	UpdatedAt string   `json:"updated_at,omitempty"`
 }
@@ -116,52 +181,84 @@ type ListGroupsResponse struct {
 	Total    int     `json:"total"`
 }
 
-// ListGroups retrieves a list of IAM groups
+// ListGroups retrieves one page of IAM groups, see EachGroup and ListAllGroups
+// to follow every page
 func (s *Service) ListGroups(ctx context.Context, req *ListGroupsRequest) (*ListGroupsResponse, error) {
-	query := make(map[string]string)
-	if req.Filter != "" {
-		query["filter"] = req.Filter
-	}
-	if req.PageSize > 0 {
-		query["page_size"] = fmt.Sprintf("%d", req.PageSize)
-	}
-	if req.Page > 0 {
-		query["page"] = fmt.Sprintf("%d", req.Page)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationListGroups)
+	defer cancel()
+
+	query := listQuery(
+		"filter", req.Filter,
+		"page_size", pageParam(req.PageSize),
+		"page", pageParam(req.Page),
+	)
+	path, err := s.tenantPath("groups")
+	if err != nil {
+		return nil, err
 	}
+	return list(ctx, s.rawGet, "groups", path, query, decodeGroupsPage)
+}
 
-	path := fmt.Sprintf("/api/v1/tenants/%s/groups", s.tenantID)
+// AmbiguousGroupError reports a group name shared by more than one group
+type AmbiguousGroupError struct {
+	Name string
+	IDs  []string
+}
 
-	apiReq := &client.Request{
-		Method: "GET",
-		Path:   path,
-		Query:  query,
-	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+func (e *AmbiguousGroupError) Error() string {
+	return fmt.Sprintf("group name %q is ambiguous: matches groups %s", e.Name, strings.Join(e.IDs, ", "))
+}
+
+// GetGroupByName retrieves the IAM group with the given name using a server-side
+// filter. It returns a 404 *client.Error when no group has that name and an
+// *AmbiguousGroupError when several groups have it.
+func (s *Service) GetGroupByName(ctx context.Context, name string) (*Group, error) {
+	filter := fmt.Sprintf(`name eq "%s"`, strings.ReplaceAll(name, `"`, `\"`))
+	groups, err := s.ListAllGroups(ctx, &ListGroupsRequest{Filter: filter})
 	if err != nil {
-		return nil, fmt.Errorf("failed to list groups: %w", err)
+		return nil, fmt.Errorf("failed to get group by name %q: %w", name, err)
 	}
 
-	if err := client.CheckResponse(resp); err != nil {
-		return nil, err
-	}
-	if resp == nil {
-		return nil, fmt.Errorf("nil response from API")
-	}
-	var groups []Group
-	if err := json.Unmarshal(resp.Body, &groups); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	// Match exactly in case the server applies the filter loosely
+	var matches []Group
+	for _, g := range groups {
+		if g.Name == name {
+			matches = append(matches, g)
+		}
 	}
-	// Wrap the groups array in the expected response structure
-	result := &ListGroupsResponse{
-		Groups: groups,
-		Total:  len(groups),
+
+	switch len(matches) {
+	case 0:
+		return nil, &client.Error{
+			StatusCode: 404,
+			Message:    fmt.Sprintf("group %q not found", name),
+		}
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, g := range matches {
+			ids[i] = g.ID
+		}
+		return nil, &AmbiguousGroupError{Name: name, IDs: ids}
 	}
-	return result, nil
 }
 
 // GetGroup retrieves a specific IAM group by ID
 func (s *Service) GetGroup(ctx context.Context, id string) (*Group, error) {
-	path := fmt.Sprintf("/api/v1/tenants/%s/groups/%s", s.tenantID, id)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationGetGroup)
+	defer cancel()
+
+	id = s.normalizeID(ctx, "group", id)
+	fill := s.cache.Load().startFill()
+	if group, ok := s.cachedGroup(id); ok {
+		return group, nil
+	}
+
+	path, err := s.tenantPath("groups", id)
+	if err != nil {
+		return nil, err
+	}
 
 	req := &client.Request{
 		Method: "GET",
@@ -182,12 +279,25 @@ func (s *Service) GetGroup(ctx context.Context, id string) (*Group, error) {
 	if err := json.Unmarshal(resp.Body, &group); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	fill.set(cacheKindGroup, id, group)
 	return &group, nil
 }
 
 // CreateGroup creates a new IAM group
 func (s *Service) CreateGroup(ctx context.Context, group *Group) (*Group, error) {
-	path := fmt.Sprintf("/api/v1/tenants/%s/groups", s.tenantID)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationCreateGroup)
+	defer cancel()
+
+	if dryRun(ctx, AuditActionCreate, AuditEntityGroup, group.Name) {
+		result := *group
+		result.ID = DryRunIDPrefix + group.Name
+		return &result, nil
+	}
+
+	path, err := s.tenantPath("groups")
+	if err != nil {
+		return nil, err
+	}
 
 	// Create a simplified request body without the ID field
 	requestBody := map[string]interface{}{
@@ -202,12 +312,17 @@ func (s *Service) CreateGroup(ctx context.Context, group *Group) (*Group, error)
 		requestBody["members"] = group.Members
 	}
 
+	key, err := s.idempotencyKey(AuditEntityGroup, requestBody)
+	if err != nil {
+		return nil, err
+	}
 	apiReq := &client.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   requestBody,
+		Method:  "POST",
+		Path:    path,
+		Body:    requestBody,
+		Headers: map[string]string{IdempotencyKeyHeader: key},
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	resp, err := s.writer().Do(ctx, apiReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create group: %w", err)
 	}
@@ -216,17 +331,75 @@ func (s *Service) CreateGroup(ctx context.Context, group *Group) (*Group, error)
 		return nil, err
 	}
 
+	// Handle 204 No Content or a body without the group, fetching the
+	// server-assigned ID, members and timestamps separately
 	var result Group
-	if err := json.Unmarshal(resp.Body, &result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+	if resp.StatusCode != 204 && len(bytes.TrimSpace(resp.Body)) > 0 {
+		if err := json.Unmarshal(resp.Body, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	if result.ID == "" {
+		created, err := s.fetchCreatedGroup(ctx, group.Name, resp)
+		if err != nil {
+			return nil, fmt.Errorf("group %q was created but could not be read back: %w", group.Name, err)
+		}
+		result = *created
 	}
 
+	s.recordAudit(ctx, AuditEntityGroup, result.ID, AuditActionCreate, resp, nil, &result)
 	return &result, nil
 }
 
+// fetchCreatedGroup reads back a group whose create response carried no
+// body, using the ID from the Location header when present and the group
+// name otherwise
+func (s *Service) fetchCreatedGroup(ctx context.Context, name string, resp *client.Response) (*Group, error) {
+	if location := resp.Headers.Get("Location"); location != "" {
+		location = strings.TrimSuffix(location, "/")
+		if id := location[strings.LastIndex(location, "/")+1:]; id != "" {
+			return s.GetGroup(ctx, id)
+		}
+	}
+	return s.GetGroupByName(ctx, name)
+}
+
+// EnsureGroup creates a group, or returns the existing group with the same name
+// when the API reports a conflict. The boolean result is true when the group was created.
+func (s *Service) EnsureGroup(ctx context.Context, group *Group) (*Group, bool, error) {
+	created, err := s.CreateGroup(ctx, group)
+	if err == nil {
+		return created, true, nil
+	}
+	if !client.IsConflictError(err) {
+		return nil, false, err
+	}
+
+	existing, lookupErr := s.GetGroupByName(ctx, group.Name)
+	if lookupErr != nil {
+		if client.IsNotFoundError(lookupErr) {
+			return nil, false, fmt.Errorf("group %q reported as conflicting but was not found: %w", group.Name, err)
+		}
+		return nil, false, fmt.Errorf("failed to look up existing group %q: %w", group.Name, lookupErr)
+	}
+	return existing, false, nil
+}
+
 // UpdateGroup updates an existing IAM group
 func (s *Service) UpdateGroup(ctx context.Context, id string, group *Group) (*Group, error) {
-	path := fmt.Sprintf("/api/v1/tenants/%s/groups/%s", s.tenantID, id)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationUpdateGroup)
+	defer cancel()
+
+	id = s.normalizeID(ctx, "group", id)
+	if dryRun(ctx, AuditActionUpdate, AuditEntityGroup, id) {
+		result := *group
+		result.ID = DryRunIDPrefix + id
+		return &result, nil
+	}
+	path, err := s.tenantPath("groups", id)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create a simplified request body without the ID field (same as CreateGroup)
 	requestBody := map[string]interface{}{
@@ -246,7 +419,9 @@ func (s *Service) UpdateGroup(ctx context.Context, id string, group *Group) (*Gr
 		Path:   path,
 		Body:   requestBody,
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	before := s.auditBefore(func() (interface{}, error) { return s.GetGroup(ctx, id) })
+	resp, err := s.writer().Do(ctx, apiReq)
+	s.invalidateGroup(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update group %s: %w", id, err)
 	}
@@ -258,7 +433,12 @@ func (s *Service) UpdateGroup(ctx context.Context, id string, group *Group) (*Gr
 	// Handle 204 No Content response (common for successful updates)
 	if resp.StatusCode == 204 || len(resp.Body) == 0 {
 		// For 204 responses, fetch the updated group data separately
-		return s.GetGroup(ctx, id)
+		updated, err := s.GetGroup(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		s.recordAudit(ctx, AuditEntityGroup, id, AuditActionUpdate, resp, before, updated)
+		return updated, nil
 	}
 
 	var result Group
@@ -266,18 +446,31 @@ func (s *Service) UpdateGroup(ctx context.Context, id string, group *Group) (*Gr
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	s.recordAudit(ctx, AuditEntityGroup, id, AuditActionUpdate, resp, before, &result)
 	return &result, nil
 }
 
 // DeleteGroup deletes an IAM group
 func (s *Service) DeleteGroup(ctx context.Context, id string) error {
-	path := fmt.Sprintf("/api/v1/tenants/%s/groups/%s", s.tenantID, id)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationDeleteGroup)
+	defer cancel()
+
+	id = s.normalizeID(ctx, "group", id)
+	if dryRun(ctx, AuditActionDelete, AuditEntityGroup, id) {
+		return nil
+	}
+	path, err := s.tenantPath("groups", id)
+	if err != nil {
+		return err
+	}
 
 	apiReq := &client.Request{
 		Method: "DELETE",
 		Path:   path,
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	before := s.auditBefore(func() (interface{}, error) { return s.GetGroup(ctx, id) })
+	resp, err := s.writer().Do(ctx, apiReq)
+	s.invalidateGroup(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete group %s: %w", id, err)
 	}
@@ -285,57 +478,37 @@ func (s *Service) DeleteGroup(ctx context.Context, id string) error {
 	if err := client.CheckResponse(resp); err != nil {
 		return err
 	}
+	s.recordAudit(ctx, AuditEntityGroup, id, AuditActionDelete, resp, before, nil)
 
 	return nil
 }
 
 // ListRoles retrieves a list of IAM roles (both basic and custom)
 func (s *Service) ListRoles(ctx context.Context, filter string) ([]Role, error) {
-	query := make(map[string]string)
-	if filter != "" {
-		query["filter"] = filter
-	}
-
-	path := fmt.Sprintf("/api/v1/tenants/%s/roles", s.tenantID)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationListRoles)
+	defer cancel()
 
-	apiReq := &client.Request{
-		Method: "GET",
-		Path:   path,
-		Query:  query,
-	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	path, err := s.tenantPath("roles")
 	if err != nil {
-		return nil, fmt.Errorf("failed to list roles: %w", err)
-	}
-
-	if err := client.CheckResponse(resp); err != nil {
 		return nil, err
 	}
-
-	var result struct {
-		Roles []Role `json:"roles"`
-	}
-	if err := json.Unmarshal(resp.Body, &result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	return result.Roles, nil
+	return list(ctx, s.rawGet, "roles", path, listQuery("filter", filter), envelope[Role]("roles"))
 }
 
-// GetRole retrieves a specific IAM role by name
+// GetRole retrieves a specific IAM role by name, trying the tenant-scoped
+// path before the global one
 func (s *Service) GetRole(ctx context.Context, name string) (*Role, error) {
-	path := fmt.Sprintf("/api/v1/roles/%s", name)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationGetRole)
+	defer cancel()
 
-	apiReq := &client.Request{
-		Method: "GET",
-		Path:   path,
-	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get role %s: %w", name, err)
+	name = s.normalizeID(ctx, "role", name)
+	fill := s.cache.Load().startFill()
+	if role, ok := s.cachedRole(name); ok {
+		return role, nil
 	}
 
-	if err := client.CheckResponse(resp); err != nil {
+	resp, err := s.getRoleResponse(ctx, name)
+	if err != nil {
 		return nil, err
 	}
 
@@ -343,13 +516,57 @@ func (s *Service) GetRole(ctx context.Context, name string) (*Role, error) {
 	if err := json.Unmarshal(resp.Body, &role); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	fill.set(cacheKindRole, name, role)
 
 	return &role, nil
 }
 
+// getRoleResponse fetches a basic role from the tenant-scoped path, where some
+// tenants keep their basic roles, falling back to the global path on a 404
+func (s *Service) getRoleResponse(ctx context.Context, name string) (*client.Response, error) {
+	tenantRolePath, err := s.tenantPath("roles", name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range []string{tenantRolePath, s.apiPath("roles/%s", url.PathEscape(name))} {
+		resp, err := s.rawClient.Do(ctx, &client.Request{
+			Method: "GET",
+			Path:   path,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get role %s: %w", name, err)
+		}
+		if resp.StatusCode == 404 {
+			continue
+		}
+		if err := client.CheckResponse(resp); err != nil {
+			return nil, err
+		}
+		return resp, nil
+	}
+
+	return nil, &client.Error{
+		StatusCode: 404,
+		Message:    fmt.Sprintf("role %s not found", name),
+	}
+}
+
 // CreateCustomRole creates a new IAM custom role
 func (s *Service) CreateCustomRole(ctx context.Context, role *CustomRole) (*CustomRole, error) {
-	path := fmt.Sprintf("/api/v1/tenants/%s/roles", s.tenantID)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationCreateCustomRole)
+	defer cancel()
+
+	if dryRun(ctx, AuditActionCreate, AuditEntityCustomRole, role.ID) {
+		result := *role
+		result.ID = DryRunIDPrefix + role.ID
+		return &result, nil
+	}
+
+	path, err := s.tenantPath("roles")
+	if err != nil {
+		return nil, err
+	}
 
 	// Create a request body that matches the API specification
 	requestBody := map[string]interface{}{
@@ -361,13 +578,25 @@ func (s *Service) CreateCustomRole(ctx context.Context, role *CustomRole) (*Cust
 	if role.Name != "" {
 		requestBody["name"] = role.Name
 	}
+	if role.Title != "" {
+		requestBody["title"] = role.Title
+	}
+	if role.Description != "" {
+		requestBody["description"] = role.Description
+	}
 
+	key, err := s.idempotencyKey(AuditEntityCustomRole, requestBody)
+	if err != nil {
+		return nil, err
+	}
 	apiReq := &client.Request{
-		Method: "POST",
-		Path:   path,
-		Body:   requestBody,
+		Method:  "POST",
+		Path:    path,
+		Body:    requestBody,
+		Headers: map[string]string{IdempotencyKeyHeader: key},
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	resp, err := s.writer().Do(ctx, apiReq)
+	s.invalidateCustomRole(ctx, role.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create custom role: %w", err)
 	}
@@ -376,17 +605,40 @@ func (s *Service) CreateCustomRole(ctx context.Context, role *CustomRole) (*Cust
 		return nil, err
 	}
 
+	// Handle 204 No Content from servers that do not return the created role
+	if resp.StatusCode == 204 || len(bytes.TrimSpace(resp.Body)) == 0 {
+		created, err := s.GetCustomRole(ctx, role.ID)
+		if err != nil {
+			return nil, fmt.Errorf("custom role %s was created but could not be read back: %w", role.ID, err)
+		}
+		s.recordAudit(ctx, AuditEntityCustomRole, role.ID, AuditActionCreate, resp, nil, created)
+		return created, nil
+	}
+
 	var result CustomRole
 	if err := json.Unmarshal(resp.Body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	s.recordAudit(ctx, AuditEntityCustomRole, role.ID, AuditActionCreate, resp, nil, &result)
 	return &result, nil
 }
 
 // GetCustomRole retrieves a specific IAM custom role by name
 func (s *Service) GetCustomRole(ctx context.Context, name string) (*CustomRole, error) {
-	path := fmt.Sprintf("/api/v1/tenants/%s/roles/%s", s.tenantID, name)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationGetCustomRole)
+	defer cancel()
+
+	name = s.normalizeID(ctx, "custom role", name)
+	fill := s.cache.Load().startFill()
+	if role, ok := s.cachedCustomRole(name); ok {
+		return role, nil
+	}
+
+	path, err := s.tenantPath("roles", name)
+	if err != nil {
+		return nil, err
+	}
 
 	apiReq := &client.Request{
 		Method: "GET",
@@ -405,13 +657,26 @@ func (s *Service) GetCustomRole(ctx context.Context, name string) (*CustomRole,
 	if err := json.Unmarshal(resp.Body, &role); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	fill.set(cacheKindCustomRole, name, role)
 
 	return &role, nil
 }
 
 // UpdateCustomRole updates an existing IAM custom role
 func (s *Service) UpdateCustomRole(ctx context.Context, name string, role *CustomRole) (*CustomRole, error) {
-	path := fmt.Sprintf("/api/v1/tenants/%s/roles/%s", s.tenantID, name)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationUpdateCustomRole)
+	defer cancel()
+
+	name = s.normalizeID(ctx, "custom role", name)
+	if dryRun(ctx, AuditActionUpdate, AuditEntityCustomRole, name) {
+		result := *role
+		result.ID = DryRunIDPrefix + name
+		return &result, nil
+	}
+	path, err := s.tenantPath("roles", name)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create a request body that matches the API specification
 	requestBody := map[string]interface{}{
@@ -422,13 +687,21 @@ func (s *Service) UpdateCustomRole(ctx context.Context, name string, role *Custo
 	if role.Name != "" {
 		requestBody["name"] = role.Name
 	}
+	if role.Title != "" {
+		requestBody["title"] = role.Title
+	}
+	if role.Description != "" {
+		requestBody["description"] = role.Description
+	}
 
 	apiReq := &client.Request{
 		Method: "PUT",
 		Path:   path,
 		Body:   requestBody,
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	before := s.auditBefore(func() (interface{}, error) { return s.GetCustomRole(ctx, name) })
+	resp, err := s.writer().Do(ctx, apiReq)
+	s.invalidateCustomRole(ctx, name)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update custom role %s: %w", name, err)
 	}
@@ -440,7 +713,12 @@ func (s *Service) UpdateCustomRole(ctx context.Context, name string, role *Custo
 	// Handle 204 No Content response (common for successful updates)
 	if resp.StatusCode == 204 || len(resp.Body) == 0 {
 		// For 204 responses, fetch the updated role data separately
-		return s.GetCustomRole(ctx, name)
+		updated, err := s.GetCustomRole(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		s.recordAudit(ctx, AuditEntityCustomRole, name, AuditActionUpdate, resp, before, updated)
+		return updated, nil
 	}
 
 	var result CustomRole
@@ -448,75 +726,111 @@ func (s *Service) UpdateCustomRole(ctx context.Context, name string, role *Custo
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	s.recordAudit(ctx, AuditEntityCustomRole, name, AuditActionUpdate, resp, before, &result)
 	return &result, nil
 }
 
-// DeleteCustomRole deletes an IAM custom role
-func (s *Service) DeleteCustomRole(ctx context.Context, name string) error {
-	path := fmt.Sprintf("/api/v1/tenants/%s/roles/%s", s.tenantID, name)
+// DeleteCustomRole deletes an IAM custom role. With opts.Cascade the role is
+// first removed from every group bound to it, see DeleteOptions. Without it,
+// a delete the API refuses because groups still use the role returns a 409
+// *client.Error naming those groups.
+func (s *Service) DeleteCustomRole(ctx context.Context, name string, opts DeleteOptions) error {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationDeleteCustomRole)
+	defer cancel()
+
+	name = s.normalizeID(ctx, "custom role", name)
+	if opts.Cascade {
+		if err := s.removeCustomRoleBindings(ctx, name); err != nil {
+			return err
+		}
+	}
+	if dryRun(ctx, AuditActionDelete, AuditEntityCustomRole, name) {
+		return nil
+	}
+	path, err := s.tenantPath("roles", name)
+	if err != nil {
+		return err
+	}
 
 	apiReq := &client.Request{
 		Method: "DELETE",
 		Path:   path,
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	before := s.auditBefore(func() (interface{}, error) { return s.GetCustomRole(ctx, name) })
+	resp, err := s.writer().Do(ctx, apiReq)
+	s.invalidateCustomRole(ctx, name)
 	if err != nil {
 		return fmt.Errorf("failed to delete custom role %s: %w", name, err)
 	}
 
 	if err := client.CheckResponse(resp); err != nil {
+		if client.IsConflictError(err) {
+			return s.customRoleInUseError(ctx, name, err.(*client.Error))
+		}
 		return err
 	}
+	s.recordAudit(ctx, AuditEntityCustomRole, name, AuditActionDelete, resp, before, nil)
 
 	return nil
 }
 
-// ListRoleBindings retrieves a list of IAM role bindings
-func (s *Service) ListRoleBindings(ctx context.Context, filter string) ([]RoleBinding, error) {
-	query := make(map[string]string)
-	if filter != "" {
-		query["filter"] = filter
-	}
-
-	resp, err := s.client.Get(ctx, "bindings", query)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list role bindings: %w", err)
-	}
+// ListRoleBindingsRequest represents a request to list role bindings
+type ListRoleBindingsRequest struct {
+	GroupID string `json:"group_id,omitempty"`
+	RoleID  string `json:"role_id,omitempty"`
+	Filter  string `json:"filter,omitempty"`
+}
 
-	if err := client.CheckResponse(resp); err != nil {
-		return nil, err
-	}
+// ListRoleBindings retrieves a list of IAM role bindings matching a free-form filter
+func (s *Service) ListRoleBindings(ctx context.Context, filter string) ([]RoleBinding, error) {
+	return s.ListRoleBindingsWithOptions(ctx, &ListRoleBindingsRequest{Filter: filter})
+}
 
-	var result struct {
-		Bindings []RoleBinding `json:"bindings"`
-	}
-	if err := json.Unmarshal(resp.Body, &result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
+// ListRoleBindingsWithOptions retrieves a list of IAM role bindings filtered server-side
+// by group, role and/or a free-form filter
+func (s *Service) ListRoleBindingsWithOptions(ctx context.Context, req *ListRoleBindingsRequest) ([]RoleBinding, error) {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationListRoleBindings)
+	defer cancel()
+
+	if req == nil {
+		req = &ListRoleBindingsRequest{}
+	}
+	query := listQuery(
+		"group_id", req.GroupID,
+		"role_id", req.RoleID,
+		"filter", req.Filter,
+	)
+	return list(ctx, s.client.Get, "role bindings", "bindings", query, envelope[RoleBinding]("bindings"))
+}
 
-	return result.Bindings, nil
+// SetStrictGet controls how GetRoleBinding treats a group that exists but
+// does not list the requested role. By default a binding is synthesized to
+// work around the V2 API not always returning freshly created bindings; in
+// strict mode a 404 *client.Error is returned instead, so a deleted binding
+// is removed from state on Read.
+func (s *Service) SetStrictGet(enabled bool) {
+	s.strictGet = enabled
 }
 
 // GetRoleBinding retrieves a specific IAM role binding by name
 // Since role bindings are stored as group role assignments, we parse the binding ID
 // (format: "groupId-roleId") to make direct API calls instead of searching all groups
 func (s *Service) GetRoleBinding(ctx context.Context, name string) (*RoleBinding, error) {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationGetRoleBinding)
+	defer cancel()
+
+	name = s.normalizeID(ctx, "role binding", name)
 
 	// Parse the binding ID to extract groupId and roleId
-	// Expected format: "groupId-roleId" (e.g., "EYNaCiYX6WFmoPxXCGMf-custom.TerraformTestShayne")
-	parts := strings.Split(name, "-")
-	if len(parts) < 2 {
-		return nil, &client.Error{
-			StatusCode: 400,
-			Message:    fmt.Sprintf("invalid role binding ID format: %s", name),
-		}
+	// Expected format: "groupId/roleId" (e.g., "EYNaCiYX6WFmoPxXCGMf/custom.TerraformTestShayne"),
+	// with the legacy "groupId-roleId" form still accepted
+	groupID, roleID, err := s.ParseRoleBindingID(name)
+	if err != nil {
+		return nil, err
 	}
 
-	groupID := parts[0]
-	roleID := strings.Join(parts[1:], "-") // Handle role IDs that might contain hyphens
-
-	// First get the group to get its name
-	group, err := s.GetGroup(ctx, groupID)
+	// First get the group to get its name, once per WithOperationCache operation
+	group, err := s.operationGroup(ctx, groupID)
 	if err != nil {
 		if client.IsNotFoundError(err) {
 			return nil, &client.Error{
@@ -528,7 +842,10 @@ func (s *Service) GetRoleBinding(ctx context.Context, name string) (*RoleBinding
 	}
 
 	// Get roles for this specific group using V2 API
-	path := fmt.Sprintf("/api/v2/tenants/%s/groups/%s/roles", s.tenantID, groupID)
+	path, err := s.tenantV2Path("groups", groupID, "roles")
+	if err != nil {
+		return nil, err
+	}
 
 	// Use rawClient to make direct V2 API call
 	req := &client.Request{
@@ -559,48 +876,23 @@ func (s *Service) GetRoleBinding(ctx context.Context, name string) (*RoleBinding
 	}
 
 	// Look for the specific role assignment
-	// Since we're calling /groups/{groupId}/roles, every role returned is bound to this group
+	// Since we're calling /groups/{groupId}/roles, every role returned is bound to this group.
+	// Custom roles are compared by plain ID whichever prefix the API or binding ID carries.
+	wantID, wantCustom := normalizeRoleID(roleID)
 	for _, roleBinding := range roleBindings {
-		// Now check if this role matches what we're looking for
-		currentRoleID := roleBinding.RoleID
-		roleMatches := false
-
+		roleMatches := roleBinding.RoleID == roleID
 		if roleBinding.IsCustom {
-			// For custom roles, try multiple formats:
-			// 1. Direct match: "custom.TerraformTestShayne" == "custom.TerraformTestShayne"
-			// 2. API might return just "TerraformTestShayne" but we expect "custom.TerraformTestShayne"
-			// 3. API might return "custom.TerraformTestShayne" but we expect "TerraformTestShayne"
-			// 4. API returns full path "custom-roles/custom.TerraformTestShayne"
-			if currentRoleID == roleID ||
-				currentRoleID == strings.TrimPrefix(roleID, "custom.") ||
-				fmt.Sprintf("custom.%s", currentRoleID) == roleID ||
-				(strings.HasPrefix(currentRoleID, "custom-roles/custom.") &&
-					strings.TrimPrefix(currentRoleID, "custom-roles/custom.") == strings.TrimPrefix(roleID, "custom.")) {
-				roleMatches = true
-			}
-		} else {
-			// For system roles, direct match should work
-			if currentRoleID == roleID {
-				roleMatches = true
-			}
+			roleMatches = plainRoleID(roleBinding) == wantID
 		}
 
 		if roleMatches {
 			// Found the role assignment, reconstruct the binding
 			// Use the original roleID from our parsed binding ID to maintain consistency
-			rolePrefix := "roles/"
-			actualRoleID := roleID
-			if roleBinding.IsCustom {
-				rolePrefix = "roles/custom."
-				// For custom roles, strip any existing "custom." prefix to avoid duplication
-				actualRoleID = strings.TrimPrefix(roleID, "custom.")
-			}
-
 			binding := &RoleBinding{
 				ID:        name,
 				Name:      "", // Don't set name here - let the resource preserve the configured name
-				Role:      fmt.Sprintf("%s%s", rolePrefix, actualRoleID),
-				Members:   []string{fmt.Sprintf("group:%s", group.Name)},
+				Role:      denormalizeRoleID(wantID, roleBinding.IsCustom || wantCustom, roleIDFormatTerraform),
+				Members:   s.normalizeMembers(ctx, []string{fmt.Sprintf("group:%s", group.Name)}),
 				Condition: "",              // Role bindings don't have conditions in V2 API
 				CreatedAt: group.CreatedAt, // Use group creation time as fallback
 				UpdatedAt: group.UpdatedAt, // Use group update time as fallback
@@ -610,6 +902,13 @@ func (s *Service) GetRoleBinding(ctx context.Context, name string) (*RoleBinding
 	}
 
 	// Role assignment not found for this group via API
+	if s.strictGet {
+		return nil, &client.Error{
+			StatusCode: 404,
+			Message:    fmt.Sprintf("role binding %s not found", name),
+		}
+	}
+
 	// This appears to be an API implementation issue where the V2 GET endpoint
 	// doesn't return role bindings that were successfully created via POST.
 	// As a workaround, we'll assume the role binding exists if we can successfully
@@ -619,14 +918,14 @@ func (s *Service) GetRoleBinding(ctx context.Context, name string) (*RoleBinding
 	// If we got this far, the group exists and the roleID is valid format
 	// Construct a binding based on the ID format with proper role format
 	// The configuration expects "roles/custom.{roleId}" format for custom roles
-	role := "roles/" + roleID
+	role := denormalizeRoleID(wantID, wantCustom, roleIDFormatTerraform)
 
 	// Return a constructed binding - this is a workaround for the API inconsistency
 	binding := &RoleBinding{
 		ID:        name,
 		Name:      "", // Don't set name here - let the resource preserve the configured name
 		Role:      role,
-		Members:   []string{fmt.Sprintf("group:%s", group.Name)},
+		Members:   s.normalizeMembers(ctx, []string{fmt.Sprintf("group:%s", group.Name)}),
 		Condition: "", // Role bindings don't have conditions in V2 API
 		CreatedAt: "", // Empty timestamps will be filled by Update method
 		UpdatedAt: "", // Empty timestamps will be filled by Update method
@@ -637,9 +936,24 @@ func (s *Service) GetRoleBinding(ctx context.Context, name string) (*RoleBinding
 
 // CreateRoleBinding creates a new IAM role binding using V2 group role endpoints
 func (s *Service) CreateRoleBinding(ctx context.Context, binding *RoleBinding) (*RoleBinding, error) {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationCreateRoleBinding)
+	defer cancel()
+
 	fmt.Printf("=== DEBUG CreateRoleBinding START ===\n")
 	fmt.Printf("Input binding: %+v\n", binding)
 
+	normalized := *binding
+	normalized.Members = s.normalizeMembers(ctx, binding.Members)
+	binding = &normalized
+
+	if dryRun(ctx, AuditActionCreate, AuditEntityRoleBinding, binding.Role) {
+		return s.dryRunRoleBinding(binding), nil
+	}
+
+	if err := s.ValidateMembers(ctx, binding.Members); err != nil {
+		return nil, err
+	}
+
 	// Extract group ID from members array (expected format: "group:groupName")
 	var groupID string
 	var groupName string
@@ -648,24 +962,22 @@ func (s *Service) CreateRoleBinding(ctx context.Context, binding *RoleBinding) (
 			groupName = strings.TrimPrefix(member, "group:")
 			fmt.Printf("DEBUG: Looking for group with name: '%s'\n", groupName)
 			// Find the group by name to get its ID
-			groupsResp, err := s.ListGroups(ctx, &ListGroupsRequest{})
+			group, err := s.GetGroupByName(ctx, groupName)
 			if err != nil {
-				fmt.Printf("ERROR: ListGroups failed: %v\n", err)
-				return nil, fmt.Errorf("failed to list groups to find group '%s': %w", groupName, err)
-			}
-			fmt.Printf("DEBUG: ListGroups returned %d groups\n", len(groupsResp.Groups))
-			for i, group := range groupsResp.Groups {
-				fmt.Printf("DEBUG: Group[%d]: ID='%s', Name='%s'\n", i, group.ID, group.Name)
-				if group.Name == groupName {
-					groupID = group.ID
-					fmt.Printf("DEBUG: Found matching group with ID: '%s'\n", groupID)
-					break
+				if client.IsNotFoundError(err) {
+					return nil, fmt.Errorf("group '%s' not found: %w", groupName, err)
 				}
+				var ambiguous *AmbiguousGroupError
+				if errors.As(err, &ambiguous) {
+					return nil, fmt.Errorf("%w; use group_id with one of these ids to choose the group to bind", err)
+				}
+				return nil, fmt.Errorf("failed to find group '%s': %w", groupName, err)
 			}
-			if groupID == "" {
-				fmt.Printf("ERROR: Group '%s' not found in %d returned groups\n", groupName, len(groupsResp.Groups))
-				return nil, fmt.Errorf("group '%s' not found", groupName)
-			}
+			groupID = group.ID
+			tflog.Debug(ctx, "Resolved role binding group by name", map[string]interface{}{
+				"group_name": groupName,
+				"group_id":   groupID,
+			})
 			break
 		}
 	}
@@ -676,34 +988,24 @@ func (s *Service) CreateRoleBinding(ctx context.Context, binding *RoleBinding) (
 
 	fmt.Printf("Found groupName: '%s', groupID: '%s'\n", groupName, groupID)
 
-	// Parse role to extract roleId and determine if it's custom
-	roleId := binding.Role
-	isCustom := false
-
-	// Handle "roles/custom.roleId" format from main.tf
-	if strings.HasPrefix(roleId, "roles/custom.") {
-		roleId = strings.TrimPrefix(roleId, "roles/custom.")
-		// For custom roles, the API expects just the role name, not the full path
-		isCustom = true
-	} else if strings.HasPrefix(roleId, "roles/") {
-		roleId = strings.TrimPrefix(roleId, "roles/")
-		isCustom = false
-	}
+	// Parse role to extract roleId and determine if it's custom, accepting
+	// "roles/custom.roleId" as in main.tf as well as the bare forms
+	roleId, isCustom := normalizeRoleID(binding.Role)
 
 	fmt.Printf("Parsed roleId: '%s', isCustom: %t\n", roleId, isCustom)
 
+	if err := s.requireRole(ctx, roleId, isCustom); err != nil {
+		return nil, err
+	}
+
 	// Based on manual testing, the V2 API expects the full role ID including "custom." prefix
 	// Manual curl shows 404 when using just "TerraformTest" but processes when using "custom.TerraformTest"
 
-	// For the V2 API, we need the full role ID
-	apiRoleId := roleId
-	if isCustom {
-		apiRoleId = "custom." + roleId // V2 API expects full role ID like "custom.TerraformTest"
-	}
+	// For the V2 API, we need the full role ID like "custom.TerraformTest"
+	apiRoleId := denormalizeRoleID(roleId, isCustom, roleIDFormatAPI)
 
 	// Based on NodeJS code: bindings: ["bu:${data.Store_ID}"]
-	// Let's try some common business unit patterns
-	bindings := []string{"bu:001"} // Try a common store ID format
+	bindings := s.resolveBindings(binding.Bindings, legacyRoleBindingBindings)
 
 	payload := map[string]interface{}{
 		"roleId":   apiRoleId, // Use full role ID for V2 API
@@ -713,8 +1015,11 @@ func (s *Service) CreateRoleBinding(ctx context.Context, binding *RoleBinding) (
 
 	fmt.Printf("API payload: %+v\n", payload)
 
-	// Use V2 group role endpoint: POST /api/v2/tenants/{tenantId}/groups/{groupId}/roles
-	path := fmt.Sprintf("/api/v2/tenants/%s/groups/%s/roles", s.tenantID, groupID)
+	// Use V2 group role endpoint: POST {V2 base path}/tenants/{tenantId}/groups/{groupId}/roles
+	path, err := s.tenantV2Path("groups", groupID, "roles")
+	if err != nil {
+		return nil, err
+	}
 
 	fmt.Printf("API endpoint: %s\n", path)
 
@@ -728,7 +1033,9 @@ func (s *Service) CreateRoleBinding(ctx context.Context, binding *RoleBinding) (
 		},
 	}
 	fmt.Printf("[DEBUG CreateRoleBinding] Using rawClient for V2 API: path='%s'\n", path)
-	resp, err := s.rawClient.Do(ctx, req)
+	unlock := s.lockGroup(groupID)
+	resp, err := s.writer().Do(ctx, req)
+	unlock()
 	if err != nil {
 		fmt.Printf("ERROR: API call failed: %v\n", err)
 		return nil, fmt.Errorf("failed to create role binding for group %s: %w", groupID, err)
@@ -745,25 +1052,15 @@ func (s *Service) CreateRoleBinding(ctx context.Context, binding *RoleBinding) (
 	// The V2 API may return the role assignment, but we construct our response
 	// to match the expected RoleBinding format
 	// Create composite ID - include custom prefix if it's a custom role to match GetRoleBinding expectations
-	// For the ID, we want to use the original role name, not the full API path
-	originalRoleId := binding.Role
-	if strings.HasPrefix(originalRoleId, "roles/custom.") {
-		originalRoleId = strings.TrimPrefix(originalRoleId, "roles/custom.")
-	} else if strings.HasPrefix(originalRoleId, "roles/") {
-		originalRoleId = strings.TrimPrefix(originalRoleId, "roles/")
-	}
-
-	compositeRoleId := originalRoleId
-	if isCustom {
-		compositeRoleId = "custom." + originalRoleId
-	}
+	// The composite ID uses the same "custom.roleId" form as the API role ID
 	result := &RoleBinding{
-		ID:      fmt.Sprintf("%s-%s", groupID, compositeRoleId), // Create composite ID
+		ID:      s.FormatRoleBindingID(groupID, apiRoleId), // Create composite ID
 		Name:    binding.Name,
 		Role:    binding.Role,
 		Members: binding.Members,
 		// Only set Condition if it's not empty to maintain consistency with Terraform
 		Condition: binding.Condition,
+		Bindings:  bindings,
 	}
 
 	fmt.Printf("Returning result: %+v\n", result)
@@ -776,6 +1073,9 @@ func (s *Service) CreateRoleBinding(ctx context.Context, binding *RoleBinding) (
 // Since role bindings are actually group role assignments in V2 API,
 // we handle updates by validating the current state and returning it
 func (s *Service) UpdateRoleBinding(ctx context.Context, name string, binding *RoleBinding) (*RoleBinding, error) {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationUpdateRoleBinding)
+	defer cancel()
+
 	fmt.Printf("=== DEBUG UpdateRoleBinding START: name=%s, binding=%+v ===\n", name, binding)
 
 	// For role bindings (group role assignments), we don't actually update them
@@ -818,47 +1118,81 @@ func (s *Service) UpdateRoleBinding(ctx context.Context, name string, binding *R
 	return updatedBinding, nil
 }
 
+// SetDeleteFallback controls whether a role binding delete that is refused
+// with 403 is retried by POSTing the role with empty bindings. The fallback
+// is enabled by default; when disabled the 403 is returned as a *client.Error,
+// so a genuine permission problem is not masked.
+func (s *Service) SetDeleteFallback(enabled bool) {
+	s.disableDeleteFallback = !enabled
+}
+
 // DeleteRoleBinding deletes an IAM role binding using V2 group role endpoints
 func (s *Service) DeleteRoleBinding(ctx context.Context, name string) error {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationDeleteRoleBinding)
+	defer cancel()
+
+	name = s.normalizeID(ctx, "role binding", name)
 
 	// Parse the binding ID to extract groupId and roleId
-	// Expected format: "groupId-roleId" (e.g., "EYNaCiYX6WFmoPxXCGMf-custom.TerraformTest")
-	parts := strings.Split(name, "-")
-	if len(parts) < 2 {
-		return &client.Error{
-			StatusCode: 400,
-			Message:    fmt.Sprintf("invalid role binding ID format: %s", name),
-		}
+	// Expected format: "groupId/roleId" (e.g., "EYNaCiYX6WFmoPxXCGMf/custom.TerraformTest"),
+	// with the legacy "groupId-roleId" form still accepted
+	groupID, roleID, err := s.ParseRoleBindingID(name)
+	if err != nil {
+		return err
 	}
 
-	groupID := parts[0]
-	roleID := strings.Join(parts[1:], "-") // Handle role IDs that might contain hyphens
-
 	// Determine if it's a custom role and extract the role name
-	roleId := roleID
-	isCustom := false
+	roleId, isCustom := normalizeRoleID(roleID)
+
+	return s.removeRoleFromGroup(ctx, name, groupID, roleId, isCustom)
+}
+
+// RemoveRoleFromGroup removes a role from a group using the V2 API.
+// It is the counterpart of AddRoleToGroup and takes the role ID without the "custom." prefix.
+func (s *Service) RemoveRoleFromGroup(ctx context.Context, groupID, roleID string, isCustom bool) error {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationRemoveRoleFromGroup)
+	defer cancel()
+
+	groupID = s.normalizeID(ctx, "group", groupID)
+	roleID = s.normalizeID(ctx, "role", roleID)
+	name := s.FormatRoleBindingID(groupID, denormalizeRoleID(roleID, isCustom, roleIDFormatAPI))
+	return s.removeRoleFromGroup(ctx, name, groupID, roleID, isCustom)
+}
 
-	if strings.HasPrefix(roleId, "custom.") {
-		roleId = strings.TrimPrefix(roleId, "custom.")
-		isCustom = true
+// removeRoleFromGroup performs the V2 role removal for a group, serialized per group.
+// name is the composite role binding ID used in error messages.
+func (s *Service) removeRoleFromGroup(ctx context.Context, name, groupID, roleId string, isCustom bool) error {
+	if dryRun(ctx, AuditActionDelete, AuditEntityRoleBinding, name) {
+		return nil
 	}
 
+	unlock := s.lockGroup(groupID)
+	defer unlock()
+
 	// Try different V2 endpoints to find the correct delete pattern
-	// Option 1: DELETE /api/v2/tenants/{tenantId}/groups/{groupId}/roles/{roleId}
-	path := fmt.Sprintf("/api/v2/tenants/%s/groups/%s/roles/%s", s.tenantID, groupID, roleId)
+	// Option 1: DELETE {V2 base path}/tenants/{tenantId}/groups/{groupId}/roles/{roleId}
+	path, err := s.tenantV2Path("groups", groupID, "roles", roleId)
+	if err != nil {
+		return err
+	}
 
 	// Use rawClient to make direct V2 API call
 	req := &client.Request{
 		Method: "DELETE",
 		Path:   path,
 	}
-	resp, err := s.rawClient.Do(ctx, req)
+	resp, err := s.writer().Do(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to delete role binding %s: %w", name, err)
 	}
 
+	// A 404 means the binding is already gone, which is the desired state
+	if resp.StatusCode == 404 {
+		return nil
+	}
+
 	// If we get 403, try alternative approach: POST with empty bindings to remove the role
-	if resp.StatusCode == 403 {
+	if resp.StatusCode == 403 && !s.disableDeleteFallback {
 
 		// Try to "update" the role binding by setting bindings to empty
 		// This might be how the API expects role removal
@@ -868,7 +1202,10 @@ func (s *Service) DeleteRoleBinding(ctx context.Context, name string) error {
 			"bindings": []string{}, // Empty bindings might remove the role
 		}
 
-		postPath := fmt.Sprintf("/api/v2/tenants/%s/groups/%s/roles", s.tenantID, groupID)
+		postPath, err := s.tenantV2Path("groups", groupID, "roles")
+		if err != nil {
+			return err
+		}
 
 		// Use rawClient to make direct V2 API call
 		postReq := &client.Request{
@@ -879,7 +1216,7 @@ func (s *Service) DeleteRoleBinding(ctx context.Context, name string) error {
 				"Content-Type": "application/json",
 			},
 		}
-		postResp, postErr := s.rawClient.Do(ctx, postReq)
+		postResp, postErr := s.writer().Do(ctx, postReq)
 		if postErr != nil {
 			return fmt.Errorf("failed to remove role binding %s via POST method: %w", name, postErr)
 		}
@@ -887,6 +1224,7 @@ func (s *Service) DeleteRoleBinding(ctx context.Context, name string) error {
 		if err := client.CheckResponse(postResp); err != nil {
 			return fmt.Errorf("alternative delete method failed for role binding %s: %w", name, err)
 		}
+		s.recordAudit(ctx, AuditEntityRoleBinding, name, AuditActionDelete, postResp, map[string]interface{}{"roleId": roleId, "isCustom": isCustom}, nil)
 
 		return nil
 	}
@@ -894,6 +1232,7 @@ func (s *Service) DeleteRoleBinding(ctx context.Context, name string) error {
 	if err := client.CheckResponse(resp); err != nil {
 		return err
 	}
+	s.recordAudit(ctx, AuditEntityRoleBinding, name, AuditActionDelete, resp, map[string]interface{}{"roleId": roleId, "isCustom": isCustom}, nil)
 
 	return nil
 }
@@ -913,14 +1252,26 @@ type SetResourceDto struct {
 
 // SetResource creates or updates an IAM resource using PUT endpoint
 func (s *Service) SetResource(ctx context.Context, id string, dto *SetResourceDto) (*Resource, error) {
-	path := fmt.Sprintf("/api/v1/tenants/%s/resources/%s", s.tenantID, id)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationSetResource)
+	defer cancel()
+
+	id = s.normalizeID(ctx, "resource", id)
+	if dryRun(ctx, "set", AuditEntityResource, id) {
+		return &Resource{ID: DryRunIDPrefix + id, Name: dto.Name, Props: dto.Props}, nil
+	}
+	path, err := s.tenantPath("resources", id)
+	if err != nil {
+		return nil, err
+	}
 
 	apiReq := &client.Request{
 		Method: "PUT",
 		Path:   path,
 		Body:   dto,
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	before := s.auditBefore(func() (interface{}, error) { return s.GetResource(ctx, id) })
+	resp, err := s.writer().Do(ctx, apiReq)
+	s.cache.Load().invalidate(cacheKindResource, id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to set resource %s: %w", id, err)
 	}
@@ -930,16 +1281,33 @@ func (s *Service) SetResource(ctx context.Context, id string, dto *SetResourceDt
 	}
 
 	var result Resource
-	if err := json.Unmarshal(resp.Body, &result); err != nil {
+	if err := unmarshalResources(resp.Body, &result); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	action := AuditActionUpdate
+	if before == nil {
+		action = AuditActionCreate
+	}
+	s.recordAudit(ctx, AuditEntityResource, id, action, resp, before, &result)
 	return &result, nil
 }
 
 // GetResource retrieves a specific IAM resource by ID
 func (s *Service) GetResource(ctx context.Context, id string) (*Resource, error) {
-	path := fmt.Sprintf("/api/v1/tenants/%s/resources/%s", s.tenantID, id)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationGetResource)
+	defer cancel()
+
+	id = s.normalizeID(ctx, "resource", id)
+	fill := s.cache.Load().startFill()
+	if resource, ok := s.cachedResource(id); ok {
+		return resource, nil
+	}
+
+	path, err := s.tenantPath("resources", id)
+	if err != nil {
+		return nil, err
+	}
 
 	apiReq := &client.Request{
 		Method: "GET",
@@ -955,22 +1323,35 @@ func (s *Service) GetResource(ctx context.Context, id string) (*Resource, error)
 	}
 
 	var resource Resource
-	if err := json.Unmarshal(resp.Body, &resource); err != nil {
+	if err := unmarshalResources(resp.Body, &resource); err != nil {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
+	fill.set(cacheKindResource, id, resource)
 
 	return &resource, nil
 }
 
 // DeleteResource deletes an IAM resource
 func (s *Service) DeleteResource(ctx context.Context, id string) error {
-	path := fmt.Sprintf("/api/v1/tenants/%s/resources/%s", s.tenantID, id)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationDeleteResource)
+	defer cancel()
+
+	id = s.normalizeID(ctx, "resource", id)
+	if dryRun(ctx, AuditActionDelete, AuditEntityResource, id) {
+		return nil
+	}
+	path, err := s.tenantPath("resources", id)
+	if err != nil {
+		return err
+	}
 
 	apiReq := &client.Request{
 		Method: "DELETE",
 		Path:   path,
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
+	before := s.auditBefore(func() (interface{}, error) { return s.GetResource(ctx, id) })
+	resp, err := s.writer().Do(ctx, apiReq)
+	s.cache.Load().invalidate(cacheKindResource, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete resource %s: %w", id, err)
 	}
@@ -978,6 +1359,7 @@ func (s *Service) DeleteResource(ctx context.Context, id string) error {
 	if err := client.CheckResponse(resp); err != nil {
 		return err
 	}
+	s.recordAudit(ctx, AuditEntityResource, id, AuditActionDelete, resp, before, nil)
 
 	return nil
 }
@@ -986,70 +1368,64 @@ func (s *Service) DeleteResource(ctx context.Context, id string) error {
 type GetResourcesRequest struct {
 	Permission string `json:"permission,omitempty"`
 	Type       string `json:"type,omitempty"`
+	PageSize   int    `json:"page_size,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	// FetchAll follows next_page from Page and returns the resources of
+	// every page in one response
+	FetchAll bool `json:"-"`
 }
 
-// GetResourcesResponse represents a response from listing resources
+// GetResourcesResponse represents a response from listing resources. Total is
+// the count reported by the API, or the number of resources returned when it
+// reports none.
 type GetResourcesResponse struct {
 	Resources []Resource `json:"resources"`
+	NextPage  int        `json:"next_page,omitempty"`
 	Total     int        `json:"total"`
 }
 
-// GetResources retrieves a list of IAM resources
+// GetResources retrieves one page of IAM resources, or every page when
+// req.FetchAll is set
 func (s *Service) GetResources(ctx context.Context, req *GetResourcesRequest) (*GetResourcesResponse, error) {
-	query := make(map[string]string)
-	if req != nil {
-		if req.Permission != "" {
-			query["permission"] = req.Permission
-		}
-		if req.Type != "" {
-			query["type"] = req.Type
-		}
-	}
-
-	path := fmt.Sprintf("/api/v1/tenants/%s/resources", s.tenantID)
+	ctx, cancel := s.withOperationTimeout(ctx, OperationListResources)
+	defer cancel()
 
-	apiReq := &client.Request{
-		Method: "GET",
-		Path:   path,
-		Query:  query,
+	if req == nil {
+		req = &GetResourcesRequest{}
 	}
-	resp, err := s.rawClient.Do(ctx, apiReq)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list resources: %w", err)
+	if req.FetchAll {
+		return s.getAllResources(ctx, req)
 	}
-
-	if err := client.CheckResponse(resp); err != nil {
+	page, err := s.getResourcesPage(ctx, req, req.Page)
+	if err != nil {
 		return nil, err
 	}
-
-	var resources []Resource
-	if err := json.Unmarshal(resp.Body, &resources); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
-	}
-
-	// Wrap the resources array in the expected response structure
-	result := &GetResourcesResponse{
-		Resources: resources,
-		Total:     len(resources),
-	}
-
-	return result, nil
+	return page.response(), nil
 }
 
 // AddRoleToGroup adds a role to a group using the V2 API
 func (s *Service) AddRoleToGroup(ctx context.Context, groupID, roleID string, isCustom bool, bindings []string) error {
+	ctx, cancel := s.withOperationTimeout(ctx, OperationAddRoleToGroup)
+	defer cancel()
+
+	groupID = s.normalizeID(ctx, "group", groupID)
+	roleID = s.normalizeID(ctx, "role", roleID)
+	if dryRun(ctx, AuditActionCreate, AuditEntityRoleBinding, s.FormatRoleBindingID(groupID, RoleAssignment{RoleID: roleID, IsCustom: isCustom}.compositeRoleID())) {
+		return nil
+	}
+
 	// For custom roles, verify the role exists before attempting to add it to the group
 	if isCustom {
-		_, err := s.GetCustomRole(ctx, roleID)
-		if err != nil {
-			return fmt.Errorf("custom role %s not found or inaccessible: %w", roleID, err)
+		if err := s.requireCustomRole(ctx, roleID); err != nil {
+			return err
 		}
 	}
 
-	// Use provided bindings or default to all resources
-	if len(bindings) == 0 {
-		bindings = []string{"*"} // Default binding to all resources
-	}
+	unlock := s.lockGroup(groupID)
+	defer unlock()
+
+	// Use provided bindings, then the provider defaults, then all resources
+	bindings = s.resolveBindings(bindings, allResourcesBindings)
 
 	// Create the payload for the V2 API with required bindings array
 	// For custom roles, use the role ID as-is (no custom. prefix needed for V2 API)
@@ -1059,8 +1435,11 @@ func (s *Service) AddRoleToGroup(ctx context.Context, groupID, roleID string, is
 		"bindings": bindings,
 	}
 
-	// Use the V2 API endpoint: POST /api/v2/tenants/{tenantId}/groups/{groupId}/roles
-	path := fmt.Sprintf("/api/v2/tenants/%s/groups/%s/roles", s.tenantID, groupID)
+	// Use the V2 API endpoint: POST {V2 base path}/tenants/{tenantId}/groups/{groupId}/roles
+	path, err := s.tenantV2Path("groups", groupID, "roles")
+	if err != nil {
+		return err
+	}
 
 	// Use raw client to bypass the /api/v1 prefix that ServiceClient adds
 	// This allows us to make direct calls to the V2 API endpoint
@@ -1073,7 +1452,7 @@ func (s *Service) AddRoleToGroup(ctx context.Context, groupID, roleID string, is
 		},
 	}
 
-	resp, err := s.rawClient.Do(ctx, req)
+	resp, err := s.writer().Do(ctx, req)
 	if err != nil {
 		return fmt.Errorf("failed to add role %s to group %s: %w", roleID, groupID, err)
 	}
@@ -1082,6 +1461,7 @@ func (s *Service) AddRoleToGroup(ctx context.Context, groupID, roleID string, is
 	if err := client.CheckResponse(resp); err != nil {
 		return fmt.Errorf("API error adding role %s to group %s: %w", roleID, groupID, err)
 	}
+	s.recordAudit(ctx, AuditEntityRoleBinding, s.FormatRoleBindingID(groupID, RoleAssignment{RoleID: roleID, IsCustom: isCustom}.compositeRoleID()), AuditActionCreate, resp, nil, payload)
 
 	return nil
 }
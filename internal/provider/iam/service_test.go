@@ -4,9 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
 )
 
@@ -149,6 +152,75 @@ func TestService_CreateUpdateDeleteGroup(t *testing.T) {
 	}
 }
 
+func TestService_CreateGroup_ServerAssignedFields(t *testing.T) {
+	full := Group{
+		ID:        "g1",
+		Name:      "Group1",
+		Members:   []string{"user@example.com"},
+		CreatedAt: "2024-01-01T00:00:00Z",
+		UpdatedAt: "2024-01-01T00:00:00Z",
+	}
+	fullBody, _ := json.Marshal(full)
+	listBody, _ := json.Marshal([]Group{full})
+
+	cases := []struct {
+		name       string
+		createResp *client.Response
+		wantGET    string
+	}{
+		{
+			name:       "201 with body",
+			createResp: &client.Response{StatusCode: 201, Body: fullBody},
+		},
+		{
+			name:       "204 with location",
+			createResp: &client.Response{StatusCode: 204, Headers: http.Header{"Location": []string{"/api/v1/tenants/t/groups/g1"}}},
+			wantGET:    "/api/v1/tenants/t/groups/g1",
+		},
+		{
+			name:       "204 without location",
+			createResp: &client.Response{StatusCode: 204},
+			wantGET:    "/api/v1/tenants/t/groups",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gets []string
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				if req.Method == "POST" {
+					return tc.createResp, nil
+				}
+				gets = append(gets, req.Path)
+				if strings.HasSuffix(req.Path, "/groups") {
+					return &client.Response{StatusCode: 200, Body: listBody}, nil
+				}
+				return &client.Response{StatusCode: 200, Body: fullBody}, nil
+			}}
+			svc := &Service{rawClient: mock, tenantID: "t"}
+
+			g, err := svc.CreateGroup(context.Background(), &Group{Name: "Group1", Members: []string{"USER@example.com"}})
+			if err != nil {
+				t.Fatalf("CreateGroup failed: %v", err)
+			}
+			if g.ID != "g1" || g.CreatedAt != full.CreatedAt || g.UpdatedAt != full.UpdatedAt {
+				t.Errorf("server-assigned fields missing: %+v", g)
+			}
+			if len(g.Members) != 1 || g.Members[0] != "user@example.com" {
+				t.Errorf("members = %v, want the server-normalized members", g.Members)
+			}
+
+			if tc.wantGET == "" {
+				if len(gets) != 0 {
+					t.Errorf("unexpected follow-up GETs: %v", gets)
+				}
+			} else if len(gets) != 1 || gets[0] != tc.wantGET {
+				t.Errorf("follow-up GETs = %v, want [%s]", gets, tc.wantGET)
+			}
+		})
+	}
+}
+
 func TestService_ListRoles_GetRole_CreateCustom_GetCustomRole(t *testing.T) {
 	// ListRoles
 	rolesResp := struct {
@@ -254,7 +326,7 @@ func TestService_UpdateCustomRole_DeleteCustomRole(t *testing.T) {
 		return nil, errors.New("unexpected method")
 	}}
 	svc = &Service{rawClient: mockDel, tenantID: "t"}
-	if err := svc.DeleteCustomRole(context.Background(), "cr1"); err != nil {
+	if err := svc.DeleteCustomRole(context.Background(), "cr1", DeleteOptions{}); err != nil {
 		t.Fatalf("DeleteCustomRole failed: %v", err)
 	}
 
@@ -263,7 +335,7 @@ func TestService_UpdateCustomRole_DeleteCustomRole(t *testing.T) {
 		return &client.Response{StatusCode: 500}, errors.New("api error")
 	}}
 	svc = &Service{rawClient: mockDelErr, tenantID: "t"}
-	if err := svc.DeleteCustomRole(context.Background(), "cr1"); err == nil {
+	if err := svc.DeleteCustomRole(context.Background(), "cr1", DeleteOptions{}); err == nil {
 		t.Fatalf("DeleteCustomRole expected error, got nil")
 	}
 }
@@ -329,6 +401,9 @@ func TestService_RoleBindingAndResourceFlows(t *testing.T) {
 		if strings.Contains(req.Path, "/groups") && req.Method == "GET" {
 			return &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1","name":"my-group"}]`)}, nil
 		}
+		if strings.HasSuffix(req.Path, "/roles/Role1") && req.Method == "GET" {
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"Role1","name":"Role1"}`)}, nil
+		}
 		// POST to V2 create binding
 		if strings.Contains(req.Path, "/api/v2/") && strings.HasSuffix(req.Path, "/roles") && req.Method == "POST" {
 			return &client.Response{StatusCode: 201, Body: []byte(`{"ok":true}`)}, nil
@@ -499,6 +574,41 @@ func TestService_DeleteRoleBinding_403Fallback(t *testing.T) {
 	}
 }
 
+func TestService_DeleteRoleBinding_403FallbackDisabled(t *testing.T) {
+	var methods []string
+	mockRaw := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		methods = append(methods, req.Method)
+		if req.Method == "DELETE" {
+			return &client.Response{StatusCode: 403, Body: []byte(`{"message":"forbidden"}`)}, nil
+		}
+		return &client.Response{StatusCode: 200, Body: []byte(`{"ok":true}`)}, nil
+	}}
+
+	svc := &Service{rawClient: mockRaw, tenantID: "t"}
+	svc.SetDeleteFallback(false)
+	err := svc.DeleteRoleBinding(context.Background(), "g1/Role1")
+	if !client.IsForbiddenError(err) {
+		t.Fatalf("expected a forbidden error, got %v", err)
+	}
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != 403 {
+		t.Fatalf("expected a *client.Error with status 403, got %#v", err)
+	}
+	if len(methods) != 1 || methods[0] != "DELETE" {
+		t.Fatalf("expected only the DELETE call, got %v", methods)
+	}
+
+	// Re-enabling restores the POST workaround
+	methods = nil
+	svc.SetDeleteFallback(true)
+	if err := svc.DeleteRoleBinding(context.Background(), "g1/Role1"); err != nil {
+		t.Fatalf("DeleteRoleBinding with fallback expected nil, got %v", err)
+	}
+	if len(methods) != 2 || methods[1] != "POST" {
+		t.Fatalf("expected DELETE then POST, got %v", methods)
+	}
+}
+
 func TestService_AddRoleToGroup_CustomRoleNotFound(t *testing.T) {
 	// When isCustom=true, AddRoleToGroup calls GetCustomRole which should be simulated to fail
 	mockRaw := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
@@ -787,7 +897,7 @@ func TestService_FullHappyPath(t *testing.T) {
 	if _, err := svc.UpdateCustomRole(context.Background(), "cr1", &cr); err != nil {
 		t.Fatalf("UpdateCustomRole: %v", err)
 	}
-	if err := svc.DeleteCustomRole(context.Background(), "cr1"); err != nil {
+	if err := svc.DeleteCustomRole(context.Background(), "cr1", DeleteOptions{}); err != nil {
 		t.Fatalf("DeleteCustomRole: %v", err)
 	}
 	if _, err := svc.GetRoleBinding(context.Background(), "g1-Role1"); err != nil {
@@ -1004,6 +1114,38 @@ func TestService_GetRoleBinding_FallbackConstructsBinding(t *testing.T) {
 	}
 }
 
+func TestService_GetRoleBinding_StrictGet(t *testing.T) {
+	group := Group{ID: "g1", Name: "grp"}
+	gbody, _ := json.Marshal(group)
+
+	// V2 returns empty array for the group's roles
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if strings.Contains(req.Path, "/api/v2/") && strings.HasSuffix(req.Path, "/roles") && req.Method == "GET" {
+			return &client.Response{StatusCode: 200, Body: []byte(`[]`)}, nil
+		}
+		if strings.Contains(req.Path, "/groups/g1") && req.Method == "GET" {
+			return &client.Response{StatusCode: 200, Body: gbody}, nil
+		}
+		return &client.Response{StatusCode: 404}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+	svc.SetStrictGet(true)
+
+	b, err := svc.GetRoleBinding(context.Background(), "g1/RoleX")
+	if b != nil {
+		t.Fatalf("expected no binding in strict mode, got %+v", b)
+	}
+	if !client.IsNotFoundError(err) {
+		t.Fatalf("expected a not-found error in strict mode, got %v", err)
+	}
+
+	svc.SetStrictGet(false)
+	b, err = svc.GetRoleBinding(context.Background(), "g1/RoleX")
+	if err != nil || b == nil || b.Role != "roles/RoleX" {
+		t.Fatalf("lenient mode should synthesize the binding, got %+v, %v", b, err)
+	}
+}
+
 func TestService_CreateGroup_WithOptionalFields(t *testing.T) {
 	created := Group{ID: "g2", Name: "G2", Description: "desc", Members: []string{"m1"}}
 	cbody, _ := json.Marshal(created)
@@ -1188,6 +1330,9 @@ func TestService_CreateRoleBinding_CustomInput(t *testing.T) {
 		if strings.Contains(req.Path, "/groups") && req.Method == "GET" {
 			return &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1","name":"grp"}]`)}, nil
 		}
+		if strings.HasSuffix(req.Path, "/roles/cr1") && req.Method == "GET" {
+			return &client.Response{StatusCode: 200, Body: []byte(`{"id":"cr1","name":"cr1","permissions":[]}`)}, nil
+		}
 		if strings.Contains(req.Path, "/api/v2/") && strings.HasSuffix(req.Path, "/roles") && req.Method == "POST" {
 			return &client.Response{StatusCode: 201}, nil
 		}
@@ -1256,7 +1401,7 @@ func TestService_DeleteCustomRole_CheckResponseError(t *testing.T) {
 		return &client.Response{StatusCode: 200, Body: []byte(`{}`)}, nil
 	}}
 	svc := &Service{rawClient: mock, tenantID: "t"}
-	err := svc.DeleteCustomRole(context.Background(), "cr1")
+	err := svc.DeleteCustomRole(context.Background(), "cr1", DeleteOptions{})
 	if err == nil {
 		t.Fatalf("expected error from DeleteCustomRole, got nil")
 	}
@@ -1417,3 +1562,453 @@ func TestService_SetResource_ErrorOnDo(t *testing.T) {
 		t.Fatalf("expected error from SetResource, got: %v", err)
 	}
 }
+
+func TestService_ListRoleBindingsWithOptions_QueryParams(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *ListRoleBindingsRequest
+		want map[string]string
+	}{
+		{name: "nil request", req: nil, want: map[string]string{}},
+		{name: "empty request", req: &ListRoleBindingsRequest{}, want: map[string]string{}},
+		{name: "group only", req: &ListRoleBindingsRequest{GroupID: "g1"}, want: map[string]string{"group_id": "g1"}},
+		{name: "role only", req: &ListRoleBindingsRequest{RoleID: "r1"}, want: map[string]string{"role_id": "r1"}},
+		{name: "filter only", req: &ListRoleBindingsRequest{Filter: "f"}, want: map[string]string{"filter": "f"}},
+		{name: "group and role", req: &ListRoleBindingsRequest{GroupID: "g1", RoleID: "r1"}, want: map[string]string{"group_id": "g1", "role_id": "r1"}},
+		{name: "all", req: &ListRoleBindingsRequest{GroupID: "g1", RoleID: "r1", Filter: "f"}, want: map[string]string{"group_id": "g1", "role_id": "r1", "filter": "f"}},
+	}
+
+	for _, tc := range cases {
+		var gotPath string
+		var gotQuery map[string]string
+		mockSvc := &MockServiceClient{GetFunc: func(ctx context.Context, path string, query map[string]string) (*client.Response, error) {
+			gotPath = path
+			gotQuery = query
+			return &client.Response{StatusCode: 200, Body: []byte(`{"bindings":[{"id":"b1"}]}`)}, nil
+		}}
+		svc := &Service{rawClient: &MockClient{}, tenantID: "t", client: mockSvc}
+		res, err := svc.ListRoleBindingsWithOptions(context.Background(), tc.req)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		if len(res) != 1 || res[0].ID != "b1" {
+			t.Fatalf("%s: unexpected result: %+v", tc.name, res)
+		}
+		if gotPath != "bindings" {
+			t.Fatalf("%s: unexpected path %q", tc.name, gotPath)
+		}
+		if len(gotQuery) != len(tc.want) {
+			t.Fatalf("%s: got query %v, want %v", tc.name, gotQuery, tc.want)
+		}
+		for k, v := range tc.want {
+			if gotQuery[k] != v {
+				t.Fatalf("%s: query[%s] = %q, want %q", tc.name, k, gotQuery[k], v)
+			}
+		}
+	}
+}
+
+func TestService_ListRoleBindings_FilterWrapper(t *testing.T) {
+	var gotQuery map[string]string
+	mockSvc := &MockServiceClient{GetFunc: func(ctx context.Context, path string, query map[string]string) (*client.Response, error) {
+		gotQuery = query
+		return &client.Response{StatusCode: 200, Body: []byte(`{"bindings":[]}`)}, nil
+	}}
+	svc := &Service{rawClient: &MockClient{}, tenantID: "t", client: mockSvc}
+	if _, err := svc.ListRoleBindings(context.Background(), "role=r1"); err != nil {
+		t.Fatalf("ListRoleBindings failed: %v", err)
+	}
+	if len(gotQuery) != 1 || gotQuery["filter"] != "role=r1" {
+		t.Fatalf("unexpected query: %v", gotQuery)
+	}
+}
+
+func TestService_EnsureGroup(t *testing.T) {
+	conflict := &client.Response{StatusCode: 409, Body: []byte(`{"message":"group already exists"}`)}
+	listBody := []byte(`[{"id":"g-1","name":"ops-team"},{"id":"g-2","name":"ops"}]`)
+
+	cases := []struct {
+		name        string
+		groupName   string
+		createResp  *client.Response
+		wantID      string
+		wantCreated bool
+		wantErr     string
+		wantList    bool
+	}{
+		{name: "created", groupName: "ops", createResp: &client.Response{StatusCode: 201, Body: []byte(`{"id":"g-new","name":"ops"}`)}, wantID: "g-new", wantCreated: true},
+		{name: "conflict then found by exact name", groupName: "ops", createResp: conflict, wantID: "g-2", wantList: true},
+		{name: "conflict then not found", groupName: "op", createResp: conflict, wantErr: "not found", wantList: true},
+		{name: "other error is returned", groupName: "ops", createResp: &client.Response{StatusCode: 400, Body: []byte(`{"message":"bad"}`)}, wantErr: "bad"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			listed := false
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				switch req.Method {
+				case "POST":
+					return tc.createResp, nil
+				case "GET":
+					listed = true
+					return &client.Response{StatusCode: 200, Body: listBody}, nil
+				}
+				return nil, errors.New("unexpected request")
+			}}
+			svc := &Service{rawClient: mock, tenantID: "t"}
+
+			group, created, err := svc.EnsureGroup(context.Background(), &Group{Name: tc.groupName})
+			if listed != tc.wantList {
+				t.Errorf("listed = %v, want %v", listed, tc.wantList)
+			}
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if group.ID != tc.wantID {
+				t.Errorf("ID = %q, want %q", group.ID, tc.wantID)
+			}
+			if created != tc.wantCreated {
+				t.Errorf("created = %v, want %v", created, tc.wantCreated)
+			}
+		})
+	}
+}
+
+func TestService_DeleteRoleBinding_StatusHandling(t *testing.T) {
+	cases := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "404 already deleted", status: 404},
+		{name: "204 deleted", status: 204},
+		{name: "500 server error", status: 500, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				calls++
+				if req.Method != "DELETE" {
+					t.Errorf("unexpected %s request to %s", req.Method, req.Path)
+				}
+				return &client.Response{StatusCode: tc.status, Body: []byte(`{}`)}, nil
+			}}
+			svc := &Service{rawClient: mock, tenantID: "t"}
+
+			for _, del := range []func() error{
+				func() error { return svc.DeleteRoleBinding(context.Background(), "g1-Role1") },
+				func() error { return svc.RemoveRoleFromGroup(context.Background(), "g1", "Role1", false) },
+			} {
+				err := del()
+				if tc.wantErr && err == nil {
+					t.Errorf("expected error for status %d", tc.status)
+				}
+				if !tc.wantErr && err != nil {
+					t.Errorf("unexpected error for status %d: %v", tc.status, err)
+				}
+			}
+			if calls != 2 {
+				t.Errorf("expected 2 DELETE calls, got %d", calls)
+			}
+		})
+	}
+}
+
+func TestService_GetGroupByName(t *testing.T) {
+	cases := []struct {
+		name         string
+		groupName    string
+		listBody     string
+		wantID       string
+		wantErr      string
+		wantNotFound bool
+	}{
+		{name: "found", groupName: "ops", listBody: `[{"id":"g-2","name":"ops"}]`, wantID: "g-2"},
+		{name: "loose server match is filtered exactly", groupName: "ops", listBody: `[{"id":"g-1","name":"ops-team"},{"id":"g-2","name":"ops"}]`, wantID: "g-2"},
+		{name: "not found", groupName: "ops", listBody: `[]`, wantErr: "not found", wantNotFound: true},
+		{name: "ambiguous", groupName: "ops", listBody: `[{"id":"g-2","name":"ops"},{"id":"g-3","name":"ops"}]`, wantErr: "ambiguous"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotFilter string
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				if req.Method != "GET" || req.Path != "/api/v1/tenants/t/groups" {
+					return nil, errors.New("unexpected request")
+				}
+				gotFilter = req.Query["filter"]
+				return &client.Response{StatusCode: 200, Body: []byte(tc.listBody)}, nil
+			}}
+			svc := &Service{rawClient: mock, tenantID: "t"}
+
+			group, err := svc.GetGroupByName(context.Background(), tc.groupName)
+			if want := `name eq "` + tc.groupName + `"`; gotFilter != want {
+				t.Errorf("filter = %q, want %q", gotFilter, want)
+			}
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+				}
+				if client.IsNotFoundError(err) != tc.wantNotFound {
+					t.Errorf("IsNotFoundError = %v, want %v", client.IsNotFoundError(err), tc.wantNotFound)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if group.ID != tc.wantID {
+				t.Errorf("ID = %q, want %q", group.ID, tc.wantID)
+			}
+		})
+	}
+}
+
+func TestService_CreateRoleBinding_GroupLookup(t *testing.T) {
+	cases := []struct {
+		name     string
+		listBody string
+		wantErr  string
+		wantIDs  []string
+	}{
+		{name: "unique name", listBody: `[{"id":"g-1","name":"cashiers"}]`},
+		{name: "duplicate name", listBody: `[{"id":"g-1","name":"cashiers"},{"id":"g-2","name":"cashiers"}]`, wantErr: "use group_id", wantIDs: []string{"g-1", "g-2"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var posted string
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				switch {
+				case req.Method == "GET" && strings.Contains(req.Path, "/roles/"):
+					return &client.Response{StatusCode: 200, Body: []byte(`{"id":"pos.admin","name":"pos.admin"}`)}, nil
+				case req.Method == "GET":
+					return &client.Response{StatusCode: 200, Body: []byte(tc.listBody)}, nil
+				case req.Method == "POST":
+					posted = req.Path
+					return &client.Response{StatusCode: 201, Body: []byte(`{}`)}, nil
+				}
+				return nil, errors.New("unexpected request")
+			}}
+			svc := &Service{rawClient: mock, tenantID: "t"}
+
+			_, err := svc.CreateRoleBinding(context.Background(), &RoleBinding{Role: "roles/pos.admin", Members: []string{"group:cashiers"}})
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if posted != "/api/v2/tenants/t/groups/g-1/roles" {
+					t.Errorf("posted to %q, want the g-1 roles path", posted)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+			var ambiguous *AmbiguousGroupError
+			if !errors.As(err, &ambiguous) {
+				t.Fatalf("expected an *AmbiguousGroupError, got %T", err)
+			}
+			if strings.Join(ambiguous.IDs, ",") != strings.Join(tc.wantIDs, ",") {
+				t.Errorf("IDs = %v, want %v", ambiguous.IDs, tc.wantIDs)
+			}
+			for _, id := range tc.wantIDs {
+				if !strings.Contains(err.Error(), id) {
+					t.Errorf("error %q does not list group %s", err, id)
+				}
+			}
+			if posted != "" {
+				t.Errorf("nothing should be posted for an ambiguous group, got %q", posted)
+			}
+		})
+	}
+}
+
+func TestService_CreateRoleBinding_GroupNotFound(t *testing.T) {
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		if req.Method == "GET" {
+			return &client.Response{StatusCode: 200, Body: []byte(`[]`)}, nil
+		}
+		return nil, errors.New("unexpected request")
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	_, err := svc.CreateRoleBinding(context.Background(), &RoleBinding{Role: "roles/pos.admin", Members: []string{"group:cashiers"}})
+	if err == nil || !strings.Contains(err.Error(), "group 'cashiers' not found") {
+		t.Fatalf("expected the group not found error, got %v", err)
+	}
+	if !client.IsNotFoundError(err) {
+		t.Errorf("expected the error to wrap the not found error, got %v", err)
+	}
+}
+
+func TestService_GetRole_TenantScoped(t *testing.T) {
+	roleBody, _ := json.Marshal(Role{ID: "r1", Name: "Role1"})
+
+	tests := []struct {
+		name      string
+		found     map[string]bool
+		wantPaths []string
+		wantErr   bool
+	}{
+		{
+			name:      "tenant-scoped hit",
+			found:     map[string]bool{"/api/v1/tenants/t/roles/r1": true, "/api/v1/roles/r1": true},
+			wantPaths: []string{"/api/v1/tenants/t/roles/r1"},
+		},
+		{
+			name:      "global fallback",
+			found:     map[string]bool{"/api/v1/roles/r1": true},
+			wantPaths: []string{"/api/v1/tenants/t/roles/r1", "/api/v1/roles/r1"},
+		},
+		{
+			name:      "not found",
+			found:     map[string]bool{},
+			wantPaths: []string{"/api/v1/tenants/t/roles/r1", "/api/v1/roles/r1"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var paths []string
+			mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+				paths = append(paths, req.Path)
+				if tt.found[req.Path] {
+					return &client.Response{StatusCode: 200, Body: roleBody}, nil
+				}
+				return &client.Response{StatusCode: 404, Body: []byte(`{"message":"Not Found"}`)}, nil
+			}}
+			svc := &Service{rawClient: mock, tenantID: "t"}
+
+			role, err := svc.GetRole(context.Background(), "r1")
+			if tt.wantErr {
+				if !client.IsNotFoundError(err) {
+					t.Fatalf("expected not found error, got %v", err)
+				}
+			} else if err != nil {
+				t.Fatalf("GetRole failed: %v", err)
+			} else if role.ID != "r1" {
+				t.Fatalf("GetRole id mismatch: %s", role.ID)
+			}
+			if strings.Join(paths, ",") != strings.Join(tt.wantPaths, ",") {
+				t.Fatalf("requested paths %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestService_GetRole_ServerErrorDoesNotFallBack(t *testing.T) {
+	calls := 0
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		calls++
+		return &client.Response{StatusCode: 500, Body: []byte(`{"message":"boom"}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	if _, err := svc.GetRole(context.Background(), "r1"); !client.IsServerError(err) {
+		t.Fatalf("expected server error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 request, got %d", calls)
+	}
+}
+
+func TestService_GetRole_TenantScopedThroughClient(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path != "/api/v1/tenants/t/roles/pos.cashier" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"id":"pos.cashier","name":"Cashier"}`))
+	}))
+	defer server.Close()
+
+	cfg := client.DefaultConfig()
+	cfg.BaseURL = server.URL
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, cfg)
+	if err != nil {
+		t.Fatalf("client.New failed: %v", err)
+	}
+
+	role, err := NewService(apiClient, "t").GetRole(context.Background(), "pos.cashier")
+	if err != nil {
+		t.Fatalf("GetRole failed: %v", err)
+	}
+	if role.ID != "pos.cashier" {
+		t.Fatalf("GetRole id mismatch: %s", role.ID)
+	}
+	if want := []string{"/api/v1/tenants/t/roles/pos.cashier"}; strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Fatalf("requested paths %v, want %v", paths, want)
+	}
+}
+
+func TestService_GetRole_InvalidTenant(t *testing.T) {
+	calls := 0
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		calls++
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"r1"}`)}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: ""}
+
+	if _, err := svc.GetRole(context.Background(), "r1"); err == nil {
+		t.Fatal("expected an error for an empty tenant ID")
+	}
+	if calls != 0 {
+		t.Fatalf("expected no requests, got %d", calls)
+	}
+}
+
+func TestService_CustomRole_TitleDescription(t *testing.T) {
+	var bodies []map[string]interface{}
+	mock := &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+		body := req.Body.(map[string]interface{})
+		bodies = append(bodies, body)
+		out, _ := json.Marshal(CustomRole{ID: "cr1", Title: "Cashier", Description: "Runs the till"})
+		return &client.Response{StatusCode: 200, Body: out}, nil
+	}}
+	svc := &Service{rawClient: mock, tenantID: "t"}
+
+	role := &CustomRole{ID: "cr1", Title: "Cashier", Description: "Runs the till"}
+	created, err := svc.CreateCustomRole(context.Background(), role)
+	if err != nil {
+		t.Fatalf("CreateCustomRole: %v", err)
+	}
+	updated, err := svc.UpdateCustomRole(context.Background(), "cr1", role)
+	if err != nil {
+		t.Fatalf("UpdateCustomRole: %v", err)
+	}
+	for _, got := range []*CustomRole{created, updated} {
+		if got.Title != "Cashier" || got.Description != "Runs the till" {
+			t.Errorf("decoded title/description = %q/%q", got.Title, got.Description)
+		}
+	}
+	for i, body := range bodies {
+		if body["title"] != "Cashier" || body["description"] != "Runs the till" {
+			t.Errorf("request %d body missing title/description: %v", i, body)
+		}
+	}
+
+	// Unset fields are not sent
+	bodies = nil
+	if _, err := svc.CreateCustomRole(context.Background(), &CustomRole{ID: "cr2"}); err != nil {
+		t.Fatalf("CreateCustomRole: %v", err)
+	}
+	if _, ok := bodies[0]["title"]; ok {
+		t.Errorf("empty title should be omitted: %v", bodies[0])
+	}
+	if _, ok := bodies[0]["description"]; ok {
+		t.Errorf("empty description should be omitted: %v", bodies[0])
+	}
+}
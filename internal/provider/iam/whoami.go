@@ -0,0 +1,103 @@
+package iam
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// Principal is the identity behind the provider's access token
+type Principal struct {
+	Subject  string
+	ClientID string
+	TenantID string
+	// Scopes are the scopes granted to the token, or the configured scopes
+	// when the API does not report them, see ScopesReported
+	Scopes         []string
+	ScopesReported bool
+}
+
+// selfResponse is the IAM user/self payload. Introspection-style responses
+// (RFC 7662) carry sub and a space-separated scope instead of id and scopes.
+type selfResponse struct {
+	ID       string   `json:"id"`
+	Subject  string   `json:"sub"`
+	ClientID string   `json:"client_id"`
+	TenantID string   `json:"tenant_id"`
+	Scopes   []string `json:"scopes"`
+	Scope    string   `json:"scope"`
+}
+
+// credentialSource is implemented by clients that can report the configured
+// OAuth2 credential, such as *client.Client
+type credentialSource interface {
+	Credential() client.Credential
+}
+
+// WhoAmI returns the principal the provider authenticates as, read from the
+// IAM user endpoint. Fields the endpoint omits are filled from the
+// configured credential. A 403 is returned as a *client.Error naming the
+// missing scope.
+func (s *Service) WhoAmI(ctx context.Context) (*Principal, error) {
+	resp, err := s.rawClient.Do(ctx, &client.Request{
+		Method: "GET",
+		Path:   s.apiPath("user"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get caller identity: %w", err)
+	}
+	if err := client.CheckResponse(resp); err != nil {
+		if apiErr, ok := err.(*client.Error); ok && apiErr.IsForbidden() {
+			forbidden := *apiErr
+			forbidden.Message = "insufficient scope to read the caller identity: " + apiErr.Message
+			return nil, &forbidden
+		}
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("nil response from API")
+	}
+
+	var self selfResponse
+	if len(resp.Body) > 0 {
+		if err := json.Unmarshal(resp.Body, &self); err != nil {
+			return nil, fmt.Errorf("failed to parse caller identity: %w", err)
+		}
+	}
+
+	principal := &Principal{
+		Subject:  self.Subject,
+		ClientID: self.ClientID,
+		TenantID: self.TenantID,
+		Scopes:   self.Scopes,
+	}
+	if principal.Subject == "" {
+		principal.Subject = self.ID
+	}
+	if len(principal.Scopes) == 0 && self.Scope != "" {
+		principal.Scopes = strings.Fields(self.Scope)
+	}
+	principal.ScopesReported = len(principal.Scopes) > 0
+	if principal.TenantID == "" {
+		principal.TenantID = s.tenantID
+	}
+	if source, ok := s.rawClient.(credentialSource); ok {
+		credential := source.Credential()
+		if principal.ClientID == "" {
+			principal.ClientID = credential.ClientID
+		}
+		if !principal.ScopesReported {
+			principal.Scopes = credential.Scopes
+		}
+	}
+	if principal.Subject == "" {
+		principal.Subject = principal.ClientID
+	}
+	principal.Scopes = append([]string(nil), principal.Scopes...)
+	sort.Strings(principal.Scopes)
+	return principal, nil
+}
@@ -0,0 +1,131 @@
+package iam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// credentialMock is a MockClient that reports a configured credential
+type credentialMock struct {
+	MockClient
+	credential client.Credential
+}
+
+func (m *credentialMock) Credential() client.Credential {
+	return m.credential
+}
+
+func selfEndpointMock(t *testing.T, status int, body string) *credentialMock {
+	t.Helper()
+	return &credentialMock{
+		MockClient: MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+			if req.Method != "GET" || req.Path != "/api/v1/user" {
+				t.Errorf("unexpected request %s %s", req.Method, req.Path)
+			}
+			return &client.Response{StatusCode: status, Body: []byte(body)}, nil
+		}},
+		credential: client.Credential{ClientID: "configured-client", Scopes: []string{"iam:write", "iam:read"}},
+	}
+}
+
+func TestService_WhoAmI(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want Principal
+	}{
+		{
+			name: "self endpoint",
+			body: `{"id":"user-1","client_id":"api-client","tenant_id":"acme","scopes":["iam:read","iam:admin"]}`,
+			want: Principal{Subject: "user-1", ClientID: "api-client", TenantID: "acme", Scopes: []string{"iam:admin", "iam:read"}, ScopesReported: true},
+		},
+		{
+			name: "introspection response",
+			body: `{"active":true,"sub":"svc-1","client_id":"api-client","scope":"iam:read  pos:read"}`,
+			want: Principal{Subject: "svc-1", ClientID: "api-client", TenantID: "t", Scopes: []string{"iam:read", "pos:read"}, ScopesReported: true},
+		},
+		{
+			name: "missing fields use configured credential",
+			body: `{}`,
+			want: Principal{Subject: "configured-client", ClientID: "configured-client", TenantID: "t", Scopes: []string{"iam:read", "iam:write"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{rawClient: selfEndpointMock(t, http.StatusOK, tt.body), tenantID: "t"}
+
+			got, err := s.WhoAmI(context.Background())
+			if err != nil {
+				t.Fatalf("WhoAmI failed: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Errorf("WhoAmI() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_WhoAmI_InsufficientScope(t *testing.T) {
+	s := &Service{rawClient: selfEndpointMock(t, http.StatusForbidden, `{"message":"missing scope iam:read"}`), tenantID: "t"}
+
+	_, err := s.WhoAmI(context.Background())
+	if !client.IsForbiddenError(err) {
+		t.Fatalf("WhoAmI error = %v, want a 403 *client.Error", err)
+	}
+	if !strings.Contains(err.Error(), "insufficient scope") || !strings.Contains(err.Error(), "missing scope iam:read") {
+		t.Errorf("error %q should name the insufficient scope and keep the API message", err)
+	}
+}
+
+func TestService_WhoAmI_OtherErrors(t *testing.T) {
+	s := &Service{rawClient: selfEndpointMock(t, http.StatusUnauthorized, `{"message":"expired"}`), tenantID: "t"}
+	if _, err := s.WhoAmI(context.Background()); !client.IsUnauthorizedError(err) {
+		t.Errorf("WhoAmI error = %v, want a 401 *client.Error", err)
+	}
+
+	s = &Service{rawClient: selfEndpointMock(t, http.StatusOK, `not json`), tenantID: "t"}
+	if _, err := s.WhoAmI(context.Background()); err == nil || !strings.Contains(err.Error(), "failed to parse caller identity") {
+		t.Errorf("WhoAmI error = %v, want a parse error", err)
+	}
+}
+
+func TestService_WhoAmI_RealClient(t *testing.T) {
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/user" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`{"id":"user-1","client_id":"api-client","scopes":["iam:read"]}`))
+	}))
+	defer server.Close()
+
+	cfg := client.DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.MaxRetries = 0
+	apiClient, err := client.New(&auth.Config{TestToken: "test-token", TenantID: "t"}, cfg)
+	if err != nil {
+		t.Fatalf("client.New failed: %v", err)
+	}
+
+	got, err := NewService(apiClient, "t").WhoAmI(context.Background())
+	if err != nil {
+		t.Fatalf("WhoAmI failed: %v", err)
+	}
+	if gotAuth != "Bearer test-token" {
+		t.Errorf("Authorization = %q, want the test token", gotAuth)
+	}
+	want := Principal{Subject: "user-1", ClientID: "api-client", TenantID: "t", Scopes: []string{"iam:read"}, ScopesReported: true}
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("WhoAmI() = %+v, want %+v", *got, want)
+	}
+}
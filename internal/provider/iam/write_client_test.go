@@ -0,0 +1,56 @@
+package iam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+func TestService_WriteClient(t *testing.T) {
+	var reads, writes []string
+	record := func(calls *[]string) *MockClient {
+		return &MockClient{DoFunc: func(ctx context.Context, req *client.Request) (*client.Response, error) {
+			*calls = append(*calls, req.Method+" "+req.Path)
+			switch req.Method {
+			case "GET":
+				if req.Path == "/api/v1/tenants/t/groups" {
+					return &client.Response{StatusCode: 200, Body: []byte(`[{"id":"g1","name":"ops"}]`)}, nil
+				}
+				return &client.Response{StatusCode: 200, Body: []byte(`{"id":"g1","name":"ops"}`)}, nil
+			case "DELETE":
+				return &client.Response{StatusCode: 204}, nil
+			}
+			return &client.Response{StatusCode: 201, Body: []byte(`{"id":"g1","name":"ops"}`)}, nil
+		}}
+	}
+	svc := &Service{rawClient: record(&reads), writeClient: record(&writes), tenantID: "t"}
+	ctx := context.Background()
+
+	if _, err := svc.GetGroup(ctx, "g1"); err != nil {
+		t.Fatalf("GetGroup failed: %v", err)
+	}
+	if _, err := svc.CreateGroup(ctx, &Group{Name: "ops"}); err != nil {
+		t.Fatalf("CreateGroup failed: %v", err)
+	}
+	if _, err := svc.CreateRoleBinding(ctx, &RoleBinding{Role: "roles/pos.cashier", Members: []string{"group:ops"}}); err != nil {
+		t.Fatalf("CreateRoleBinding failed: %v", err)
+	}
+	if err := svc.DeleteGroup(ctx, "g1"); err != nil {
+		t.Fatalf("DeleteGroup failed: %v", err)
+	}
+
+	wantReads := []string{"GET /api/v1/tenants/t/groups/g1", "GET /api/v1/tenants/t/groups", "GET /api/v1/tenants/t/roles/pos.cashier"}
+	wantWrites := []string{"POST /api/v1/tenants/t/groups", "POST /api/v2/tenants/t/groups/g1/roles", "DELETE /api/v1/tenants/t/groups/g1"}
+	if len(reads) != len(wantReads) || reads[0] != wantReads[0] || reads[1] != wantReads[1] || reads[2] != wantReads[2] {
+		t.Errorf("reads = %q, want %q", reads, wantReads)
+	}
+	if len(writes) != len(wantWrites) {
+		t.Fatalf("writes = %q, want %q", writes, wantWrites)
+	}
+	for i := range wantWrites {
+		if writes[i] != wantWrites[i] {
+			t.Errorf("writes[%d] = %q, want %q", i, writes[i], wantWrites[i])
+		}
+	}
+}
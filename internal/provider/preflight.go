@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+// preflightTimeout bounds each individual preflight check
+const preflightTimeout = 10 * time.Second
+
+// Preflight checks that the auth and API endpoints resolved by Configure are
+// reachable (DNS and TLS) and that a token can be acquired with the resolved
+// auth configuration. All checks run and each failure is reported as its own
+// diagnostic, so operators can fix the environment before any resource is
+// touched. The API endpoint is checked through the configured client when
+// Configure has built one, so its TLS settings apply.
+//
+// Configure runs it before building the client when the provider preflight
+// setting is enabled; it is not run otherwise.
+func (p *HiiRetailProvider) Preflight(ctx context.Context) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if p.authConfig == nil {
+		diags.AddError("Provider Not Configured", "Preflight requires the provider to be configured first.")
+		return diags
+	}
+
+	apiClient := &http.Client{Timeout: preflightTimeout}
+	apiURL := p.authConfig.APIURL
+	if p.client != nil {
+		apiClient = p.client.HTTPClient()
+		apiURL = p.client.BaseURL()
+	}
+	if err := checkEndpointReachable(ctx, apiClient, apiURL); err != nil {
+		diags.AddError("API Endpoint Unreachable", fmt.Sprintf("Could not reach %s: %s", apiURL, err))
+	}
+
+	// A test token needs no auth endpoint
+	if p.authConfig.TestToken != "" {
+		return diags
+	}
+
+	authURL := p.authConfig.AuthURL
+	if err := checkEndpointReachable(ctx, &http.Client{Timeout: preflightTimeout}, authURL); err != nil {
+		diags.AddError("Auth Endpoint Unreachable", fmt.Sprintf("Could not reach %s: %s", authURL, err))
+		diags.AddWarning("Token Acquisition Skipped", "Token acquisition was not attempted because the auth endpoint is unreachable.")
+		return diags
+	}
+
+	if err := acquireToken(ctx, p.authConfig); err != nil {
+		diags.AddError("Token Acquisition Failed", fmt.Sprintf("Could not acquire a token from %s: %s", authURL, err))
+	}
+
+	return diags
+}
+
+// acquireToken requests a token with a copy of authConfig, so the defaults
+// the auth client fills in do not leak into the provider configuration
+func acquireToken(ctx context.Context, authConfig *auth.Config) error {
+	config := *authConfig
+	authClient, err := auth.New(&config)
+	if err != nil {
+		return err
+	}
+	defer authClient.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, preflightTimeout)
+	defer cancel()
+
+	_, err = authClient.GetToken(ctx)
+	return err
+}
+
+// checkEndpointReachable issues a GET to the endpoint and treats any HTTP
+// response as reachable; only DNS, connection and TLS failures are errors
+func checkEndpointReachable(ctx context.Context, httpClient *http.Client, endpoint string) error {
+	if endpoint == "" {
+		return errors.New("endpoint is not configured")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, preflightTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint URL: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		var dnsErr *net.DNSError
+		var certErr *tls.CertificateVerificationError
+		switch {
+		case errors.As(err, &dnsErr):
+			return fmt.Errorf("DNS lookup failed: %w", err)
+		case errors.As(err, &certErr):
+			return fmt.Errorf("TLS verification failed: %w", err)
+		default:
+			return fmt.Errorf("connection failed: %w", err)
+		}
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	return nil
+}
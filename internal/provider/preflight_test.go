@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// closedServerURL returns the URL of a server that is no longer listening
+func closedServerURL() string {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+	return server.URL
+}
+
+func diagSummaries(diags diag.Diagnostics) []string {
+	summaries := make([]string, 0, len(diags))
+	for _, d := range diags {
+		summaries = append(summaries, d.Summary())
+	}
+	return summaries
+}
+
+func newPreflightClient(t *testing.T, authConfig *auth.Config, cfg *client.Config) *client.Client {
+	t.Helper()
+	apiClient, err := client.New(authConfig, cfg)
+	if err != nil {
+		t.Fatalf("client.New() error = %v", err)
+	}
+	return apiClient
+}
+
+// newPreflightServers starts an auth server that issues a token to client
+// "test-client-123" and rejects any other client, and an API server that answers
+// every request with 401
+func newPreflightServers(t *testing.T) (authServer, apiServer *httptest.Server) {
+	t.Helper()
+	authServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		clientID, _, _ := r.BasicAuth()
+		if clientID == "" {
+			r.ParseForm()
+			clientID = r.PostForm.Get("client_id")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if clientID != "test-client-123" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		w.Write([]byte(`{"access_token":"token","token_type":"Bearer","expires_in":3600}`))
+	}))
+	apiServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(authServer.Close)
+	t.Cleanup(apiServer.Close)
+	return authServer, apiServer
+}
+
+func TestHiiRetailProvider_Preflight(t *testing.T) {
+	authServer, apiServer := newPreflightServers(t)
+
+	tests := []struct {
+		name          string
+		clientID      string
+		authURL       string
+		apiURL        string
+		wantSummaries []string
+	}{
+		{
+			name:     "reachable",
+			clientID: "test-client-123",
+			authURL:  authServer.URL + "/oauth2/token",
+			apiURL:   apiServer.URL,
+		},
+		{
+			name:          "unreachable auth",
+			clientID:      "test-client-123",
+			authURL:       closedServerURL() + "/oauth2/token",
+			apiURL:        apiServer.URL,
+			wantSummaries: []string{"Auth Endpoint Unreachable", "Token Acquisition Skipped"},
+		},
+		{
+			name:          "unreachable api",
+			clientID:      "test-client-123",
+			authURL:       authServer.URL + "/oauth2/token",
+			apiURL:        closedServerURL(),
+			wantSummaries: []string{"API Endpoint Unreachable"},
+		},
+		{
+			name:          "token rejected",
+			clientID:      "unknown-client",
+			authURL:       authServer.URL + "/oauth2/token",
+			apiURL:        apiServer.URL,
+			wantSummaries: []string{"Token Acquisition Failed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &HiiRetailProvider{authConfig: &auth.Config{
+				ClientID:         tt.clientID,
+				ClientSecret:     "test-secret-456",
+				TenantID:         "t",
+				AuthURL:          tt.authURL,
+				APIURL:           tt.apiURL,
+				MaxRetries:       1,
+				DisableDiscovery: true,
+			}}
+
+			got := diagSummaries(p.Preflight(context.Background()))
+			if len(got) != len(tt.wantSummaries) {
+				t.Fatalf("diagnostics = %v, want %v", got, tt.wantSummaries)
+			}
+			for i := range got {
+				if got[i] != tt.wantSummaries[i] {
+					t.Errorf("diagnostic %d = %q, want %q", i, got[i], tt.wantSummaries[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHiiRetailProvider_Preflight_ConfiguredClient(t *testing.T) {
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(tlsServer.Close)
+
+	tests := []struct {
+		name          string
+		config        func(cfg *client.Config)
+		wantSummaries []string
+	}{
+		{
+			name:          "untrusted certificate",
+			config:        func(cfg *client.Config) { cfg.BaseURL = tlsServer.URL },
+			wantSummaries: []string{"API Endpoint Unreachable"},
+		},
+		{
+			name: "client TLS settings apply",
+			config: func(cfg *client.Config) {
+				cfg.BaseURL = tlsServer.URL
+				cfg.InsecureSkipVerify = true
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := client.DefaultConfig()
+			tt.config(cfg)
+			authConfig := &auth.Config{TestToken: "test-token", TenantID: "t"}
+			p := &HiiRetailProvider{authConfig: authConfig, client: newPreflightClient(t, authConfig, cfg)}
+
+			got := diagSummaries(p.Preflight(context.Background()))
+			if len(got) != len(tt.wantSummaries) {
+				t.Fatalf("diagnostics = %v, want %v", got, tt.wantSummaries)
+			}
+			for i := range got {
+				if got[i] != tt.wantSummaries[i] {
+					t.Errorf("diagnostic %d = %q, want %q", i, got[i], tt.wantSummaries[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHiiRetailProvider_Preflight_NotConfigured(t *testing.T) {
+	p := &HiiRetailProvider{}
+	if diags := p.Preflight(context.Background()); !diags.HasError() {
+		t.Fatal("expected an error when the provider is not configured")
+	}
+}
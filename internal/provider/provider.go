@@ -5,26 +5,37 @@ import (
 	"fmt"
 	"net/http"
 	"os"
-	"strconv"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam/datasources"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam/resources"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/resource_iam_role_binding"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/jsonschema"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/validators"
 )
 
+// Default HiiRetail endpoints, used unless auth_url or api_url override them
+const (
+	defaultAuthURL = "https://auth.retailsvc.com/oauth2/token"
+	defaultAPIURL  = "https://iam-api.retailsvc.com"
+)
+
 // APIClient represents the configuration for making API calls
 // This is used by resources that need direct HTTP access
 type APIClient struct {
@@ -39,6 +50,9 @@ var _ provider.Provider = &HiiRetailProvider{}
 // HiiRetailProvider defines the provider implementation.
 type HiiRetailProvider struct {
 	version string
+	// authConfig and client are resolved and built by Configure, checked by Preflight
+	authConfig *auth.Config
+	client     *client.Client
 }
 
 // HiiRetailProviderModel describes the provider data model.
@@ -50,9 +64,28 @@ type HiiRetailProviderModel struct {
 	IAMEndpoint    types.String `tfsdk:"iam_endpoint"`
 	CCCEndpoint    types.String `tfsdk:"ccc_endpoint"`
 	TokenURL       types.String `tfsdk:"token_url"`
+	AuthURL        types.String `tfsdk:"auth_url"`
+	APIURL         types.String `tfsdk:"api_url"`
 	Scopes         types.Set    `tfsdk:"scopes"`
 	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
 	MaxRetries     types.Int64  `tfsdk:"max_retries"`
+
+	WriteClientID     types.String `tfsdk:"write_client_id"`
+	WriteClientSecret types.String `tfsdk:"write_client_secret"`
+	WriteScopes       types.Set    `tfsdk:"write_scopes"`
+
+	DefaultBindings        types.List   `tfsdk:"default_bindings"`
+	RoleBindingIDDelimiter types.String `tfsdk:"role_binding_id_delimiter"`
+	TrimIDs                types.Bool   `tfsdk:"trim_ids"`
+	ValidateMembers        types.Bool   `tfsdk:"validate_members"`
+	IDCase                 types.String `tfsdk:"id_case"`
+	PropsSchemas           types.Map    `tfsdk:"props_schemas"`
+	OperationTimeouts      types.Map    `tfsdk:"operation_timeouts"`
+	Preflight              types.Bool   `tfsdk:"preflight"`
+	ReadCache              types.Bool   `tfsdk:"read_cache"`
+
+	TraceParent types.String `tfsdk:"traceparent"`
+	TraceState  types.String `tfsdk:"tracestate"`
 }
 
 func (p *HiiRetailProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -85,11 +118,13 @@ func (p *HiiRetailProvider) Schema(ctx context.Context, req provider.SchemaReque
 				Optional:            true,
 			},
 			"base_url": schema.StringAttribute{
-				Description:         "Base URL for the HiiRetail APIs. Can also be set via HIIRETAIL_BASE_URL environment variable.",
-				MarkdownDescription: "Base URL for the HiiRetail APIs. Can also be set via `HIIRETAIL_BASE_URL` environment variable.",
+				Description:         "Deprecated alias of api_url. Can also be set via HIIRETAIL_BASE_URL environment variable.",
+				MarkdownDescription: "Deprecated alias of `api_url`. Can also be set via `HIIRETAIL_BASE_URL` environment variable.",
 				Optional:            true,
+				DeprecationMessage:  "The 'base_url' attribute is deprecated and will be removed in the next major release. Use 'api_url' instead.",
 				Validators: []validator.String{
 					validators.StringIsURL(),
+					stringvalidator.ConflictsWith(path.MatchRoot("api_url")),
 				},
 			},
 			"iam_endpoint": schema.StringAttribute{
@@ -103,13 +138,29 @@ func (p *HiiRetailProvider) Schema(ctx context.Context, req provider.SchemaReque
 				Optional:            true,
 			},
 			"token_url": schema.StringAttribute{
-				Description:         "OAuth2 token URL. Can also be set via HIIRETAIL_TOKEN_URL environment variable.",
-				MarkdownDescription: "OAuth2 token URL. Can also be set via `HIIRETAIL_TOKEN_URL` environment variable.",
+				Description:         "Deprecated alias of auth_url. Can also be set via HIIRETAIL_TOKEN_URL environment variable.",
+				MarkdownDescription: "Deprecated alias of `auth_url`. Can also be set via `HIIRETAIL_TOKEN_URL` environment variable.",
 				Optional:            true,
+				DeprecationMessage:  "The 'token_url' attribute is deprecated and will be removed in the next major release. Use 'auth_url' instead.",
 				Validators: []validator.String{
 					validators.StringIsURL(),
+					stringvalidator.ConflictsWith(path.MatchRoot("auth_url")),
 				},
 			},
+			"auth_url": schema.StringAttribute{
+				Description: "OAuth2 token endpoint URL. Must use https. Can also be set via HIIRETAIL_AUTH_URL environment variable. " +
+					"Defaults to 'https://auth.retailsvc.com/oauth2/token'.",
+				MarkdownDescription: "OAuth2 token endpoint URL. Must use `https`. Can also be set via `HIIRETAIL_AUTH_URL` environment variable. " +
+					"Defaults to `https://auth.retailsvc.com/oauth2/token`.",
+				Optional: true,
+			},
+			"api_url": schema.StringAttribute{
+				Description: "IAM API base URL. Must use https. Can also be set via HIIRETAIL_API_URL environment variable. " +
+					"Defaults to 'https://iam-api.retailsvc.com'.",
+				MarkdownDescription: "IAM API base URL. Must use `https`. Can also be set via `HIIRETAIL_API_URL` environment variable. " +
+					"Defaults to `https://iam-api.retailsvc.com`.",
+				Optional: true,
+			},
 			"scopes": schema.SetAttribute{
 				ElementType:         types.StringType,
 				Description:         "OAuth2 scopes to request. Defaults to ['iam:read', 'iam:write'].",
@@ -126,6 +177,111 @@ func (p *HiiRetailProvider) Schema(ctx context.Context, req provider.SchemaReque
 				MarkdownDescription: "Maximum number of retries for failed requests. Defaults to 3.",
 				Optional:            true,
 			},
+			"write_client_id": schema.StringAttribute{
+				Description: "OAuth2 client ID used only for create, update and delete requests. When set, client_id can be limited to read scopes. " +
+					"Can also be set via HIIRETAIL_WRITE_CLIENT_ID environment variable.",
+				MarkdownDescription: "OAuth2 client ID used only for create, update and delete requests. When set, `client_id` can be limited to read scopes. " +
+					"Can also be set via `HIIRETAIL_WRITE_CLIENT_ID` environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"write_client_secret": schema.StringAttribute{
+				Description:         "OAuth2 client secret for write_client_id. Can also be set via HIIRETAIL_WRITE_CLIENT_SECRET environment variable.",
+				MarkdownDescription: "OAuth2 client secret for `write_client_id`. Can also be set via `HIIRETAIL_WRITE_CLIENT_SECRET` environment variable.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"write_scopes": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Description:         "OAuth2 scopes to request for the write credential. Defaults to scopes.",
+				MarkdownDescription: "OAuth2 scopes to request for the write credential. Defaults to `scopes`.",
+				Optional:            true,
+			},
+			"default_bindings": schema.ListAttribute{
+				ElementType:         types.StringType,
+				Description:         "Bindings, such as 'bu:001', applied by role bindings that do not set their own bindings.",
+				MarkdownDescription: "Bindings, such as `bu:001`, applied by role bindings that do not set their own `bindings`.",
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"preflight": schema.BoolAttribute{
+				Description: "Check that the auth and API endpoints are reachable and that a token can be acquired before any resource is planned, " +
+					"reporting each failure separately. Defaults to false.",
+				MarkdownDescription: "Check that the auth and API endpoints are reachable and that a token can be acquired before any resource is planned, " +
+					"reporting each failure separately. Defaults to `false`.",
+				Optional: true,
+			},
+			"read_cache": schema.BoolAttribute{
+				Description: "Serve repeated reads of the same group, role, custom role or resource within one run from memory. " +
+					"Writes through the provider drop the entries they change; changes made outside the run are not seen for up to 30 seconds. Defaults to false.",
+				MarkdownDescription: "Serve repeated reads of the same group, role, custom role or resource within one run from memory. " +
+					"Writes through the provider drop the entries they change; changes made outside the run are not seen for up to 30 seconds. Defaults to `false`.",
+				Optional: true,
+			},
+			"role_binding_id_delimiter": schema.StringAttribute{
+				Description: "Delimiter between the tenant, group, role and hash parts of hiiretail_iam_role_binding IDs. " +
+					"Defaults to '/'. It must not occur in group or role IDs; existing hyphen-delimited IDs are upgraded to it.",
+				MarkdownDescription: "Delimiter between the tenant, group, role and hash parts of `hiiretail_iam_role_binding` IDs. " +
+					"Defaults to `/`. It must not occur in group or role IDs; existing hyphen-delimited IDs are upgraded to it.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"trim_ids": schema.BoolAttribute{
+				Description:         "Strip leading and trailing whitespace from group, role and resource IDs before they are sent. Defaults to false.",
+				MarkdownDescription: "Strip leading and trailing whitespace from group, role and resource IDs before they are sent. Defaults to `false`.",
+				Optional:            true,
+			},
+			"validate_members": schema.BoolAttribute{
+				Description: "Check that the group of a role binding exists before the role is bound, " +
+					"failing with the unresolved members instead of a partial write. Defaults to false.",
+				MarkdownDescription: "Check that the group of a role binding exists before the role is bound, " +
+					"failing with the unresolved members instead of a partial write. Defaults to `false`.",
+				Optional: true,
+			},
+			"id_case": schema.StringAttribute{
+				Description: "Case folding applied to group, role and resource IDs before they are sent: 'preserve', 'lower' or 'upper'. " +
+					"Role prefixes such as 'custom.' keep their case. Only use it for tenants whose IDs are case-insensitive. Defaults to 'preserve'.",
+				MarkdownDescription: "Case folding applied to group, role and resource IDs before they are sent: `preserve`, `lower` or `upper`. " +
+					"Role prefixes such as `custom.` keep their case. Only use it for tenants whose IDs are case-insensitive. Defaults to `preserve`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("preserve", "lower", "upper"),
+				},
+			},
+			"props_schemas": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "JSON Schema documents that hiiretail_iam_resource props must match, keyed by resource type, " +
+					"the prefix before ':' in the resource id (e.g. 'bu' for 'bu:001'). Props of other types only need to be valid JSON.",
+				MarkdownDescription: "JSON Schema documents that `hiiretail_iam_resource` `props` must match, keyed by resource type, " +
+					"the prefix before `:` in the resource id (e.g. `bu` for `bu:001`). Props of other types only need to be valid JSON.",
+				Optional: true,
+			},
+			"operation_timeouts": schema.MapAttribute{
+				ElementType: types.StringType,
+				Description: "Deadlines for individual IAM operations, keyed by operation name such as 'list_groups' or 'create_role_binding', " +
+					"as durations such as '30s' or '2m'. Each deadline covers the whole operation, including retries and paging; " +
+					"timeout_seconds still bounds each request. Operations without an entry are only bounded by timeout_seconds.",
+				MarkdownDescription: "Deadlines for individual IAM operations, keyed by operation name such as `list_groups` or `create_role_binding`, " +
+					"as durations such as `30s` or `2m`. Each deadline covers the whole operation, including retries and paging; " +
+					"`timeout_seconds` still bounds each request. Operations without an entry are only bounded by `timeout_seconds`.",
+				Optional: true,
+			},
+			"traceparent": schema.StringAttribute{
+				Description: "W3C traceparent of the calling span. Every API request is sent as a new child span of it. " +
+					"Can also be set via TRACEPARENT environment variable.",
+				MarkdownDescription: "W3C `traceparent` of the calling span. Every API request is sent as a new child span of it. " +
+					"Can also be set via `TRACEPARENT` environment variable.",
+				Optional: true,
+			},
+			"tracestate": schema.StringAttribute{
+				Description:         "W3C tracestate sent with traceparent. Can also be set via TRACESTATE environment variable.",
+				MarkdownDescription: "W3C `tracestate` sent with `traceparent`. Can also be set via `TRACESTATE` environment variable.",
+				Optional:            true,
+			},
 		},
 	}
 }
@@ -151,13 +307,19 @@ func (p *HiiRetailProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	// Build client configuration with hardcoded URLs and defaults
+	apiURL, diags := resolveAliasedEndpointURL(data.APIURL, data.BaseURL, "api_url", "base_url", auth.EnvAPIURL, auth.EnvBaseURL, defaultAPIURL)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build client configuration with defaults
 	clientConfig := &client.Config{
-		BaseURL:      "https://iam-api.retailsvc.com", // Hardcoded IAM API URL base
-		IAMEndpoint:  "/api/v1",                       // Most resources use V1 API - role bindings will bypass this
-		CCCEndpoint:  "/ccc/v1",                       // Default CCC endpoint
-		Timeout:      30 * time.Second,                // Default timeout
-		MaxRetries:   3,                               // Default retries
+		BaseURL:      apiURL,
+		BasePath:     client.DefaultBasePath, // Most resources use V1 API - role bindings will bypass this
+		CCCEndpoint:  "/ccc/v1",              // Default CCC endpoint
+		Timeout:      30 * time.Second,       // Default timeout
+		MaxRetries:   3,                      // Default retries
 		RetryWaitMin: 1 * time.Second,
 		RetryWaitMax: 30 * time.Second,
 	}
@@ -172,20 +334,41 @@ func (p *HiiRetailProvider) Configure(ctx context.Context, req provider.Configur
 	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
 		clientConfig.MaxRetries = int(data.MaxRetries.ValueInt64())
 	}
+	iamOptions, diags := buildIAMOptions(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	clientConfig.TraceParent, clientConfig.TraceState, diags = resolveTraceContext(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Convert AuthClientConfig to auth.Config with hardcoded URLs
+	// Convert AuthClientConfig to auth.Config with the resolved endpoints
 	authConfigV2 := &auth.Config{
 		ClientID:         authConfig.ClientID,
 		ClientSecret:     authConfig.ClientSecret,
 		TenantID:         authConfig.TenantID,
-		AuthURL:          authConfig.TokenURL,             // Already set to hardcoded auth URL
-		APIURL:           "https://iam-api.retailsvc.com", // Hardcoded IAM API URL base (auth client handles path separately)
+		AuthURL:          authConfig.TokenURL,
+		APIURL:           apiURL, // auth client handles the API path separately
 		Scopes:           authConfig.Scopes,
 		Timeout:          authConfig.Timeout,
 		MaxRetries:       authConfig.MaxRetries,
 		DisableDiscovery: authConfig.DisableDiscovery,
 	}
 
+	// When asked, check the environment before the client acquires its token,
+	// so an unreachable auth endpoint is reported as such
+	p.authConfig = authConfigV2
+	p.client = nil
+	if data.Preflight.ValueBool() {
+		resp.Diagnostics.Append(p.Preflight(ctx)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Create unified API client
 	apiClient, err := client.New(authConfigV2, clientConfig)
 	if err != nil {
@@ -196,9 +379,29 @@ func (p *HiiRetailProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	// Make the client available to resources and data sources
-	resp.DataSourceData = apiClient
-	resp.ResourceData = apiClient
+	// Use a separate credential for mutating requests when one is configured
+	writeAuthConfig, diags := buildWriteAuthConfig(ctx, &data, authConfigV2)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if writeAuthConfig != nil {
+		if err := apiClient.SetWriteAuth(writeAuthConfig); err != nil {
+			resp.Diagnostics.AddError(
+				"Client Setup Failed",
+				fmt.Sprintf("Failed to initialize write API client: %s", err.Error()),
+			)
+			return
+		}
+	}
+
+	p.client = apiClient
+
+	// Resources and data sources share one IAM service, so its read cache
+	// spans the whole run
+	providerData := iam.NewProviderData(apiClient, iamOptions)
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
 }
 
 func (p *HiiRetailProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -216,7 +419,11 @@ func (p *HiiRetailProvider) DataSources(ctx context.Context) []func() datasource
 		// IAM data sources
 		datasources.NewGroupsDataSource,
 		datasources.NewRolesDataSource,
+		datasources.NewPermissionsDataSource,
+		datasources.NewAuthEndpointsDataSource,
 		datasources.NewResourceDataSource,
+		datasources.NewCallerIdentityDataSource,
+		datasources.NewGroupRoleBindingsDataSource,
 	}
 }
 
@@ -277,8 +484,10 @@ func buildAuthConfig(ctx context.Context, data *HiiRetailProviderModel) (*auth.A
 		)
 	}
 
-	// Set hardcoded auth URL for HiiRetail
-	config.TokenURL = "https://auth.retailsvc.com/oauth2/token"
+	// Get auth URL with precedence: terraform.tfvars → HIIRETAIL_AUTH_URL → default
+	tokenURL, urlDiags := resolveAliasedEndpointURL(data.AuthURL, data.TokenURL, "auth_url", "token_url", auth.EnvAuthURL, auth.EnvTokenURL, defaultAuthURL)
+	diags.Append(urlDiags...)
+	config.TokenURL = tokenURL
 
 	// Get scopes with precedence: terraform.tfvars → TF_VAR_* → HIIRETAIL_* → default
 	if !data.Scopes.IsNull() && !data.Scopes.IsUnknown() {
@@ -286,9 +495,9 @@ func buildAuthConfig(ctx context.Context, data *HiiRetailProviderModel) (*auth.A
 		diags.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
 		config.Scopes = scopes
 	} else if tfVarScopes := os.Getenv("TF_VAR_scopes"); tfVarScopes != "" {
-		config.Scopes = strings.Split(tfVarScopes, ",")
-	} else if hiiRetailScopes := os.Getenv("HIIRETAIL_SCOPES"); hiiRetailScopes != "" {
-		config.Scopes = strings.Split(hiiRetailScopes, ",")
+		config.Scopes = auth.ParseScopes(tfVarScopes)
+	} else if hiiRetailScopes := os.Getenv(auth.EnvScopes); hiiRetailScopes != "" {
+		config.Scopes = auth.ParseScopes(hiiRetailScopes)
 	} else {
 		config.Scopes = []string{
 			"IAM:create:roles", "IAM:read:roles", "IAM:update:roles", "IAM:delete:roles",
@@ -302,17 +511,17 @@ func buildAuthConfig(ctx context.Context, data *HiiRetailProviderModel) (*auth.A
 	if !data.TimeoutSeconds.IsNull() && !data.TimeoutSeconds.IsUnknown() {
 		config.Timeout = time.Duration(data.TimeoutSeconds.ValueInt64()) * time.Second
 	} else if tfVarTimeout := os.Getenv("TF_VAR_timeout_seconds"); tfVarTimeout != "" {
-		if timeoutVal, err := strconv.ParseInt(tfVarTimeout, 10, 64); err == nil {
-			config.Timeout = time.Duration(timeoutVal) * time.Second
-		} else {
-			config.Timeout = 30 * time.Second // Default on parse error
+		timeout, err := auth.ParseTimeoutSeconds("TF_VAR_timeout_seconds", tfVarTimeout)
+		if err != nil {
+			diags.AddError("Invalid Timeout", err.Error())
 		}
-	} else if hiiRetailTimeout := os.Getenv("HIIRETAIL_TIMEOUT_SECONDS"); hiiRetailTimeout != "" {
-		if timeoutVal, err := strconv.ParseInt(hiiRetailTimeout, 10, 64); err == nil {
-			config.Timeout = time.Duration(timeoutVal) * time.Second
-		} else {
-			config.Timeout = 30 * time.Second // Default on parse error
+		config.Timeout = timeout
+	} else if hiiRetailTimeout := os.Getenv(auth.EnvTimeoutSeconds); hiiRetailTimeout != "" {
+		timeout, err := auth.ParseTimeoutSeconds(auth.EnvTimeoutSeconds, hiiRetailTimeout)
+		if err != nil {
+			diags.AddError("Invalid Timeout", err.Error())
 		}
+		config.Timeout = timeout
 	} else {
 		config.Timeout = 30 * time.Second // Default timeout
 	}
@@ -321,17 +530,17 @@ func buildAuthConfig(ctx context.Context, data *HiiRetailProviderModel) (*auth.A
 	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
 		config.MaxRetries = int(data.MaxRetries.ValueInt64())
 	} else if tfVarRetries := os.Getenv("TF_VAR_max_retries"); tfVarRetries != "" {
-		if retriesVal, err := strconv.Atoi(tfVarRetries); err == nil {
-			config.MaxRetries = retriesVal
-		} else {
-			config.MaxRetries = 3 // Default on parse error
+		retries, err := auth.ParseMaxRetries("TF_VAR_max_retries", tfVarRetries)
+		if err != nil {
+			diags.AddError("Invalid Max Retries", err.Error())
 		}
-	} else if hiiRetailRetries := os.Getenv("HIIRETAIL_MAX_RETRIES"); hiiRetailRetries != "" {
-		if retriesVal, err := strconv.Atoi(hiiRetailRetries); err == nil {
-			config.MaxRetries = retriesVal
-		} else {
-			config.MaxRetries = 3 // Default on parse error
+		config.MaxRetries = retries
+	} else if hiiRetailRetries := os.Getenv(auth.EnvMaxRetries); hiiRetailRetries != "" {
+		retries, err := auth.ParseMaxRetries(auth.EnvMaxRetries, hiiRetailRetries)
+		if err != nil {
+			diags.AddError("Invalid Max Retries", err.Error())
 		}
+		config.MaxRetries = retries
 	} else {
 		config.MaxRetries = 3 // Default max retries
 	}
@@ -343,6 +552,230 @@ func buildAuthConfig(ctx context.Context, data *HiiRetailProviderModel) (*auth.A
 	return config, diags
 }
 
+// buildWriteAuthConfig returns the credential for mutating requests, or nil when no
+// write credential is configured. It copies readConfig and replaces the client
+// credentials and, when set, the scopes.
+// Precedence order: 1. terraform.tfvars 2. HIIRETAIL_WRITE_* env vars
+func buildWriteAuthConfig(ctx context.Context, data *HiiRetailProviderModel, readConfig *auth.Config) (*auth.Config, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	clientID := os.Getenv(auth.EnvWriteClientID)
+	if !data.WriteClientID.IsNull() && !data.WriteClientID.IsUnknown() {
+		clientID = data.WriteClientID.ValueString()
+	}
+	clientSecret := os.Getenv(auth.EnvWriteClientSecret)
+	if !data.WriteClientSecret.IsNull() && !data.WriteClientSecret.IsUnknown() {
+		clientSecret = data.WriteClientSecret.ValueString()
+	}
+
+	if clientID == "" && clientSecret == "" {
+		return nil, diags
+	}
+	if clientID == "" || clientSecret == "" {
+		diags.AddError(
+			"Incomplete Write Credential",
+			"write_client_id and write_client_secret must be configured together, via terraform.tfvars or the HIIRETAIL_WRITE_CLIENT_ID and HIIRETAIL_WRITE_CLIENT_SECRET environment variables",
+		)
+		return nil, diags
+	}
+
+	writeConfig := *readConfig
+	writeConfig.ClientID = clientID
+	writeConfig.ClientSecret = clientSecret
+
+	if !data.WriteScopes.IsNull() && !data.WriteScopes.IsUnknown() {
+		scopes := make([]string, 0, len(data.WriteScopes.Elements()))
+		diags.Append(data.WriteScopes.ElementsAs(ctx, &scopes, false)...)
+		writeConfig.Scopes = scopes
+	} else if envScopes := os.Getenv(auth.EnvWriteScopes); envScopes != "" {
+		writeConfig.Scopes = auth.ParseScopes(envScopes)
+	}
+
+	return &writeConfig, diags
+}
+
+// buildIAMOptions collects the IAM settings the shared Service is built with
+func buildIAMOptions(ctx context.Context, data *HiiRetailProviderModel) (iam.Options, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	opts := iam.Options{
+		ValidateMembers:        data.ValidateMembers.ValueBool(),
+		RoleBindingIDDelimiter: data.RoleBindingIDDelimiter.ValueString(),
+		IDNormalization: iam.IDNormalization{
+			TrimSpace: data.TrimIDs.ValueBool(),
+			Case:      iam.ParseIDCase(data.IDCase.ValueString()),
+		},
+		ReadCache: data.ReadCache.ValueBool(),
+	}
+
+	var d diag.Diagnostics
+	opts.DefaultBindings, d = buildDefaultBindings(ctx, data)
+	diags.Append(d...)
+	opts.PropsSchemas, d = buildPropsSchemas(ctx, data)
+	diags.Append(d...)
+	opts.OperationTimeouts, d = buildOperationTimeouts(ctx, data)
+	diags.Append(d...)
+	return opts, diags
+}
+
+// buildOperationTimeouts parses operation_timeouts, rejecting operation names
+// the IAM service does not know and durations that are not positive. It
+// returns nil when unset.
+func buildOperationTimeouts(ctx context.Context, data *HiiRetailProviderModel) (map[string]time.Duration, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if data.OperationTimeouts.IsNull() || data.OperationTimeouts.IsUnknown() {
+		return nil, diags
+	}
+
+	raw := make(map[string]string, len(data.OperationTimeouts.Elements()))
+	diags.Append(data.OperationTimeouts.ElementsAs(ctx, &raw, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	operations := iam.Operations()
+	timeouts := make(map[string]time.Duration, len(raw))
+	for operation, value := range raw {
+		attrPath := path.Root("operation_timeouts").AtMapKey(operation)
+		if !slices.Contains(operations, operation) {
+			diags.AddAttributeError(attrPath, "Invalid Operation Timeout",
+				fmt.Sprintf("Unknown operation %q. Valid operations are: %s.", operation, strings.Join(operations, ", ")))
+			continue
+		}
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			diags.AddAttributeError(attrPath, "Invalid Operation Timeout",
+				fmt.Sprintf("The timeout for %s must be a positive duration such as \"30s\" or \"2m\", got %q.", operation, value))
+			continue
+		}
+		timeouts[operation] = timeout
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	return timeouts, diags
+}
+
+// buildDefaultBindings returns the trimmed default_bindings, or nil when unset.
+// Blank entries are rejected.
+func buildDefaultBindings(ctx context.Context, data *HiiRetailProviderModel) ([]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if data.DefaultBindings.IsNull() || data.DefaultBindings.IsUnknown() {
+		return nil, diags
+	}
+
+	var raw []string
+	diags.Append(data.DefaultBindings.ElementsAs(ctx, &raw, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	if len(raw) == 0 {
+		diags.AddAttributeError(path.Root("default_bindings"), "Invalid Default Bindings",
+			"default_bindings must contain at least one binding when set")
+		return nil, diags
+	}
+
+	bindings := make([]string, 0, len(raw))
+	for i, binding := range raw {
+		binding = strings.TrimSpace(binding)
+		if binding == "" {
+			diags.AddAttributeError(path.Root("default_bindings").AtListIndex(i), "Invalid Default Bindings",
+				"default_bindings entries must not be empty")
+			continue
+		}
+		bindings = append(bindings, binding)
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	return bindings, diags
+}
+
+// buildPropsSchemas returns props_schemas, or nil when unset. Each schema is
+// compiled so an invalid one is reported against its key.
+func buildPropsSchemas(ctx context.Context, data *HiiRetailProviderModel) (map[string]string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if data.PropsSchemas.IsNull() || data.PropsSchemas.IsUnknown() {
+		return nil, diags
+	}
+
+	schemas := make(map[string]string, len(data.PropsSchemas.Elements()))
+	diags.Append(data.PropsSchemas.ElementsAs(ctx, &schemas, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	for resourceType, doc := range schemas {
+		if _, err := jsonschema.Compile(doc); err != nil {
+			diags.AddAttributeError(path.Root("props_schemas").AtMapKey(resourceType), "Invalid Props Schema",
+				fmt.Sprintf("The schema for resource type %q is invalid: %s", resourceType, err))
+		}
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+	return schemas, diags
+}
+
+// resolveEndpointURL returns the endpoint from the attribute, then the
+// environment variable, then the fallback. Configured values must be HTTPS URLs.
+func resolveEndpointURL(value types.String, attribute, envVar, fallback string) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !value.IsNull() && !value.IsUnknown() {
+		endpoint, err := auth.ParseEndpointURL(attribute, value.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(attribute), "Invalid Endpoint URL", err.Error())
+		}
+		return endpoint, diags
+	}
+	if env := os.Getenv(envVar); env != "" {
+		endpoint, err := auth.ParseEndpointURL(envVar, env)
+		if err != nil {
+			diags.AddError("Invalid Endpoint URL", err.Error())
+		}
+		return endpoint, diags
+	}
+	return fallback, diags
+}
+
+// resolveAliasedEndpointURL is resolveEndpointURL for an endpoint that also
+// has a deprecated attribute and environment variable. Each deprecated
+// spelling is used only when its replacement is not set; the attribute is
+// flagged by its schema deprecation, the environment variable with a warning.
+func resolveAliasedEndpointURL(value, alias types.String, attribute, aliasAttribute, envVar, aliasEnvVar, fallback string) (string, diag.Diagnostics) {
+	if value.IsNull() && !alias.IsNull() {
+		return resolveEndpointURL(alias, aliasAttribute, envVar, fallback)
+	}
+	if value.IsNull() && os.Getenv(envVar) == "" && os.Getenv(aliasEnvVar) != "" {
+		endpoint, diags := resolveEndpointURL(value, attribute, aliasEnvVar, fallback)
+		diags.AddWarning("Deprecated Environment Variable",
+			fmt.Sprintf("%s is deprecated and will be removed in the next major release. Use %s instead.", aliasEnvVar, envVar))
+		return endpoint, diags
+	}
+	return resolveEndpointURL(value, attribute, envVar, fallback)
+}
+
+// resolveTraceContext returns the traceparent and tracestate from the
+// attributes or, when neither is set, from TRACEPARENT and TRACESTATE. An
+// invalid attribute is an error; an invalid environment context is ignored
+// with a warning so a stray variable does not block the run.
+func resolveTraceContext(data *HiiRetailProviderModel) (string, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !data.TraceParent.IsNull() || !data.TraceState.IsNull() {
+		parent, state := data.TraceParent.ValueString(), data.TraceState.ValueString()
+		if err := client.ValidateTraceContext(parent, state); err != nil {
+			diags.AddAttributeError(path.Root("traceparent"), "Invalid Trace Context", err.Error())
+		}
+		return parent, state, diags
+	}
+
+	parent, state := os.Getenv(client.EnvTraceParent), os.Getenv(client.EnvTraceState)
+	if err := client.ValidateTraceContext(parent, state); err != nil {
+		diags.AddWarning("Invalid Trace Context",
+			fmt.Sprintf("Ignoring %s and %s: %s", client.EnvTraceParent, client.EnvTraceState, err))
+		return "", "", diags
+	}
+	return parent, state, diags
+}
+
 // resolveBaseURL determines the appropriate base URL for API calls
 func resolveBaseURL(config *auth.AuthClientConfig) string {
 	if config.BaseURL != "" {
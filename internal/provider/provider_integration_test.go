@@ -9,7 +9,7 @@ import (
 	"time"
 
 	localProvider "github.com/extenda/hiiretail-terraform-providers/internal/provider"
-	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
 	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	tfsdk "github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	tftypes "github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -63,20 +63,20 @@ func TestOIDCIntegration(t *testing.T) {
 		errorContains string
 	}{
 		{
-			name:          "Valid OIDC credentials - expect auth failure in unit test",
+			name:          "Valid OIDC credentials - plain http token_url rejected",
 			clientId:      "valid-client",
 			clientSecret:  "valid-secret",
 			baseUrl:       mockOAuthServer.URL,
 			expectedError: true,
-			errorContains: "OAuth2 authentication failed",
+			errorContains: "token_url must use https",
 		},
 		{
-			name:          "Invalid OIDC credentials - expect auth failure in unit test",
+			name:          "Invalid OIDC credentials - plain http token_url rejected",
 			clientId:      "invalid-client",
 			clientSecret:  "invalid-secret",
 			baseUrl:       mockOAuthServer.URL,
 			expectedError: true,
-			errorContains: "OAuth2 authentication failed",
+			errorContains: "token_url must use https",
 		},
 	}
 
@@ -88,29 +88,61 @@ func TestOIDCIntegration(t *testing.T) {
 			configValue := tftypes.NewValue(
 				tftypes.Object{
 					AttributeTypes: map[string]tftypes.Type{
-						"client_id":       tftypes.String,
-						"client_secret":   tftypes.String,
-						"base_url":        tftypes.String,
-						"iam_endpoint":    tftypes.String,
-						"ccc_endpoint":    tftypes.String,
-						"token_url":       tftypes.String,
-						"scopes":          tftypes.Set{ElementType: tftypes.String},
-						"timeout_seconds": tftypes.Number,
-						"max_retries":     tftypes.Number,
-						"tenant_id":       tftypes.String,
+						"client_id":                 tftypes.String,
+						"client_secret":             tftypes.String,
+						"base_url":                  tftypes.String,
+						"iam_endpoint":              tftypes.String,
+						"ccc_endpoint":              tftypes.String,
+						"token_url":                 tftypes.String,
+						"auth_url":                  tftypes.String,
+						"api_url":                   tftypes.String,
+						"scopes":                    tftypes.Set{ElementType: tftypes.String},
+						"timeout_seconds":           tftypes.Number,
+						"max_retries":               tftypes.Number,
+						"write_client_id":           tftypes.String,
+						"write_client_secret":       tftypes.String,
+						"write_scopes":              tftypes.Set{ElementType: tftypes.String},
+						"default_bindings":          tftypes.List{ElementType: tftypes.String},
+						"props_schemas":             tftypes.Map{ElementType: tftypes.String},
+						"operation_timeouts":        tftypes.Map{ElementType: tftypes.String},
+						"role_binding_id_delimiter": tftypes.String,
+						"preflight":                 tftypes.Bool,
+						"read_cache":                tftypes.Bool,
+						"trim_ids":                  tftypes.Bool,
+						"validate_members":          tftypes.Bool,
+						"id_case":                   tftypes.String,
+						"traceparent":               tftypes.String,
+						"tracestate":                tftypes.String,
+						"tenant_id":                 tftypes.String,
 					},
 				},
 				map[string]tftypes.Value{
-					"client_id":       tftypes.NewValue(tftypes.String, tc.clientId),
-					"client_secret":   tftypes.NewValue(tftypes.String, tc.clientSecret),
-					"base_url":        tftypes.NewValue(tftypes.String, tc.baseUrl),
-					"iam_endpoint":    tftypes.NewValue(tftypes.String, nil),
-					"ccc_endpoint":    tftypes.NewValue(tftypes.String, nil),
-					"token_url":       tftypes.NewValue(tftypes.String, tc.baseUrl+"/oauth/token"),
-					"scopes":          tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
-					"timeout_seconds": tftypes.NewValue(tftypes.Number, nil),
-					"max_retries":     tftypes.NewValue(tftypes.Number, nil),
-					"tenant_id":       tftypes.NewValue(tftypes.String, "test-tenant"),
+					"client_id":                 tftypes.NewValue(tftypes.String, tc.clientId),
+					"client_secret":             tftypes.NewValue(tftypes.String, tc.clientSecret),
+					"base_url":                  tftypes.NewValue(tftypes.String, tc.baseUrl),
+					"iam_endpoint":              tftypes.NewValue(tftypes.String, nil),
+					"ccc_endpoint":              tftypes.NewValue(tftypes.String, nil),
+					"token_url":                 tftypes.NewValue(tftypes.String, tc.baseUrl+"/oauth/token"),
+					"auth_url":                  tftypes.NewValue(tftypes.String, nil),
+					"api_url":                   tftypes.NewValue(tftypes.String, nil),
+					"scopes":                    tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+					"timeout_seconds":           tftypes.NewValue(tftypes.Number, nil),
+					"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+					"write_client_id":           tftypes.NewValue(tftypes.String, nil),
+					"write_client_secret":       tftypes.NewValue(tftypes.String, nil),
+					"write_scopes":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+					"default_bindings":          tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+					"props_schemas":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+					"operation_timeouts":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+					"role_binding_id_delimiter": tftypes.NewValue(tftypes.String, nil),
+					"preflight":                 tftypes.NewValue(tftypes.Bool, nil),
+					"read_cache":                tftypes.NewValue(tftypes.Bool, nil),
+					"trim_ids":                  tftypes.NewValue(tftypes.Bool, nil),
+					"validate_members":          tftypes.NewValue(tftypes.Bool, nil),
+					"id_case":                   tftypes.NewValue(tftypes.String, nil),
+					"traceparent":               tftypes.NewValue(tftypes.String, nil),
+					"tracestate":                tftypes.NewValue(tftypes.String, nil),
+					"tenant_id":                 tftypes.NewValue(tftypes.String, "test-tenant"),
 				},
 			)
 			config := tfsdk.Config{
@@ -139,11 +171,12 @@ func TestOIDCIntegration(t *testing.T) {
 					t.Errorf("Expected no error but got: %v", resp.Diagnostics.Errors())
 					return
 				}
-				apiClient, ok := resp.ResourceData.(*client.Client)
+				providerData, ok := resp.ResourceData.(*iam.ProviderData)
 				if !ok {
-					t.Error("Expected ResourceData to be *client.Client")
+					t.Error("Expected ResourceData to be *iam.ProviderData")
 					return
 				}
+				apiClient := providerData.Client
 				if apiClient == nil {
 					t.Error("Expected client to be non-nil")
 					return
@@ -3,11 +3,18 @@ package provider
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
@@ -70,64 +77,128 @@ func TestHiiRetailProvider_Configure(t *testing.T) {
 		{
 			name: "Valid configuration with all fields - expect auth failure in unit test",
 			config: map[string]tftypes.Value{
-				"client_id":       tftypes.NewValue(tftypes.String, "test-client-id"),
-				"client_secret":   tftypes.NewValue(tftypes.String, "test-client-secret"),
-				"base_url":        tftypes.NewValue(tftypes.String, "https://test-api.example.com"),
-				"iam_endpoint":    tftypes.NewValue(tftypes.String, "/iam/v1"),
-				"ccc_endpoint":    tftypes.NewValue(tftypes.String, "/ccc/v1"),
-				"token_url":       tftypes.NewValue(tftypes.String, "https://auth.example.com/token"),
-				"scopes":          tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "iam:read")}),
-				"timeout_seconds": tftypes.NewValue(tftypes.Number, 30),
-				"max_retries":     tftypes.NewValue(tftypes.Number, 3),
-				"tenant_id":       tftypes.NewValue(tftypes.String, "test-tenant"),
+				"client_id":                 tftypes.NewValue(tftypes.String, "test-client-id"),
+				"client_secret":             tftypes.NewValue(tftypes.String, "test-client-secret"),
+				"base_url":                  tftypes.NewValue(tftypes.String, "https://test-api.example.com"),
+				"iam_endpoint":              tftypes.NewValue(tftypes.String, "/iam/v1"),
+				"ccc_endpoint":              tftypes.NewValue(tftypes.String, "/ccc/v1"),
+				"token_url":                 tftypes.NewValue(tftypes.String, "https://auth.example.com/token"),
+				"auth_url":                  tftypes.NewValue(tftypes.String, nil),
+				"api_url":                   tftypes.NewValue(tftypes.String, nil),
+				"scopes":                    tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{tftypes.NewValue(tftypes.String, "iam:read")}),
+				"timeout_seconds":           tftypes.NewValue(tftypes.Number, 30),
+				"max_retries":               tftypes.NewValue(tftypes.Number, 3),
+				"write_client_id":           tftypes.NewValue(tftypes.String, nil),
+				"write_client_secret":       tftypes.NewValue(tftypes.String, nil),
+				"write_scopes":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"default_bindings":          tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"props_schemas":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"operation_timeouts":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"role_binding_id_delimiter": tftypes.NewValue(tftypes.String, nil),
+				"preflight":                 tftypes.NewValue(tftypes.Bool, nil),
+				"read_cache":                tftypes.NewValue(tftypes.Bool, nil),
+				"trim_ids":                  tftypes.NewValue(tftypes.Bool, nil),
+				"validate_members":          tftypes.NewValue(tftypes.Bool, nil),
+				"id_case":                   tftypes.NewValue(tftypes.String, nil),
+				"traceparent":               tftypes.NewValue(tftypes.String, nil),
+				"tracestate":                tftypes.NewValue(tftypes.String, nil),
+				"tenant_id":                 tftypes.NewValue(tftypes.String, "test-tenant"),
 			},
 			expectedError: "OAuth2 authentication failed",
 		},
 		{
 			name: "Valid minimal configuration - expect auth failure in unit test",
 			config: map[string]tftypes.Value{
-				"client_id":       tftypes.NewValue(tftypes.String, "test-client-id"),
-				"client_secret":   tftypes.NewValue(tftypes.String, "test-client-secret"),
-				"base_url":        tftypes.NewValue(tftypes.String, nil),
-				"iam_endpoint":    tftypes.NewValue(tftypes.String, nil),
-				"ccc_endpoint":    tftypes.NewValue(tftypes.String, nil),
-				"token_url":       tftypes.NewValue(tftypes.String, nil),
-				"scopes":          tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
-				"timeout_seconds": tftypes.NewValue(tftypes.Number, nil),
-				"max_retries":     tftypes.NewValue(tftypes.Number, nil),
-				"tenant_id":       tftypes.NewValue(tftypes.String, "test-tenant"),
+				"client_id":                 tftypes.NewValue(tftypes.String, "test-client-id"),
+				"client_secret":             tftypes.NewValue(tftypes.String, "test-client-secret"),
+				"base_url":                  tftypes.NewValue(tftypes.String, nil),
+				"iam_endpoint":              tftypes.NewValue(tftypes.String, nil),
+				"ccc_endpoint":              tftypes.NewValue(tftypes.String, nil),
+				"token_url":                 tftypes.NewValue(tftypes.String, nil),
+				"auth_url":                  tftypes.NewValue(tftypes.String, nil),
+				"api_url":                   tftypes.NewValue(tftypes.String, nil),
+				"scopes":                    tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"timeout_seconds":           tftypes.NewValue(tftypes.Number, nil),
+				"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+				"write_client_id":           tftypes.NewValue(tftypes.String, nil),
+				"write_client_secret":       tftypes.NewValue(tftypes.String, nil),
+				"write_scopes":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"default_bindings":          tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"props_schemas":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"operation_timeouts":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"role_binding_id_delimiter": tftypes.NewValue(tftypes.String, nil),
+				"preflight":                 tftypes.NewValue(tftypes.Bool, nil),
+				"read_cache":                tftypes.NewValue(tftypes.Bool, nil),
+				"trim_ids":                  tftypes.NewValue(tftypes.Bool, nil),
+				"validate_members":          tftypes.NewValue(tftypes.Bool, nil),
+				"id_case":                   tftypes.NewValue(tftypes.String, nil),
+				"traceparent":               tftypes.NewValue(tftypes.String, nil),
+				"tracestate":                tftypes.NewValue(tftypes.String, nil),
+				"tenant_id":                 tftypes.NewValue(tftypes.String, "test-tenant"),
 			},
 			expectedError: "OAuth2 authentication failed",
 		},
 		{
 			name: "Missing client_id - should fail validation",
 			config: map[string]tftypes.Value{
-				"client_id":       tftypes.NewValue(tftypes.String, nil),
-				"client_secret":   tftypes.NewValue(tftypes.String, "test-client-secret"),
-				"base_url":        tftypes.NewValue(tftypes.String, nil),
-				"iam_endpoint":    tftypes.NewValue(tftypes.String, nil),
-				"ccc_endpoint":    tftypes.NewValue(tftypes.String, nil),
-				"token_url":       tftypes.NewValue(tftypes.String, nil),
-				"scopes":          tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
-				"timeout_seconds": tftypes.NewValue(tftypes.Number, nil),
-				"max_retries":     tftypes.NewValue(tftypes.Number, nil),
-				"tenant_id":       tftypes.NewValue(tftypes.String, "test-tenant"),
+				"client_id":                 tftypes.NewValue(tftypes.String, nil),
+				"client_secret":             tftypes.NewValue(tftypes.String, "test-client-secret"),
+				"base_url":                  tftypes.NewValue(tftypes.String, nil),
+				"iam_endpoint":              tftypes.NewValue(tftypes.String, nil),
+				"ccc_endpoint":              tftypes.NewValue(tftypes.String, nil),
+				"token_url":                 tftypes.NewValue(tftypes.String, nil),
+				"auth_url":                  tftypes.NewValue(tftypes.String, nil),
+				"api_url":                   tftypes.NewValue(tftypes.String, nil),
+				"scopes":                    tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"timeout_seconds":           tftypes.NewValue(tftypes.Number, nil),
+				"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+				"write_client_id":           tftypes.NewValue(tftypes.String, nil),
+				"write_client_secret":       tftypes.NewValue(tftypes.String, nil),
+				"write_scopes":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"default_bindings":          tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"props_schemas":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"operation_timeouts":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"role_binding_id_delimiter": tftypes.NewValue(tftypes.String, nil),
+				"preflight":                 tftypes.NewValue(tftypes.Bool, nil),
+				"read_cache":                tftypes.NewValue(tftypes.Bool, nil),
+				"trim_ids":                  tftypes.NewValue(tftypes.Bool, nil),
+				"validate_members":          tftypes.NewValue(tftypes.Bool, nil),
+				"id_case":                   tftypes.NewValue(tftypes.String, nil),
+				"traceparent":               tftypes.NewValue(tftypes.String, nil),
+				"tracestate":                tftypes.NewValue(tftypes.String, nil),
+				"tenant_id":                 tftypes.NewValue(tftypes.String, "test-tenant"),
 			},
 			expectedError: "client authentication failed",
 		},
 		{
 			name: "Missing client_secret - should fail validation",
 			config: map[string]tftypes.Value{
-				"client_id":       tftypes.NewValue(tftypes.String, "test-client-id"),
-				"client_secret":   tftypes.NewValue(tftypes.String, nil),
-				"base_url":        tftypes.NewValue(tftypes.String, nil),
-				"iam_endpoint":    tftypes.NewValue(tftypes.String, nil),
-				"ccc_endpoint":    tftypes.NewValue(tftypes.String, nil),
-				"token_url":       tftypes.NewValue(tftypes.String, nil),
-				"scopes":          tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
-				"timeout_seconds": tftypes.NewValue(tftypes.Number, nil),
-				"max_retries":     tftypes.NewValue(tftypes.Number, nil),
-				"tenant_id":       tftypes.NewValue(tftypes.String, "test-tenant"),
+				"client_id":                 tftypes.NewValue(tftypes.String, "test-client-id"),
+				"client_secret":             tftypes.NewValue(tftypes.String, nil),
+				"base_url":                  tftypes.NewValue(tftypes.String, nil),
+				"iam_endpoint":              tftypes.NewValue(tftypes.String, nil),
+				"ccc_endpoint":              tftypes.NewValue(tftypes.String, nil),
+				"token_url":                 tftypes.NewValue(tftypes.String, nil),
+				"auth_url":                  tftypes.NewValue(tftypes.String, nil),
+				"api_url":                   tftypes.NewValue(tftypes.String, nil),
+				"scopes":                    tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"timeout_seconds":           tftypes.NewValue(tftypes.Number, nil),
+				"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+				"write_client_id":           tftypes.NewValue(tftypes.String, nil),
+				"write_client_secret":       tftypes.NewValue(tftypes.String, nil),
+				"write_scopes":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"default_bindings":          tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"props_schemas":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"operation_timeouts":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"role_binding_id_delimiter": tftypes.NewValue(tftypes.String, nil),
+				"preflight":                 tftypes.NewValue(tftypes.Bool, nil),
+				"read_cache":                tftypes.NewValue(tftypes.Bool, nil),
+				"trim_ids":                  tftypes.NewValue(tftypes.Bool, nil),
+				"validate_members":          tftypes.NewValue(tftypes.Bool, nil),
+				"id_case":                   tftypes.NewValue(tftypes.String, nil),
+				"traceparent":               tftypes.NewValue(tftypes.String, nil),
+				"tracestate":                tftypes.NewValue(tftypes.String, nil),
+				"tenant_id":                 tftypes.NewValue(tftypes.String, "test-tenant"),
 			},
 			expectedError: "client authentication failed",
 		},
@@ -144,16 +215,32 @@ func TestHiiRetailProvider_Configure(t *testing.T) {
 			// Create configuration
 			configValue := tftypes.NewValue(tftypes.Object{
 				AttributeTypes: map[string]tftypes.Type{
-					"client_id":       tftypes.String,
-					"client_secret":   tftypes.String,
-					"tenant_id":       tftypes.String,
-					"base_url":        tftypes.String,
-					"iam_endpoint":    tftypes.String,
-					"ccc_endpoint":    tftypes.String,
-					"token_url":       tftypes.String,
-					"scopes":          tftypes.Set{ElementType: tftypes.String},
-					"timeout_seconds": tftypes.Number,
-					"max_retries":     tftypes.Number,
+					"client_id":                 tftypes.String,
+					"client_secret":             tftypes.String,
+					"tenant_id":                 tftypes.String,
+					"base_url":                  tftypes.String,
+					"iam_endpoint":              tftypes.String,
+					"ccc_endpoint":              tftypes.String,
+					"token_url":                 tftypes.String,
+					"auth_url":                  tftypes.String,
+					"api_url":                   tftypes.String,
+					"scopes":                    tftypes.Set{ElementType: tftypes.String},
+					"timeout_seconds":           tftypes.Number,
+					"max_retries":               tftypes.Number,
+					"write_client_id":           tftypes.String,
+					"write_client_secret":       tftypes.String,
+					"write_scopes":              tftypes.Set{ElementType: tftypes.String},
+					"default_bindings":          tftypes.List{ElementType: tftypes.String},
+					"props_schemas":             tftypes.Map{ElementType: tftypes.String},
+					"operation_timeouts":        tftypes.Map{ElementType: tftypes.String},
+					"role_binding_id_delimiter": tftypes.String,
+					"preflight":                 tftypes.Bool,
+					"read_cache":                tftypes.Bool,
+					"trim_ids":                  tftypes.Bool,
+					"validate_members":          tftypes.Bool,
+					"id_case":                   tftypes.String,
+					"traceparent":               tftypes.String,
+					"tracestate":                tftypes.String,
 				},
 			}, tc.config)
 
@@ -246,15 +333,31 @@ func TestHiiRetailProvider_OIDCConfiguration(t *testing.T) {
 
 			// Create configuration
 			configMap := map[string]tftypes.Value{
-				"client_id":       tftypes.NewValue(tftypes.String, tc.clientId),
-				"client_secret":   tftypes.NewValue(tftypes.String, tc.clientSecret),
-				"iam_endpoint":    tftypes.NewValue(tftypes.String, nil),
-				"ccc_endpoint":    tftypes.NewValue(tftypes.String, nil),
-				"token_url":       tftypes.NewValue(tftypes.String, nil),
-				"scopes":          tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
-				"timeout_seconds": tftypes.NewValue(tftypes.Number, nil),
-				"max_retries":     tftypes.NewValue(tftypes.Number, nil),
-				"tenant_id":       tftypes.NewValue(tftypes.String, "test-tenant"),
+				"client_id":                 tftypes.NewValue(tftypes.String, tc.clientId),
+				"client_secret":             tftypes.NewValue(tftypes.String, tc.clientSecret),
+				"iam_endpoint":              tftypes.NewValue(tftypes.String, nil),
+				"ccc_endpoint":              tftypes.NewValue(tftypes.String, nil),
+				"token_url":                 tftypes.NewValue(tftypes.String, nil),
+				"auth_url":                  tftypes.NewValue(tftypes.String, nil),
+				"api_url":                   tftypes.NewValue(tftypes.String, nil),
+				"scopes":                    tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"timeout_seconds":           tftypes.NewValue(tftypes.Number, nil),
+				"max_retries":               tftypes.NewValue(tftypes.Number, nil),
+				"write_client_id":           tftypes.NewValue(tftypes.String, nil),
+				"write_client_secret":       tftypes.NewValue(tftypes.String, nil),
+				"write_scopes":              tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+				"default_bindings":          tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+				"props_schemas":             tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"operation_timeouts":        tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil),
+				"role_binding_id_delimiter": tftypes.NewValue(tftypes.String, nil),
+				"preflight":                 tftypes.NewValue(tftypes.Bool, nil),
+				"read_cache":                tftypes.NewValue(tftypes.Bool, nil),
+				"trim_ids":                  tftypes.NewValue(tftypes.Bool, nil),
+				"validate_members":          tftypes.NewValue(tftypes.Bool, nil),
+				"id_case":                   tftypes.NewValue(tftypes.String, nil),
+				"traceparent":               tftypes.NewValue(tftypes.String, nil),
+				"tracestate":                tftypes.NewValue(tftypes.String, nil),
+				"tenant_id":                 tftypes.NewValue(tftypes.String, "test-tenant"),
 			}
 
 			if tc.baseUrl != "" {
@@ -265,16 +368,32 @@ func TestHiiRetailProvider_OIDCConfiguration(t *testing.T) {
 
 			configValue := tftypes.NewValue(tftypes.Object{
 				AttributeTypes: map[string]tftypes.Type{
-					"client_id":       tftypes.String,
-					"client_secret":   tftypes.String,
-					"base_url":        tftypes.String,
-					"iam_endpoint":    tftypes.String,
-					"ccc_endpoint":    tftypes.String,
-					"token_url":       tftypes.String,
-					"scopes":          tftypes.Set{ElementType: tftypes.String},
-					"timeout_seconds": tftypes.Number,
-					"max_retries":     tftypes.Number,
-					"tenant_id":       tftypes.String,
+					"client_id":                 tftypes.String,
+					"client_secret":             tftypes.String,
+					"base_url":                  tftypes.String,
+					"iam_endpoint":              tftypes.String,
+					"ccc_endpoint":              tftypes.String,
+					"token_url":                 tftypes.String,
+					"auth_url":                  tftypes.String,
+					"api_url":                   tftypes.String,
+					"scopes":                    tftypes.Set{ElementType: tftypes.String},
+					"timeout_seconds":           tftypes.Number,
+					"max_retries":               tftypes.Number,
+					"write_client_id":           tftypes.String,
+					"write_client_secret":       tftypes.String,
+					"write_scopes":              tftypes.Set{ElementType: tftypes.String},
+					"default_bindings":          tftypes.List{ElementType: tftypes.String},
+					"props_schemas":             tftypes.Map{ElementType: tftypes.String},
+					"operation_timeouts":        tftypes.Map{ElementType: tftypes.String},
+					"role_binding_id_delimiter": tftypes.String,
+					"preflight":                 tftypes.Bool,
+					"read_cache":                tftypes.Bool,
+					"trim_ids":                  tftypes.Bool,
+					"validate_members":          tftypes.Bool,
+					"id_case":                   tftypes.String,
+					"traceparent":               tftypes.String,
+					"tracestate":                tftypes.String,
+					"tenant_id":                 tftypes.String,
 				},
 			}, configMap)
 
@@ -303,7 +422,8 @@ func TestHiiRetailProvider_OIDCConfiguration(t *testing.T) {
 				}
 
 				// Check API client configuration
-				if apiClient, ok := resp.ResourceData.(*client.Client); ok {
+				if providerData, ok := resp.ResourceData.(*iam.ProviderData); ok {
+					apiClient := providerData.Client
 					// Note: The new client structure doesn't expose these fields directly
 					// TODO: Update tests to match new client interface
 					t.Log("API client configured successfully")
@@ -311,7 +431,7 @@ func TestHiiRetailProvider_OIDCConfiguration(t *testing.T) {
 						t.Error("Expected client to be non-nil")
 					}
 				} else {
-					t.Error("Expected ResourceData to be *client.Client")
+					t.Error("Expected ResourceData to be *iam.ProviderData")
 				}
 			}
 		})
@@ -346,3 +466,376 @@ func containsAtIndex(s, substr string) bool {
 	}
 	return false
 }
+
+func TestBuildAuthConfig_EnvOverrides(t *testing.T) {
+	newModel := func() *HiiRetailProviderModel {
+		return &HiiRetailProviderModel{
+			TenantID:       types.StringValue("tenant"),
+			ClientID:       types.StringValue("client"),
+			ClientSecret:   types.StringValue("secret"),
+			Scopes:         types.SetNull(types.StringType),
+			TimeoutSeconds: types.Int64Null(),
+			MaxRetries:     types.Int64Null(),
+		}
+	}
+
+	t.Run("valid values", func(t *testing.T) {
+		t.Setenv("HIIRETAIL_SCOPES", "iam:read, iam:write")
+		t.Setenv("HIIRETAIL_TIMEOUT_SECONDS", "45")
+		t.Setenv("HIIRETAIL_MAX_RETRIES", "5")
+
+		config, diags := buildAuthConfig(context.Background(), newModel())
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if len(config.Scopes) != 2 || config.Scopes[1] != "iam:write" {
+			t.Errorf("Scopes = %v, want [iam:read iam:write]", config.Scopes)
+		}
+		if config.Timeout != 45*time.Second {
+			t.Errorf("Timeout = %v, want 45s", config.Timeout)
+		}
+		if config.MaxRetries != 5 {
+			t.Errorf("MaxRetries = %d, want 5", config.MaxRetries)
+		}
+	})
+
+	for _, tc := range []struct {
+		name, key, value string
+	}{
+		{"timeout out of range", "HIIRETAIL_TIMEOUT_SECONDS", "1"},
+		{"timeout malformed", "HIIRETAIL_TIMEOUT_SECONDS", "30s"},
+		{"retries out of range", "HIIRETAIL_MAX_RETRIES", "11"},
+		{"retries malformed", "HIIRETAIL_MAX_RETRIES", "many"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(tc.key, tc.value)
+
+			_, diags := buildAuthConfig(context.Background(), newModel())
+			if !diags.HasError() {
+				t.Fatalf("expected an error for %s=%q", tc.key, tc.value)
+			}
+		})
+	}
+}
+
+func TestBuildWriteAuthConfig(t *testing.T) {
+	readConfig := &auth.Config{ClientID: "reader", ClientSecret: "read-secret", TenantID: "tenant", Scopes: []string{"IAM:read:groups"}}
+	newModel := func() *HiiRetailProviderModel {
+		return &HiiRetailProviderModel{
+			WriteClientID:     types.StringNull(),
+			WriteClientSecret: types.StringNull(),
+			WriteScopes:       types.SetNull(types.StringType),
+		}
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		config, diags := buildWriteAuthConfig(context.Background(), newModel(), readConfig)
+		if diags.HasError() || config != nil {
+			t.Fatalf("expected no write config, got %+v, %v", config, diags)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		model := newModel()
+		model.WriteClientID = types.StringValue("writer")
+		model.WriteClientSecret = types.StringValue("write-secret")
+		model.WriteScopes = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("IAM:create:groups")})
+
+		config, diags := buildWriteAuthConfig(context.Background(), model, readConfig)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if config.ClientID != "writer" || config.ClientSecret != "write-secret" || config.TenantID != "tenant" {
+			t.Errorf("write config = %+v, want write credential for tenant", config)
+		}
+		if len(config.Scopes) != 1 || config.Scopes[0] != "IAM:create:groups" {
+			t.Errorf("Scopes = %v, want [IAM:create:groups]", config.Scopes)
+		}
+		if readConfig.ClientID != "reader" {
+			t.Error("read config was modified")
+		}
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv(auth.EnvWriteClientID, "writer")
+		t.Setenv(auth.EnvWriteClientSecret, "write-secret")
+
+		config, diags := buildWriteAuthConfig(context.Background(), newModel(), readConfig)
+		if diags.HasError() || config == nil || config.ClientID != "writer" {
+			t.Fatalf("expected write config from environment, got %+v, %v", config, diags)
+		}
+		if len(config.Scopes) != 1 || config.Scopes[0] != "IAM:read:groups" {
+			t.Errorf("Scopes = %v, want the read scopes by default", config.Scopes)
+		}
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+		model := newModel()
+		model.WriteClientID = types.StringValue("writer")
+
+		if _, diags := buildWriteAuthConfig(context.Background(), model, readConfig); !diags.HasError() {
+			t.Fatal("expected an error when the write secret is missing")
+		}
+	})
+}
+
+func TestBuildDefaultBindings(t *testing.T) {
+	listOf := func(values ...string) types.List {
+		elems := make([]attr.Value, 0, len(values))
+		for _, v := range values {
+			elems = append(elems, types.StringValue(v))
+		}
+		return types.ListValueMust(types.StringType, elems)
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		bindings, diags := buildDefaultBindings(context.Background(), &HiiRetailProviderModel{DefaultBindings: types.ListNull(types.StringType)})
+		if diags.HasError() || bindings != nil {
+			t.Fatalf("expected no default bindings, got %v, %v", bindings, diags)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		bindings, diags := buildDefaultBindings(context.Background(), &HiiRetailProviderModel{DefaultBindings: listOf(" bu:001 ", "bu:002")})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if len(bindings) != 2 || bindings[0] != "bu:001" || bindings[1] != "bu:002" {
+			t.Errorf("bindings = %v, want [bu:001 bu:002]", bindings)
+		}
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		if _, diags := buildDefaultBindings(context.Background(), &HiiRetailProviderModel{DefaultBindings: listOf()}); !diags.HasError() {
+			t.Fatal("expected an error for an empty default_bindings list")
+		}
+	})
+
+	t.Run("blank entry", func(t *testing.T) {
+		if _, diags := buildDefaultBindings(context.Background(), &HiiRetailProviderModel{DefaultBindings: listOf("bu:001", "  ")}); !diags.HasError() {
+			t.Fatal("expected an error for a blank binding")
+		}
+	})
+}
+
+func TestBuildOperationTimeouts(t *testing.T) {
+	mapOf := func(values map[string]string) types.Map {
+		elems := make(map[string]attr.Value, len(values))
+		for k, v := range values {
+			elems[k] = types.StringValue(v)
+		}
+		return types.MapValueMust(types.StringType, elems)
+	}
+
+	t.Run("not configured", func(t *testing.T) {
+		timeouts, diags := buildOperationTimeouts(context.Background(), &HiiRetailProviderModel{OperationTimeouts: types.MapNull(types.StringType)})
+		if diags.HasError() || timeouts != nil {
+			t.Fatalf("expected no timeouts, got %v, %v", timeouts, diags)
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		timeouts, diags := buildOperationTimeouts(context.Background(), &HiiRetailProviderModel{
+			OperationTimeouts: mapOf(map[string]string{iam.OperationListGroups: "30s", iam.OperationCreateRoleBinding: "2m"}),
+		})
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if timeouts[iam.OperationListGroups] != 30*time.Second || timeouts[iam.OperationCreateRoleBinding] != 2*time.Minute {
+			t.Errorf("timeouts = %v, want list_groups 30s and create_role_binding 2m", timeouts)
+		}
+	})
+
+	t.Run("unknown operation", func(t *testing.T) {
+		_, diags := buildOperationTimeouts(context.Background(), &HiiRetailProviderModel{OperationTimeouts: mapOf(map[string]string{"list_group": "30s"})})
+		if !diags.HasError() {
+			t.Fatal("expected an error for an unknown operation")
+		}
+		if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, `"list_group"`) || !strings.Contains(detail, iam.OperationListGroups) {
+			t.Errorf("detail %q should name the operation and the valid ones", detail)
+		}
+	})
+
+	t.Run("invalid duration", func(t *testing.T) {
+		for _, value := range []string{"soon", "0s", "-1m"} {
+			if _, diags := buildOperationTimeouts(context.Background(), &HiiRetailProviderModel{OperationTimeouts: mapOf(map[string]string{iam.OperationListGroups: value})}); !diags.HasError() {
+				t.Errorf("expected an error for timeout %q", value)
+			}
+		}
+	})
+}
+
+func TestBuildIAMOptions(t *testing.T) {
+	opts, diags := buildIAMOptions(context.Background(), &HiiRetailProviderModel{
+		DefaultBindings:        types.ListValueMust(types.StringType, []attr.Value{types.StringValue("bu:001")}),
+		ValidateMembers:        types.BoolValue(true),
+		RoleBindingIDDelimiter: types.StringValue("::"),
+		TrimIDs:                types.BoolValue(true),
+		IDCase:                 types.StringValue("lower"),
+		PropsSchemas:           types.MapNull(types.StringType),
+		OperationTimeouts:      types.MapValueMust(types.StringType, map[string]attr.Value{iam.OperationGetGroup: types.StringValue("10s")}),
+		ReadCache:              types.BoolValue(true),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	want := iam.Options{
+		DefaultBindings:        []string{"bu:001"},
+		ValidateMembers:        true,
+		RoleBindingIDDelimiter: "::",
+		IDNormalization:        iam.IDNormalization{TrimSpace: true, Case: iam.IDCaseLower},
+		OperationTimeouts:      map[string]time.Duration{iam.OperationGetGroup: 10 * time.Second},
+		ReadCache:              true,
+	}
+	if !reflect.DeepEqual(opts, want) {
+		t.Errorf("options = %+v, want %+v", opts, want)
+	}
+}
+
+func TestResolveEndpointURL(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		t.Setenv(auth.EnvAPIURL, "")
+
+		endpoint, diags := resolveEndpointURL(types.StringNull(), "api_url", auth.EnvAPIURL, defaultAPIURL)
+		if diags.HasError() || endpoint != defaultAPIURL {
+			t.Fatalf("expected the default API URL, got %q, %v", endpoint, diags)
+		}
+	})
+
+	t.Run("valid override", func(t *testing.T) {
+		t.Setenv(auth.EnvAPIURL, "https://env.example.com")
+
+		endpoint, diags := resolveEndpointURL(types.StringValue("https://iam.example.com/"), "api_url", auth.EnvAPIURL, defaultAPIURL)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+		if endpoint != "https://iam.example.com" {
+			t.Errorf("endpoint = %q, want the attribute to take precedence over %s", endpoint, auth.EnvAPIURL)
+		}
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv(auth.EnvAuthURL, "https://auth.example.com/oauth2/token")
+
+		endpoint, diags := resolveEndpointURL(types.StringNull(), "auth_url", auth.EnvAuthURL, defaultAuthURL)
+		if diags.HasError() || endpoint != "https://auth.example.com/oauth2/token" {
+			t.Fatalf("expected the auth URL from the environment, got %q, %v", endpoint, diags)
+		}
+	})
+
+	t.Run("deprecated attribute alias", func(t *testing.T) {
+		t.Setenv(auth.EnvAPIURL, "https://env.example.com")
+
+		endpoint, diags := resolveAliasedEndpointURL(types.StringNull(), types.StringValue("https://legacy.example.com"),
+			"api_url", "base_url", auth.EnvAPIURL, auth.EnvBaseURL, defaultAPIURL)
+		if diags.HasError() || endpoint != "https://legacy.example.com" {
+			t.Fatalf("expected base_url to be used when api_url is not set, got %q, %v", endpoint, diags)
+		}
+	})
+
+	t.Run("deprecated environment alias", func(t *testing.T) {
+		t.Setenv(auth.EnvAuthURL, "")
+		t.Setenv(auth.EnvTokenURL, "https://legacy.example.com/oauth2/token")
+
+		endpoint, diags := resolveAliasedEndpointURL(types.StringNull(), types.StringNull(),
+			"auth_url", "token_url", auth.EnvAuthURL, auth.EnvTokenURL, defaultAuthURL)
+		if diags.HasError() || endpoint != "https://legacy.example.com/oauth2/token" {
+			t.Fatalf("expected the token URL from %s, got %q, %v", auth.EnvTokenURL, endpoint, diags)
+		}
+		if len(diags.Warnings()) != 1 || !strings.Contains(diags.Warnings()[0].Detail(), auth.EnvAuthURL) {
+			t.Errorf("expected a deprecation warning naming %s, got %v", auth.EnvAuthURL, diags)
+		}
+
+		t.Setenv(auth.EnvAuthURL, "https://auth.example.com/oauth2/token")
+		endpoint, diags = resolveAliasedEndpointURL(types.StringNull(), types.StringNull(),
+			"auth_url", "token_url", auth.EnvAuthURL, auth.EnvTokenURL, defaultAuthURL)
+		if endpoint != "https://auth.example.com/oauth2/token" || len(diags) != 0 {
+			t.Errorf("expected %s to take precedence without a warning, got %q, %v", auth.EnvAuthURL, endpoint, diags)
+		}
+	})
+
+	for _, tc := range []struct {
+		name, value, want string
+	}{
+		{"http rejected", "http://iam.example.com", "must use https"},
+		{"malformed", "https://iam example.com:port", "not a valid URL"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, diags := resolveEndpointURL(types.StringValue(tc.value), "api_url", auth.EnvAPIURL, defaultAPIURL)
+			if !diags.HasError() {
+				t.Fatalf("expected an error for api_url %q", tc.value)
+			}
+			if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, tc.want) {
+				t.Errorf("detail = %q, want it to contain %q", detail, tc.want)
+			}
+		})
+	}
+
+	t.Run("invalid environment value", func(t *testing.T) {
+		t.Setenv(auth.EnvAuthURL, "http://auth.example.com/token")
+
+		if _, diags := buildAuthConfig(context.Background(), &HiiRetailProviderModel{
+			TenantID:     types.StringValue("tenant"),
+			ClientID:     types.StringValue("client"),
+			ClientSecret: types.StringValue("secret"),
+			Scopes:       types.SetNull(types.StringType),
+		}); !diags.HasError() {
+			t.Fatalf("expected an error for %s over http", auth.EnvAuthURL)
+		}
+	})
+}
+
+func TestResolveTraceContext(t *testing.T) {
+	const parent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	t.Run("none configured", func(t *testing.T) {
+		t.Setenv(client.EnvTraceParent, "")
+		t.Setenv(client.EnvTraceState, "")
+
+		gotParent, gotState, diags := resolveTraceContext(&HiiRetailProviderModel{})
+		if diags.HasError() || gotParent != "" || gotState != "" {
+			t.Fatalf("expected no trace context, got %q, %q, %v", gotParent, gotState, diags)
+		}
+	})
+
+	t.Run("attributes take precedence", func(t *testing.T) {
+		t.Setenv(client.EnvTraceParent, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00")
+		t.Setenv(client.EnvTraceState, "env=1")
+
+		gotParent, gotState, diags := resolveTraceContext(&HiiRetailProviderModel{
+			TraceParent: types.StringValue(parent),
+			TraceState:  types.StringValue("vendor=1"),
+		})
+		if diags.HasError() || gotParent != parent || gotState != "vendor=1" {
+			t.Fatalf("expected the attribute trace context, got %q, %q, %v", gotParent, gotState, diags)
+		}
+	})
+
+	t.Run("from environment", func(t *testing.T) {
+		t.Setenv(client.EnvTraceParent, parent)
+		t.Setenv(client.EnvTraceState, "env=1")
+
+		gotParent, gotState, diags := resolveTraceContext(&HiiRetailProviderModel{})
+		if diags.HasError() || gotParent != parent || gotState != "env=1" {
+			t.Fatalf("expected the environment trace context, got %q, %q, %v", gotParent, gotState, diags)
+		}
+	})
+
+	t.Run("invalid attribute is an error", func(t *testing.T) {
+		_, _, diags := resolveTraceContext(&HiiRetailProviderModel{TraceParent: types.StringValue("not-a-traceparent")})
+		if !diags.HasError() {
+			t.Fatal("expected an error for an invalid traceparent attribute")
+		}
+	})
+
+	t.Run("invalid environment is ignored", func(t *testing.T) {
+		t.Setenv(client.EnvTraceParent, "not-a-traceparent")
+		t.Setenv(client.EnvTraceState, "")
+
+		gotParent, _, diags := resolveTraceContext(&HiiRetailProviderModel{})
+		if diags.HasError() || diags.WarningsCount() != 1 || gotParent != "" {
+			t.Fatalf("expected the environment trace context to be dropped with a warning, got %q, %v", gotParent, diags)
+		}
+	})
+}
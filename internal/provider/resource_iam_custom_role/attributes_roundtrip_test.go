@@ -0,0 +1,219 @@
+package resource_iam_custom_role
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+// setupStatefulServer returns a server that stores the created role and serves it back on GET
+func setupStatefulServer(t *testing.T) (*httptest.Server, *IamCustomRoleResource) {
+	var mu sync.Mutex
+	var stored *CustomRoleResponse
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodPost:
+			var req CustomRoleRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			stored = &CustomRoleResponse{
+				ID:          req.ID,
+				Name:        req.Name,
+				TenantID:    "test-tenant-123",
+				Permissions: req.Permissions,
+			}
+			w.WriteHeader(http.StatusCreated)
+			require.NoError(t, json.NewEncoder(w).Encode(stored))
+		case http.MethodGet:
+			if stored == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			require.NoError(t, json.NewEncoder(w).Encode(stored))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+
+	return server, &IamCustomRoleResource{
+		client:   server.Client(),
+		baseURL:  server.URL,
+		tenantID: "test-tenant-123",
+	}
+}
+
+func permissionsModel(t *testing.T, attributes types.Map) types.List {
+	ctx := context.Background()
+	permType := PermissionsType{ObjectType: types.ObjectType{AttrTypes: PermissionsValue{}.AttributeTypes(ctx)}}
+	list, diags := types.ListValueFrom(ctx, permType, []PermissionsValue{{
+		Alias:      types.StringNull(),
+		Attributes: attributes,
+		Id:         types.StringValue("pos.payment.create"),
+		state:      attr.ValueStateKnown,
+	}})
+	require.False(t, diags.HasError(), "building permissions list: %v", diags)
+	return list
+}
+
+func TestPermissionAttributes_CreateReadRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	server, r := setupStatefulServer(t)
+	defer server.Close()
+
+	configured := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"store":  types.StringValue("001"),
+		"region": types.StringValue("north"),
+	})
+	plan := IamCustomRoleModel{
+		Id:          types.StringValue("custom-role-1"),
+		Name:        types.StringValue("Custom Role"),
+		Permissions: permissionsModel(t, configured),
+	}
+
+	apiReq, err := r.modelToAPIRequest(ctx, plan)
+	require.NoError(t, err)
+	require.Equal(t, map[string]interface{}{"store": "001", "region": "north"}, apiReq.Permissions[0].Attributes)
+
+	created, err := r.createCustomRole(ctx, apiReq)
+	require.NoError(t, err)
+
+	state := plan
+	require.NoError(t, r.apiResponseToModel(ctx, created, &state))
+
+	read, err := r.readCustomRole(ctx, "custom-role-1")
+	require.NoError(t, err)
+	refreshed := state
+	require.NoError(t, r.apiResponseToModel(ctx, read, &refreshed))
+
+	var perms []PermissionsValue
+	require.False(t, refreshed.Permissions.ElementsAs(ctx, &perms, false).HasError())
+	require.Len(t, perms, 1)
+	require.True(t, configured.Equal(perms[0].Attributes), "attributes drifted: %s", perms[0].Attributes)
+	require.True(t, plan.Permissions.Equal(refreshed.Permissions), "permissions should not produce a diff")
+}
+
+func TestPermissionAttributes_EmptyRoundTripStaysNull(t *testing.T) {
+	ctx := context.Background()
+	server, r := setupStatefulServer(t)
+	defer server.Close()
+
+	plan := IamCustomRoleModel{
+		Id:          types.StringValue("custom-role-2"),
+		Name:        types.StringValue("Custom Role"),
+		Permissions: permissionsModel(t, types.MapNull(types.StringType)),
+	}
+
+	apiReq, err := r.modelToAPIRequest(ctx, plan)
+	require.NoError(t, err)
+	require.Nil(t, apiReq.Permissions[0].Attributes)
+
+	created, err := r.createCustomRole(ctx, apiReq)
+	require.NoError(t, err)
+
+	state := plan
+	require.NoError(t, r.apiResponseToModel(ctx, created, &state))
+	require.True(t, plan.Permissions.Equal(state.Permissions), "empty attributes should not produce a diff")
+}
+
+func TestPermissionAttributes_EmptyMapRoundTripStaysEmpty(t *testing.T) {
+	ctx := context.Background()
+	server, r := setupStatefulServer(t)
+	defer server.Close()
+
+	plan := IamCustomRoleModel{
+		Id:          types.StringValue("custom-role-3"),
+		Name:        types.StringValue("Custom Role"),
+		Permissions: permissionsModel(t, types.MapValueMust(types.StringType, map[string]attr.Value{})),
+	}
+
+	apiReq, err := r.modelToAPIRequest(ctx, plan)
+	require.NoError(t, err)
+
+	created, err := r.createCustomRole(ctx, apiReq)
+	require.NoError(t, err)
+
+	state := plan
+	require.NoError(t, r.apiResponseToModel(ctx, created, &state))
+	require.True(t, plan.Permissions.Equal(state.Permissions), "attributes = {} should not read back as null")
+}
+
+func TestIamCustomRoleResource_UpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+	r := &IamCustomRoleResource{}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.Equal(t, int64(1), schemaResp.Schema.Version)
+
+	upgrader, ok := r.UpgradeState(ctx)[0]
+	require.True(t, ok, "expected a version 0 upgrader")
+
+	permissionType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"alias":      tftypes.String,
+		"attributes": tftypes.Object{AttributeTypes: map[string]tftypes.Type{}},
+		"id":         tftypes.String,
+	}}
+	permission := func(id string, attributes tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(permissionType, map[string]tftypes.Value{
+			"alias":      tftypes.NewValue(tftypes.String, nil),
+			"attributes": attributes,
+			"id":         tftypes.NewValue(tftypes.String, id),
+		})
+	}
+	raw := tftypes.NewValue(upgrader.PriorSchema.Type().TerraformType(ctx), map[string]tftypes.Value{
+		"description": tftypes.NewValue(tftypes.String, nil),
+		"id":          tftypes.NewValue(tftypes.String, "custom-role-1"),
+		"name":        tftypes.NewValue(tftypes.String, "Custom Role"),
+		"permissions": tftypes.NewValue(tftypes.List{ElementType: permissionType}, []tftypes.Value{
+			permission("pos.payment.create", tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, nil)),
+			permission("pos.payment.refund", tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{})),
+		}),
+		"tenant_id": tftypes.NewValue(tftypes.String, "test-tenant-123"),
+		"title":     tftypes.NewValue(tftypes.String, nil),
+	})
+
+	req := resource.UpgradeStateRequest{State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: raw}}
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	upgrader.StateUpgrader(ctx, req, resp)
+	require.False(t, resp.Diagnostics.HasError(), "upgrade diagnostics: %v", resp.Diagnostics)
+
+	var out IamCustomRoleModel
+	require.False(t, resp.State.Get(ctx, &out).HasError())
+	require.Equal(t, "custom-role-1", out.Id.ValueString())
+
+	var perms []PermissionsValue
+	require.False(t, out.Permissions.ElementsAs(ctx, &perms, false).HasError())
+	require.Len(t, perms, 2)
+	require.Equal(t, "pos.payment.create", perms[0].Id.ValueString())
+	require.True(t, perms[0].Attributes.IsNull())
+	require.False(t, perms[1].Attributes.IsNull())
+	require.Empty(t, perms[1].Attributes.Elements())
+}
+
+func TestAttributesToMapValue(t *testing.T) {
+	v, err := attributesToMapValue(map[string]interface{}{})
+	require.NoError(t, err)
+	require.True(t, v.IsNull())
+
+	v, err = attributesToMapValue(map[string]interface{}{"k": "v"})
+	require.NoError(t, err)
+	require.Len(t, v.Elements(), 1)
+
+	_, err = attributesToMapValue(map[string]interface{}{"k": 1})
+	require.Error(t, err)
+}
@@ -43,8 +43,8 @@ func TestModelToAPIRequest_PermissionsWithAliasAndAttributes(t *testing.T) {
 	ctx := context.Background()
 	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
 
-	// Build a PermissionsValue with alias and null attributes (AttributesValue has no attribute types)
-	attributesObj := types.ObjectNull(AttributesValue{}.AttributeTypes(ctx))
+	// Build a PermissionsValue with alias and null attributes
+	attributesObj := types.MapNull(types.StringType)
 
 	pv := PermissionsValue{
 		Alias:      types.StringValue("alias"),
@@ -63,6 +63,6 @@ func TestModelToAPIRequest_PermissionsWithAliasAndAttributes(t *testing.T) {
 	require.Len(t, apiReq.Permissions, 1)
 	pa := apiReq.Permissions[0]
 	require.Equal(t, "perm.id", pa.ID)
-	// attributes are null (AttributesValue has no attribute types)
+	// attributes are null
 	require.Nil(t, pa.Attributes)
 }
@@ -10,15 +10,13 @@ import (
 )
 
 func TestGeneratedHelpers_EqualNegativeAndTerraformValueBranches(t *testing.T) {
-	ctx := context.Background()
-
 	// Compare AttributesValue to a different type
 	av := AttributesValue{state: attr.ValueStateKnown}
 	var other attr.Value = PermissionsValue{state: attr.ValueStateKnown}
 	require.False(t, av.Equal(other))
 
 	// PermissionsValue Equal negative: different state
-	pvKnown := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)), Id: types.StringValue("x")}
+	pvKnown := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.MapNull(types.StringType), Id: types.StringValue("x")}
 	pvNull := PermissionsValue{state: attr.ValueStateNull}
 	require.False(t, pvKnown.Equal(pvNull))
 }
@@ -27,8 +25,8 @@ func TestModelToAPIRequest_MixedAttributeTypes(t *testing.T) {
 	ctx := context.Background()
 	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
 
-	// Build an empty attributes object using the generated AttributesValue attribute types
-	attrsObj := types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	// Build an empty attributes map
+	attrsObj := types.MapValueMust(types.StringType, map[string]attr.Value{})
 
 	pv := PermissionsValue{
 		Alias:      types.StringNull(),
@@ -45,7 +43,6 @@ func TestModelToAPIRequest_MixedAttributeTypes(t *testing.T) {
 	req, err := r.modelToAPIRequest(ctx, data)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(req.Permissions))
-	// AttributesValue has no declared attribute types in the generated code,
-	// so we expect the request to include no attributes (the provider filters by declared attr types).
+	// An empty attributes map is omitted from the request
 	require.Equal(t, 0, len(req.Permissions[0].Attributes))
 }
@@ -18,7 +18,7 @@ func TestGeneratedHelpers_ErrorAndStateBranches(t *testing.T) {
 	// Build an object missing alias
 	objValsMissingAlias := map[string]attr.Value{
 		// "alias" omitted
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("sys.r.a"),
 	}
 	objMissingAlias, objDiags := types.ObjectValue(attrTypes, objValsMissingAlias)
@@ -31,7 +31,7 @@ func TestGeneratedHelpers_ErrorAndStateBranches(t *testing.T) {
 	// 2) NewPermissionsValue - extra attribute should produce diagnostic and return Unknown
 	attrs := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("sys.r.a"),
 		"extra":      types.StringValue("bad"),
 	}
@@ -55,7 +55,7 @@ func TestGeneratedHelpers_ErrorAndStateBranches(t *testing.T) {
 	// Attributes null
 	pvn := PermissionsValue{
 		Alias:      types.StringNull(),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringNull(),
 		state:      attr.ValueStateKnown,
 	}
@@ -66,7 +66,7 @@ func TestGeneratedHelpers_ErrorAndStateBranches(t *testing.T) {
 	// Attributes unknown
 	pvu := PermissionsValue{
 		Alias:      types.StringNull(),
-		Attributes: types.ObjectUnknown(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapUnknown(types.StringType),
 		Id:         types.StringNull(),
 		state:      attr.ValueStateKnown,
 	}
@@ -76,7 +76,7 @@ func TestGeneratedHelpers_ErrorAndStateBranches(t *testing.T) {
 
 	// Attributes known (empty map)
 	attrsKnown := map[string]attr.Value{}
-	attrsObj, _ := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), attrsKnown)
+	attrsObj, _ := types.MapValue(types.StringType, attrsKnown)
 	pvKnown := PermissionsValue{
 		Alias:      types.StringValue("a"),
 		Attributes: attrsObj,
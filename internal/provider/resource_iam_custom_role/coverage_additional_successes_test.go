@@ -12,11 +12,10 @@ import (
 func TestPermissionsType_ValueFromObject_Success_New(t *testing.T) {
 	ctx := context.Background()
 
-	// Build inner attributes object for the 'attributes' field
-	attributesAttrTypes := AttributesValue{}.AttributeTypes(ctx)
-	attributesVal, diags := types.ObjectValue(attributesAttrTypes, map[string]attr.Value{})
+	// Build the string map for the 'attributes' field
+	attributesVal, diags := types.MapValue(types.StringType, map[string]attr.Value{})
 	if diags.HasError() {
-		t.Fatalf("failed to construct attributes object: %v", diags)
+		t.Fatalf("failed to construct attributes map: %v", diags)
 	}
 
 	// Build the permissions object value using the attribute types reported by PermissionsValue
@@ -61,10 +60,9 @@ func TestNewPermissionsValue_Success_New(t *testing.T) {
 
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
 
-	attributesAttrTypes := AttributesValue{}.AttributeTypes(ctx)
-	attributesVal, diags := types.ObjectValue(attributesAttrTypes, map[string]attr.Value{})
+	attributesVal, diags := types.MapValue(types.StringType, map[string]attr.Value{})
 	if diags.HasError() {
-		t.Fatalf("failed to construct inner attributes object: %v", diags)
+		t.Fatalf("failed to construct attributes map: %v", diags)
 	}
 
 	attrs := map[string]attr.Value{
@@ -66,7 +66,7 @@ func Test_NewPermissionsValue_ExtraAndWrongTypes(t *testing.T) {
     attrsExtra := map[string]attr.Value{
         "alias":      types.StringValue("ok"),
         "id":         types.StringValue("perm.x.y"),
-        "attributes": types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{}),
+        "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}),
         "extra":      types.StringValue("extra"),
     }
 
@@ -82,7 +82,7 @@ func Test_NewPermissionsValue_ExtraAndWrongTypes(t *testing.T) {
     attrsWrong := map[string]attr.Value{
         "alias":      types.ObjectValueMust(AttributesValue{}.AttributeTypes(context.Background()), map[string]attr.Value{}),
         "id":         types.StringValue("perm.x.y"),
-        "attributes": types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{}),
+        "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}),
     }
 
     v2, diags2 := NewPermissionsValue(attributeTypes, attrsWrong)
@@ -15,7 +15,7 @@ func Test_PermissionsValue_ObjectNegativeBranches(t *testing.T) {
 
 	// 1) Missing alias
 	attrs1 := map[string]attr.Value{
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("perm.x.y"),
 	}
 	obj1, _ := types.ObjectValue(attrTypes, attrs1)
@@ -39,7 +39,7 @@ func Test_PermissionsValue_ObjectNegativeBranches(t *testing.T) {
 	// 3) Missing id
 	attrs3 := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 	}
 	obj3, _ := types.ObjectValue(attrTypes, attrs3)
 	_, diags3 := pt.ValueFromObject(ctx, obj3)
@@ -50,7 +50,7 @@ func Test_PermissionsValue_ObjectNegativeBranches(t *testing.T) {
 	// 4) Wrong type for alias
 	attrs4 := map[string]attr.Value{
 		"alias":      types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("perm.x.y"),
 	}
 	obj4, diags4 := types.ObjectValue(attrTypes, attrs4)
@@ -66,7 +66,7 @@ func Test_PermissionsValue_ObjectNegativeBranches(t *testing.T) {
 	// 5) Extra attribute
 	attrs5 := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("perm.x.y"),
 		"extra":      types.StringValue("x"),
 	}
@@ -79,7 +79,7 @@ func Test_PermissionsValue_ObjectNegativeBranches(t *testing.T) {
 	// 6) Wrong attribute type in NewPermissionsValue
 	attrs6 := map[string]attr.Value{
 		"alias":      types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("perm.x.y"),
 	}
 	v2, diags6 := NewPermissionsValue(attrTypes, attrs6)
@@ -95,7 +95,7 @@ func Test_NewPermissionsValue_Success(t *testing.T) {
 
 	attrs := map[string]attr.Value{
 		"alias":      types.StringValue("alias-val"),
-		"attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		"attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		"id":         types.StringValue("perm.x.y"),
 	}
 
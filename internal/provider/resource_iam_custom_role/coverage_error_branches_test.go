@@ -18,7 +18,7 @@ func TestPermissionsType_ValueFromObject_WrongType(t *testing.T) {
 	attrs := map[string]attr.Value{
 		"alias": types.Int64Value(1),
 		"attributes": func() attr.Value {
-			v, _ := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+			v, _ := types.MapValue(types.StringType, map[string]attr.Value{})
 			return v
 		}(),
 		"id": types.StringValue("perm.x.y"),
@@ -43,7 +43,7 @@ func TestNewPermissionsValue_MissingExtraWrongType(t *testing.T) {
 
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
 
-	innerObj, diags := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	innerObj, diags := types.MapValue(types.StringType, map[string]attr.Value{})
 	if diags.HasError() {
 		t.Fatalf("failed to build inner attributes object: %v", diags)
 	}
@@ -118,7 +118,7 @@ func TestPermissionsValue_ToTerraform_NullUnknownKnown(t *testing.T) {
 	}
 
 	// Known
-	innerObj, diags := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	innerObj, diags := types.MapValue(types.StringType, map[string]attr.Value{})
 	if diags.HasError() {
 		t.Fatalf("failed to create inner attributes object: %v", diags)
 	}
@@ -165,7 +165,7 @@ func TestPermissionsType_ValueFromObject_MissingAndWrongTypes(t *testing.T) {
 	// Missing alias
 	attrsMissingAlias := map[string]attr.Value{
 		"attributes": func() attr.Value {
-			v, _ := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+			v, _ := types.MapValue(types.StringType, map[string]attr.Value{})
 			return v
 		}(),
 		"id": types.StringValue("perm.x.y"),
@@ -201,7 +201,7 @@ func TestPermissionsType_ValueFromObject_MissingAndWrongTypes(t *testing.T) {
 	attrsMissingId := map[string]attr.Value{
 		"alias": types.StringValue("a"),
 		"attributes": func() attr.Value {
-			v, _ := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+			v, _ := types.MapValue(types.StringType, map[string]attr.Value{})
 			return v
 		}(),
 	}
@@ -237,7 +237,7 @@ func TestPermissionsType_ValueFromObject_MissingAndWrongTypes(t *testing.T) {
 	attrsWrongIdType := map[string]attr.Value{
 		"alias": types.StringValue("a"),
 		"attributes": func() attr.Value {
-			v, _ := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+			v, _ := types.MapValue(types.StringType, map[string]attr.Value{})
 			return v
 		}(),
 		"id": types.Int64Value(5),
@@ -26,7 +26,7 @@ func TestExercise_AllHelpers(t *testing.T) {
     }
 
     // Value Type and AttributeTypes
-    pv := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)), Id: types.StringValue("id")}
+    pv := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.MapNull(types.StringType), Id: types.StringValue("id")}
     _ = pv.Type(ctx)
     _ = pv.AttributeTypes(ctx)
     _ = pv.String()
@@ -44,7 +44,7 @@ func TestExercise_AllHelpers(t *testing.T) {
 
     // NewPermissionsValueMust with valid attrs
     permAttrTypes := PermissionsValue{}.AttributeTypes(ctx)
-    innerObj, diags := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+    innerObj, diags := types.MapValue(types.StringType, map[string]attr.Value{})
     if diags.HasError() {
         t.Fatalf("failed to build inner object: %v", diags)
     }
@@ -68,12 +68,11 @@ func TestPermissionsType_ValueFromObject_Success(t *testing.T) {
 	// Build the attributes for the object
 	attributes := map[string]attr.Value{}
 
-	// alias and id are string basetypes; attributes is an object (empty)
+	// alias and id are string basetypes; attributes is a string map (empty)
 	attributes["alias"] = types.StringValue("a")
 	attributes["id"] = types.StringValue("perm.x.y")
-	// For attributes field, create an empty object value matching AttributesValue types
-	attributes["attributes"] = types.ObjectValueMust(
-		AttributesValue{}.AttributeTypes(ctx),
+	attributes["attributes"] = types.MapValueMust(
+		types.StringType,
 		map[string]attr.Value{},
 	)
 
@@ -95,12 +94,10 @@ func TestPermissionsType_ValueFromObject_Success(t *testing.T) {
 }
 
 func TestPermissionsValue_Equal_OtherTypeAndState(t *testing.T) {
-	ctx := context.Background()
-
 	// Known value
 	p1 := PermissionsValue{
 		Alias:      types.StringValue("a"),
-		Attributes: types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		Id:         types.StringValue("perm.x.y"),
 		state:      attr.ValueStateKnown,
 	}
@@ -15,7 +15,7 @@ func TestPermissionsType_ValueFromObject_MissingAlias_Custom(t *testing.T) {
 
 	attributes := map[string]attr.Value{
 		// omit alias
-		"attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		"attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		"id":         types.StringValue("perm.x.y"),
 	}
 
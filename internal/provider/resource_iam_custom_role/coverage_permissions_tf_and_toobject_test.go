@@ -91,7 +91,7 @@ func TestPermissionsValue_ToObjectValue_Branches(t *testing.T) {
 	}
 
 	// Known
-	innerObj, diags := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	innerObj, diags := types.MapValue(types.StringType, map[string]attr.Value{})
 	if diags.HasError() {
 		t.Fatalf("failed to create inner attributes object: %v", diags)
 	}
@@ -120,7 +120,7 @@ func TestNewPermissionsValueMust_PanicOnError(t *testing.T) {
 	attrs := map[string]attr.Value{
 		"alias": types.StringValue("a"),
 		"attributes": func() attr.Value {
-			v, _ := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+			v, _ := types.MapValue(types.StringType, map[string]attr.Value{})
 			return v
 		}(),
 	}
@@ -50,7 +50,7 @@ func Test_NewPermissionsValueMust_PanicsOnInvalidInput(t *testing.T) {
 	// missing id to produce diagnostic
 	attrs := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 	}
 
 	defer func() {
@@ -64,10 +64,9 @@ func Test_NewPermissionsValueMust_PanicsOnInvalidInput(t *testing.T) {
 
 // Test PermissionsValue.Equal when fields differ
 func Test_PermissionsValue_Equal_DifferentFields(t *testing.T) {
-	ctx := context.Background()
 	base := PermissionsValue{
 		Alias:      types.StringValue("a"),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringValue("perm.x.y"),
 		state:      attr.ValueStateKnown,
 	}
@@ -20,7 +20,7 @@ func TestModelToAPIRequest_ValidInput(t *testing.T) {
 	permission := PermissionsValue{
 		Id:         types.StringValue("pos.payment.create"),
 		Alias:      types.StringValue("Create Payment"),
-		Attributes: types.ObjectNull(map[string]attr.Type{}),
+		Attributes: types.MapNull(types.StringType),
 		state:      attr.ValueStateKnown,
 	}
 
@@ -97,19 +97,19 @@ func TestModelToAPIRequest_MultiplePermissions(t *testing.T) {
 		{
 			Id:         types.StringValue("pos.payment.create"),
 			Alias:      types.StringValue("Create Payment"),
-			Attributes: types.ObjectNull(map[string]attr.Type{}),
+			Attributes: types.MapNull(types.StringType),
 			state:      attr.ValueStateKnown,
 		},
 		{
 			Id:         types.StringValue("pos.payment.read"),
 			Alias:      types.StringValue("Read Payment"),
-			Attributes: types.ObjectNull(map[string]attr.Type{}),
+			Attributes: types.MapNull(types.StringType),
 			state:      attr.ValueStateKnown,
 		},
 		{
 			Id:         types.StringValue("pos.refund.create"),
 			Alias:      types.StringNull(),
-			Attributes: types.ObjectNull(map[string]attr.Type{}),
+			Attributes: types.MapNull(types.StringType),
 			state:      attr.ValueStateKnown,
 		},
 	}
@@ -78,7 +78,7 @@ func TestEdgeCases_PermissionWithNullAlias(t *testing.T) {
 	permission := PermissionsValue{
 		Id:         types.StringValue("pos.payment.create"),
 		Alias:      types.StringNull(), // Null alias
-		Attributes: types.ObjectNull(map[string]attr.Type{}),
+		Attributes: types.MapNull(types.StringType),
 		state:      attr.ValueStateKnown,
 	}
 
@@ -113,7 +113,7 @@ func TestEdgeCases_PermissionWithUnknownAlias(t *testing.T) {
 	permission := PermissionsValue{
 		Id:         types.StringValue("pos.payment.create"),
 		Alias:      types.StringUnknown(), // Unknown alias
-		Attributes: types.ObjectNull(map[string]attr.Type{}),
+		Attributes: types.MapNull(types.StringType),
 		state:      attr.ValueStateKnown,
 	}
 
@@ -202,7 +202,7 @@ func TestEdgeCases_LargePermissionSets(t *testing.T) {
 		permissions[i] = PermissionsValue{
 			Id:         types.StringValue("pos.payment.create"),
 			Alias:      types.StringValue("Create Payment"),
-			Attributes: types.ObjectNull(map[string]attr.Type{}),
+			Attributes: types.MapNull(types.StringType),
 			state:      attr.ValueStateKnown,
 		}
 	}
@@ -238,7 +238,7 @@ func TestEdgeCases_SpecialCharactersInStrings(t *testing.T) {
 	permission := PermissionsValue{
 		Id:         types.StringValue("pos.payment.create"),
 		Alias:      types.StringValue("Create Payment with Special Chars: äöü €£¥"),
-		Attributes: types.ObjectNull(map[string]attr.Type{}),
+		Attributes: types.MapNull(types.StringType),
 		state:      attr.ValueStateKnown,
 	}
 
@@ -331,7 +331,7 @@ func TestEdgeCases_ConcurrentDataAccess(t *testing.T) {
 	permission := PermissionsValue{
 		Id:         types.StringValue("pos.payment.create"),
 		Alias:      types.StringValue("Create Payment"),
-		Attributes: types.ObjectNull(map[string]attr.Type{}),
+		Attributes: types.MapNull(types.StringType),
 		state:      attr.ValueStateKnown,
 	}
 
@@ -13,7 +13,7 @@ func TestExercise_GeneratedPaths(t *testing.T) {
 	ctx := context.Background()
 
 	// PermissionsValue known
-	pv := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), Id: types.StringValue("id")}
+	pv := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{}), Id: types.StringValue("id")}
 
 	if pv.IsNull() || pv.IsUnknown() {
 		t.Fatalf("pv should be known")
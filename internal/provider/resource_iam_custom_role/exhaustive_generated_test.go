@@ -22,7 +22,7 @@ func TestGenerated_ExhaustiveHelpers(t *testing.T) {
 	// Build a known PermissionsValue and exercise Equal/ToObjectValue/ToTerraformValue
 	pv := PermissionsValue{
 		Alias:      types.StringValue("a"),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringValue("perm.id"),
 		state:      attr.ValueStateKnown,
 	}
@@ -48,7 +48,7 @@ func TestNewPermissionsValue_ErrorCases(t *testing.T) {
 	wrongAttrs := map[string]attr.Value{
 		"alias":      types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
 		"id":         types.StringValue("i"),
-		"attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		"attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}),
 	}
 
 	_, diags3 := NewPermissionsValue(attrTypes, wrongAttrs)
@@ -63,7 +63,7 @@ func TestPermissionsValue_ToTerraformValue_UnhandledStatePanics(t *testing.T) {
 	// create a PermissionsValue with an invalid state
 	pv := PermissionsValue{
 		Alias:      types.StringValue("a"),
-		Attributes: types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		Id:         types.StringValue("i"),
 		// choose a small out-of-band state that will hit the default branch
 		state: attr.ValueState(3),
@@ -84,7 +84,7 @@ func TestPermissionsValue_ToObjectValue_NullAndUnknownAttributes(t *testing.T) {
 	// Attributes null
 	pvNull := PermissionsValue{
 		Alias:      types.StringValue("a"),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringValue("i"),
 		state:      attr.ValueStateKnown,
 	}
@@ -107,7 +107,7 @@ func TestPermissionsValue_ToObjectValue_NullAndUnknownAttributes(t *testing.T) {
 	// Attributes unknown
 	pvUnknown := PermissionsValue{
 		Alias:      types.StringValue("a"),
-		Attributes: types.ObjectUnknown(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapUnknown(types.StringType),
 		Id:         types.StringValue("i"),
 		state:      attr.ValueStateKnown,
 	}
@@ -128,11 +128,9 @@ func TestPermissionsValue_ToObjectValue_NullAndUnknownAttributes(t *testing.T) {
 }
 
 func TestPermissionsValue_Equal_DifferentStatesAndFields(t *testing.T) {
-	ctx := context.Background()
-
 	pv1 := PermissionsValue{
 		Alias:      types.StringValue("a"),
-		Attributes: types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		Id:         types.StringValue("i"),
 		state:      attr.ValueStateKnown,
 	}
@@ -22,7 +22,7 @@ func TestGenerated_PermissionsValue_Conversions(t *testing.T) {
 	// Build a known PermissionsValue using simple types
 	pv := PermissionsValue{
 		Alias:      types.StringNull(),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringValue("perm.id"),
 		state:      attr.ValueStateKnown,
 	}
@@ -83,7 +83,7 @@ func TestPermissions_ValueFromObject_And_ValueFromTerraform(t *testing.T) {
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
 	attrs := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("perm.id"),
 	}
 
@@ -129,7 +129,7 @@ func TestNewPermissionsValue_Errors(t *testing.T) {
 	// Provide attributes map missing 'id' to trigger diagnostics
 	attrs := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		// "id" is intentionally missing
 	}
 
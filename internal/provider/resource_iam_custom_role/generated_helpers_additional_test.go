@@ -20,8 +20,8 @@ func TestPermissionsValue_ToAndFromTerraform_And_ObjectConversions(t *testing.T)
 	attrs := map[string]attr.Value{}
 
 	attrs["alias"] = types.StringValue("alias-val")
-	// Attributes inner object uses AttributesValue attribute types (empty map)
-	attrs["attributes"] = types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	// Attributes is an empty string map
+	attrs["attributes"] = types.MapValueMust(types.StringType, map[string]attr.Value{})
 	attrs["id"] = types.StringValue("sys.res.act")
 
 	// Use NewPermissionsValueMust to create a known value
@@ -101,7 +101,7 @@ func TestPermissionsValue_NewPermissionsValue_ErrorsAndMustPanics(t *testing.T)
 	// Extra attribute should cause diagnostics
 	extra := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		"attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		"id":         types.StringValue("sys.r.a"),
 		"extra":      types.StringValue("x"),
 	}
@@ -16,7 +16,7 @@ func TestPermissionsValue_ValueFromObject_Success_And_EqualNegative(t *testing.T
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
 	objVals := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("sys.r.a"),
 	}
 
@@ -17,7 +17,7 @@ func TestPermissionsType_ValueFromObject_SuccessAndMissing(t *testing.T) {
 	// Build a proper object value and ensure ValueFromObject succeeds
 	attrs := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("p1"),
 	}
 	obj, diags := types.ObjectValue(attrTypes, attrs)
@@ -33,7 +33,7 @@ func TestPermissionsType_ValueFromObject_SuccessAndMissing(t *testing.T) {
 	// Now create an object missing the 'id' attribute to force diagnostic
 	badAttrs := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 	}
 	badObj, diagsObj := types.ObjectValue(attrTypes, badAttrs)
 	// Either constructing the object or ValueFromObject should produce diagnostics
@@ -59,7 +59,7 @@ func TestPermissionsValue_ToObjectValue_ToTerraformValue(t *testing.T) {
 	// Known permissions value
 	pv := PermissionsValue{
 		Alias:      types.StringNull(),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringValue("pid"),
 		state:      attr.ValueStateKnown,
 	}
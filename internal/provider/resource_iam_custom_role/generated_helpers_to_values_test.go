@@ -28,7 +28,7 @@ func TestPermissionsValue_ToTerraformValue_NullUnknownKnown(t *testing.T) {
 	// Known with valid inner values
 	pv := PermissionsValue{
 		Alias:      types.StringValue("a"),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringValue("sys.r.a"),
 		state:      attr.ValueStateKnown,
 	}
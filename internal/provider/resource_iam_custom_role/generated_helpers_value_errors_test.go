@@ -29,7 +29,7 @@ func TestNewPermissionsValue_WrongAttributeTypeProducesDiagnostic(t *testing.T)
 	// Provide wrong type for alias (object instead of string)
 	attrs := map[string]attr.Value{
 		"alias":      types.ObjectNull(map[string]attr.Type{}),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("sys.r.a"),
 	}
 
@@ -49,7 +49,7 @@ func TestNewPermissionsValue_MissingAndExtraAttributes(t *testing.T) {
 	// Extra: provide an unexpected attribute key
 	attrs := map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		"attributes": types.MapNull(types.StringType),
 		"id":         types.StringValue("sys.r.a"),
 		"extra":      types.StringValue("x"),
 	}
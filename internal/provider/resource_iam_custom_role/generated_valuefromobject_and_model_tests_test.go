@@ -31,7 +31,7 @@ func TestPermissions_ValueFromObject_Behavior(t *testing.T) {
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
 
 	// attributes sub-object can be empty (AttributesValue.AttributeTypes is empty)
-	attributesObj := types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	attributesObj := types.MapValueMust(types.StringType, map[string]attr.Value{})
 
 	goodObj := types.ObjectValueMust(attrTypes, map[string]attr.Value{
 		"alias":      types.StringValue("alias-val"),
@@ -86,7 +86,7 @@ func TestPermissionsValue_StateAndEqual(t *testing.T) {
 
 	// Create two known PermissionsValue instances and compare equality
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
-	attributesObj := types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	attributesObj := types.MapValueMust(types.StringType, map[string]attr.Value{})
 	a := NewPermissionsValueMust(attrTypes, map[string]attr.Value{
 		"alias":      types.StringValue("a1"),
 		"attributes": attributesObj,
@@ -117,7 +117,7 @@ func TestModelToAPIRequest_WithAttributes(t *testing.T) {
 	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
 
 	// Build a PermissionsValue with an attributes object matching the generated AttributesValue types
-	attributesObj := types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	attributesObj := types.MapValueMust(types.StringType, map[string]attr.Value{})
 
 	// Build a PermissionsValue manually to allow a non-empty attributes object
 	pv := PermissionsValue{
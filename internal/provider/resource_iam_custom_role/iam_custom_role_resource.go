@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -41,12 +42,16 @@ type APIClient struct {
 type CustomRoleRequest struct {
 	ID          string       `json:"id"`
 	Name        string       `json:"name,omitempty"`
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
 	Permissions []Permission `json:"permissions"`
 }
 
 type CustomRoleResponse struct {
 	ID          string       `json:"id"`
 	Name        string       `json:"name"`
+	Title       string       `json:"title,omitempty"`
+	Description string       `json:"description,omitempty"`
 	TenantID    string       `json:"tenant_id"`
 	Permissions []Permission `json:"permissions"`
 	CreatedAt   string       `json:"created_at,omitempty"`
@@ -78,6 +83,8 @@ func (r *IamCustomRoleResource) Metadata(ctx context.Context, req resource.Metad
 func (r *IamCustomRoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	// Use the generated schema from the generated file
 	resp.Schema = IamCustomRoleResourceSchema(ctx)
+	// Version 1 changed permissions[*].attributes from an object to a string map
+	resp.Schema.Version = 1
 }
 
 func (r *IamCustomRoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
@@ -330,28 +337,53 @@ func (r *IamCustomRoleResource) Delete(ctx context.Context, req resource.DeleteR
 	// Resource is automatically removed from state when Delete returns without error
 }
 
+// ImportState accepts either the custom role ID or a {tenant}/{roleId}
+// composite. The tenant of a composite must match the provider tenant.
 func (r *IamCustomRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// The import ID should be the custom role ID
-	id := req.ID
-	if id == "" {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			"Custom role ID is required for import. Use: terraform import hiiretail_iam_custom_role.example role-id",
-		)
+	tenantID, id, err := parseCustomRoleImportID(req.ID, r.tenantID)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", err.Error())
 		return
 	}
 
 	// Set the ID in the state using path.Root
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), types.StringValue(id))...)
+	if tenantID != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_id"), types.StringValue(tenantID))...)
+	}
 
 	// Log the successful import
 	tflog.Trace(ctx, "imported custom role resource", map[string]interface{}{
-		"id": id,
+		"id":        id,
+		"tenant_id": tenantID,
 	})
 
 	// The Read operation will be called automatically after ImportState to populate the full state
 }
 
+// parseCustomRoleImportID splits an import ID of the form roleId or
+// tenant/roleId, returning the tenant (providerTenant for a bare ID) and the
+// role ID
+func parseCustomRoleImportID(importID, providerTenant string) (string, string, error) {
+	const usage = "Use: terraform import hiiretail_iam_custom_role.example role-id or tenant/role-id"
+
+	if importID == "" {
+		return "", "", fmt.Errorf("custom role ID is required for import. %s", usage)
+	}
+
+	tenantID, id, composite := strings.Cut(importID, "/")
+	if !composite {
+		return providerTenant, importID, nil
+	}
+	if tenantID == "" || id == "" || strings.Contains(id, "/") {
+		return "", "", fmt.Errorf("import ID %q is not of the form tenant/role-id. %s", importID, usage)
+	}
+	if providerTenant != "" && tenantID != providerTenant {
+		return "", "", fmt.Errorf("import ID tenant %q does not match the provider tenant %q; configure the provider for tenant %q or import with the bare role ID", tenantID, providerTenant, tenantID)
+	}
+	return tenantID, id, nil
+}
+
 // Helper methods for API communication and data conversion
 
 // modelToAPIRequest converts Terraform model to API request format
@@ -360,10 +392,16 @@ func (r *IamCustomRoleResource) modelToAPIRequest(ctx context.Context, data IamC
 		ID: data.Id.ValueString(),
 	}
 
-	// Add name if provided
+	// Add name, title and description if provided
 	if !data.Name.IsNull() && !data.Name.IsUnknown() {
 		req.Name = data.Name.ValueString()
 	}
+	if !data.Title.IsNull() && !data.Title.IsUnknown() {
+		req.Title = data.Title.ValueString()
+	}
+	if !data.Description.IsNull() && !data.Description.IsUnknown() {
+		req.Description = data.Description.ValueString()
+	}
 
 	// Convert permissions
 	var permissions []PermissionsValue
@@ -386,7 +424,7 @@ func (r *IamCustomRoleResource) modelToAPIRequest(ctx context.Context, data IamC
 		// Add attributes if provided
 		if !perm.Attributes.IsNull() && !perm.Attributes.IsUnknown() {
 			attrs := make(map[string]interface{})
-			for key, value := range perm.Attributes.Attributes() {
+			for key, value := range perm.Attributes.Elements() {
 				if strVal, ok := value.(types.String); ok && !strVal.IsNull() {
 					attrs[key] = strVal.ValueString()
 				}
@@ -396,6 +434,7 @@ func (r *IamCustomRoleResource) modelToAPIRequest(ctx context.Context, data IamC
 			}
 		}
 	}
+	sortPermissions(req.Permissions)
 
 	return req, nil
 }
@@ -563,14 +602,25 @@ func (r *IamCustomRoleResource) apiResponseToModel(ctx context.Context, apiResp
 	data.Id = types.StringValue(apiResp.ID)
 	data.Name = types.StringValue(apiResp.Name)
 	data.TenantId = types.StringValue(apiResp.TenantID)
+	data.Title = optionalStringValue(data.Title, apiResp.Title)
+	data.Description = optionalStringValue(data.Description, apiResp.Description)
+
+	prior := priorPermissions(data.Permissions)
+	apiPermissions := alignPermissions(prior, apiResp.Permissions)
+
+	// Prior attributes by permission id, consumed in order for repeated ids
+	priorAttributes := make(map[string][]types.Map, len(prior))
+	for _, p := range prior {
+		priorAttributes[p.Id.ValueString()] = append(priorAttributes[p.Id.ValueString()], p.Attributes)
+	}
 
 	// Convert permissions back to Terraform format
-	permissionsList := make([]PermissionsValue, len(apiResp.Permissions))
+	permissionsList := make([]PermissionsValue, len(apiPermissions))
 	tflog.Debug(ctx, "Converting permissions", map[string]interface{}{
 		"permissions_count": len(apiResp.Permissions),
 	})
 
-	for i, perm := range apiResp.Permissions {
+	for i, perm := range apiPermissions {
 		tflog.Debug(ctx, "Processing permission", map[string]interface{}{
 			"index":           i,
 			"perm.ID":         perm.ID,
@@ -582,11 +632,15 @@ func (r *IamCustomRoleResource) apiResponseToModel(ctx context.Context, apiResp
 		// This ensures consistency between planned and actual state
 		aliasValue := types.StringNull()
 
-		// Handle attributes - Always set to null when not provided in config to match Terraform expectations
-		// The API response might return empty objects, but if the config doesn't specify attributes,
-		// we should return null to maintain consistency with the planned state
-		var attributesValue types.Object
-		attributesValue = types.ObjectNull(AttributesValue{}.AttributeTypes(ctx))
+		// Handle attributes - the API returns them as a string map
+		priorAttrs := types.MapNull(types.StringType)
+		if queue := priorAttributes[perm.ID]; len(queue) > 0 {
+			priorAttrs, priorAttributes[perm.ID] = queue[0], queue[1:]
+		}
+		attributesValue, err := attributesStateValue(priorAttrs, perm.Attributes)
+		if err != nil {
+			return fmt.Errorf("failed to convert attributes for permission %s: %w", perm.ID, err)
+		}
 
 		permissionsList[i] = PermissionsValue{
 			Id:         types.StringValue(perm.ID),
@@ -625,3 +679,51 @@ func (r *IamCustomRoleResource) apiResponseToModel(ctx context.Context, apiResp
 	data.Permissions = permissionsListValue
 	return nil
 }
+
+// optionalStringValue returns the state value for an optional string the API
+// returns as an empty string when it is not set. An empty remote value keeps
+// a null or empty prior value, so omitting the attribute does not show a diff.
+func optionalStringValue(prior types.String, remote string) types.String {
+	if remote == "" {
+		if !prior.IsUnknown() && prior.ValueString() == "" {
+			return prior
+		}
+		return types.StringNull()
+	}
+	return types.StringValue(remote)
+}
+
+// attributesStateValue returns the state value for permission attributes. An
+// empty or missing remote map keeps an empty prior map and is stored as null
+// otherwise, so configurations with attributes = {} or without attributes do
+// not show a diff.
+func attributesStateValue(prior types.Map, remote map[string]interface{}) (types.Map, error) {
+	if len(remote) == 0 && !prior.IsNull() && !prior.IsUnknown() && len(prior.Elements()) == 0 {
+		return prior, nil
+	}
+	return attributesToMapValue(remote)
+}
+
+// attributesToMapValue converts API permission attributes into a Terraform string map.
+// Nil or empty attributes are returned as a null map.
+func attributesToMapValue(attributes map[string]interface{}) (types.Map, error) {
+	if len(attributes) == 0 {
+		return types.MapNull(types.StringType), nil
+	}
+
+	elements := make(map[string]attr.Value, len(attributes))
+	for key, value := range attributes {
+		strVal, ok := value.(string)
+		if !ok {
+			return types.MapNull(types.StringType), fmt.Errorf("attribute %q must be a string, got %T", key, value)
+		}
+		elements[key] = types.StringValue(strVal)
+	}
+
+	mapValue, diags := types.MapValue(types.StringType, elements)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), fmt.Errorf("%s", diags[0].Summary())
+	}
+
+	return mapValue, nil
+}
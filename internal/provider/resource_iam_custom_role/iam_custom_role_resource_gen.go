@@ -1,11 +1,12 @@
-// Code generated by terraform-plugin-framework-generator DO NOT EDIT.
+// Originally generated by terraform-plugin-framework-generator. The schema,
+// model and permission attributes have since diverged from the generator
+// output and this file is maintained by hand; do not regenerate it.
 
 package resource_iam_custom_role
 
 import (
 	"context"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -23,6 +24,11 @@ import (
 func IamCustomRoleResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Description of the custom role.",
+				MarkdownDescription: "Description of the custom role.",
+			},
 			"id": schema.StringAttribute{
 				Required: true,
 			},
@@ -40,13 +46,8 @@ func IamCustomRoleResourceSchema(ctx context.Context) schema.Schema {
 						"alias": schema.StringAttribute{
 							Computed: true,
 						},
-						"attributes": schema.SingleNestedAttribute{
-							Attributes: map[string]schema.Attribute{},
-							CustomType: AttributesType{
-								ObjectType: types.ObjectType{
-									AttrTypes: AttributesValue{}.AttributeTypes(ctx),
-								},
-							},
+						"attributes": schema.MapAttribute{
+							ElementType:         types.StringType,
 							Optional:            true,
 							Computed:            true,
 							Description:         "attributes must be an object with up to 10 props keys up to 40 chars values as strings up to 256 chars",
@@ -56,9 +57,6 @@ func IamCustomRoleResourceSchema(ctx context.Context) schema.Schema {
 							Required:            true,
 							Description:         "Permission id must follow the pattern: {systemPrefix}.{resource}.{action} and contain a-z letters and hyphens where the systemPrefix size is 3, the resource and action parts are between 2 and 16 characters.",
 							MarkdownDescription: "Permission id must follow the pattern: {systemPrefix}.{resource}.{action} and contain a-z letters and hyphens where the systemPrefix size is 3, the resource and action parts are between 2 and 16 characters.",
-							Validators: []validator.String{
-								stringvalidator.RegexMatches(regexp.MustCompile("^[a-z][-a-z]{2}\\.[a-z][-a-z]{1,15}\\.[a-z][-a-z]{1,15}$"), ""),
-							},
 						},
 					},
 					CustomType: PermissionsType{
@@ -67,7 +65,10 @@ func IamCustomRoleResourceSchema(ctx context.Context) schema.Schema {
 						},
 					},
 				},
-				Required:            true,
+				Required: true,
+				Validators: []validator.List{
+					PermissionsValidator(),
+				},
 				Description:         "Only 100 permissions per role is allowed. The only exception is pos permissions, we allow up to 500 of them ",
 				MarkdownDescription: "Only 100 permissions per role is allowed. The only exception is pos permissions, we allow up to 500 of them ",
 			},
@@ -75,15 +76,22 @@ func IamCustomRoleResourceSchema(ctx context.Context) schema.Schema {
 				Optional: true,
 				Computed: true,
 			},
+			"title": schema.StringAttribute{
+				Optional:            true,
+				Description:         "Human-readable title for the custom role.",
+				MarkdownDescription: "Human-readable title for the custom role.",
+			},
 		},
 	}
 }
 
 type IamCustomRoleModel struct {
+	Description types.String `tfsdk:"description"`
 	Id          types.String `tfsdk:"id"`
 	Name        types.String `tfsdk:"name"`
 	Permissions types.List   `tfsdk:"permissions"`
 	TenantId    types.String `tfsdk:"tenant_id"`
+	Title       types.String `tfsdk:"title"`
 }
 
 var _ basetypes.ObjectTypable = PermissionsType{}
@@ -139,12 +147,12 @@ func (t PermissionsType) ValueFromObject(ctx context.Context, in basetypes.Objec
 		return nil, diags
 	}
 
-	attributesVal, ok := attributesAttribute.(basetypes.ObjectValue)
+	attributesVal, ok := attributesAttribute.(basetypes.MapValue)
 
 	if !ok {
 		diags.AddError(
 			"Attribute Wrong Type",
-			fmt.Sprintf(`attributes expected to be basetypes.ObjectValue, was: %T`, attributesAttribute))
+			fmt.Sprintf(`attributes expected to be basetypes.MapValue, was: %T`, attributesAttribute))
 	}
 
 	idAttribute, ok := attributes["id"]
@@ -268,12 +276,12 @@ func NewPermissionsValue(attributeTypes map[string]attr.Type, attributes map[str
 		return NewPermissionsValueUnknown(), diags
 	}
 
-	attributesVal, ok := attributesAttribute.(basetypes.ObjectValue)
+	attributesVal, ok := attributesAttribute.(basetypes.MapValue)
 
 	if !ok {
 		diags.AddError(
 			"Attribute Wrong Type",
-			fmt.Sprintf(`attributes expected to be basetypes.ObjectValue, was: %T`, attributesAttribute))
+			fmt.Sprintf(`attributes expected to be basetypes.MapValue, was: %T`, attributesAttribute))
 	}
 
 	idAttribute, ok := attributes["id"]
@@ -375,7 +383,7 @@ var _ basetypes.ObjectValuable = PermissionsValue{}
 
 type PermissionsValue struct {
 	Alias      basetypes.StringValue `tfsdk:"alias"`
-	Attributes basetypes.ObjectValue `tfsdk:"attributes"`
+	Attributes basetypes.MapValue    `tfsdk:"attributes"`
 	Id         basetypes.StringValue `tfsdk:"id"`
 	state      attr.ValueState
 }
@@ -387,8 +395,8 @@ func (v PermissionsValue) ToTerraformValue(ctx context.Context) (tftypes.Value,
 	var err error
 
 	attrTypes["alias"] = basetypes.StringType{}.TerraformType(ctx)
-	attrTypes["attributes"] = basetypes.ObjectType{
-		AttrTypes: AttributesValue{}.AttributeTypes(ctx),
+	attrTypes["attributes"] = basetypes.MapType{
+		ElemType: types.StringType,
 	}.TerraformType(ctx)
 	attrTypes["id"] = basetypes.StringType{}.TerraformType(ctx)
 
@@ -451,31 +459,31 @@ func (v PermissionsValue) String() string {
 func (v PermissionsValue) ToObjectValue(ctx context.Context) (basetypes.ObjectValue, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
-	var attributes basetypes.ObjectValue
+	var attributes basetypes.MapValue
 
 	if v.Attributes.IsNull() {
-		attributes = types.ObjectNull(
-			AttributesValue{}.AttributeTypes(ctx),
+		attributes = types.MapNull(
+			types.StringType,
 		)
 	}
 
 	if v.Attributes.IsUnknown() {
-		attributes = types.ObjectUnknown(
-			AttributesValue{}.AttributeTypes(ctx),
+		attributes = types.MapUnknown(
+			types.StringType,
 		)
 	}
 
 	if !v.Attributes.IsNull() && !v.Attributes.IsUnknown() {
-		attributes = types.ObjectValueMust(
-			AttributesValue{}.AttributeTypes(ctx),
-			v.Attributes.Attributes(),
+		attributes = types.MapValueMust(
+			types.StringType,
+			v.Attributes.Elements(),
 		)
 	}
 
 	attributeTypes := map[string]attr.Type{
 		"alias": basetypes.StringType{},
-		"attributes": basetypes.ObjectType{
-			AttrTypes: AttributesValue{}.AttributeTypes(ctx),
+		"attributes": basetypes.MapType{
+			ElemType: types.StringType,
 		},
 		"id": basetypes.StringType{},
 	}
@@ -540,8 +548,8 @@ func (v PermissionsValue) Type(ctx context.Context) attr.Type {
 func (v PermissionsValue) AttributeTypes(ctx context.Context) map[string]attr.Type {
 	return map[string]attr.Type{
 		"alias": basetypes.StringType{},
-		"attributes": basetypes.ObjectType{
-			AttrTypes: AttributesValue{}.AttributeTypes(ctx),
+		"attributes": basetypes.MapType{
+			ElemType: types.StringType,
 		},
 		"id": basetypes.StringType{},
 	}
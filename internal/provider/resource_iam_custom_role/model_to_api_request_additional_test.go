@@ -23,7 +23,7 @@ func TestModelToAPIRequest_AliasAndNameOmitted(t *testing.T) {
 	// Permission with alias set
 	pv := PermissionsValue{
 		Alias:      types.StringValue("ali"),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringValue("perm1"),
 		state:      attr.ValueStateKnown,
 	}
@@ -86,12 +86,12 @@ func TestModelToAPIRequest_AttributesStringAndNonStringValues(t *testing.T) {
 
 	permType := PermissionsType{ObjectType: types.ObjectType{AttrTypes: PermissionsValue{}.AttributeTypes(ctx)}}
 
-	// Attributes object is known but empty (AttributesValue has no declared attribute types)
+	// Attributes map is known but empty
 	attrVals := map[string]attr.Value{}
 
 	pv := PermissionsValue{
 		Alias:      types.StringNull(),
-		Attributes: types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), attrVals),
+		Attributes: types.MapValueMust(types.StringType, attrVals),
 		Id:         types.StringValue("perm-1"),
 		state:      attr.ValueStateKnown,
 	}
@@ -104,7 +104,7 @@ func TestModelToAPIRequest_AttributesStringAndNonStringValues(t *testing.T) {
 	req, err := r.modelToAPIRequest(ctx, data)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(req.Permissions))
-	// No attributes should be included because the attributes object is empty
+	// No attributes should be included because the attributes map is empty
 	require.Equal(t, 0, len(req.Permissions[0].Attributes))
 }
 
@@ -117,7 +117,7 @@ func TestModelToAPIRequest_AttributesEmptyAndNull(t *testing.T) {
 	// Attributes null should result in no attributes in request
 	pv := PermissionsValue{
 		Alias:      types.StringNull(),
-		Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)),
+		Attributes: types.MapNull(types.StringType),
 		Id:         types.StringValue("perm-2"),
 		state:      attr.ValueStateKnown,
 	}
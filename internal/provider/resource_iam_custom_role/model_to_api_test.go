@@ -21,7 +21,7 @@ func TestModelToAPIRequest_PermissionsAndNameConversion(t *testing.T) {
 	// First permission: has alias and one string attribute
 	p1 := PermissionsValue{
 		Alias:      types.StringValue("alias1"),
-		Attributes: types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		Id:         types.StringValue("pos.payment.create"),
 		state:      attr.ValueStateKnown,
 	}
@@ -29,7 +29,7 @@ func TestModelToAPIRequest_PermissionsAndNameConversion(t *testing.T) {
 	// Second permission: alias null, attributes with a string value
 	p2 := PermissionsValue{
 		Alias:      types.StringNull(),
-		Attributes: types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		Attributes: types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		Id:         types.StringValue("sys.user.manage"),
 		state:      attr.ValueStateKnown,
 	}
@@ -33,7 +33,7 @@ func TestNewPermissionsValue_AdditiveCases(t *testing.T) {
 	}
 
 	// extra attribute present
-	extra := map[string]attr.Value{"alias": types.StringValue("a"), "id": types.StringValue("x"), "attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "unexpected": types.StringValue("y")}
+	extra := map[string]attr.Value{"alias": types.StringValue("a"), "id": types.StringValue("x"), "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}), "unexpected": types.StringValue("y")}
 	vExtra, diagExtra := NewPermissionsValue(attrTypes, extra)
 	if !diagExtra.HasError() {
 		t.Fatalf("expected diagnostics for extra attribute")
@@ -43,7 +43,7 @@ func TestNewPermissionsValue_AdditiveCases(t *testing.T) {
 	}
 
 	// nominal success path
-	okAttrs := map[string]attr.Value{"alias": types.StringValue("a"), "id": types.StringValue("pos.payment.create"), "attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})}
+	okAttrs := map[string]attr.Value{"alias": types.StringValue("a"), "id": types.StringValue("pos.payment.create"), "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{})}
 	vOk, diagOk := NewPermissionsValue(attrTypes, okAttrs)
 	if diagOk.HasError() {
 		t.Fatalf("unexpected diagnostics on success path: %v", diagOk)
@@ -23,7 +23,7 @@ func TestNewPermissionsValue_Exhaustive(t *testing.T) {
 	}
 
 	// 2) Extra attribute -> expect diags
-	attrs2 := map[string]attr.Value{"alias": types.StringValue("a"), "id": types.StringValue("x"), "attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "extra": types.StringValue("x")}
+	attrs2 := map[string]attr.Value{"alias": types.StringValue("a"), "id": types.StringValue("x"), "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}), "extra": types.StringValue("x")}
 	v2, diags2 := NewPermissionsValue(attrTypes, attrs2)
 	if !diags2.HasError() {
 		t.Fatalf("expected diagnostics error for extra attribute, got none")
@@ -33,7 +33,7 @@ func TestNewPermissionsValue_Exhaustive(t *testing.T) {
 	}
 
 	// 3) Wrong attribute type -> alias provided as object
-	attrs3 := map[string]attr.Value{"alias": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "id": types.StringValue("x"), "attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})}
+	attrs3 := map[string]attr.Value{"alias": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "id": types.StringValue("x"), "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{})}
 	v3, diags3 := NewPermissionsValue(attrTypes, attrs3)
 	if !diags3.HasError() {
 		t.Fatalf("expected diagnostics error for wrong attribute type, got none")
@@ -43,7 +43,7 @@ func TestNewPermissionsValue_Exhaustive(t *testing.T) {
 	}
 
 	// 4) Success
-	attrs4 := map[string]attr.Value{"alias": types.StringValue("a"), "id": types.StringValue("pos.payment.create"), "attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})}
+	attrs4 := map[string]attr.Value{"alias": types.StringValue("a"), "id": types.StringValue("pos.payment.create"), "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{})}
 	v4, diags4 := NewPermissionsValue(attrTypes, attrs4)
 	if diags4.HasError() {
 		t.Fatalf("unexpected diagnostics on success path: %v", diags4)
@@ -0,0 +1,60 @@
+package resource_iam_custom_role
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// sortPermissions sorts permissions by id for the API request. The sort is
+// stable, so repeated ids keep their relative order and attributes.
+func sortPermissions(permissions []Permission) {
+	sort.SliceStable(permissions, func(i, j int) bool {
+		return permissions[i].ID < permissions[j].ID
+	})
+}
+
+// alignPermissions orders API permissions like the prior permissions, so a
+// response in another order than configured does not produce a plan.
+// Permissions without a prior counterpart, such as on import, follow sorted
+// by id.
+func alignPermissions(prior []PermissionsValue, permissions []Permission) []Permission {
+	remaining := make(map[string][]Permission, len(permissions))
+	for _, perm := range permissions {
+		remaining[perm.ID] = append(remaining[perm.ID], perm)
+	}
+
+	aligned := make([]Permission, 0, len(permissions))
+	for _, p := range prior {
+		id := p.Id.ValueString()
+		if queue := remaining[id]; len(queue) > 0 {
+			aligned = append(aligned, queue[0])
+			remaining[id] = queue[1:]
+		}
+	}
+
+	var rest []Permission
+	for _, perm := range permissions {
+		if queue := remaining[perm.ID]; len(queue) > 0 {
+			rest = append(rest, queue[0])
+			remaining[perm.ID] = queue[1:]
+		}
+	}
+	sortPermissions(rest)
+	return append(aligned, rest...)
+}
+
+// priorPermissions returns the known permissions of a model, nil when the
+// list is null or unknown
+func priorPermissions(list types.List) []PermissionsValue {
+	if list.IsNull() || list.IsUnknown() {
+		return nil
+	}
+	var permissions []PermissionsValue
+	for _, element := range list.Elements() {
+		if perm, ok := element.(PermissionsValue); ok && !perm.IsNull() && !perm.IsUnknown() {
+			permissions = append(permissions, perm)
+		}
+	}
+	return permissions
+}
@@ -0,0 +1,172 @@
+package resource_iam_custom_role
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// orderedPermissions builds a permissions list with the given ids, each with an
+// "id" attribute repeating the id so attributes can be followed through sorting
+func orderedPermissions(t *testing.T, ids ...string) types.List {
+	t.Helper()
+	ctx := context.Background()
+	values := make([]PermissionsValue, len(ids))
+	for i, id := range ids {
+		attrs, diags := types.MapValue(types.StringType, map[string]attr.Value{"id": types.StringValue(id)})
+		require.False(t, diags.HasError())
+		values[i] = PermissionsValue{
+			Id:         types.StringValue(id),
+			Alias:      types.StringNull(),
+			Attributes: attrs,
+			state:      attr.ValueStateKnown,
+		}
+	}
+	permType := PermissionsType{ObjectType: types.ObjectType{AttrTypes: PermissionsValue{}.AttributeTypes(ctx)}}
+	list, diags := types.ListValueFrom(ctx, permType, values)
+	require.False(t, diags.HasError())
+	return list
+}
+
+func apiPermissions(ids ...string) []Permission {
+	permissions := make([]Permission, len(ids))
+	for i, id := range ids {
+		permissions[i] = Permission{ID: id, Attributes: map[string]interface{}{"id": id}}
+	}
+	return permissions
+}
+
+func permissionIDs(permissions []Permission) []string {
+	ids := make([]string, len(permissions))
+	for i, p := range permissions {
+		ids[i] = p.ID
+	}
+	return ids
+}
+
+func TestModelToAPIRequest_SortsPermissions(t *testing.T) {
+	ctx := context.Background()
+	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
+	data := IamCustomRoleModel{
+		Id:          types.StringValue("c1"),
+		Permissions: orderedPermissions(t, "pos.sales.read", "iam.groups.list", "pos.items.read"),
+	}
+
+	req, err := r.modelToAPIRequest(ctx, data)
+	require.NoError(t, err)
+	require.Equal(t, []string{"iam.groups.list", "pos.items.read", "pos.sales.read"}, permissionIDs(req.Permissions))
+	for _, p := range req.Permissions {
+		require.Equal(t, p.ID, p.Attributes["id"], "attributes must move with their permission")
+	}
+}
+
+func TestAPIResponseToModel_ReorderedPermissions(t *testing.T) {
+	ctx := context.Background()
+	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
+	configured := orderedPermissions(t, "pos.sales.read", "iam.groups.list", "pos.items.read")
+
+	tests := []struct {
+		name  string
+		prior types.List
+		api   []string
+		want  types.List
+	}{
+		{
+			name:  "reordered by the API",
+			prior: configured,
+			api:   []string{"iam.groups.list", "pos.items.read", "pos.sales.read"},
+			want:  configured,
+		},
+		{
+			name:  "added remotely",
+			prior: configured,
+			api:   []string{"pos.zones.read", "iam.groups.list", "pos.items.read", "iam.roles.list", "pos.sales.read"},
+			want:  orderedPermissions(t, "pos.sales.read", "iam.groups.list", "pos.items.read", "iam.roles.list", "pos.zones.read"),
+		},
+		{
+			name:  "removed remotely",
+			prior: configured,
+			api:   []string{"pos.items.read", "pos.sales.read"},
+			want:  orderedPermissions(t, "pos.sales.read", "pos.items.read"),
+		},
+		{
+			name:  "import sorts by id",
+			prior: types.ListNull(PermissionsType{ObjectType: types.ObjectType{AttrTypes: PermissionsValue{}.AttributeTypes(ctx)}}),
+			api:   []string{"pos.sales.read", "iam.groups.list"},
+			want:  orderedPermissions(t, "iam.groups.list", "pos.sales.read"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := IamCustomRoleModel{Permissions: tt.prior}
+			apiResp := &CustomRoleResponse{ID: "c1", Name: "C1", TenantID: "tid", Permissions: apiPermissions(tt.api...)}
+
+			require.NoError(t, r.apiResponseToModel(ctx, apiResp, &data))
+			require.True(t, data.Permissions.Equal(tt.want), "permissions = %s, want %s", data.Permissions, tt.want)
+		})
+	}
+}
+
+func TestResource_ReadReorderedPermissions_NoPlannedChange(t *testing.T) {
+	ctx := context.Background()
+	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
+	r.baseURL = "http://api"
+	r.tenantID = "tid"
+
+	// The API stores permissions and always returns them reversed
+	var stored []Permission
+	r.client = &http.Client{Transport: &mockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		if req.Method == "POST" {
+			var body CustomRoleRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			stored = body.Permissions
+			status = http.StatusCreated
+		}
+		reversed := make([]Permission, len(stored))
+		for i, p := range stored {
+			reversed[len(stored)-1-i] = p
+		}
+		b, _ := json.Marshal(CustomRoleResponse{ID: "c1", Name: "C1", TenantID: "tid", Permissions: reversed})
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBuffer(b))}, nil
+	}}}
+
+	schema := IamCustomRoleResourceSchema(ctx)
+	plan := IamCustomRoleModel{
+		Id:          types.StringValue("c1"),
+		Name:        types.StringValue("C1"),
+		TenantId:    types.StringValue("tid"),
+		Permissions: orderedPermissions(t, "pos.sales.read", "iam.groups.list", "pos.items.read"),
+	}
+
+	var creq resource.CreateRequest
+	creq.Plan.Schema = schema
+	require.False(t, creq.Plan.Set(ctx, plan).HasError())
+	var cresp resource.CreateResponse
+	cresp.State.Schema = schema
+	r.Create(ctx, creq, &cresp)
+	require.False(t, cresp.Diagnostics.HasError(), "create diagnostics: %v", cresp.Diagnostics.Errors())
+	require.Equal(t, []string{"iam.groups.list", "pos.items.read", "pos.sales.read"}, permissionIDs(stored))
+
+	var created IamCustomRoleModel
+	require.False(t, cresp.State.Get(ctx, &created).HasError())
+	require.True(t, created.Permissions.Equal(plan.Permissions), "state after create differs from plan")
+
+	rreq := resource.ReadRequest{State: cresp.State}
+	rresp := resource.ReadResponse{State: cresp.State}
+	r.Read(ctx, rreq, &rresp)
+	require.False(t, rresp.Diagnostics.HasError(), "read diagnostics: %v", rresp.Diagnostics.Errors())
+
+	var refreshed IamCustomRoleModel
+	require.False(t, rresp.State.Get(ctx, &refreshed).HasError())
+	require.True(t, refreshed.Permissions.Equal(plan.Permissions), "refreshed permissions %s would plan a change from %s", refreshed.Permissions, plan.Permissions)
+}
@@ -0,0 +1,94 @@
+package resource_iam_custom_role
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+)
+
+const (
+	// MaxPOSPermissions is the number of pos.* permissions allowed per role
+	MaxPOSPermissions = iam.MaxPOSPermissions
+	// MaxGeneralPermissions is the number of non-POS permissions allowed per role
+	MaxGeneralPermissions = iam.MaxGeneralPermissions
+)
+
+// permissionIDRegexp is the permission id format enforced by the IAM API
+var permissionIDRegexp = iam.PermissionIDRegexp
+
+var _ validator.List = permissionsValidator{}
+
+// permissionsValidator checks permission ids and per-role limits at plan time
+// so violations are reported before the API round trip.
+type permissionsValidator struct{}
+
+// PermissionsValidator returns a validator for the custom role permissions list.
+// Each malformed id is reported on its own element, and the POS and general
+// permission limits are reported on the list.
+func PermissionsValidator() validator.List {
+	return permissionsValidator{}
+}
+
+func (v permissionsValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("permission ids must match %s; at most %d pos and %d general permissions are allowed per role",
+		permissionIDRegexp.String(), MaxPOSPermissions, MaxGeneralPermissions)
+}
+
+func (v permissionsValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("permission ids must match `%s`; at most %d pos and %d general permissions are allowed per role",
+		permissionIDRegexp.String(), MaxPOSPermissions, MaxGeneralPermissions)
+}
+
+func (v permissionsValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var ids []string
+	var idPaths []path.Path
+	for i, elem := range req.ConfigValue.Elements() {
+		id, ok := permissionElementID(ctx, elem)
+		if !ok {
+			continue
+		}
+		ids = append(ids, id)
+		idPaths = append(idPaths, req.Path.AtListIndex(i).AtName("id"))
+	}
+
+	for _, err := range iam.ValidatePermissionIDs(ids) {
+		var idErr *iam.InvalidPermissionIDError
+		var limitErr *iam.PermissionLimitError
+		switch {
+		case errors.As(err, &idErr):
+			resp.Diagnostics.AddAttributeError(idPaths[idErr.Index], "Invalid Permission ID", err.Error())
+		case errors.As(err, &limitErr) && limitErr.POS:
+			resp.Diagnostics.AddAttributeError(req.Path, "Too Many POS Permissions", err.Error())
+		default:
+			resp.Diagnostics.AddAttributeError(req.Path, "Too Many Permissions", err.Error())
+		}
+	}
+}
+
+// permissionElementID extracts a known id from a permissions list element.
+func permissionElementID(ctx context.Context, elem interface{}) (string, bool) {
+	obj, ok := elem.(basetypes.ObjectValuable)
+	if !ok {
+		return "", false
+	}
+	objValue, diags := obj.ToObjectValue(ctx)
+	if diags.HasError() || objValue.IsNull() || objValue.IsUnknown() {
+		return "", false
+	}
+	id, ok := objValue.Attributes()["id"].(types.String)
+	if !ok || id.IsNull() || id.IsUnknown() {
+		return "", false
+	}
+	return id.ValueString(), true
+}
@@ -0,0 +1,86 @@
+package resource_iam_custom_role
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func permissionsList(t *testing.T, ids ...string) types.List {
+	t.Helper()
+	ctx := context.Background()
+	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
+
+	elems := make([]attr.Value, len(ids))
+	for i, id := range ids {
+		elems[i] = NewPermissionsValueMust(attrTypes, map[string]attr.Value{
+			"id":         types.StringValue(id),
+			"alias":      types.StringNull(),
+			"attributes": types.MapNull(types.StringType),
+		})
+	}
+
+	list, diags := types.ListValue(PermissionsType{ObjectType: types.ObjectType{AttrTypes: attrTypes}}, elems)
+	require.False(t, diags.HasError(), "building list: %v", diags)
+	return list
+}
+
+func validatePermissions(t *testing.T, list types.List) *validator.ListResponse {
+	t.Helper()
+	req := validator.ListRequest{
+		Path:        path.Root("permissions"),
+		ConfigValue: list,
+	}
+	resp := &validator.ListResponse{}
+	PermissionsValidator().ValidateList(context.Background(), req, resp)
+	return resp
+}
+
+func TestPermissionsValidator_ValidIDs(t *testing.T) {
+	resp := validatePermissions(t, permissionsList(t, "pos.payment.create", "iam.group-member.read", "ccc.config.list"))
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+}
+
+func TestPermissionsValidator_MalformedID(t *testing.T) {
+	resp := validatePermissions(t, permissionsList(t, "pos.payment.create", "POS.payment.create", "iam.groups"))
+	require.Equal(t, 2, resp.Diagnostics.ErrorsCount(), "diagnostics: %v", resp.Diagnostics)
+
+	for i, d := range resp.Diagnostics.Errors() {
+		withPath, ok := d.(interface{ Path() path.Path })
+		require.True(t, ok, "diagnostic should carry an attribute path")
+		assert.Equal(t, path.Root("permissions").AtListIndex(i+1).AtName("id"), withPath.Path())
+		assert.Equal(t, "Invalid Permission ID", d.Summary())
+	}
+}
+
+func TestPermissionsValidator_GeneralLimitExceeded(t *testing.T) {
+	ids := make([]string, 0, MaxGeneralPermissions+1)
+	for i := 0; i <= MaxGeneralPermissions; i++ {
+		ids = append(ids, fmt.Sprintf("iam.res%s.read", string(rune('a'+i/26))+string(rune('a'+i%26))))
+	}
+
+	resp := validatePermissions(t, permissionsList(t, ids...))
+	require.Equal(t, 1, resp.Diagnostics.ErrorsCount(), "diagnostics: %v", resp.Diagnostics)
+	assert.Equal(t, "Too Many Permissions", resp.Diagnostics.Errors()[0].Summary())
+
+	// POS permissions have their own, higher limit
+	posIDs := make([]string, len(ids))
+	for i, id := range ids {
+		posIDs[i] = "pos" + id[3:]
+	}
+	resp = validatePermissions(t, permissionsList(t, posIDs...))
+	assert.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+}
+
+func TestPermissionsValidator_NullAndUnknown(t *testing.T) {
+	elemType := PermissionsType{ObjectType: types.ObjectType{AttrTypes: PermissionsValue{}.AttributeTypes(context.Background())}}
+	assert.False(t, validatePermissions(t, types.ListNull(elemType)).Diagnostics.HasError())
+	assert.False(t, validatePermissions(t, types.ListUnknown(elemType)).Diagnostics.HasError())
+}
@@ -1,7 +1,6 @@
 package resource_iam_custom_role
 
 import (
-	"context"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -9,11 +8,9 @@ import (
 )
 
 func TestPermissionsValue_Equal_Permutations(t *testing.T) {
-	ctx := context.Background()
-
 	// Known values equal
-	a := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)), Id: types.StringValue("x")}
-	b := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)), Id: types.StringValue("x")}
+	a := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.MapNull(types.StringType), Id: types.StringValue("x")}
+	b := PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.MapNull(types.StringType), Id: types.StringValue("x")}
 	if !a.Equal(b) {
 		t.Fatalf("expected equal for identical known values")
 	}
@@ -25,14 +22,14 @@ func TestPermissionsValue_Equal_Permutations(t *testing.T) {
 	}
 
 	// Attributes difference
-	objAttrs, _ := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{"k": types.StringValue("v")})
+	objAttrs, _ := types.MapValue(types.StringType, map[string]attr.Value{"k": types.StringValue("v")})
 	b = PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: objAttrs, Id: types.StringValue("x")}
 	if a.Equal(b) {
 		t.Fatalf("expected not equal when attributes differ")
 	}
 
 	// Id difference
-	b = PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.ObjectNull(AttributesValue{}.AttributeTypes(ctx)), Id: types.StringValue("other")}
+	b = PermissionsValue{state: attr.ValueStateKnown, Alias: types.StringValue("a"), Attributes: types.MapNull(types.StringType), Id: types.StringValue("other")}
 	if a.Equal(b) {
 		t.Fatalf("expected not equal when id differs")
 	}
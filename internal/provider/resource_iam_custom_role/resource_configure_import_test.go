@@ -3,9 +3,11 @@ package resource_iam_custom_role
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
 func TestConfigure_WithAPIClientPointer(t *testing.T) {
@@ -89,3 +91,68 @@ func TestImportState_MissingID(t *testing.T) {
 		t.Fatalf("expected diagnostics when import ID is missing")
 	}
 }
+
+func importCustomRole(t *testing.T, providerTenant, importID string) (IamCustomRoleModel, resource.ImportStateResponse) {
+	t.Helper()
+	ctx := context.Background()
+	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
+	r.tenantID = providerTenant
+
+	var resp resource.ImportStateResponse
+	resp.State.Schema = IamCustomRoleResourceSchema(ctx)
+	resp.State.Raw = tftypes.NewValue(resp.State.Schema.Type().TerraformType(ctx), nil)
+	r.ImportState(ctx, resource.ImportStateRequest{ID: importID}, &resp)
+
+	var out IamCustomRoleModel
+	if !resp.Diagnostics.HasError() {
+		if diags := resp.State.Get(ctx, &out); diags.HasError() {
+			t.Fatalf("reading imported state: %v", diags)
+		}
+	}
+	return out, resp
+}
+
+func TestImportState_BareID(t *testing.T) {
+	out, resp := importCustomRole(t, "tid", "cashier")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if out.Id.ValueString() != "cashier" {
+		t.Fatalf("expected id cashier, got %q", out.Id.ValueString())
+	}
+	if out.TenantId.ValueString() != "tid" {
+		t.Fatalf("expected tenant_id from the provider, got %q", out.TenantId.ValueString())
+	}
+}
+
+func TestImportState_CompositeID(t *testing.T) {
+	out, resp := importCustomRole(t, "tid", "tid/cashier")
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	if out.Id.ValueString() != "cashier" {
+		t.Fatalf("expected id cashier, got %q", out.Id.ValueString())
+	}
+	if out.TenantId.ValueString() != "tid" {
+		t.Fatalf("expected tenant_id tid, got %q", out.TenantId.ValueString())
+	}
+}
+
+func TestImportState_TenantMismatch(t *testing.T) {
+	_, resp := importCustomRole(t, "tid", "other/cashier")
+	if !resp.Diagnostics.HasError() {
+		t.Fatalf("expected diagnostics when the import tenant differs from the provider tenant")
+	}
+	want := `import ID tenant "other" does not match the provider tenant "tid"`
+	if detail := resp.Diagnostics[0].Detail(); !strings.Contains(detail, want) {
+		t.Fatalf("expected detail containing %q, got %q", want, detail)
+	}
+}
+
+func TestImportState_MalformedCompositeID(t *testing.T) {
+	for _, id := range []string{"/cashier", "tid/", "tid/cashier/extra"} {
+		if _, resp := importCustomRole(t, "tid", id); !resp.Diagnostics.HasError() {
+			t.Errorf("expected diagnostics for import ID %q", id)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package resource_iam_custom_role
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ resource.ResourceWithUpgradeState = &IamCustomRoleResource{}
+
+// iamCustomRoleModelV0 is the version 0 state, whose permission attributes
+// were an object without attributes
+type iamCustomRoleModelV0 struct {
+	Description types.String `tfsdk:"description"`
+	Id          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Permissions types.List   `tfsdk:"permissions"`
+	TenantId    types.String `tfsdk:"tenant_id"`
+	Title       types.String `tfsdk:"title"`
+}
+
+// UpgradeState rewrites version 0 permission attributes as string maps: a
+// null object stays null and an empty object becomes an empty map.
+func (r *IamCustomRoleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := iamCustomRoleResourceSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: upgradeStateV0,
+		},
+	}
+}
+
+// iamCustomRoleResourceSchemaV0 is the version 0 schema, kept as it was so
+// later schema changes do not affect how version 0 state is decoded.
+func iamCustomRoleResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"id": schema.StringAttribute{
+				Required: true,
+			},
+			"name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"permissions": schema.ListNestedAttribute{
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"alias": schema.StringAttribute{
+							Computed: true,
+						},
+						"attributes": schema.SingleNestedAttribute{
+							Attributes: map[string]schema.Attribute{},
+							Optional:   true,
+							Computed:   true,
+						},
+						"id": schema.StringAttribute{
+							Required: true,
+						},
+					},
+				},
+				Required: true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"title": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+func upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var prior iamCustomRoleModelV0
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributeTypes := PermissionsValue{}.AttributeTypes(ctx)
+	permissionType := PermissionsType{ObjectType: types.ObjectType{AttrTypes: attributeTypes}}
+
+	permissions := types.ListNull(permissionType)
+	if !prior.Permissions.IsNull() {
+		elements := make([]attr.Value, 0, len(prior.Permissions.Elements()))
+		for _, element := range prior.Permissions.Elements() {
+			obj, ok := element.(types.Object)
+			if !ok {
+				continue
+			}
+			values := obj.Attributes()
+
+			attributes := types.MapNull(types.StringType)
+			if v0, ok := values["attributes"].(types.Object); ok && !v0.IsNull() && !v0.IsUnknown() {
+				attributes = types.MapValueMust(types.StringType, map[string]attr.Value{})
+			}
+
+			permission, diags := NewPermissionsValue(attributeTypes, map[string]attr.Value{
+				"alias":      values["alias"],
+				"attributes": attributes,
+				"id":         values["id"],
+			})
+			resp.Diagnostics.Append(diags...)
+			elements = append(elements, permission)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		list, diags := types.ListValue(permissionType, elements)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		permissions = list
+	}
+
+	upgraded := IamCustomRoleModel{
+		Description: prior.Description,
+		Id:          prior.Id,
+		Name:        prior.Name,
+		Permissions: permissions,
+		TenantId:    prior.TenantId,
+		Title:       prior.Title,
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &upgraded)...)
+}
@@ -0,0 +1,95 @@
+package resource_iam_custom_role
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomRole_TitleDescriptionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
+	r.baseURL = "http://api"
+	r.tenantID = "tid"
+
+	// The mock API stores the posted role and returns it on read
+	var stored CustomRoleResponse
+	r.client = &http.Client{Transport: &mockRoundTripper{RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+		status := http.StatusOK
+		if req.Method == "POST" || req.Method == "PUT" {
+			var body CustomRoleRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&body))
+			stored = CustomRoleResponse{ID: "c1", Name: body.Name, Title: body.Title, Description: body.Description, TenantID: "tid", Permissions: body.Permissions}
+			if req.Method == "POST" {
+				status = http.StatusCreated
+			}
+		}
+		b, _ := json.Marshal(stored)
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewBuffer(b))}, nil
+	}}}
+
+	permType := PermissionsType{ObjectType: types.ObjectType{AttrTypes: PermissionsValue{}.AttributeTypes(ctx)}}
+	list, _ := types.ListValueFrom(ctx, permType, []PermissionsValue{})
+	data := IamCustomRoleModel{
+		Id:          types.StringValue("c1"),
+		Name:        types.StringValue("Cashier"),
+		Title:       types.StringValue("Store cashier"),
+		Description: types.StringValue("Can open and close the till"),
+		Permissions: list,
+	}
+
+	apiReq, err := r.modelToAPIRequest(ctx, data)
+	require.NoError(t, err)
+	require.Equal(t, "Store cashier", apiReq.Title)
+	require.Equal(t, "Can open and close the till", apiReq.Description)
+
+	_, err = r.createCustomRole(ctx, apiReq)
+	require.NoError(t, err)
+
+	readResp, err := r.readCustomRole(ctx, "c1")
+	require.NoError(t, err)
+
+	var state IamCustomRoleModel
+	require.NoError(t, r.apiResponseToModel(ctx, readResp, &state))
+	require.Equal(t, types.StringValue("Store cashier"), state.Title)
+	require.Equal(t, types.StringValue("Can open and close the till"), state.Description)
+}
+
+func TestCustomRole_OmittedTitleDescriptionNoDiff(t *testing.T) {
+	ctx := context.Background()
+	r := NewIamCustomRoleResource().(*IamCustomRoleResource)
+
+	// The server returns empty strings for fields that were never set
+	var apiResp CustomRoleResponse
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"c1","name":"Cashier","title":"","description":"","permissions":[]}`), &apiResp))
+
+	tests := []struct {
+		name  string
+		prior types.String
+		want  types.String
+	}{
+		{name: "omitted", prior: types.StringNull(), want: types.StringNull()},
+		{name: "configured empty", prior: types.StringValue(""), want: types.StringValue("")},
+		{name: "removed remotely", prior: types.StringValue("Old"), want: types.StringNull()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := IamCustomRoleModel{Title: tt.prior, Description: tt.prior}
+			require.NoError(t, r.apiResponseToModel(ctx, &apiResp, &data))
+			require.Equal(t, tt.want, data.Title)
+			require.Equal(t, tt.want, data.Description)
+		})
+	}
+
+	// Unset fields are left out of the request body
+	body, err := json.Marshal(&CustomRoleRequest{ID: "c1"})
+	require.NoError(t, err)
+	require.NotContains(t, string(body), "title")
+	require.NotContains(t, string(body), "description")
+}
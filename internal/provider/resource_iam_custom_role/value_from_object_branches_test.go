@@ -13,7 +13,7 @@ func TestPermissionsType_ValueFromObject_MissingAttributesIndividually(t *testin
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
 
 	// Missing alias only
-	attrs1 := map[string]attr.Value{"attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "id": types.StringValue("x")}
+	attrs1 := map[string]attr.Value{"attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}), "id": types.StringValue("x")}
 	obj1, _ := types.ObjectValue(attrTypes, attrs1)
 	v1, d1 := PermissionsType{}.ValueFromObject(ctx, obj1)
 	if !d1.HasError() || v1 != nil {
@@ -29,7 +29,7 @@ func TestPermissionsType_ValueFromObject_MissingAttributesIndividually(t *testin
 	}
 
 	// Missing id only
-	attrs3 := map[string]attr.Value{"alias": types.StringValue("a"), "attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})}
+	attrs3 := map[string]attr.Value{"alias": types.StringValue("a"), "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{})}
 	obj3, _ := types.ObjectValue(attrTypes, attrs3)
 	v3, d3 := PermissionsType{}.ValueFromObject(ctx, obj3)
 	if !d3.HasError() || v3 != nil {
@@ -42,7 +42,7 @@ func TestPermissionsType_ValueFromObject_WrongTypeIndividually(t *testing.T) {
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
 
 	// alias wrong type
-	attrs1 := map[string]attr.Value{"alias": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "id": types.StringValue("x")}
+	attrs1 := map[string]attr.Value{"alias": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}), "id": types.StringValue("x")}
 	obj1, _ := types.ObjectValue(attrTypes, attrs1)
 	v1, d1 := PermissionsType{}.ValueFromObject(ctx, obj1)
 	if !d1.HasError() || v1 != nil {
@@ -58,7 +58,7 @@ func TestPermissionsType_ValueFromObject_WrongTypeIndividually(t *testing.T) {
 	}
 
 	// id wrong type
-	attrs3 := map[string]attr.Value{"alias": types.StringValue("a"), "attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}), "id": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})}
+	attrs3 := map[string]attr.Value{"alias": types.StringValue("a"), "attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}), "id": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})}
 	obj3, _ := types.ObjectValue(attrTypes, attrs3)
 	v3, d3 := PermissionsType{}.ValueFromObject(ctx, obj3)
 	if !d3.HasError() || v3 != nil {
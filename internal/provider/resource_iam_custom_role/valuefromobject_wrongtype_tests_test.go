@@ -19,7 +19,7 @@ func TestPermissions_ValueFromObject_WrongTypes(t *testing.T) {
 	wrongAliasObj, _ := types.ObjectValue(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
 	obj, _ := types.ObjectValue(attrTypes, map[string]attr.Value{
 		"alias":      wrongAliasObj,
-		"attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		"attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		"id":         types.StringValue("id1"),
 	})
 
@@ -38,7 +38,7 @@ func TestPermissions_ValueFromObject_WrongTypes(t *testing.T) {
 	// id wrong type
 	obj3, _ := types.ObjectValue(attrTypes, map[string]attr.Value{
 		"alias":      types.StringValue("a"),
-		"attributes": types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
+		"attributes": types.MapValueMust(types.StringType, map[string]attr.Value{}),
 		"id":         types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{}),
 	})
 	_, diags3 := PermissionsType{}.ValueFromObject(ctx, obj3)
@@ -15,7 +15,7 @@ func TestPermissions_ValueFromTerraform_RoundTripAndNullUnknown(t *testing.T) {
 
 	// Build a known PermissionsValue with empty attributes
 	attrTypes := PermissionsValue{}.AttributeTypes(ctx)
-	attributesObj := types.ObjectValueMust(AttributesValue{}.AttributeTypes(ctx), map[string]attr.Value{})
+	attributesObj := types.MapValueMust(types.StringType, map[string]attr.Value{})
 	_ = NewPermissionsValueMust(attrTypes, map[string]attr.Value{
 		"alias":      types.StringValue("alias"),
 		"attributes": attributesObj,
@@ -27,7 +27,7 @@ func TestPermissions_ValueFromTerraform_RoundTripAndNullUnknown(t *testing.T) {
 	tfType := permType.TerraformType(ctx)
 	vals := map[string]tftypes.Value{
 		"alias":      tftypes.NewValue(tftypes.String, "alias"),
-		"attributes": tftypes.NewValue(AttributesType{ObjectType: types.ObjectType{AttrTypes: AttributesValue{}.AttributeTypes(ctx)}}.TerraformType(ctx), map[string]tftypes.Value{}),
+		"attributes": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{}),
 		"id":         tftypes.NewValue(tftypes.String, "pid"),
 	}
 	tfVal := tftypes.NewValue(tfType, vals)
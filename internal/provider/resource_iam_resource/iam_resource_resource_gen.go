@@ -14,14 +14,17 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/jsonschema"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &IAMResourceResource{}
 var _ resource.ResourceWithImportState = &IAMResourceResource{}
+var _ resource.ResourceWithModifyPlan = &IAMResourceResource{}
 
 func NewIAMResourceResource() resource.Resource {
 	return &IAMResourceResource{}
@@ -29,15 +32,19 @@ func NewIAMResourceResource() resource.Resource {
 
 // IAMResourceResource defines the resource implementation.
 type IAMResourceResource struct {
+	client  *client.Client
 	service *iam.Service
+	// propsSchemas are the compiled provider props_schemas by resource type
+	propsSchemas map[string]*jsonschema.Schema
 }
 
 // IAMResourceResourceModel describes the resource data model.
 type IAMResourceResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	Name     types.String `tfsdk:"name"`
-	Props    types.String `tfsdk:"props"`
-	TenantID types.String `tfsdk:"tenant_id"`
+	ID          types.String  `tfsdk:"id"`
+	Name        types.String  `tfsdk:"name"`
+	Props       types.String  `tfsdk:"props"`
+	PropsObject types.Dynamic `tfsdk:"props_object"`
+	TenantID    types.String  `tfsdk:"tenant_id"`
 }
 
 func (r *IAMResourceResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -76,6 +83,10 @@ func (r *IAMResourceResource) Schema(ctx context.Context, req resource.SchemaReq
 					&jsonValidator{},
 				},
 			},
+			"props_object": schema.DynamicAttribute{
+				MarkdownDescription: "Flexible properties given as an object instead of a JSON string, e.g. `{ region = \"north\" }`. Cannot be combined with `props`.",
+				Optional:            true,
+			},
 			"tenant_id": schema.StringAttribute{
 				MarkdownDescription: "Tenant identifier, inherited from provider configuration",
 				Computed:            true,
@@ -90,21 +101,25 @@ func (r *IAMResourceResource) Configure(ctx context.Context, req resource.Config
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.service = iam.NewService(client, client.TenantID())
+	r.client = providerData.Client
+	r.service = providerData.Service
+	r.propsSchemas = compilePropsSchemas(providerData.Options.PropsSchemas, &resp.Diagnostics)
 }
 
 func (r *IAMResourceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data IAMResourceResourceModel
 
 	// Read Terraform plan data into the model
@@ -133,15 +148,34 @@ func (r *IAMResourceResource) Create(ctx context.Context, req resource.CreateReq
 			return
 		}
 
-		// Parse JSON for API call
+		// Send props as canonical JSON so integers are not coerced to floats
 		if propsStr != "" {
-			if err := json.Unmarshal([]byte(propsStr), &propsData); err != nil {
+			canonical, err := iam.CanonicalizeProps(propsStr)
+			if err != nil {
 				resp.Diagnostics.AddError(
 					"Props JSON Parse Error",
 					fmt.Sprintf("Failed to parse props JSON: %s", err.Error()),
 				)
 				return
 			}
+			if canonical != "" {
+				propsData = json.RawMessage(canonical)
+			}
+		}
+	}
+
+	// props_object is sent the same way; ConfigValidators rejects setting both
+	if propsData == nil && !data.PropsObject.IsNull() {
+		canonical, err := propsObjectJSON(ctx, data.PropsObject)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Props Object",
+				fmt.Sprintf("Failed to encode props_object: %s", err.Error()),
+			)
+			return
+		}
+		if canonical != "" {
+			propsData = json.RawMessage(canonical)
 		}
 	}
 
@@ -164,19 +198,14 @@ func (r *IAMResourceResource) Create(ctx context.Context, req resource.CreateReq
 	data.Name = types.StringValue(createdResource.Name)
 	data.TenantID = types.StringValue(r.service.TenantID())
 
-	// Handle props response
-	if createdResource.Props != nil {
-		propsJson, err := json.Marshal(createdResource.Props)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Props Serialization Error",
-				fmt.Sprintf("Failed to serialize props: %s", err.Error()),
-			)
-			return
-		}
-		data.Props = types.StringValue(string(propsJson))
-	} else {
-		data.Props = types.StringNull()
+	// Handle props response, keeping the planned props if only key order or
+	// formatting differ
+	if _, err := setPropsState(ctx, &data, createdResource.Props); err != nil {
+		resp.Diagnostics.AddError(
+			"Props Serialization Error",
+			fmt.Sprintf("Failed to serialize props: %s", err.Error()),
+		)
+		return
 	}
 
 	// Save data into Terraform state
@@ -184,6 +213,8 @@ func (r *IAMResourceResource) Create(ctx context.Context, req resource.CreateReq
 }
 
 func (r *IAMResourceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data IAMResourceResourceModel
 
 	// Read Terraform prior state data into the model
@@ -222,19 +253,20 @@ func (r *IAMResourceResource) Read(ctx context.Context, req resource.ReadRequest
 	data.Name = types.StringValue(resource.Name)
 	data.TenantID = types.StringValue(r.service.TenantID())
 
-	// Handle props response
-	if resource.Props != nil {
-		propsJson, err := json.Marshal(resource.Props)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Props Serialization Error",
-				fmt.Sprintf("Failed to serialize props: %s", err.Error()),
-			)
-			return
-		}
-		data.Props = types.StringValue(string(propsJson))
-	} else {
-		data.Props = types.StringNull()
+	// Handle props response, keeping the stored props if only key order or
+	// formatting differ
+	changed, err := setPropsState(ctx, &data, resource.Props)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Props Serialization Error",
+			fmt.Sprintf("Failed to serialize props: %s", err.Error()),
+		)
+		return
+	}
+	if changed {
+		tflog.Info(ctx, "Resource props changed outside Terraform", map[string]interface{}{
+			"resource_id": resourceId,
+		})
 	}
 
 	// Save updated data into Terraform state
@@ -242,6 +274,8 @@ func (r *IAMResourceResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *IAMResourceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data IAMResourceResourceModel
 
 	// Read Terraform plan data into the model
@@ -273,15 +307,34 @@ func (r *IAMResourceResource) Update(ctx context.Context, req resource.UpdateReq
 			return
 		}
 
-		// Parse JSON for API call
+		// Send props as canonical JSON so integers are not coerced to floats
 		if propsStr != "" {
-			if err := json.Unmarshal([]byte(propsStr), &propsData); err != nil {
+			canonical, err := iam.CanonicalizeProps(propsStr)
+			if err != nil {
 				resp.Diagnostics.AddError(
 					"Props JSON Parse Error",
 					fmt.Sprintf("Failed to parse props JSON: %s", err.Error()),
 				)
 				return
 			}
+			if canonical != "" {
+				propsData = json.RawMessage(canonical)
+			}
+		}
+	}
+
+	// props_object is sent the same way; ConfigValidators rejects setting both
+	if propsData == nil && !data.PropsObject.IsNull() {
+		canonical, err := propsObjectJSON(ctx, data.PropsObject)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid Props Object",
+				fmt.Sprintf("Failed to encode props_object: %s", err.Error()),
+			)
+			return
+		}
+		if canonical != "" {
+			propsData = json.RawMessage(canonical)
 		}
 	}
 
@@ -304,19 +357,14 @@ func (r *IAMResourceResource) Update(ctx context.Context, req resource.UpdateReq
 	data.Name = types.StringValue(updatedResource.Name)
 	data.TenantID = types.StringValue(r.service.TenantID())
 
-	// Handle props response
-	if updatedResource.Props != nil {
-		propsJson, err := json.Marshal(updatedResource.Props)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Props Serialization Error",
-				fmt.Sprintf("Failed to serialize props: %s", err.Error()),
-			)
-			return
-		}
-		data.Props = types.StringValue(string(propsJson))
-	} else {
-		data.Props = types.StringNull()
+	// Handle props response, keeping the planned props if only key order or
+	// formatting differ
+	if _, err := setPropsState(ctx, &data, updatedResource.Props); err != nil {
+		resp.Diagnostics.AddError(
+			"Props Serialization Error",
+			fmt.Sprintf("Failed to serialize props: %s", err.Error()),
+		)
+		return
 	}
 
 	// Save updated data into Terraform state
@@ -324,6 +372,8 @@ func (r *IAMResourceResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *IAMResourceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data IAMResourceResourceModel
 
 	// Read Terraform prior state data into the model
@@ -0,0 +1,41 @@
+package resource_iam_resource
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+)
+
+// normalizeProps re-encodes a props JSON document canonically, see
+// iam.CanonicalizeProps, so semantically equal documents compare equal
+func normalizeProps(props string) (string, error) {
+	return iam.CanonicalizeProps(props)
+}
+
+// propsStateValue returns the props value to store in state for the props
+// returned by the API. If prior, the planned or previously stored value, is
+// semantically equal it is kept as written so key order, formatting and number
+// representation do not show up as drift; otherwise the remote props are
+// stored as canonical JSON.
+// The second result reports whether the remote props differ from prior.
+func propsStateValue(prior types.String, remote interface{}) (types.String, bool, error) {
+	if remote == nil {
+		// An empty string is how props are omitted in configuration
+		if !prior.IsNull() && !prior.IsUnknown() && prior.ValueString() == "" {
+			return prior, false, nil
+		}
+		return types.StringNull(), !prior.IsNull(), nil
+	}
+
+	normalized, err := iam.CanonicalizeProps(remote)
+	if err != nil {
+		return types.StringNull(), false, err
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if priorNormalized, err := normalizeProps(prior.ValueString()); err == nil && priorNormalized == normalized {
+			return prior, false, nil
+		}
+	}
+	return types.StringValue(normalized), true, nil
+}
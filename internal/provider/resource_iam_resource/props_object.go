@@ -0,0 +1,226 @@
+package resource_iam_resource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+)
+
+var _ resource.ResourceWithConfigValidators = &IAMResourceResource{}
+
+// ConfigValidators rejects configurations that set props both as a JSON
+// string and as an object
+func (r *IAMResourceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.Conflicting(path.MatchRoot("props"), path.MatchRoot("props_object")),
+	}
+}
+
+// propsObjectJSON encodes a props_object value as canonical props JSON, see
+// iam.CanonicalizeProps. A null object encodes as "".
+func propsObjectJSON(ctx context.Context, value types.Dynamic) (string, error) {
+	if value.IsNull() || value.IsUnderlyingValueNull() {
+		return "", nil
+	}
+	raw, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return "", err
+	}
+	decoded, err := terraformValueToJSON(raw)
+	if err != nil {
+		return "", err
+	}
+	return iam.CanonicalizeProps(decoded)
+}
+
+// terraformValueToJSON converts a Terraform value to the value encoding/json
+// writes as its JSON equivalent. Numbers are kept exact as json.Number.
+func terraformValueToJSON(value tftypes.Value) (interface{}, error) {
+	if !value.IsFullyKnown() {
+		return nil, fmt.Errorf("props_object contains values that are not known until apply")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch typ := value.Type(); {
+	case typ.Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		return jsonNumber(n), nil
+	case typ.Is(tftypes.Object{}), typ.Is(tftypes.Map{}):
+		var attrs map[string]tftypes.Value
+		if err := value.As(&attrs); err != nil {
+			return nil, err
+		}
+		object := make(map[string]interface{}, len(attrs))
+		for name, attrValue := range attrs {
+			converted, err := terraformValueToJSON(attrValue)
+			if err != nil {
+				return nil, err
+			}
+			object[name] = converted
+		}
+		return object, nil
+	case typ.Is(tftypes.Tuple{}), typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}):
+		var elems []tftypes.Value
+		if err := value.As(&elems); err != nil {
+			return nil, err
+		}
+		array := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			converted, err := terraformValueToJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			array[i] = converted
+		}
+		return array, nil
+	default:
+		return nil, fmt.Errorf("props_object has a value of unsupported type %s", typ)
+	}
+}
+
+// jsonNumber formats n without a fraction or exponent when it is an integer,
+// so large integers are not sent as floats
+func jsonNumber(n *big.Float) json.Number {
+	if n.IsInt() {
+		i, _ := n.Int(nil)
+		return json.Number(i.String())
+	}
+	return json.Number(n.Text('g', -1))
+}
+
+// propsObjectValue converts props JSON to the props_object value Terraform
+// would build from the equivalent object in configuration: JSON objects
+// become objects and arrays become tuples
+func propsObjectValue(props string) (types.Dynamic, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(props)))
+	decoder.UseNumber()
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return types.DynamicNull(), fmt.Errorf("failed to decode props: %w", err)
+	}
+	value, err := jsonToAttrValue(decoded)
+	if err != nil {
+		return types.DynamicNull(), err
+	}
+	return types.DynamicValue(value), nil
+}
+
+func jsonToAttrValue(decoded interface{}) (attr.Value, error) {
+	switch v := decoded.(type) {
+	case nil:
+		return types.DynamicNull(), nil
+	case string:
+		return types.StringValue(v), nil
+	case bool:
+		return types.BoolValue(v), nil
+	case json.Number:
+		n, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %s in props: %w", v, err)
+		}
+		return types.NumberValue(n), nil
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for name, attrDecoded := range v {
+			attrValue, err := jsonToAttrValue(attrDecoded)
+			if err != nil {
+				return nil, err
+			}
+			attrTypes[name] = attrValue.Type(context.Background())
+			attrs[name] = attrValue
+		}
+		object, diags := types.ObjectValue(attrTypes, attrs)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to convert props object: %v", diags)
+		}
+		return object, nil
+	case []interface{}:
+		elemTypes := make([]attr.Type, len(v))
+		elems := make([]attr.Value, len(v))
+		for i, elemDecoded := range v {
+			elem, err := jsonToAttrValue(elemDecoded)
+			if err != nil {
+				return nil, err
+			}
+			elemTypes[i] = elem.Type(context.Background())
+			elems[i] = elem
+		}
+		tuple, diags := types.TupleValue(elemTypes, elems)
+		if diags.HasError() {
+			return nil, fmt.Errorf("failed to convert props array: %v", diags)
+		}
+		return tuple, nil
+	default:
+		return nil, fmt.Errorf("unsupported props value %T", decoded)
+	}
+}
+
+// propsObjectStateValue is propsStateValue for props_object: prior is kept
+// when it is semantically equal to the props returned by the API, otherwise
+// the remote props are stored in object form
+func propsObjectStateValue(ctx context.Context, prior types.Dynamic, remote interface{}) (types.Dynamic, bool, error) {
+	normalized, err := iam.CanonicalizeProps(remote)
+	if err != nil {
+		return types.DynamicNull(), false, err
+	}
+	if normalized == "" {
+		return types.DynamicNull(), !prior.IsNull(), nil
+	}
+
+	if !prior.IsNull() && !prior.IsUnknown() {
+		if priorNormalized, err := propsObjectJSON(ctx, prior); err == nil && priorNormalized == normalized {
+			return prior, false, nil
+		}
+	}
+	value, err := propsObjectValue(normalized)
+	if err != nil {
+		return types.DynamicNull(), false, err
+	}
+	return value, true, nil
+}
+
+// setPropsState stores the props returned by the API in data, in object form
+// when the configuration used props_object and as a JSON string otherwise.
+// It reports whether the remote props differ from those in data.
+func setPropsState(ctx context.Context, data *IAMResourceResourceModel, remote interface{}) (bool, error) {
+	if !data.PropsObject.IsNull() {
+		props, changed, err := propsObjectStateValue(ctx, data.PropsObject, remote)
+		if err != nil {
+			return false, err
+		}
+		data.PropsObject = props
+		return changed, nil
+	}
+
+	props, changed, err := propsStateValue(data.Props, remote)
+	if err != nil {
+		return false, err
+	}
+	data.Props = props
+	return changed, nil
+}
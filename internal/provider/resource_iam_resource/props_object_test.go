@@ -0,0 +1,151 @@
+package resource_iam_resource
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// storePropsObject is the props_object for
+// {"region":"north","id":9007199254740993,"tags":["b","a"]}
+func storePropsObject(t *testing.T) types.Dynamic {
+	t.Helper()
+	tags, diags := types.TupleValue(
+		[]attr.Type{types.StringType, types.StringType},
+		[]attr.Value{types.StringValue("b"), types.StringValue("a")},
+	)
+	require.False(t, diags.HasError(), "%v", diags)
+	id, _, err := big.ParseFloat("9007199254740993", 10, 512, big.ToNearestEven)
+	require.NoError(t, err)
+	object, diags := types.ObjectValue(
+		map[string]attr.Type{"region": types.StringType, "id": types.NumberType, "tags": tags.Type(context.Background())},
+		map[string]attr.Value{"region": types.StringValue("north"), "id": types.NumberValue(id), "tags": tags},
+	)
+	require.False(t, diags.HasError(), "%v", diags)
+	return types.DynamicValue(object)
+}
+
+func TestIAMResource_Create_PropsObject(t *testing.T) {
+	var sent string
+	r := NewIAMResourceResource().(*IAMResourceResource)
+	setServiceField(r, newTestServiceWith(mockRawClientFunc(func(req *client.Request) *client.Response {
+		body, err := json.Marshal(req.Body)
+		require.NoError(t, err)
+		sent = string(body)
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"res-1","name":"Resource","props":{"tags":["b","a"],"id":9007199254740993,"region":"north"}}`)}
+	})))
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	planned := storePropsObject(t)
+	var creq resource.CreateRequest
+	creq.Plan.Schema = schemaResp.Schema
+	require.False(t, creq.Plan.Set(context.Background(), IAMResourceResourceModel{
+		ID:          types.StringValue("res-1"),
+		Name:        types.StringValue("Resource"),
+		PropsObject: planned,
+		TenantID:    types.StringUnknown(),
+	}).HasError())
+
+	var cresp resource.CreateResponse
+	cresp.State.Schema = schemaResp.Schema
+	r.Create(context.Background(), creq, &cresp)
+	require.False(t, cresp.Diagnostics.HasError(), "Create diagnostics: %v", cresp.Diagnostics.Errors())
+	require.Equal(t, `{"name":"Resource","props":{"id":9007199254740993,"region":"north","tags":["b","a"]}}`, sent)
+
+	var out IAMResourceResourceModel
+	require.False(t, cresp.State.Get(context.Background(), &out).HasError())
+	require.True(t, out.Props.IsNull(), "props = %s, want null when props_object is used", out.Props)
+	require.True(t, out.PropsObject.Equal(planned), "props_object = %s, want the planned value", out.PropsObject)
+}
+
+func TestIAMResource_Read_PropsObject(t *testing.T) {
+	prior := IAMResourceResourceModel{
+		ID:          types.StringValue("res-1"),
+		Name:        types.StringValue("Resource"),
+		PropsObject: storePropsObject(t),
+		TenantID:    types.StringValue("test-tenant"),
+	}
+
+	t.Run("same props", func(t *testing.T) {
+		rresp := readWithRemote(t, prior, 200, `{"id":"res-1","name":"Resource","props":{"region":"north","tags":["b","a"],"id":9007199254740993}}`)
+		var out IAMResourceResourceModel
+		require.False(t, rresp.State.Get(context.Background(), &out).HasError())
+		require.True(t, out.PropsObject.Equal(prior.PropsObject), "props_object = %s, want the prior value", out.PropsObject)
+		require.True(t, out.Props.IsNull())
+	})
+
+	t.Run("props changed remotely", func(t *testing.T) {
+		rresp := readWithRemote(t, prior, 200, `{"id":"res-1","name":"Resource","props":{"region":"south","open":true,"note":null}}`)
+		var out IAMResourceResourceModel
+		require.False(t, rresp.State.Get(context.Background(), &out).HasError())
+		require.True(t, out.Props.IsNull(), "props = %s, want the object form kept", out.Props)
+		encoded, err := propsObjectJSON(context.Background(), out.PropsObject)
+		require.NoError(t, err)
+		require.Equal(t, `{"note":null,"open":true,"region":"south"}`, encoded)
+	})
+
+	t.Run("props removed remotely", func(t *testing.T) {
+		rresp := readWithRemote(t, prior, 200, `{"id":"res-1","name":"Resource"}`)
+		var out IAMResourceResourceModel
+		require.False(t, rresp.State.Get(context.Background(), &out).HasError())
+		require.True(t, out.PropsObject.IsNull(), "props_object = %s, want null", out.PropsObject)
+	})
+}
+
+func TestIAMResource_PropsConflictsWithPropsObject(t *testing.T) {
+	r := NewIAMResourceResource().(*IAMResourceResource)
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	validate := func(model IAMResourceResourceModel) resource.ValidateConfigResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		require.False(t, state.Set(context.Background(), model).HasError())
+		req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}
+		var resp resource.ValidateConfigResponse
+		for _, v := range r.ConfigValidators(context.Background()) {
+			v.ValidateResource(context.Background(), req, &resp)
+		}
+		return resp
+	}
+
+	resp := validate(IAMResourceResourceModel{
+		Name:        types.StringValue("Resource"),
+		Props:       types.StringValue(`{"region":"north"}`),
+		PropsObject: storePropsObject(t),
+	})
+	require.True(t, resp.Diagnostics.HasError(), "expected an error when both props and props_object are set")
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "props_object")
+
+	resp = validate(IAMResourceResourceModel{
+		Name:        types.StringValue("Resource"),
+		PropsObject: storePropsObject(t),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "props_object alone: %v", resp.Diagnostics.Errors())
+}
+
+func TestIAMResource_ModifyPlan_PropsObjectSchema(t *testing.T) {
+	object, diags := types.ObjectValue(
+		map[string]attr.Type{"region": types.NumberType},
+		map[string]attr.Value{"region": types.NumberValue(big.NewFloat(1))},
+	)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	got := modifyPlan(t, map[string]string{"store": storePropsSchema}, IAMResourceResourceModel{
+		ID:          types.StringValue("store:001"),
+		Name:        types.StringValue("Store"),
+		PropsObject: types.DynamicValue(object),
+	})
+	require.True(t, got.HasError(), "expected a schema violation")
+	require.Contains(t, got.Errors()[0].Detail(), "/region")
+}
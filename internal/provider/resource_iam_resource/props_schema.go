@@ -0,0 +1,105 @@
+package resource_iam_resource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/jsonschema"
+)
+
+// compilePropsSchemas compiles the provider props_schemas. The provider has
+// already rejected invalid schemas, so a failure here is reported as an error.
+func compilePropsSchemas(docs map[string]string, diags *diag.Diagnostics) map[string]*jsonschema.Schema {
+	if len(docs) == 0 {
+		return nil
+	}
+	schemas := make(map[string]*jsonschema.Schema, len(docs))
+	for resourceType, doc := range docs {
+		schema, err := jsonschema.Compile(doc)
+		if err != nil {
+			diags.AddError("Invalid Props Schema", fmt.Sprintf("The schema for resource type %q is invalid: %s", resourceType, err))
+			continue
+		}
+		schemas[resourceType] = schema
+	}
+	return schemas
+}
+
+// resourceType returns the type of a resource ID, the prefix before ":", or
+// "" when the ID has none
+func resourceType(id string) string {
+	prefix, _, found := strings.Cut(id, ":")
+	if !found {
+		return ""
+	}
+	return prefix
+}
+
+// ModifyPlan validates props, or props_object, against the provider
+// props_schemas entry for the resource type. Resources without a schema only need valid JSON props,
+// which the attribute validator checks.
+func (r *IAMResourceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || len(r.propsSchemas) == 0 {
+		return
+	}
+
+	var plan IAMResourceResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	propsPath, propsJSON := path.Root("props"), plan.Props.ValueString()
+	if !plan.PropsObject.IsNull() {
+		encoded, err := propsObjectJSON(ctx, plan.PropsObject)
+		if err != nil {
+			// Unknown until apply, or reported when props_object is sent
+			return
+		}
+		propsPath, propsJSON = path.Root("props_object"), encoded
+	} else if plan.Props.IsUnknown() {
+		return
+	}
+	if propsJSON == "" {
+		return
+	}
+
+	// Create uses the name as the ID when none is set
+	id := plan.ID
+	if id.IsNull() || id.IsUnknown() || id.ValueString() == "" {
+		id = plan.Name
+	}
+	if id.IsUnknown() {
+		return
+	}
+	schema, ok := r.propsSchemas[resourceType(id.ValueString())]
+	if !ok {
+		return
+	}
+
+	props, err := jsonschema.Decode([]byte(propsJSON))
+	if err != nil {
+		// Reported by the props attribute validator
+		return
+	}
+	for _, violation := range schema.Validate(props) {
+		resp.Diagnostics.AddAttributeError(
+			propsPath,
+			"Props Schema Violation",
+			fmt.Sprintf("props %s does not match the schema for resource type %q: %s",
+				violationPath(violation.Path), resourceType(id.ValueString()), violation.Message),
+		)
+	}
+}
+
+// violationPath formats a JSON Pointer for diagnostics
+func violationPath(pointer string) string {
+	if pointer == "" {
+		return "(root)"
+	}
+	return pointer
+}
@@ -0,0 +1,142 @@
+package resource_iam_resource
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+const storePropsSchema = `{
+  "type": "object",
+  "required": ["region"],
+  "properties": {
+    "region": {"type": "string"},
+    "address": {
+      "type": "object",
+      "required": ["city"],
+      "properties": {"city": {"type": "string"}}
+    }
+  }
+}`
+
+// modifyPlan runs ModifyPlan for plan with schemas registered by type
+func modifyPlan(t *testing.T, schemas map[string]string, plan IAMResourceResourceModel) diag.Diagnostics {
+	t.Helper()
+	var diags diag.Diagnostics
+	r := NewIAMResourceResource().(*IAMResourceResource)
+	r.propsSchemas = compilePropsSchemas(schemas, &diags)
+	require.False(t, diags.HasError(), "compile diagnostics: %v", diags.Errors())
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	var req resource.ModifyPlanRequest
+	req.Plan.Schema = schemaResp.Schema
+	require.False(t, req.Plan.Set(context.Background(), plan).HasError())
+
+	var resp resource.ModifyPlanResponse
+	resp.Plan = req.Plan
+	r.ModifyPlan(context.Background(), req, &resp)
+	return resp.Diagnostics
+}
+
+func TestIAMResource_ModifyPlan_PropsSchema(t *testing.T) {
+	schemas := map[string]string{"store": storePropsSchema}
+
+	tests := []struct {
+		name      string
+		id        types.String
+		props     string
+		wantPaths []string
+	}{
+		{
+			name:  "valid props",
+			id:    types.StringValue("store:001"),
+			props: `{"region":"north","address":{"city":"Oslo"}}`,
+		},
+		{
+			name:      "missing required field",
+			id:        types.StringValue("store:001"),
+			props:     `{"address":{"city":"Oslo"}}`,
+			wantPaths: []string{"props (root)"},
+		},
+		{
+			name:      "nested violation",
+			id:        types.StringValue("store:001"),
+			props:     `{"region":"north","address":{}}`,
+			wantPaths: []string{"props /address"},
+		},
+		{
+			name:      "wrong type",
+			id:        types.StringValue("store:001"),
+			props:     `{"region":7}`,
+			wantPaths: []string{"props /region"},
+		},
+		{
+			name:      "type taken from name when id is null",
+			id:        types.StringNull(),
+			props:     `{}`,
+			wantPaths: []string{"props (root)"},
+		},
+		{
+			name:  "no schema for type",
+			id:    types.StringValue("bu:001"),
+			props: `{}`,
+		},
+		{
+			name:  "id without type",
+			id:    types.StringValue("plain-id"),
+			props: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diags := modifyPlan(t, schemas, IAMResourceResourceModel{
+				ID:       tt.id,
+				Name:     types.StringValue("store:fallback"),
+				Props:    types.StringValue(tt.props),
+				TenantID: types.StringUnknown(),
+			})
+			require.Len(t, diags.Errors(), len(tt.wantPaths), "diagnostics: %v", diags.Errors())
+			for i, want := range tt.wantPaths {
+				d, ok := diags.Errors()[i].(diag.DiagnosticWithPath)
+				require.True(t, ok)
+				require.True(t, d.Path().Equal(path.Root("props")))
+				require.Equal(t, "Props Schema Violation", d.Summary())
+				require.True(t, strings.HasPrefix(d.Detail(), want+" "), "detail %q", d.Detail())
+			}
+		})
+	}
+}
+
+func TestIAMResource_ModifyPlan_RequiredFieldMessage(t *testing.T) {
+	diags := modifyPlan(t, map[string]string{"store": storePropsSchema}, IAMResourceResourceModel{
+		ID:       types.StringValue("store:001"),
+		Name:     types.StringValue("Store"),
+		Props:    types.StringValue(`{"address":{"city":"Oslo"}}`),
+		TenantID: types.StringUnknown(),
+	})
+	require.Len(t, diags.Errors(), 1)
+	require.Contains(t, diags.Errors()[0].Detail(), `resource type "store"`)
+	require.Contains(t, diags.Errors()[0].Detail(), "region")
+}
+
+func TestIAMResource_ModifyPlan_NoSchemasOrProps(t *testing.T) {
+	plan := IAMResourceResourceModel{
+		ID:       types.StringValue("store:001"),
+		Name:     types.StringValue("Store"),
+		Props:    types.StringNull(),
+		TenantID: types.StringUnknown(),
+	}
+	require.False(t, modifyPlan(t, map[string]string{"store": storePropsSchema}, plan).HasError())
+
+	plan.Props = types.StringValue(`{}`)
+	require.False(t, modifyPlan(t, nil, plan).HasError())
+}
@@ -0,0 +1,209 @@
+package resource_iam_resource
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// mockRawClientFunc answers every request with fn
+type mockRawClientFunc func(req *client.Request) *client.Response
+
+func (f mockRawClientFunc) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	return f(req), nil
+}
+
+func newTestServiceWith(raw iam.RawClient) *iam.Service {
+	s := &iam.Service{}
+	v := reflect.ValueOf(s).Elem()
+	rawClientField := v.FieldByName("rawClient")
+	*(*iam.RawClient)(unsafe.Pointer(rawClientField.UnsafeAddr())) = raw
+
+	tenantIDField := v.FieldByName("tenantID")
+	*(*string)(unsafe.Pointer(tenantIDField.UnsafeAddr())) = "test-tenant"
+
+	return s
+}
+
+// readWithRemote runs Read against prior state while the API answers with
+// status and body
+func readWithRemote(t *testing.T, prior IAMResourceResourceModel, status int, body string) resource.ReadResponse {
+	t.Helper()
+	r := NewIAMResourceResource().(*IAMResourceResource)
+	setServiceField(r, newTestServiceWith(mockRawClientFunc(func(req *client.Request) *client.Response {
+		return &client.Response{StatusCode: status, Body: []byte(body)}
+	})))
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	var rreq resource.ReadRequest
+	rreq.State.Schema = schemaResp.Schema
+	require.False(t, rreq.State.Set(context.Background(), prior).HasError())
+
+	var rresp resource.ReadResponse
+	rresp.State.Schema = schemaResp.Schema
+	rresp.State.Raw = rreq.State.Raw.Copy()
+
+	r.Read(context.Background(), rreq, &rresp)
+	require.False(t, rresp.Diagnostics.HasError(), "Read diagnostics: %v", rresp.Diagnostics.Errors())
+	return rresp
+}
+
+func TestIAMResource_Read_PropsKeyOrder(t *testing.T) {
+	configured := `{
+  "store": {"region": "north", "id": 42},
+  "enabled": true,
+  "tags": ["b", "a"]
+}`
+	prior := IAMResourceResourceModel{
+		ID:       types.StringValue("res-1"),
+		Name:     types.StringValue("Resource"),
+		Props:    types.StringValue(configured),
+		TenantID: types.StringValue("test-tenant"),
+	}
+
+	tests := []struct {
+		name      string
+		body      string
+		wantProps string
+	}{
+		{
+			name:      "same props in different key order",
+			body:      `{"id":"res-1","name":"Resource","props":{"tags":["b","a"],"enabled":true,"store":{"id":42,"region":"north"}}}`,
+			wantProps: configured,
+		},
+		{
+			name:      "props changed remotely",
+			body:      `{"id":"res-1","name":"Resource","props":{"tags":["a","b"],"enabled":true,"store":{"id":42,"region":"north"}}}`,
+			wantProps: `{"enabled":true,"store":{"id":42,"region":"north"},"tags":["a","b"]}`,
+		},
+		{
+			name:      "props removed remotely",
+			body:      `{"id":"res-1","name":"Resource"}`,
+			wantProps: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rresp := readWithRemote(t, prior, 200, tt.body)
+
+			var out IAMResourceResourceModel
+			require.False(t, rresp.State.Get(context.Background(), &out).HasError())
+			if tt.wantProps == "" {
+				require.True(t, out.Props.IsNull(), "props = %s, want null", out.Props)
+				return
+			}
+			// State equal to the configured value means no planned change
+			require.Equal(t, tt.wantProps, out.Props.ValueString())
+		})
+	}
+}
+
+func TestIAMResource_Read_NotFoundRemovesResource(t *testing.T) {
+	prior := IAMResourceResourceModel{
+		ID:       types.StringValue("res-1"),
+		Name:     types.StringValue("Resource"),
+		Props:    types.StringNull(),
+		TenantID: types.StringValue("test-tenant"),
+	}
+
+	rresp := readWithRemote(t, prior, 404, `{"message":"Resource not found"}`)
+	require.True(t, rresp.State.Raw.IsNull(), "resource should be removed from state")
+}
+
+func TestIAMResource_Create_KeepsPlannedPropsFormatting(t *testing.T) {
+	r := NewIAMResourceResource().(*IAMResourceResource)
+	setServiceField(r, newTestServiceWith(mockRawClientFunc(func(req *client.Request) *client.Response {
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"res-1","name":"Resource","props":{"a":1,"b":2}}`)}
+	})))
+
+	planned := `{"b": 2, "a": 1}`
+	data := IAMResourceResourceModel{
+		ID:       types.StringValue("res-1"),
+		Name:     types.StringValue("Resource"),
+		Props:    types.StringValue(planned),
+		TenantID: types.StringUnknown(),
+	}
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	var creq resource.CreateRequest
+	creq.Plan.Schema = schemaResp.Schema
+	require.False(t, creq.Plan.Set(context.Background(), data).HasError())
+
+	var cresp resource.CreateResponse
+	cresp.State.Schema = schemaResp.Schema
+	r.Create(context.Background(), creq, &cresp)
+	require.False(t, cresp.Diagnostics.HasError(), "Create diagnostics: %v", cresp.Diagnostics.Errors())
+
+	var out IAMResourceResourceModel
+	require.False(t, cresp.State.Get(context.Background(), &out).HasError())
+	require.Equal(t, planned, out.Props.ValueString())
+}
+
+func Test_normalizeProps(t *testing.T) {
+	got, err := normalizeProps(`{"b": [1, {"d": 1, "c": 2}], "a": null}`)
+	require.NoError(t, err)
+	require.Equal(t, `{"a":null,"b":[1,{"c":2,"d":1}]}`, got)
+
+	_, err = normalizeProps(`{not json`)
+	require.Error(t, err)
+}
+
+func TestIAMResource_PropsRoundTripWithIntegersAndNesting(t *testing.T) {
+	configured := `{"limits": {"max": 9007199254740993, "ratio": 1.0}, "ids": [3, 2, 1], "nested": {"b": {"d": 10, "c": 1e2}, "a": 0}}`
+
+	var sent string
+	r := NewIAMResourceResource().(*IAMResourceResource)
+	setServiceField(r, newTestServiceWith(mockRawClientFunc(func(req *client.Request) *client.Response {
+		if req.Method == "PUT" {
+			body, err := json.Marshal(req.Body)
+			require.NoError(t, err)
+			sent = string(body)
+		}
+		// The API echoes the props back with keys in its own order
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"res-1","name":"Resource","props":` +
+			`{"nested":{"a":0,"b":{"c":100,"d":10}},"ids":[3,2,1],"limits":{"ratio":1,"max":9007199254740993}}}`)}
+	})))
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	var creq resource.CreateRequest
+	creq.Plan.Schema = schemaResp.Schema
+	require.False(t, creq.Plan.Set(context.Background(), IAMResourceResourceModel{
+		ID:       types.StringValue("res-1"),
+		Name:     types.StringValue("Resource"),
+		Props:    types.StringValue(configured),
+		TenantID: types.StringUnknown(),
+	}).HasError())
+
+	var cresp resource.CreateResponse
+	cresp.State.Schema = schemaResp.Schema
+	r.Create(context.Background(), creq, &cresp)
+	require.False(t, cresp.Diagnostics.HasError(), "Create diagnostics: %v", cresp.Diagnostics.Errors())
+	require.Contains(t, sent, `"max":9007199254740993`, "integers must not be sent as floats")
+
+	var created IAMResourceResourceModel
+	require.False(t, cresp.State.Get(context.Background(), &created).HasError())
+	require.Equal(t, configured, created.Props.ValueString())
+
+	// Reading the same props back keeps the configured value: no drift
+	rresp := readWithRemote(t, created, 200, `{"id":"res-1","name":"Resource","props":`+
+		`{"limits":{"max":9007199254740993,"ratio":1},"ids":[3,2,1],"nested":{"b":{"c":100,"d":10},"a":0}}}`)
+	var read IAMResourceResourceModel
+	require.False(t, rresp.State.Get(context.Background(), &read).HasError())
+	require.Equal(t, configured, read.Props.ValueString())
+}
@@ -0,0 +1,74 @@
+package resource_iam_role_binding
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// LegacyPropertiesDeprecationWarning is emitted at plan time whenever the legacy
+// name/role/members properties are configured. The legacy properties are
+// scheduled for removal in the next major release of the provider.
+const LegacyPropertiesDeprecationWarning = "The legacy properties 'name', 'role' and 'members' are deprecated and will be removed in the next major release. " +
+	"Use 'group_id' and the 'roles' list (with per-role 'bindings') instead."
+
+var _ resource.ResourceWithConfigValidators = &IamRoleBindingResource{}
+
+// ConfigValidators rejects configurations that mix legacy and new properties and
+// warns about legacy property usage before Create is ever reached.
+func (r *IamRoleBindingResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		mixedPropertiesValidator{},
+		legacyPropertiesDeprecationValidator{},
+	}
+}
+
+// mixedPropertiesValidator errors when legacy and new property structures are
+// configured on the same resource.
+type mixedPropertiesValidator struct{}
+
+func (v mixedPropertiesValidator) Description(ctx context.Context) string {
+	return "legacy properties (name, role, members) cannot be combined with new properties (group_id, roles)"
+}
+
+func (v mixedPropertiesValidator) MarkdownDescription(ctx context.Context) string {
+	return "legacy properties (`name`, `role`, `members`) cannot be combined with new properties (`group_id`, `roles`)"
+}
+
+func (v mixedPropertiesValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoleBindingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if errs := ValidateMixedProperties(ctx, &data); len(errs) > 0 {
+		resp.Diagnostics.AddError("Mixed Property Structures", strings.Join(errs, "; "))
+	}
+}
+
+// legacyPropertiesDeprecationValidator warns when only the legacy property
+// structure is configured, pointing users at the new structure.
+type legacyPropertiesDeprecationValidator struct{}
+
+func (v legacyPropertiesDeprecationValidator) Description(ctx context.Context) string {
+	return "warns when the deprecated legacy properties (name, role, members) are used"
+}
+
+func (v legacyPropertiesDeprecationValidator) MarkdownDescription(ctx context.Context) string {
+	return "warns when the deprecated legacy properties (`name`, `role`, `members`) are used"
+}
+
+func (v legacyPropertiesDeprecationValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RoleBindingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Mixed usage is reported as an error by mixedPropertiesValidator.
+	if hasLegacyProperties(&data) && !hasNewProperties(&data) {
+		resp.Diagnostics.AddWarning("Deprecated Property Usage", LegacyPropertiesDeprecationWarning)
+	}
+}
@@ -0,0 +1,94 @@
+package resource_iam_role_binding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+// validateRoleBindingConfig runs every resource-level config validator against model.
+func validateRoleBindingConfig(t *testing.T, model RoleBindingResourceModel) diag.Diagnostics {
+	ctx := context.Background()
+	r := NewIamRoleBindingResource().(*IamRoleBindingResource)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	require.False(t, state.Set(ctx, &model).HasError())
+
+	req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: state.Raw}}
+	var diags diag.Diagnostics
+	for _, v := range r.ConfigValidators(ctx) {
+		resp := &resource.ValidateConfigResponse{}
+		v.ValidateResource(ctx, req, resp)
+		diags.Append(resp.Diagnostics...)
+	}
+	return diags
+}
+
+func nullRoleBindingModel() RoleBindingResourceModel {
+	return RoleBindingResourceModel{
+		Id:             types.StringNull(),
+		TenantId:       types.StringNull(),
+		Name:           types.StringNull(),
+		Role:           types.StringNull(),
+		Members:        types.ListNull(types.StringType),
+		GroupId:        types.StringNull(),
+		Roles:          types.ListNull(GetRoleModelObjectType()),
+		Description:    types.StringNull(),
+		Condition:      types.StringNull(),
+		RoleId:         types.StringNull(),
+		BindingsLegacy: types.ListNull(types.StringType),
+	}
+}
+
+func TestIamRoleBindingResource_ConfigValidators(t *testing.T) {
+	members := types.ListValueMust(types.StringType, []attr.Value{types.StringValue("user:alice")})
+	roles := types.ListValueMust(GetRoleModelObjectType(), []attr.Value{
+		types.ObjectValueMust(GetRoleModelObjectType().AttrTypes, map[string]attr.Value{
+			"id":        types.StringValue("pos.cashier"),
+			"is_custom": types.BoolValue(false),
+			"bindings":  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("bu:store-1")}),
+		}),
+	})
+
+	t.Run("LegacyOnlyWarns", func(t *testing.T) {
+		model := nullRoleBindingModel()
+		model.Name = types.StringValue("group-1")
+		model.Role = types.StringValue("pos.cashier")
+		model.Members = members
+
+		diags := validateRoleBindingConfig(t, model)
+		require.False(t, diags.HasError())
+		require.Equal(t, 1, diags.WarningsCount())
+		require.Equal(t, "Deprecated Property Usage", diags.Warnings()[0].Summary())
+		require.Contains(t, diags.Warnings()[0].Detail(), "group_id")
+	})
+
+	t.Run("MixedErrors", func(t *testing.T) {
+		model := nullRoleBindingModel()
+		model.Name = types.StringValue("group-1")
+		model.Roles = roles
+
+		diags := validateRoleBindingConfig(t, model)
+		require.True(t, diags.HasError())
+		require.Equal(t, "Mixed Property Structures", diags.Errors()[0].Summary())
+		require.Equal(t, 0, diags.WarningsCount())
+	})
+
+	t.Run("NewOnlyPasses", func(t *testing.T) {
+		model := nullRoleBindingModel()
+		model.GroupId = types.StringValue("group-1")
+		model.Roles = roles
+
+		diags := validateRoleBindingConfig(t, model)
+		require.Empty(t, diags)
+	})
+}
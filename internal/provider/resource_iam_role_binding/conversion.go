@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
 )
 
 // Data conversion utilities for property structure transformation (T027-T029)
@@ -46,7 +49,7 @@ func ConvertLegacyToNew(ctx context.Context, model *RoleBindingResourceModel) (*
 		}
 
 		// Create bindings list
-		bindingsList, diags := types.ListValueFrom(ctx, types.StringType, membersArray)
+		bindingsList, diags := types.ListValueFrom(ctx, types.StringType, iam.NormalizeMembers(membersArray))
 		if diags.HasError() {
 			return nil, fmt.Errorf("failed to create bindings list: %v", diags)
 		}
@@ -69,6 +72,48 @@ func ConvertLegacyToNew(ctx context.Context, model *RoleBindingResourceModel) (*
 	return newModel, nil
 }
 
+// roleBindingDtos converts the roles list to the role assignments applied by
+// iam.Service.ApplyGroupRoles
+func roleBindingDtos(roles []RoleModel) []iam.RoleBindingDto {
+	dtos := make([]iam.RoleBindingDto, 0, len(roles))
+	for _, role := range roles {
+		// A prefixed id such as "custom.Foo" names a custom role even when
+		// is_custom is not set, as in the role plan modifier
+		roleID, prefixedCustom := iam.ParseRole(role.Id.ValueString())
+		isCustom := role.IsCustom.ValueBool() || prefixedCustom
+
+		var bindings []string
+		for _, binding := range role.Bindings.Elements() {
+			if bindingStr, ok := binding.(types.String); ok {
+				bindings = append(bindings, bindingStr.ValueString())
+			}
+		}
+		dtos = append(dtos, iam.RoleBindingDto{RoleID: roleID, IsCustom: isCustom, Bindings: bindings})
+	}
+	return dtos
+}
+
+// managedRoles returns the role assignments a role binding model manages,
+// converting legacy properties first
+func managedRoles(ctx context.Context, model *RoleBindingResourceModel) ([]iam.RoleBindingDto, error) {
+	if hasLegacyProperties(model) {
+		converted, err := ConvertLegacyToNew(ctx, model)
+		if err != nil {
+			return nil, err
+		}
+		model = converted
+	}
+	if model.Roles.IsNull() || model.Roles.IsUnknown() {
+		return nil, nil
+	}
+
+	var roles []RoleModel
+	if diags := model.Roles.ElementsAs(ctx, &roles, false); diags.HasError() {
+		return nil, fmt.Errorf("failed to read roles: %v", diags)
+	}
+	return roleBindingDtos(roles), nil
+}
+
 // ConvertNewToLegacy converts new enhanced structure to legacy property structure
 // This is used for backward compatibility scenarios
 func ConvertNewToLegacy(ctx context.Context, model *RoleBindingResourceModel) (*RoleBindingResourceModel, error) {
@@ -248,16 +293,32 @@ func convertRoleToList(ctx context.Context, roles []RoleModel) (basetypes.ListVa
 	return types.ListNull(types.ObjectType{}), nil
 }
 
+// convertBindingsToList builds a list of GetBindingModelObjectType objects.
+// An empty slice yields an empty, non-null list.
 func convertBindingsToList(ctx context.Context, bindings []BindingModel) (basetypes.ListValue, error) {
-	// This is a simplified implementation - in practice, you'd use proper Framework types
-	// For now, return a null list as placeholder
-	return types.ListNull(types.ObjectType{}), nil
+	if len(bindings) == 0 {
+		return types.ListValueMust(GetBindingModelObjectType(), []attr.Value{}), nil
+	}
+
+	list, diags := types.ListValueFrom(ctx, GetBindingModelObjectType(), bindings)
+	if diags.HasError() {
+		return types.ListNull(GetBindingModelObjectType()), fmt.Errorf("failed to create bindings list: %v", diags)
+	}
+	return list, nil
 }
 
+// convertMembersToList builds a list of GetLegacyMemberModelObjectType objects.
+// An empty slice yields an empty, non-null list.
 func convertMembersToList(ctx context.Context, members []LegacyMemberModel) (basetypes.ListValue, error) {
-	// This is a simplified implementation - in practice, you'd use proper Framework types
-	// For now, return a null list as placeholder
-	return types.ListNull(types.ObjectType{}), nil
+	if len(members) == 0 {
+		return types.ListValueMust(GetLegacyMemberModelObjectType(), []attr.Value{}), nil
+	}
+
+	list, diags := types.ListValueFrom(ctx, GetLegacyMemberModelObjectType(), members)
+	if diags.HasError() {
+		return types.ListNull(GetLegacyMemberModelObjectType()), fmt.Errorf("failed to create members list: %v", diags)
+	}
+	return list, nil
 }
 
 func convertLegacyMembersToList(ctx context.Context, members []LegacyMemberModel) (basetypes.ListValue, error) {
@@ -269,19 +330,24 @@ func convertLegacyMembersToList(ctx context.Context, members []LegacyMemberModel
 func parseLegacyBinding(bindingId string) (string, string) {
 	// Parse binding ID to extract type and id
 	// Format expected: "type:id" or just "id" (defaults to user)
-	parts := strings.SplitN(bindingId, ":", 2)
-	if len(parts) == 2 {
-		return parts[0], parts[1]
+	member := iam.NormalizeMember(bindingId)
+	if memberType, memberId, ok := strings.Cut(member, ":"); ok {
+		return memberType, memberId
 	}
-	// Default to user type if no prefix
-	return "user", bindingId
+	return iam.DefaultMemberType, member
 }
 
 // Resource ID generation utilities
 
+// GenerateResourceId builds a composite resource ID using iam.DefaultRoleBindingIDDelimiter.
 func GenerateResourceId(tenantId, groupId, roleId string) string {
-	// Generate deterministic resource ID based on key components
-	return fmt.Sprintf("%s-%s-%s-%s", tenantId, groupId, roleId, generateHash(tenantId+groupId+roleId))
+	return GenerateResourceIdWithDelimiter(iam.DefaultRoleBindingIDDelimiter, tenantId, groupId, roleId)
+}
+
+// GenerateResourceIdWithDelimiter builds a deterministic composite resource ID
+// of the form {tenant}{d}{group}{d}{role}{d}{hash}.
+func GenerateResourceIdWithDelimiter(delimiter, tenantId, groupId, roleId string) string {
+	return strings.Join([]string{tenantId, groupId, roleId, generateHash(tenantId + groupId + roleId)}, delimiter)
 }
 
 func generateHash(input string) string {
@@ -297,3 +363,121 @@ func generateHash(input string) string {
 	}
 	return hashStr
 }
+
+// resourceIdHashLength is the length of the hash suffix appended by GenerateResourceId.
+const resourceIdHashLength = 8
+
+// MultiRoleId is the role segment GenerateResourceId receives for bindings
+// that manage several roles through the roles list.
+const MultiRoleId = "multi-role"
+
+// ResourceIdParts holds the components encoded in a composite resource ID.
+type ResourceIdParts struct {
+	TenantId string
+	GroupId  string
+	RoleId   string
+}
+
+// IsCustomRole reports whether the parsed role refers to a custom role.
+func (p *ResourceIdParts) IsCustomRole() bool {
+	_, isCustom := iam.ParseRole(p.RoleId)
+	return isCustom
+}
+
+// ParseResourceId parses a composite ID using iam.DefaultRoleBindingIDDelimiter,
+// see ParseResourceIdWithDelimiter.
+func ParseResourceId(resourceId, tenantId string) (*ResourceIdParts, error) {
+	return ParseResourceIdWithDelimiter(resourceId, tenantId, iam.DefaultRoleBindingIDDelimiter)
+}
+
+// ParseResourceIdWithDelimiter splits a composite ID produced by
+// GenerateResourceIdWithDelimiter back into its tenant, group and role
+// components. IDs that do not contain delimiter are parsed with
+// iam.DefaultRoleBindingIDDelimiter, so state written before a delimiter was
+// configured keeps working, or else in the legacy hyphen-delimited form.
+//
+// When tenantId is non-empty the ID must start with it, which allows tenant IDs
+// containing the delimiter; otherwise the tenant is taken to be the first
+// segment. The group is the next segment and everything up to the hash suffix
+// belongs to the role, which keeps role IDs such as "custom.Foo-Bar" intact.
+func ParseResourceIdWithDelimiter(resourceId, tenantId, delimiter string) (*ResourceIdParts, error) {
+	if resourceId == "" {
+		return nil, fmt.Errorf("resource ID cannot be empty")
+	}
+	if delimiter == "" || !strings.Contains(resourceId, delimiter) {
+		delimiter = iam.LegacyRoleBindingIDDelimiter
+		if strings.Contains(resourceId, iam.DefaultRoleBindingIDDelimiter) {
+			delimiter = iam.DefaultRoleBindingIDDelimiter
+		}
+	}
+	format := strings.Join([]string{"{tenant}", "{group}", "{role}", "{hash}"}, delimiter)
+
+	rest := resourceId
+	if tenantId != "" {
+		if !strings.HasPrefix(rest, tenantId+delimiter) {
+			return nil, fmt.Errorf("resource ID %q does not start with tenant ID %q", resourceId, tenantId)
+		}
+		rest = strings.TrimPrefix(rest, tenantId+delimiter)
+	} else {
+		idx := strings.Index(rest, delimiter)
+		if idx <= 0 {
+			return nil, fmt.Errorf("resource ID %q is missing the tenant segment: expected %s", resourceId, format)
+		}
+		tenantId, rest = rest[:idx], rest[idx+len(delimiter):]
+	}
+
+	hashIdx := strings.LastIndex(rest, delimiter)
+	if hashIdx < 0 {
+		return nil, fmt.Errorf("resource ID %q is missing the hash suffix: expected %s", resourceId, format)
+	}
+	hash := rest[hashIdx+len(delimiter):]
+	rest = rest[:hashIdx]
+	if len(hash) != resourceIdHashLength || strings.Trim(hash, "0123456789abcdef") != "" {
+		return nil, fmt.Errorf("resource ID %q has invalid hash suffix %q: expected %d lowercase hex characters", resourceId, hash, resourceIdHashLength)
+	}
+
+	groupIdx := strings.Index(rest, delimiter)
+	if groupIdx <= 0 {
+		return nil, fmt.Errorf("resource ID %q is missing the group or role segment: expected %s", resourceId, format)
+	}
+	groupId, roleId := rest[:groupIdx], rest[groupIdx+len(delimiter):]
+	if roleId == "" {
+		return nil, fmt.Errorf("resource ID %q is missing the role segment: expected %s", resourceId, format)
+	}
+
+	if expected := generateHash(tenantId + groupId + roleId); hash != expected {
+		return nil, fmt.Errorf("resource ID %q has hash %q, expected %q", resourceId, hash, expected)
+	}
+
+	return &ResourceIdParts{TenantId: tenantId, GroupId: groupId, RoleId: roleId}, nil
+}
+
+// parseImportId parses an import ID like ParseResourceIdWithDelimiter, except
+// that the legacy hyphen-delimited form is rejected: group and role IDs may
+// contain hyphens, so a hand-written legacy ID cannot be split reliably.
+func parseImportId(importId, tenantId, delimiter string) (*ResourceIdParts, error) {
+	if delimiter == "" {
+		delimiter = iam.DefaultRoleBindingIDDelimiter
+	}
+	if delimiter != iam.LegacyRoleBindingIDDelimiter && !strings.Contains(importId, delimiter) {
+		return nil, fmt.Errorf("import ID %q must have the form {tenant}%s{group}%s{role}%s{hash}", importId, delimiter, delimiter, delimiter)
+	}
+	return ParseResourceIdWithDelimiter(importId, tenantId, delimiter)
+}
+
+// UpgradeResourceId rewrites a legacy hyphen-delimited composite ID to use
+// delimiter. IDs already using delimiter are returned unchanged.
+func UpgradeResourceId(resourceId, tenantId, delimiter string) (string, error) {
+	if delimiter == "" {
+		delimiter = iam.DefaultRoleBindingIDDelimiter
+	}
+	if delimiter == iam.LegacyRoleBindingIDDelimiter || strings.Contains(resourceId, delimiter) {
+		return resourceId, nil
+	}
+
+	parts, err := ParseResourceIdWithDelimiter(resourceId, tenantId, iam.LegacyRoleBindingIDDelimiter)
+	if err != nil {
+		return "", err
+	}
+	return GenerateResourceIdWithDelimiter(delimiter, parts.TenantId, parts.GroupId, parts.RoleId), nil
+}
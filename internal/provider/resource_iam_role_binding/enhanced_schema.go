@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -60,6 +61,9 @@ func EnhancedIamRoleBindingResourceSchema(ctx context.Context) schema.Schema {
 					stringvalidator.ConflictsWith(path.MatchRoot("roles")),
 					stringvalidator.LengthAtLeast(1),
 				},
+				PlanModifiers: []planmodifier.String{
+					normalizeRole(),
+				},
 			},
 			"members": schema.ListAttribute{
 				MarkdownDescription: "**Deprecated:** Use `bindings` array instead. List of member identifiers in format 'type:id'.",
@@ -98,6 +102,9 @@ func EnhancedIamRoleBindingResourceSchema(ctx context.Context) schema.Schema {
 							Validators: []validator.String{
 								stringvalidator.LengthAtLeast(1),
 							},
+							PlanModifiers: []planmodifier.String{
+								normalizeRole(),
+							},
 						},
 						"is_custom": schema.BoolAttribute{
 							MarkdownDescription: "Whether this role is a custom role (true) or built-in role (false)",
@@ -165,12 +165,17 @@ func createTestRole(id string, isCustom bool, bindings []string) RoleModel {
 }
 
 func newTestService() *iam.Service {
+	return newTestServiceWithClient(&mockRawClient{})
+}
+
+// newTestServiceWithClient returns a service that sends its requests to raw
+func newTestServiceWithClient(raw iam.RawClient) *iam.Service {
 	s := &iam.Service{}
 	// Use unsafe to set private fields
 	v := reflect.ValueOf(s).Elem()
 	rawClientField := v.FieldByName("rawClient")
 	rawClientPtr := unsafe.Pointer(rawClientField.UnsafeAddr())
-	*(*iam.RawClient)(rawClientPtr) = &mockRawClient{}
+	*(*iam.RawClient)(rawClientPtr) = raw
 
 	tenantIDField := v.FieldByName("tenantID")
 	tenantIDPtr := unsafe.Pointer(tenantIDField.UnsafeAddr())
@@ -471,8 +476,9 @@ func TestIamRoleBindingResource_ImportState(t *testing.T) {
 	r := createTestResource(t)
 	ctx := context.Background()
 
+	importId := GenerateResourceId("test-tenant", "group1", "custom.Foo-Bar")
 	req := resource.ImportStateRequest{
-		ID: "test-import-id",
+		ID: importId,
 	}
 	resp := &resource.ImportStateResponse{}
 
@@ -501,6 +507,25 @@ func TestIamRoleBindingResource_ImportState(t *testing.T) {
 	r.ImportState(ctx, req, resp)
 
 	require.False(t, resp.Diagnostics.HasError())
+
+	var out RoleBindingResourceModel
+	require.False(t, resp.State.Get(ctx, &out).HasError())
+	require.Equal(t, importId, out.Id.ValueString())
+	require.Equal(t, "test-tenant", out.TenantId.ValueString())
+	require.Equal(t, "group1", out.GroupId.ValueString())
+
+	var roles []RoleModel
+	require.False(t, out.Roles.ElementsAs(ctx, &roles, false).HasError())
+	require.Len(t, roles, 1)
+	require.Equal(t, "custom.Foo-Bar", roles[0].Id.ValueString())
+	require.True(t, roles[0].IsCustom.ValueBool())
+
+	t.Run("InvalidId", func(t *testing.T) {
+		resp := &resource.ImportStateResponse{State: emptyState}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: "test-import-id"}, resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Invalid Import ID", resp.Diagnostics.Errors()[0].Summary())
+	})
 }
 
 // Additional test for error handling
@@ -852,14 +877,27 @@ func TestParseLegacyBinding(t *testing.T) {
 		require.Equal(t, "group", bindingType)
 		require.Equal(t, "test-group", bindingId)
 	})
+
+	t.Run("NormalizesFormatting", func(t *testing.T) {
+		for _, input := range []string{"test-user", "user:test-user", "User:test-user", " USER : test-user "} {
+			bindingType, bindingId := parseLegacyBinding(input)
+			require.Equal(t, "user", bindingType, input)
+			require.Equal(t, "test-user", bindingId, input)
+		}
+	})
 }
 
 // TestGenerateResourceId tests resource ID generation
 func TestGenerateResourceId(t *testing.T) {
 	t.Run("GenerateValidId", func(t *testing.T) {
 		id := GenerateResourceId("tenant1", "group1", "role1")
-		require.Contains(t, id, "tenant1-group1-role1-")
-		require.Greater(t, len(id), len("tenant1-group1-role1-"))
+		require.Contains(t, id, "tenant1/group1/role1/")
+		require.Greater(t, len(id), len("tenant1/group1/role1/"))
+	})
+
+	t.Run("GenerateWithDelimiter", func(t *testing.T) {
+		id := GenerateResourceIdWithDelimiter("-", "tenant1", "group1", "role1")
+		require.Equal(t, "tenant1-group1-role1-"+generateHash("tenant1group1role1"), id)
 	})
 
 	t.Run("GenerateDeterministicId", func(t *testing.T) {
@@ -895,6 +933,109 @@ func TestValidateResourceId(t *testing.T) {
 	})
 }
 
+// TestParseResourceId tests parsing composite resource IDs back into their parts
+func TestParseResourceId(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		tenantId string
+		delim    string
+		want     *ResourceIdParts
+		wantErr  string
+	}{
+		{
+			name:     "SimpleRole",
+			id:       GenerateResourceId("tenant1", "group1", "role1"),
+			tenantId: "tenant1",
+			want:     &ResourceIdParts{TenantId: "tenant1", GroupId: "group1", RoleId: "role1"},
+		},
+		{
+			name:     "CustomRoleWithHyphens",
+			id:       GenerateResourceId("tenant1", "group1", "custom.Foo-Bar"),
+			tenantId: "tenant1",
+			want:     &ResourceIdParts{TenantId: "tenant1", GroupId: "group1", RoleId: "custom.Foo-Bar"},
+		},
+		{
+			name:     "DefaultDelimiterUnderConfiguredDelimiter",
+			id:       GenerateResourceId("tenant1", "group-1", "role1"),
+			tenantId: "tenant1",
+			delim:    "::",
+			want:     &ResourceIdParts{TenantId: "tenant1", GroupId: "group-1", RoleId: "role1"},
+		},
+		{
+			name:     "LegacySimpleRole",
+			id:       GenerateResourceIdWithDelimiter("-", "tenant1", "group1", "role1"),
+			tenantId: "tenant1",
+			want:     &ResourceIdParts{TenantId: "tenant1", GroupId: "group1", RoleId: "role1"},
+		},
+		{
+			name:     "LegacyCustomRoleWithHyphens",
+			id:       GenerateResourceIdWithDelimiter("-", "tenant1", "group1", "custom.Foo-Bar"),
+			tenantId: "tenant1",
+			want:     &ResourceIdParts{TenantId: "tenant1", GroupId: "group1", RoleId: "custom.Foo-Bar"},
+		},
+		{
+			name:     "GroupWithHyphens",
+			id:       GenerateResourceId("tenant1", "store-admins", "custom.Foo-Bar"),
+			tenantId: "tenant1",
+			want:     &ResourceIdParts{TenantId: "tenant1", GroupId: "store-admins", RoleId: "custom.Foo-Bar"},
+		},
+		{
+			name:     "TenantWithHyphens",
+			id:       GenerateResourceId("test-tenant", "group1", "role1"),
+			tenantId: "test-tenant",
+			want:     &ResourceIdParts{TenantId: "test-tenant", GroupId: "group1", RoleId: "role1"},
+		},
+		{
+			name: "TenantFromId",
+			id:   GenerateResourceId("tenant1", "group1", "pos.cashier"),
+			want: &ResourceIdParts{TenantId: "tenant1", GroupId: "group1", RoleId: "pos.cashier"},
+		},
+		{
+			name:     "Empty",
+			tenantId: "tenant1",
+			wantErr:  "resource ID cannot be empty",
+		},
+		{
+			name:     "TenantMismatch",
+			id:       GenerateResourceId("tenant2", "group1", "role1"),
+			tenantId: "tenant1",
+			wantErr:  `does not start with tenant ID "tenant1"`,
+		},
+		{
+			name:     "MissingHash",
+			id:       "tenant1-group1-role1",
+			tenantId: "tenant1",
+			wantErr:  "invalid hash suffix",
+		},
+		{
+			name:     "MissingRole",
+			id:       "tenant1-group1-" + generateHash("tenant1group1"),
+			tenantId: "tenant1",
+			wantErr:  "missing the group or role segment",
+		},
+		{
+			name:     "HashMismatch",
+			id:       "tenant1-group1-role1-00000000",
+			tenantId: "tenant1",
+			wantErr:  "expected \"" + generateHash("tenant1group1role1") + "\"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseResourceIdWithDelimiter(tt.id, tt.tenantId, tt.delim)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
 // TestValidateMixedProperties tests mixed properties validation
 func TestValidateMixedProperties(t *testing.T) {
 	ctx := context.Background()
@@ -965,9 +1106,11 @@ func TestHelperFunctions(t *testing.T) {
 	})
 
 	t.Run("IsNotFoundError", func(t *testing.T) {
-		require.True(t, isNotFoundError(errors.New("not found")))
-		require.True(t, isNotFoundError(errors.New("404 error")))
-		require.False(t, isNotFoundError(errors.New("other error")))
+		require.True(t, isNotFoundError(&client.Error{StatusCode: 404, Message: "Not Found"}))
+		require.True(t, isNotFoundError(fmt.Errorf("delete failed: %w", &client.Error{StatusCode: 404})))
+		require.False(t, isNotFoundError(errors.New("not found")))
+		require.False(t, isNotFoundError(errors.New("404 error")))
+		require.False(t, isNotFoundError(&client.Error{StatusCode: 400, Message: "role not found in request"}))
 	})
 
 	t.Run("GenerateUUID", func(t *testing.T) {
@@ -1237,7 +1380,9 @@ func TestSimpleIamRoleBindingResource_Create_Success(t *testing.T) {
 }
 
 func TestSimpleIamRoleBindingResource_Read_Success(t *testing.T) {
-	r := createTestSimpleResource(t)
+	r := newSimpleResourceWithClient(&groupRolesMock{roles: []iam.RoleBindingDto{
+		{RoleID: "testrole", IsCustom: true, Bindings: []string{"user:test-user"}},
+	}})
 
 	// Use a properly formatted ID: tenantId-groupId-roleId-hash
 	// Note: IDs cannot contain hyphens due to parsing logic
@@ -1326,11 +1471,6 @@ func TestSimpleIamRoleBindingResource_ImportState(t *testing.T) {
 	r := createTestSimpleResource(t)
 	ctx := context.Background()
 
-	req := resource.ImportStateRequest{
-		ID: "test-tenant-test-group-test-role-12345678",
-	}
-	resp := &resource.ImportStateResponse{}
-
 	var schemaResp resource.SchemaResponse
 	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
 
@@ -1341,10 +1481,32 @@ func TestSimpleIamRoleBindingResource_ImportState(t *testing.T) {
 	emptyState := tfsdk.State{Schema: schemaResp.Schema}
 	diags := emptyState.Set(context.Background(), &emptyModel)
 	require.False(t, diags.HasError())
-	resp.State = emptyState
 
-	r.ImportState(ctx, req, resp)
-	require.False(t, resp.Diagnostics.HasError())
+	importId := GenerateResourceId("testtenant", "store-managers-se", "custom.Foo-Bar")
+	resp := &resource.ImportStateResponse{State: emptyState}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: importId}, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var out SimpleRoleBindingResourceModel
+	require.False(t, resp.State.Get(ctx, &out).HasError())
+	require.Equal(t, importId, out.ID.ValueString())
+	require.Equal(t, "testtenant", out.TenantID.ValueString())
+	require.Equal(t, "store-managers-se", out.GroupID.ValueString())
+	require.Equal(t, "custom.Foo-Bar", out.RoleID.ValueString())
+
+	t.Run("LegacyIdRejected", func(t *testing.T) {
+		resp := &resource.ImportStateResponse{State: emptyState}
+		legacyId := GenerateResourceIdWithDelimiter(iam.LegacyRoleBindingIDDelimiter, "testtenant", "store-managers-se", "admin")
+		r.ImportState(ctx, resource.ImportStateRequest{ID: legacyId}, resp)
+		require.True(t, resp.Diagnostics.HasError())
+		require.Equal(t, "Invalid Import ID", resp.Diagnostics.Errors()[0].Summary())
+	})
+
+	t.Run("InvalidId", func(t *testing.T) {
+		resp := &resource.ImportStateResponse{State: emptyState}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: "testtenant/store-managers-se"}, resp)
+		require.True(t, resp.Diagnostics.HasError())
+	})
 }
 
 // Test conversion utility functions
@@ -1355,7 +1517,9 @@ func TestConvertBindingsToList(t *testing.T) {
 		bindings := []BindingModel{}
 		result, err := convertBindingsToList(ctx, bindings)
 		require.NoError(t, err)
-		require.True(t, result.IsNull())
+		require.False(t, result.IsNull())
+		require.Empty(t, result.Elements())
+		require.Equal(t, GetBindingModelObjectType(), result.ElementType(ctx))
 	})
 
 	t.Run("ConvertSingleBinding", func(t *testing.T) {
@@ -1364,7 +1528,11 @@ func TestConvertBindingsToList(t *testing.T) {
 		}
 		result, err := convertBindingsToList(ctx, bindings)
 		require.NoError(t, err)
-		require.True(t, result.IsNull()) // Currently returns null as placeholder
+		require.False(t, result.IsNull())
+
+		var got []BindingModel
+		require.False(t, result.ElementsAs(ctx, &got, false).HasError())
+		require.Equal(t, bindings, got)
 	})
 }
 
@@ -1375,7 +1543,9 @@ func TestConvertMembersToList(t *testing.T) {
 		members := []LegacyMemberModel{}
 		result, err := convertMembersToList(ctx, members)
 		require.NoError(t, err)
-		require.True(t, result.IsNull())
+		require.False(t, result.IsNull())
+		require.Empty(t, result.Elements())
+		require.Equal(t, GetLegacyMemberModelObjectType(), result.ElementType(ctx))
 	})
 
 	t.Run("ConvertSingleMember", func(t *testing.T) {
@@ -1384,6 +1554,30 @@ func TestConvertMembersToList(t *testing.T) {
 		}
 		result, err := convertMembersToList(ctx, members)
 		require.NoError(t, err)
-		require.True(t, result.IsNull()) // Currently returns null as placeholder
+		require.False(t, result.IsNull())
+
+		var got []LegacyMemberModel
+		require.False(t, result.ElementsAs(ctx, &got, false).HasError())
+		require.Equal(t, members, got)
 	})
 }
+
+func TestManagedRoles(t *testing.T) {
+	model := createTestModelWithNewProperties("test-group", []RoleModel{
+		createTestRole("roles/custom.test-role", true, []string{"bu:001"}),
+		createTestRole("roles/pos.cashier", false, nil),
+	})
+
+	roles, err := managedRoles(context.Background(), &model)
+	require.NoError(t, err)
+	require.Equal(t, []iam.RoleBindingDto{
+		{RoleID: "test-role", IsCustom: true, Bindings: []string{"bu:001"}},
+		{RoleID: "pos.cashier"},
+	}, roles)
+
+	empty := createTestModelWithNewProperties("test-group", nil)
+	empty.Roles = types.ListNull(empty.Roles.ElementType(context.Background()))
+	roles, err = managedRoles(context.Background(), &empty)
+	require.NoError(t, err)
+	require.Empty(t, roles)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -47,22 +46,25 @@ func (r *IamRoleBindingResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
-	r.iamService = iam.NewService(client, client.TenantID())
+	r.client = providerData.Client
+	r.iamService = providerData.Service
 }
 
 func (r *IamRoleBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	// Look each group and custom role up at most once in this operation
+	ctx = iam.WithOperationCache(ctx)
+
 	fmt.Printf("=== ENHANCED ROLE BINDING CREATE START ===\n")
 	var data RoleBindingResourceModel
 
@@ -126,54 +128,24 @@ func (r *IamRoleBindingResource) Create(ctx context.Context, req resource.Create
 	// We'll use the terraform group ID directly - no need to look up or create groups
 	// The group resource handles group creation, role binding just adds roles to groups
 
-	// Step 2: Add roles to the group using the V2 API pattern
-	fmt.Printf("=== ENHANCED: About to add %d roles to group %s ===\n", len(roles), terraformGroupId)
-	var assignedRoles []string
-	for _, role := range roles {
-		// Parse role ID and get custom flag from config
-		roleValue := role.Id.ValueString()
-		isCustom := role.IsCustom.ValueBool() // Use the is_custom field from config
-		roleId := strings.TrimPrefix(roleValue, "roles/")
-		if isCustom {
-			roleId = strings.TrimPrefix(roleId, "custom.")
-		}
-
-		// Extract bindings from the role
-		var bindings []string
-		for _, binding := range role.Bindings.Elements() {
-			if bindingStr, ok := binding.(types.String); ok {
-				bindings = append(bindings, bindingStr.ValueString())
-			}
-		}
-
-		tflog.Debug(ctx, "Adding role to group", map[string]interface{}{
-			"group_id":  terraformGroupId,
-			"role_id":   roleId,
-			"is_custom": isCustom,
-			"bindings":  bindings,
-		})
-
-		// Use the AddRoleToGroup method with specific bindings
-		fmt.Printf("=== DEBUG: About to call AddRoleToGroup with group ID: %s ===\n", terraformGroupId)
-		err := r.iamService.AddRoleToGroup(ctx, terraformGroupId, roleId, isCustom, bindings)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Adding Role to Group",
-				fmt.Sprintf("Could not add role %s to group %s, unexpected error: %s", roleId, terraformGroupId, err.Error()),
-			)
-			return
-		}
-
-		assignedRoles = append(assignedRoles, roleValue)
-		tflog.Debug(ctx, "Added role to group", map[string]interface{}{
-			"role_id":  roleId,
-			"group_id": terraformGroupId,
-		})
+	// Step 2: Add the roles to the group using the V2 API pattern, reverting
+	// the ones already added if any fails
+	desired := roleBindingDtos(roles)
+	tflog.Debug(ctx, "Adding roles to group", map[string]interface{}{
+		"group_id": terraformGroupId,
+		"roles":    len(desired),
+	})
+	if err := r.iamService.ApplyGroupRoles(ctx, terraformGroupId, nil, desired); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Adding Roles to Group",
+			fmt.Sprintf("Could not add roles to group %s, unexpected error: %s", terraformGroupId, err.Error()),
+		)
+		return
 	}
 
 	// Generate a composite ID for the enhanced resource (since it manages multiple role bindings)
 	// Store the individual binding IDs in the composite ID for later retrieval
-	compositeId := GenerateResourceId(r.client.TenantID(), workingModel.GroupId.ValueString(), "multi-role")
+	compositeId := GenerateResourceIdWithDelimiter(roleBindingIDDelimiter(r.iamService), r.client.TenantID(), workingModel.GroupId.ValueString(), MultiRoleId)
 
 	// Store the created binding IDs for later retrieval (we'll need them for Read/Update/Delete)
 	// For now, we'll use a simple approach - in a real implementation, this might need a more sophisticated tracking mechanism
@@ -210,7 +182,7 @@ func (r *IamRoleBindingResource) Create(ctx context.Context, req resource.Create
 	tflog.Trace(ctx, "Created IAM role binding resource", map[string]interface{}{
 		"id":               compositeId,
 		"property_type":    validationResult.PropertyMix,
-		"created_bindings": len(assignedRoles),
+		"created_bindings": len(desired),
 	})
 }
 
@@ -255,6 +227,9 @@ func (r *IamRoleBindingResource) Read(ctx context.Context, req resource.ReadRequ
 }
 
 func (r *IamRoleBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Look each group and custom role up at most once in this operation
+	ctx = iam.WithOperationCache(ctx)
+
 	var data RoleBindingResourceModel
 
 	// Read Terraform plan data into the model
@@ -345,52 +320,34 @@ func (r *IamRoleBindingResource) Update(ctx context.Context, req resource.Update
 		}
 	}
 
-	// Step 2: Add roles to the group using the V2 API pattern
-	fmt.Printf("=== ENHANCED UPDATE: About to add %d roles to group %s ===\n", len(roles), existingGroup.ID)
-	var assignedRoles []string
-	for _, role := range roles {
-		// Parse role ID and get custom flag from config
-		roleValue := role.Id.ValueString()
-		isCustom := role.IsCustom.ValueBool() // Use the is_custom field from config
-		roleId := strings.TrimPrefix(roleValue, "roles/")
-		if isCustom {
-			roleId = strings.TrimPrefix(roleId, "custom.")
-		}
-
-		// Extract bindings from the role
-		var bindings []string
-		for _, binding := range role.Bindings.Elements() {
-			if bindingStr, ok := binding.(types.String); ok {
-				bindings = append(bindings, bindingStr.ValueString())
-			}
-		}
-
-		tflog.Debug(ctx, "Adding role to group in update", map[string]interface{}{
-			"group_id":  existingGroup.ID,
-			"role_id":   roleId,
-			"is_custom": isCustom,
-			"bindings":  bindings,
-		})
-
-		// Use the AddRoleToGroup method with specific bindings
-		err := r.iamService.AddRoleToGroup(ctx, existingGroup.ID, roleId, isCustom, bindings)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Error Adding Role to Group in Update",
-				fmt.Sprintf("Could not add role %s to group %s during update, unexpected error: %s", roleId, existingGroup.ID, err.Error()),
-			)
-			return
-		}
-
-		assignedRoles = append(assignedRoles, roleValue)
-		tflog.Debug(ctx, "Added role to group in update", map[string]interface{}{
-			"role_id":  roleId,
-			"group_id": existingGroup.ID,
-		})
+	// Step 2: Apply only the role changes since the prior state, reverting
+	// them if any fails
+	var prior RoleBindingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	previous, err := managedRoles(ctx, &prior)
+	if err != nil {
+		resp.Diagnostics.AddError("Legacy Property Conversion Failed", err.Error())
+		return
+	}
+	desired := roleBindingDtos(roles)
+	tflog.Debug(ctx, "Applying role changes to group in update", map[string]interface{}{
+		"group_id":       existingGroup.ID,
+		"previous_roles": len(previous),
+		"desired_roles":  len(desired),
+	})
+	if err := r.iamService.ApplyGroupRoles(ctx, existingGroup.ID, previous, desired); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Roles on Group",
+			fmt.Sprintf("Could not update roles on group %s, unexpected error: %s", existingGroup.ID, err.Error()),
+		)
+		return
 	}
 
 	// Generate a new composite ID for the updated resource
-	compositeId := GenerateResourceId(r.client.TenantID(), workingModel.GroupId.ValueString(), "multi-role")
+	compositeId := GenerateResourceIdWithDelimiter(roleBindingIDDelimiter(r.iamService), r.client.TenantID(), workingModel.GroupId.ValueString(), MultiRoleId)
 
 	// Update the model with response data
 	data.Id = types.StringValue(compositeId)
@@ -424,7 +381,7 @@ func (r *IamRoleBindingResource) Update(ctx context.Context, req resource.Update
 	tflog.Trace(ctx, "Updated IAM role binding resource", map[string]interface{}{
 		"id":               compositeId,
 		"property_type":    validationResult.PropertyMix,
-		"updated_bindings": len(assignedRoles),
+		"updated_bindings": len(desired),
 	})
 }
 
@@ -458,8 +415,44 @@ func (r *IamRoleBindingResource) Delete(ctx context.Context, req resource.Delete
 	})
 }
 
+// ImportState accepts the composite {tenant}/{group}/{role}/{hash} ID produced by
+// GenerateResourceId and populates tenant_id, group_id and, for single-role IDs,
+// the roles list so the imported state lines up with configuration.
 func (r *IamRoleBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	tenantId := ""
+	if r.client != nil {
+		tenantId = r.client.TenantID()
+	}
+
+	parts, err := parseImportId(req.ID, tenantId, roleBindingIDDelimiter(r.iamService))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Unable to import IAM role binding: %s", err),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_id"), parts.TenantId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), parts.GroupId)...)
+
+	// Bindings created with several roles carry a placeholder role segment,
+	// so the individual roles are left for Read to populate.
+	if parts.RoleId == MultiRoleId {
+		return
+	}
+
+	roles, diags := types.ListValueFrom(ctx, GetRoleModelObjectType(), []RoleModel{{
+		Id:       types.StringValue(parts.RoleId),
+		IsCustom: types.BoolValue(parts.IsCustomRole()),
+		Bindings: types.ListNull(types.StringType),
+	}})
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("roles"), roles)...)
 }
 
 // API interaction methods
@@ -517,9 +510,7 @@ func (r *IamRoleBindingResource) deleteRoleBinding(ctx context.Context, id strin
 // Helper functions
 
 func isNotFoundError(err error) bool {
-	// TODO: Implement proper error detection
-	// This should check if the error indicates a 404 Not Found response
-	return strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "404")
+	return client.IsNotFoundError(err)
 }
 
 func generateUUID() string {
@@ -0,0 +1,54 @@
+package resource_iam_role_binding
+
+import (
+	"context"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// normalizeRolePlanModifier keeps the prior state value of a role attribute
+// when the configured value names the same role in another form, such as
+// "custom.Foo" for "roles/custom.Foo". Terraform accepts the prior value as
+// the plan for a non-computed attribute, so no change is shown.
+type normalizeRolePlanModifier struct{}
+
+// normalizeRole returns the role normalizing plan modifier
+func normalizeRole() planmodifier.String {
+	return normalizeRolePlanModifier{}
+}
+
+func (m normalizeRolePlanModifier) Description(ctx context.Context) string {
+	return "roles written as roles/custom.<id>, custom.<id> or <id> are compared by role, not by spelling"
+}
+
+func (m normalizeRolePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return "roles written as `roles/custom.<id>`, `custom.<id>` or `<id>` are compared by role, not by spelling"
+}
+
+func (m normalizeRolePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() || req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+
+	planID, planCustom := iam.ParseRole(req.PlanValue.ValueString())
+	stateID, stateCustom := iam.ParseRole(req.StateValue.ValueString())
+
+	// A plain "<id>" is also a custom role when a sibling is_custom says so
+	siblingPath := req.Path.ParentPath().AtName("is_custom")
+	var planIsCustom, stateIsCustom types.Bool
+	if diags := req.Plan.GetAttribute(ctx, siblingPath, &planIsCustom); !diags.HasError() {
+		planCustom = planCustom || planIsCustom.ValueBool()
+	}
+	if diags := req.State.GetAttribute(ctx, siblingPath, &stateIsCustom); !diags.HasError() {
+		stateCustom = stateCustom || stateIsCustom.ValueBool()
+	}
+
+	if planID == stateID && planCustom == stateCustom {
+		resp.PlanValue = req.StateValue
+	}
+}
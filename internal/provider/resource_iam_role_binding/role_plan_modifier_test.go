@@ -0,0 +1,258 @@
+package resource_iam_role_binding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+// roleModifierModel returns an enhanced model with role set, or with a single
+// roles entry when roleID is set
+func roleModifierModel(t *testing.T, role, roleID string, isCustom bool) RoleBindingResourceModel {
+	model := RoleBindingResourceModel{
+		Id:             types.StringValue("t-g1"),
+		TenantId:       types.StringValue("t"),
+		Name:           types.StringNull(),
+		Role:           types.StringNull(),
+		Members:        types.ListNull(types.StringType),
+		GroupId:        types.StringValue("g1"),
+		Roles:          types.ListNull(GetRoleModelObjectType()),
+		Description:    types.StringNull(),
+		Condition:      types.StringNull(),
+		RoleId:         types.StringNull(),
+		BindingsLegacy: types.ListValueMust(types.StringType, []attr.Value{}),
+	}
+	if role != "" {
+		model.Role = types.StringValue(role)
+	}
+	if roleID != "" {
+		entry, diags := types.ObjectValue(GetRoleModelObjectType().AttrTypes, map[string]attr.Value{
+			"id":        types.StringValue(roleID),
+			"is_custom": types.BoolValue(isCustom),
+			"bindings":  types.ListValueMust(types.StringType, []attr.Value{types.StringValue("bu:001")}),
+		})
+		require.False(t, diags.HasError(), "%v", diags)
+		model.Roles = types.ListValueMust(GetRoleModelObjectType(), []attr.Value{entry})
+	}
+	return model
+}
+
+func runNormalizeRole(t *testing.T, at path.Path, plan, state RoleBindingResourceModel) types.String {
+	ctx := context.Background()
+	s := EnhancedIamRoleBindingResourceSchema(ctx)
+
+	req := planmodifier.StringRequest{
+		Path:  at,
+		Plan:  tfsdk.Plan{Schema: s},
+		State: tfsdk.State{Schema: s},
+	}
+	require.False(t, req.Plan.Set(ctx, &plan).HasError())
+	require.False(t, req.State.Set(ctx, &state).HasError())
+	require.False(t, req.Plan.GetAttribute(ctx, at, &req.PlanValue).HasError())
+	require.False(t, req.State.GetAttribute(ctx, at, &req.StateValue).HasError())
+	req.ConfigValue = req.PlanValue
+
+	resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	normalizeRole().PlanModifyString(ctx, req, resp)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	return resp.PlanValue
+}
+
+func TestNormalizeRolePlanModifier(t *testing.T) {
+	rolePath := path.Root("role")
+	rolesIDPath := path.Root("roles").AtListIndex(0).AtName("id")
+
+	tests := []struct {
+		name        string
+		at          path.Path
+		plan, state RoleBindingResourceModel
+		want        string
+	}{
+		{
+			name:  "role custom prefix matches state",
+			at:    rolePath,
+			plan:  roleModifierModel(t, "custom.Foo", "", false),
+			state: roleModifierModel(t, "roles/custom.Foo", "", false),
+			want:  "roles/custom.Foo",
+		},
+		{
+			name:  "roles id custom prefix matches state",
+			at:    rolesIDPath,
+			plan:  roleModifierModel(t, "", "custom.Foo", true),
+			state: roleModifierModel(t, "", "roles/custom.Foo", true),
+			want:  "roles/custom.Foo",
+		},
+		{
+			name:  "roles id plain with is_custom matches state",
+			at:    rolesIDPath,
+			plan:  roleModifierModel(t, "", "Foo", true),
+			state: roleModifierModel(t, "", "roles/custom.Foo", true),
+			want:  "roles/custom.Foo",
+		},
+		{
+			name:  "plain built-in role differs from custom role",
+			at:    rolePath,
+			plan:  roleModifierModel(t, "Foo", "", false),
+			state: roleModifierModel(t, "custom.Foo", "", false),
+			want:  "Foo",
+		},
+		{
+			name:  "different role is planned",
+			at:    rolesIDPath,
+			plan:  roleModifierModel(t, "", "custom.Bar", true),
+			state: roleModifierModel(t, "", "roles/custom.Foo", true),
+			want:  "custom.Bar",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := runNormalizeRole(t, tt.at, tt.plan, tt.state)
+			require.Equal(t, tt.want, got.ValueString())
+		})
+	}
+}
+
+func TestNormalizeRolePlanModifier_Create(t *testing.T) {
+	ctx := context.Background()
+	req := planmodifier.StringRequest{
+		Path:        path.Root("role"),
+		PlanValue:   types.StringValue("custom.Foo"),
+		ConfigValue: types.StringValue("custom.Foo"),
+		StateValue:  types.StringNull(),
+	}
+	resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+	normalizeRole().PlanModifyString(ctx, req, resp)
+	require.Equal(t, "custom.Foo", resp.PlanValue.ValueString())
+}
+
+func TestRoleBindingDtos_ParsesRoleIDs(t *testing.T) {
+	role := func(id string, isCustom bool) RoleModel {
+		return RoleModel{
+			Id:       types.StringValue(id),
+			IsCustom: types.BoolValue(isCustom),
+			Bindings: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("bu:001")}),
+		}
+	}
+
+	dtos := roleBindingDtos([]RoleModel{
+		role("roles/custom.Foo", true),
+		role("custom-roles/Bar", false),
+		role("Baz", true),
+		role("roles/Admin", false),
+	})
+
+	got := make([]iam.RoleBindingDto, len(dtos))
+	for i, dto := range dtos {
+		got[i] = iam.RoleBindingDto{RoleID: dto.RoleID, IsCustom: dto.IsCustom}
+	}
+	require.Equal(t, []iam.RoleBindingDto{
+		{RoleID: "Foo", IsCustom: true},
+		{RoleID: "Bar", IsCustom: true},
+		{RoleID: "Baz", IsCustom: true},
+		{RoleID: "Admin"},
+	}, got)
+
+	parts := ResourceIdParts{RoleId: "custom-roles/Foo"}
+	require.True(t, parts.IsCustomRole())
+}
+
+// roleBindingTestProvider serves only the registered role binding resource,
+// so its plan can be computed through the protocol server
+type roleBindingTestProvider struct{}
+
+func (p roleBindingTestProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "hiiretail"
+}
+
+func (p roleBindingTestProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+}
+
+func (p roleBindingTestProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+}
+
+func (p roleBindingTestProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{NewSimpleIamRoleBindingResource}
+}
+
+func (p roleBindingTestProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+// planSimpleRoleBinding plans a change of role_id from the stored stateRole
+// to configRole on hiiretail_iam_role_binding, both with is_custom = true
+func planSimpleRoleBinding(t *testing.T, stateRole, configRole string) *tfprotov6.PlanResourceChangeResponse {
+	ctx := context.Background()
+	objectType := SimpleIamRoleBindingResourceSchema(ctx).Type().TerraformType(ctx)
+
+	value := func(id, tenantID tftypes.Value, role string) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"id":          id,
+			"tenant_id":   tenantID,
+			"group_id":    tftypes.NewValue(tftypes.String, "g1"),
+			"role_id":     tftypes.NewValue(tftypes.String, role),
+			"is_custom":   tftypes.NewValue(tftypes.Bool, true),
+			"bindings":    tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+			"description": tftypes.NewValue(tftypes.String, nil),
+			"condition":   tftypes.NewValue(tftypes.String, nil),
+		}))
+		require.NoError(t, err)
+		return &dv
+	}
+	id := tftypes.NewValue(tftypes.String, GenerateResourceId("t", "g1", stateRole))
+	tenantID := tftypes.NewValue(tftypes.String, "t")
+	null := tftypes.NewValue(tftypes.String, nil)
+
+	server, err := providerserver.NewProtocol6WithError(roleBindingTestProvider{})()
+	require.NoError(t, err)
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "hiiretail_iam_role_binding",
+		PriorState:       value(id, tenantID, stateRole),
+		ProposedNewState: value(id, tenantID, configRole),
+		Config:           value(null, null, configRole),
+	})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		require.NotEqual(t, tfprotov6.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+	return resp
+}
+
+func TestSimpleIamRoleBindingResource_PlanNormalizesRole(t *testing.T) {
+	ctx := context.Background()
+	objectType := SimpleIamRoleBindingResourceSchema(ctx).Type().TerraformType(ctx)
+
+	plannedRole := func(t *testing.T, resp *tfprotov6.PlanResourceChangeResponse) string {
+		planned, err := resp.PlannedState.Unmarshal(objectType)
+		require.NoError(t, err)
+		var attrs map[string]tftypes.Value
+		require.NoError(t, planned.As(&attrs))
+		var role string
+		require.NoError(t, attrs["role_id"].As(&role))
+		return role
+	}
+
+	t.Run("same role in another form", func(t *testing.T) {
+		resp := planSimpleRoleBinding(t, "Foo", "custom.Foo")
+		require.Empty(t, resp.RequiresReplace)
+		require.Equal(t, "Foo", plannedRole(t, resp))
+	})
+
+	t.Run("different role", func(t *testing.T) {
+		resp := planSimpleRoleBinding(t, "Foo", "custom.Bar")
+		require.Equal(t, []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("role_id")}, resp.RequiresReplace)
+		require.Equal(t, "custom.Bar", plannedRole(t, resp))
+	})
+}
@@ -0,0 +1,76 @@
+package resource_iam_role_binding
+
+import (
+	"context"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+)
+
+// findGroupRole returns the V2 assignment of roleID among the roles bound to a
+// group, preferring the one whose custom flag matches
+func findGroupRole(roles []iam.RoleBindingDto, roleID string, isCustom bool) (iam.RoleBindingDto, bool) {
+	var match *iam.RoleBindingDto
+	for i := range roles {
+		if roles[i].RoleID != roleID {
+			continue
+		}
+		if roles[i].IsCustom == isCustom {
+			return roles[i], true
+		}
+		if match == nil {
+			match = &roles[i]
+		}
+	}
+	if match == nil {
+		return iam.RoleBindingDto{}, false
+	}
+	return *match, true
+}
+
+// simpleBindingsFromAPI builds the bindings attribute from the bindings the
+// API reports for a role assignment.
+//
+// The API does not preserve ordering, so the prior order is kept whenever the
+// prior value holds the same set and a sorted list is used otherwise. A null
+// prior stays null when the API reports only the bindings AddRoleToGroup sends
+// for a role configured without any.
+func simpleBindingsFromAPI(ctx context.Context, prior types.List, actual, implicit []string) (types.List, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if prior.IsNull() || prior.IsUnknown() {
+		if len(actual) == 0 || sameBindingSet(actual, implicit) {
+			return types.ListNull(types.StringType), diags
+		}
+	} else {
+		var previous []string
+		diags.Append(prior.ElementsAs(ctx, &previous, false)...)
+		if diags.HasError() {
+			return prior, diags
+		}
+		if sameBindingSet(previous, actual) {
+			return prior, diags
+		}
+		// An empty configured list is sent as the implicit bindings
+		if len(previous) == 0 && sameBindingSet(actual, implicit) {
+			return prior, diags
+		}
+	}
+
+	sorted := slices.Clone(actual)
+	slices.Sort(sorted)
+	list, d := types.ListValueFrom(ctx, types.StringType, sorted)
+	diags.Append(d...)
+	return list, diags
+}
+
+// sameBindingSet reports whether a and b hold the same bindings, ignoring order
+// and duplicates
+func sameBindingSet(a, b []string) bool {
+	a = slices.Compact(slices.Sorted(slices.Values(a)))
+	b = slices.Compact(slices.Sorted(slices.Values(b)))
+	return slices.Equal(a, b)
+}
@@ -0,0 +1,428 @@
+package resource_iam_role_binding
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/client"
+)
+
+// groupRolesMock stores the roles posted to a group and lists them back with
+// their bindings reversed, as the V2 API does not preserve ordering. Custom
+// roles among them can be looked up by ID, and roles are removed by DELETE.
+type groupRolesMock struct {
+	roles []iam.RoleBindingDto
+	posts int
+}
+
+func (m *groupRolesMock) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	groupRoles := strings.Contains(req.Path, "/groups/") && strings.HasSuffix(req.Path, "/roles")
+	switch {
+	case req.Method == "POST" && groupRoles:
+		m.posts++
+		payload := req.Body.(map[string]interface{})
+		posted := iam.RoleBindingDto{
+			RoleID:   payload["roleId"].(string),
+			IsCustom: payload["isCustom"].(bool),
+			Bindings: slices.Clone(payload["bindings"].([]string)),
+		}
+		for i, role := range m.roles {
+			if role.RoleID == posted.RoleID && role.IsCustom == posted.IsCustom {
+				m.roles[i] = posted
+				return &client.Response{StatusCode: 201, Body: []byte("{}")}, nil
+			}
+		}
+		m.roles = append(m.roles, posted)
+		return &client.Response{StatusCode: 201, Body: []byte("{}")}, nil
+	case req.Method == "GET" && !groupRoles && strings.Contains(req.Path, "/roles/"):
+		for _, role := range m.roles {
+			if role.IsCustom && strings.HasSuffix(req.Path, "/roles/"+role.RoleID) {
+				body, _ := json.Marshal(iam.CustomRole{ID: role.RoleID, Name: role.RoleID})
+				return &client.Response{StatusCode: 200, Body: body}, nil
+			}
+		}
+	case req.Method == "DELETE" && strings.Contains(req.Path, "/groups/"):
+		for i, role := range m.roles {
+			if strings.HasSuffix(req.Path, "/roles/"+role.RoleID) {
+				m.roles = slices.Delete(m.roles, i, i+1)
+				return &client.Response{StatusCode: 204}, nil
+			}
+		}
+	case req.Method == "GET" && groupRoles:
+		listed := make([]iam.RoleBindingDto, len(m.roles))
+		for i, role := range m.roles {
+			role.Bindings = slices.Clone(role.Bindings)
+			slices.Reverse(role.Bindings)
+			listed[i] = role
+		}
+		body, _ := json.Marshal(listed)
+		return &client.Response{StatusCode: 200, Body: body}, nil
+	}
+	return &client.Response{StatusCode: 404, Body: []byte(`{"message":"not found"}`)}, nil
+}
+
+func newSimpleResourceWithClient(raw iam.RawClient) *SimpleIamRoleBindingResource {
+	r := NewSimpleIamRoleBindingResource().(*SimpleIamRoleBindingResource)
+	enhancedPtr := (*IamRoleBindingResource)(unsafe.Pointer(r))
+	setServiceField(enhancedPtr, newTestServiceWithClient(raw))
+	setClientField(enhancedPtr, newTestClientForSimpleResource())
+	return r
+}
+
+func readSimpleBinding(t *testing.T, r *SimpleIamRoleBindingResource, state SimpleRoleBindingResourceModel) SimpleRoleBindingResourceModel {
+	t.Helper()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	var rreq resource.ReadRequest
+	rreq.State.Schema = schemaResp.Schema
+	require.False(t, rreq.State.Set(context.Background(), state).HasError())
+
+	var rresp resource.ReadResponse
+	rresp.State.Schema = schemaResp.Schema
+	r.Read(context.Background(), rreq, &rresp)
+	require.False(t, rresp.Diagnostics.HasError(), "%v", rresp.Diagnostics)
+
+	var out SimpleRoleBindingResourceModel
+	require.False(t, rresp.State.Get(context.Background(), &out).HasError())
+	return out
+}
+
+func bindingStrings(t *testing.T, list types.List) []string {
+	t.Helper()
+
+	var out []string
+	require.False(t, list.ElementsAs(context.Background(), &out, false).HasError())
+	return out
+}
+
+func TestSimpleIamRoleBindingResource_ReadBindings(t *testing.T) {
+	mock := &groupRolesMock{}
+	r := newSimpleResourceWithClient(mock)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	model := createTestSimpleModel("testgroup", "testrole", false, []string{"bu:001", "bu:002"})
+	var creq resource.CreateRequest
+	creq.Plan.Schema = schemaResp.Schema
+	require.False(t, creq.Plan.Set(context.Background(), model).HasError())
+	var cresp resource.CreateResponse
+	cresp.State.Schema = schemaResp.Schema
+	r.Create(context.Background(), creq, &cresp)
+	require.False(t, cresp.Diagnostics.HasError(), "%v", cresp.Diagnostics)
+
+	var created SimpleRoleBindingResourceModel
+	require.False(t, cresp.State.Get(context.Background(), &created).HasError())
+
+	t.Run("refresh keeps the configured order", func(t *testing.T) {
+		out := readSimpleBinding(t, r, created)
+		require.Equal(t, []string{"bu:001", "bu:002"}, bindingStrings(t, out.Bindings))
+	})
+
+	t.Run("prior order is kept for the same set", func(t *testing.T) {
+		prior := created
+		prior.Bindings, _ = types.ListValueFrom(context.Background(), types.StringType, []string{"bu:002", "bu:001"})
+		out := readSimpleBinding(t, r, prior)
+		require.Equal(t, []string{"bu:002", "bu:001"}, bindingStrings(t, out.Bindings))
+	})
+
+	t.Run("import reads the bindings sorted", func(t *testing.T) {
+		imported := SimpleRoleBindingResourceModel{
+			ID:       created.ID,
+			TenantID: types.StringNull(),
+			Bindings: types.ListNull(types.StringType),
+		}
+		out := readSimpleBinding(t, r, imported)
+		require.Equal(t, "testgroup", out.GroupID.ValueString())
+		require.Equal(t, []string{"bu:001", "bu:002"}, bindingStrings(t, out.Bindings))
+	})
+
+	t.Run("drift is reported", func(t *testing.T) {
+		mock.roles[0].Bindings = []string{"bu:003", "bu:001"}
+		defer func() { mock.roles[0].Bindings = []string{"bu:001", "bu:002"} }()
+
+		out := readSimpleBinding(t, r, created)
+		require.Equal(t, []string{"bu:001", "bu:003"}, bindingStrings(t, out.Bindings))
+	})
+}
+
+func TestSimpleBindingsFromAPI(t *testing.T) {
+	ctx := context.Background()
+	implicit := []string{"*"}
+
+	t.Run("null prior stays null for the implicit bindings", func(t *testing.T) {
+		out, diags := simpleBindingsFromAPI(ctx, types.ListNull(types.StringType), []string{"*"}, implicit)
+		require.False(t, diags.HasError())
+		require.True(t, out.IsNull())
+	})
+
+	t.Run("empty prior stays empty for the implicit bindings", func(t *testing.T) {
+		prior, _ := types.ListValueFrom(ctx, types.StringType, []string{})
+		out, diags := simpleBindingsFromAPI(ctx, prior, []string{"*"}, implicit)
+		require.False(t, diags.HasError())
+		require.Empty(t, bindingStrings(t, out))
+		require.False(t, out.IsNull())
+	})
+}
+
+func TestSimpleIamRoleBindingResource_ReadRemovesMissing(t *testing.T) {
+	id := GenerateResourceId("testtenant", "testgroup", "testrole")
+	state := createTestSimpleModel("testgroup", "testrole", false, []string{"bu:001"})
+	state.ID = types.StringValue(id)
+
+	read := func(t *testing.T, raw iam.RawClient) resource.ReadResponse {
+		t.Helper()
+		r := newSimpleResourceWithClient(raw)
+		var schemaResp resource.SchemaResponse
+		r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+		var rreq resource.ReadRequest
+		rreq.State.Schema = schemaResp.Schema
+		require.False(t, rreq.State.Set(context.Background(), state).HasError())
+		rresp := resource.ReadResponse{State: rreq.State}
+		r.Read(context.Background(), rreq, &rresp)
+		require.False(t, rresp.Diagnostics.HasError(), "%v", rresp.Diagnostics)
+		return rresp
+	}
+
+	t.Run("role no longer bound", func(t *testing.T) {
+		rresp := read(t, &groupRolesMock{roles: []iam.RoleBindingDto{{RoleID: "otherrole", Bindings: []string{"*"}}}})
+		require.True(t, rresp.State.Raw.IsNull(), "role binding should be removed from state")
+	})
+
+	t.Run("group deleted", func(t *testing.T) {
+		rresp := read(t, iam.RawClient(groupNotFoundClient{}))
+		require.True(t, rresp.State.Raw.IsNull(), "role binding should be removed from state")
+	})
+}
+
+func TestSimpleIamRoleBindingResource_ReadCustomRoleLookupFails(t *testing.T) {
+	id := GenerateResourceId("testtenant", "testgroup", "Auditor")
+	state := createTestSimpleModel("testgroup", "Auditor", true, []string{"bu:001"})
+	state.ID = types.StringValue(id)
+
+	r := newSimpleResourceWithClient(customRoleErrorClient{})
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	var rreq resource.ReadRequest
+	rreq.State.Schema = schemaResp.Schema
+	require.False(t, rreq.State.Set(context.Background(), state).HasError())
+	rresp := resource.ReadResponse{State: rreq.State}
+	r.Read(context.Background(), rreq, &rresp)
+	require.True(t, rresp.Diagnostics.HasError(), "a failed custom role lookup should be reported")
+
+	var out SimpleRoleBindingResourceModel
+	require.False(t, rresp.State.Get(context.Background(), &out).HasError())
+	require.True(t, out.IsCustom.ValueBool(), "is_custom must not be flipped by a failed lookup")
+}
+
+// groupListMock lists the named groups and otherwise behaves as groupRolesMock
+type groupListMock struct {
+	groupRolesMock
+	groups string
+}
+
+func (m *groupListMock) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	if req.Method == "GET" && strings.HasSuffix(req.Path, "/groups") {
+		return &client.Response{StatusCode: 200, Body: []byte(m.groups)}, nil
+	}
+	return m.groupRolesMock.Do(ctx, req)
+}
+
+func TestSimpleIamRoleBindingResource_CreateValidatesMembers(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		groupID   string
+		wantPosts int
+	}{
+		{name: "existing group", groupID: "cashiers", wantPosts: 1},
+		{name: "missing group", groupID: "ghosts", wantPosts: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := &groupListMock{groups: `[{"id":"cashiers","name":"cashiers"}]`}
+			r := newSimpleResourceWithClient(mock)
+			r.iamService.SetMemberValidation(true)
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+			var creq resource.CreateRequest
+			creq.Plan.Schema = schemaResp.Schema
+			require.False(t, creq.Plan.Set(context.Background(), createTestSimpleModel(tc.groupID, "testrole", false, []string{"bu:001"})).HasError())
+			cresp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+			r.Create(context.Background(), creq, &cresp)
+
+			require.Equal(t, tc.wantPosts, mock.posts)
+			if tc.wantPosts == 0 {
+				require.True(t, cresp.Diagnostics.HasError(), "a missing group should fail the create")
+				require.Contains(t, cresp.Diagnostics.Errors()[0].Detail(), "group:ghosts")
+			} else {
+				require.False(t, cresp.Diagnostics.HasError(), "%v", cresp.Diagnostics)
+			}
+		})
+	}
+}
+
+// customRoleErrorClient fails every request with a server error
+type customRoleErrorClient struct{}
+
+func (customRoleErrorClient) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	return &client.Response{StatusCode: 500, Body: []byte(`{"message":"internal error"}`)}, nil
+}
+
+// groupNotFoundClient answers every request with 404, as for a deleted group
+type groupNotFoundClient struct{}
+
+func (groupNotFoundClient) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	return &client.Response{StatusCode: 404, Body: []byte(`{"message":"group not found"}`)}, nil
+}
+
+func TestSimpleIamRoleBindingResource_UpdateBindings(t *testing.T) {
+	mock := &groupRolesMock{roles: []iam.RoleBindingDto{{RoleID: "testrole", Bindings: []string{"bu:001"}}}}
+	r := newSimpleResourceWithClient(mock)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+
+	state := createTestSimpleModel("testgroup", "testrole", false, []string{"bu:001"})
+	state.ID = types.StringValue(GenerateResourceId("testtenant", "testgroup", "testrole"))
+	plan := createTestSimpleModel("testgroup", "testrole", false, []string{"bu:001", "bu:002"})
+	plan.ID = types.StringUnknown()
+
+	var ureq resource.UpdateRequest
+	ureq.Plan.Schema = schemaResp.Schema
+	require.False(t, ureq.Plan.Set(context.Background(), plan).HasError())
+	ureq.State.Schema = schemaResp.Schema
+	require.False(t, ureq.State.Set(context.Background(), state).HasError())
+
+	uresp := resource.UpdateResponse{State: ureq.State}
+	r.Update(context.Background(), ureq, &uresp)
+	require.False(t, uresp.Diagnostics.HasError(), "%v", uresp.Diagnostics)
+
+	require.Equal(t, 1, mock.posts, "the changed bindings should be posted once")
+	require.ElementsMatch(t, []string{"bu:001", "bu:002"}, mock.roles[0].Bindings)
+
+	var out SimpleRoleBindingResourceModel
+	require.False(t, uresp.State.Get(context.Background(), &out).HasError())
+	require.Equal(t, state.ID.ValueString(), out.ID.ValueString())
+	require.Equal(t, []string{"bu:001", "bu:002"}, bindingStrings(t, out.Bindings))
+
+	// Unchanged bindings need no request
+	ureq2 := ureq
+	require.False(t, ureq2.State.Set(context.Background(), plan).HasError())
+	r.Update(context.Background(), ureq2, &uresp)
+	require.False(t, uresp.Diagnostics.HasError(), "%v", uresp.Diagnostics)
+	require.Equal(t, 1, mock.posts)
+}
+
+func TestSimpleIamRoleBindingResource_DeleteRemovesRole(t *testing.T) {
+	mock := &groupRolesMock{roles: []iam.RoleBindingDto{
+		{RoleID: "testrole", IsCustom: true, Bindings: []string{"bu:001"}},
+		{RoleID: "otherrole", Bindings: []string{"*"}},
+	}}
+	r := newSimpleResourceWithClient(mock)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(context.Background(), resource.SchemaRequest{}, &schemaResp)
+	state := createTestSimpleModel("testgroup", "testrole", true, []string{"bu:001"})
+	state.ID = types.StringValue(GenerateResourceId("testtenant", "testgroup", "testrole"))
+
+	// The second delete finds the role already gone, which is not an error
+	for i := 0; i < 2; i++ {
+		var dreq resource.DeleteRequest
+		dreq.State.Schema = schemaResp.Schema
+		require.False(t, dreq.State.Set(context.Background(), state).HasError())
+		var dresp resource.DeleteResponse
+		r.Delete(context.Background(), dreq, &dresp)
+		require.False(t, dresp.Diagnostics.HasError(), "%v", dresp.Diagnostics)
+	}
+	require.Equal(t, []iam.RoleBindingDto{{RoleID: "otherrole", Bindings: []string{"*"}}}, mock.roles)
+}
+
+// rollbackFlowMock is groupRolesMock with a group to look up, a custom role
+// lookup counter and a role whose re-post fails
+type rollbackFlowMock struct {
+	groupRolesMock
+	failRole string
+	roleGets map[string]int
+}
+
+func (m *rollbackFlowMock) Do(ctx context.Context, req *client.Request) (*client.Response, error) {
+	if req.Method == "GET" && strings.HasSuffix(req.Path, "/groups/testgroup") {
+		return &client.Response{StatusCode: 200, Body: []byte(`{"id":"testgroup","name":"testgroup"}`)}, nil
+	}
+	if req.Method == "GET" && !strings.HasSuffix(req.Path, "/roles") && strings.Contains(req.Path, "/roles/") {
+		m.roleGets[req.Path[strings.LastIndex(req.Path, "/")+1:]]++
+	}
+	if req.Method == "POST" && strings.HasSuffix(req.Path, "/roles") {
+		if payload := req.Body.(map[string]interface{}); payload["roleId"] == m.failRole {
+			return &client.Response{StatusCode: 500, Body: []byte(`{"message":"boom"}`)}, nil
+		}
+	}
+	return m.groupRolesMock.Do(ctx, req)
+}
+
+func TestIamRoleBindingResource_UpdateRollbackLooksUpCustomRoleOnce(t *testing.T) {
+	ctx := context.Background()
+	mock := &rollbackFlowMock{
+		groupRolesMock: groupRolesMock{roles: []iam.RoleBindingDto{
+			{RoleID: "A", IsCustom: true, Bindings: []string{"bu:001"}},
+			{RoleID: "B", IsCustom: true, Bindings: []string{"bu:001"}},
+		}},
+		failRole: "B",
+		roleGets: map[string]int{},
+	}
+	r := &IamRoleBindingResource{}
+	setServiceField(r, newTestServiceWithClient(mock))
+	setClientField(r, newTestClientForSimpleResource())
+
+	model := func(binding string) RoleBindingResourceModel {
+		role := func(id string) attr.Value {
+			return types.ObjectValueMust(GetRoleModelObjectType().AttrTypes, map[string]attr.Value{
+				"id":        types.StringValue(id),
+				"is_custom": types.BoolValue(true),
+				"bindings":  types.ListValueMust(types.StringType, []attr.Value{types.StringValue(binding)}),
+			})
+		}
+		return RoleBindingResourceModel{
+			Id:             types.StringValue("test-tenant/testgroup/multi-role"),
+			TenantId:       types.StringValue("test-tenant"),
+			Name:           types.StringNull(),
+			Role:           types.StringNull(),
+			Members:        types.ListNull(types.StringType),
+			GroupId:        types.StringValue("testgroup"),
+			Roles:          types.ListValueMust(GetRoleModelObjectType(), []attr.Value{role("A"), role("B")}),
+			Description:    types.StringNull(),
+			Condition:      types.StringNull(),
+			RoleId:         types.StringNull(),
+			BindingsLegacy: types.ListValueMust(types.StringType, []attr.Value{}),
+		}
+	}
+
+	s := EnhancedIamRoleBindingResourceSchema(ctx)
+	ureq := resource.UpdateRequest{Plan: tfsdk.Plan{Schema: s}, State: tfsdk.State{Schema: s}}
+	plan, state := model("bu:002"), model("bu:001")
+	require.False(t, ureq.Plan.Set(ctx, &plan).HasError())
+	require.False(t, ureq.State.Set(ctx, &state).HasError())
+	uresp := resource.UpdateResponse{State: ureq.State}
+	r.Update(ctx, ureq, &uresp)
+
+	require.True(t, uresp.Diagnostics.HasError(), "the failing re-post of B should fail the update")
+	require.Equal(t, []string{"bu:001"}, mock.roles[0].Bindings, "A should be rolled back")
+	// A is checked when re-posted and again when reverted, B when re-posted
+	require.Equal(t, map[string]int{"A": 1, "B": 1}, mock.roleGets)
+}
@@ -43,22 +43,27 @@ func (r *SimpleIamRoleBindingResource) Configure(ctx context.Context, req resour
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	providerData, ok := req.ProviderData.(*iam.ProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *iam.ProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
-	r.iamService = iam.NewService(client, client.TenantID())
+	r.client = providerData.Client
+	r.iamService = providerData.Service
 }
 
 func (r *SimpleIamRoleBindingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
+	// Look each group and custom role up at most once in this operation
+	ctx = iam.WithOperationCache(ctx)
+
 	var data SimpleRoleBindingResourceModel
 
 	// Read Terraform plan data into the model
@@ -93,6 +98,15 @@ func (r *SimpleIamRoleBindingResource) Create(ctx context.Context, req resource.
 		"bindings":  bindings,
 	})
 
+	// Fail before any write when member validation is on and the group is missing
+	if err := r.iamService.ValidateMembers(ctx, []string{"group:" + groupId}); err != nil {
+		resp.Diagnostics.AddError(
+			"Unresolved Role Binding Members",
+			fmt.Sprintf("Could not add role %s to group %s: %s", roleId, groupId, err.Error()),
+		)
+		return
+	}
+
 	// Use the AddRoleToGroup method
 	err := r.iamService.AddRoleToGroup(ctx, groupId, roleId, isCustom, bindings)
 	if err != nil {
@@ -104,7 +118,7 @@ func (r *SimpleIamRoleBindingResource) Create(ctx context.Context, req resource.
 	}
 
 	// Generate a unique ID for this role binding
-	compositeId := GenerateResourceId(r.client.TenantID(), groupId, roleId)
+	compositeId := GenerateResourceIdWithDelimiter(roleBindingIDDelimiter(r.iamService), r.client.TenantID(), groupId, roleId)
 
 	// Update the model with response data
 	data.ID = types.StringValue(compositeId)
@@ -122,6 +136,11 @@ func (r *SimpleIamRoleBindingResource) Create(ctx context.Context, req resource.
 }
 
 func (r *SimpleIamRoleBindingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
+	// Look each group and custom role up at most once in this operation
+	ctx = iam.WithOperationCache(ctx)
+
 	var data SimpleRoleBindingResourceModel
 
 	// Read Terraform prior state data into the model
@@ -141,28 +160,35 @@ func (r *SimpleIamRoleBindingResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
-	// Parse the ID: format is "tenantId-groupId-roleId-hash"
-	parts := strings.Split(id, "-")
-	if len(parts) < 4 {
+	// Parse the ID: format is "tenantId/groupId/roleId/hash", or the legacy
+	// hyphen-delimited form for state written before the delimiter changed
+	parts, err := ParseResourceIdWithDelimiter(id, data.TenantID.ValueString(), roleBindingIDDelimiter(r.iamService))
+	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Role Binding",
-			fmt.Sprintf("Invalid role binding ID format: %s", id),
+			fmt.Sprintf("Invalid role binding ID format: %s", err),
 		)
 		return
 	}
 
-	tenantId := parts[0]
-	groupId := parts[1]
-	roleId := parts[2]
-	// hash is parts[3] (not needed for parsing)
+	tenantId := parts.TenantId
+	groupId := parts.GroupId
+	roleId := parts.RoleId
 
 	// Determine if the role is custom by checking if it exists as a custom role
 	isCustom := false
 
-	// Check if this role exists as a custom role
-	_, err := r.iamService.GetCustomRole(ctx, roleId)
+	// Check if this role exists as a custom role. Only a 404 makes it builtin:
+	// is_custom forces replacement, so any other failure must not flip it.
+	_, err = r.iamService.GetCustomRole(ctx, roleId)
 	if err != nil {
-		// If GetCustomRole fails, it's likely a builtin role
+		if !client.IsNotFoundError(err) {
+			resp.Diagnostics.AddError(
+				"Error Reading Role Binding",
+				fmt.Sprintf("Could not check whether role %s is a custom role, unexpected error: %s", roleId, err),
+			)
+			return
+		}
 		tflog.Debug(ctx, "Role not found as custom role, assuming builtin", map[string]interface{}{
 			"role_id": roleId,
 			"error":   err.Error(),
@@ -187,9 +213,40 @@ func (r *SimpleIamRoleBindingResource) Read(ctx context.Context, req resource.Re
 	data.RoleID = types.StringValue(roleId)
 	data.IsCustom = types.BoolValue(isCustom)
 
-	// For bindings, we can't easily reconstruct them from just the ID
-	// So we'll leave them as they were in the original state
-	// In a production system, you might want to fetch them from the API
+	// Reconstruct the bindings from the V2 role assignment so that refresh and
+	// import reflect what the group actually holds
+	roles, err := r.iamService.ListGroupRoles(ctx, groupId)
+	if err != nil {
+		if client.IsNotFoundError(err) {
+			tflog.Warn(ctx, "Group not found, removing role binding from state", map[string]interface{}{
+				"id":       id,
+				"group_id": groupId,
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error Reading Role Binding",
+			fmt.Sprintf("Could not read roles of group %s, unexpected error: %s", groupId, err),
+		)
+		return
+	}
+	assignment, ok := findGroupRole(roles, roleId, isCustom)
+	if !ok {
+		tflog.Warn(ctx, "Role no longer bound to group, removing role binding from state", map[string]interface{}{
+			"id":       id,
+			"group_id": groupId,
+			"role_id":  roleId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	bindings, diags := simpleBindingsFromAPI(ctx, data.Bindings, assignment.Bindings, r.iamService.ImplicitGroupBindings())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	data.Bindings = bindings
 
 	tflog.Trace(ctx, "Read simple IAM role binding resource", map[string]interface{}{
 		"id":        id,
@@ -204,6 +261,11 @@ func (r *SimpleIamRoleBindingResource) Read(ctx context.Context, req resource.Re
 }
 
 func (r *SimpleIamRoleBindingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
+	// Look each group and custom role up at most once in this operation
+	ctx = iam.WithOperationCache(ctx)
+
 	var data SimpleRoleBindingResourceModel
 
 	// Read Terraform plan data into the model
@@ -213,9 +275,38 @@ func (r *SimpleIamRoleBindingResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	// Since this is a simple 1:1 binding, updates are not really meaningful
-	// The only things that could change are the bindings list or description
-	// For now, we'll just log the update and save the state
+	var state SimpleRoleBindingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// group_id, role_id and is_custom force replacement, so only the bindings
+	// of the role assignment can change here
+	groupId := data.GroupID.ValueString()
+	roleId := data.RoleID.ValueString()
+	isCustom := data.IsCustom.ValueBool()
+
+	var bindings []string
+	if !data.Bindings.IsNull() && !data.Bindings.IsUnknown() {
+		resp.Diagnostics.Append(data.Bindings.ElementsAs(ctx, &bindings, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	previous := []iam.RoleBindingDto{{RoleID: roleId, IsCustom: isCustom}}
+	desired := []iam.RoleBindingDto{{RoleID: roleId, IsCustom: isCustom, Bindings: bindings}}
+	if err := r.iamService.ApplyGroupRoles(ctx, groupId, previous, desired); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Role Binding",
+			fmt.Sprintf("Could not update the bindings of role %s on group %s, unexpected error: %s", roleId, groupId, err),
+		)
+		return
+	}
+
+	data.ID = state.ID
+	data.TenantID = state.TenantID
 
 	tflog.Trace(ctx, "Updated simple IAM role binding resource", map[string]interface{}{
 		"id":        data.ID.ValueString(),
@@ -229,6 +320,8 @@ func (r *SimpleIamRoleBindingResource) Update(ctx context.Context, req resource.
 }
 
 func (r *SimpleIamRoleBindingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.AppendDeprecationWarnings(&resp.Diagnostics)
+
 	var data SimpleRoleBindingResourceModel
 
 	// Read Terraform prior state data into the model
@@ -250,11 +343,11 @@ func (r *SimpleIamRoleBindingResource) Delete(ctx context.Context, req resource.
 	})
 
 	// Call the IAM service to remove the role from the group
-	err := r.removeRoleFromGroup(ctx, groupId, roleId, isCustom)
+	err := r.iamService.RemoveRoleFromGroup(ctx, groupId, roleId, isCustom)
 	if err != nil {
 		// Check if the error is a 404, which means the role binding doesn't exist
 		// This is actually success since the desired state is that it doesn't exist
-		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not assigned to this group") {
+		if client.IsNotFoundError(err) || strings.Contains(err.Error(), "not assigned to this group") {
 			tflog.Debug(ctx, "Role binding already removed from group (404 response), treating as successful deletion", map[string]interface{}{
 				"group_id":  groupId,
 				"role_id":   roleId,
@@ -279,73 +372,26 @@ func (r *SimpleIamRoleBindingResource) Delete(ctx context.Context, req resource.
 	})
 }
 
-// removeRoleFromGroup removes a role from a group using the V1 API
-func (r *SimpleIamRoleBindingResource) removeRoleFromGroup(ctx context.Context, groupId, roleId string, isCustom bool) error {
-	// Use the V1 API endpoint: DELETE /api/v1/tenants/{tenantId}/groups/{id}/roles/{roleId}
-	// This endpoint properly supports role removal and has the required permissions
-	path := fmt.Sprintf("/api/v1/tenants/%s/groups/%s/roles/%s", r.client.TenantID(), groupId, roleId)
-
-	tflog.Debug(ctx, "Making DELETE request to remove role from group (V1 API)", map[string]interface{}{
-		"path":      path,
-		"group_id":  groupId,
-		"role_id":   roleId,
-		"is_custom": isCustom,
-	})
-
-	// Build the request with isCustom query parameter if needed
-	req := &client.Request{
-		Method: "DELETE",
-		Path:   path,
-	}
-
-	// Add isCustom query parameter if the role is custom
-	if isCustom {
-		req.Query = map[string]string{
-			"isCustom": "true",
-		}
+// ImportState accepts the composite {tenant}/{group}/{role}/{hash} ID produced by
+// GenerateResourceIdWithDelimiter and populates tenant_id, group_id and role_id;
+// is_custom and bindings are filled in by the Read that follows.
+func (r *SimpleIamRoleBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tenantId := ""
+	if r.client != nil {
+		tenantId = r.client.TenantID()
 	}
 
-	resp, err := r.client.Do(ctx, req)
+	parts, err := parseImportId(req.ID, tenantId, roleBindingIDDelimiter(r.iamService))
 	if err != nil {
-		tflog.Error(ctx, "HTTP request failed (V1 API)", map[string]interface{}{
-			"error":    err.Error(),
-			"path":     path,
-			"group_id": groupId,
-			"role_id":  roleId,
-		})
-		return fmt.Errorf("failed to remove role %s from group %s: %w", roleId, groupId, err)
-	}
-
-	tflog.Debug(ctx, "DELETE request completed (V1 API)", map[string]interface{}{
-		"status_code": resp.StatusCode,
-		"path":        path,
-		"group_id":    groupId,
-		"role_id":     roleId,
-	})
-
-	// Check if the request was successful
-	if err := client.CheckResponse(resp); err != nil {
-		tflog.Error(ctx, "DELETE request failed with error response (V1 API)", map[string]interface{}{
-			"error":       err.Error(),
-			"status_code": resp.StatusCode,
-			"path":        path,
-			"group_id":    groupId,
-			"role_id":     roleId,
-		})
-		return fmt.Errorf("failed to remove role %s from group %s: %w", roleId, groupId, err)
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Unable to import IAM role binding: %s", err),
+		)
+		return
 	}
 
-	tflog.Debug(ctx, "Successfully removed role from group (V1 API)", map[string]interface{}{
-		"path":      path,
-		"group_id":  groupId,
-		"role_id":   roleId,
-		"is_custom": isCustom,
-	})
-
-	return nil
-}
-
-func (r *SimpleIamRoleBindingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import state using the composite ID format: tenantId-groupId-roleId
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("tenant_id"), parts.TenantId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("group_id"), parts.GroupId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_id"), parts.RoleId)...)
 }
@@ -6,6 +6,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -13,6 +16,8 @@ import (
 // SimpleIamRoleBindingResourceSchema provides a simple 1:1 Group-to-Role relationship schema
 func SimpleIamRoleBindingResourceSchema(ctx context.Context) schema.Schema {
 	return schema.Schema{
+		// Version 1 switched composite IDs from hyphens to iam.DefaultRoleBindingIDDelimiter.
+		Version: 1,
 		MarkdownDescription: "Manages IAM role bindings with a simple 1:1 relationship between Group and Role.\n\n" +
 			"**Properties:**\n" +
 			"- `group_id`: Group identifier for role binding (required)\n" +
@@ -37,6 +42,9 @@ func SimpleIamRoleBindingResourceSchema(ctx context.Context) schema.Schema {
 			"group_id": schema.StringAttribute{
 				MarkdownDescription: "The group identifier for the role binding",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
@@ -44,6 +52,10 @@ func SimpleIamRoleBindingResourceSchema(ctx context.Context) schema.Schema {
 			"role_id": schema.StringAttribute{
 				MarkdownDescription: "The role identifier to bind to the group",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					normalizeRole(),
+					stringplanmodifier.RequiresReplace(),
+				},
 				Validators: []validator.String{
 					stringvalidator.LengthAtLeast(1),
 				},
@@ -51,6 +63,9 @@ func SimpleIamRoleBindingResourceSchema(ctx context.Context) schema.Schema {
 			"is_custom": schema.BoolAttribute{
 				MarkdownDescription: "Whether this role is a custom role (true) or built-in role (false)",
 				Required:            true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
 			},
 
 			// Optional Properties
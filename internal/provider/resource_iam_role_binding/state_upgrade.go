@@ -0,0 +1,98 @@
+package resource_iam_role_binding
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/iam"
+)
+
+var _ resource.ResourceWithUpgradeState = &SimpleIamRoleBindingResource{}
+
+// roleBindingIDDelimiter returns the composite ID delimiter configured on svc,
+// falling back to the default when the resource has not been configured.
+func roleBindingIDDelimiter(svc *iam.Service) string {
+	if svc == nil {
+		return iam.DefaultRoleBindingIDDelimiter
+	}
+	return svc.RoleBindingIDDelimiter()
+}
+
+// UpgradeState rewrites version 0 state, whose IDs were hyphen-delimited, to
+// the configured composite ID delimiter.
+func (r *SimpleIamRoleBindingResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := simpleIamRoleBindingResourceSchemaV0()
+
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema:   &priorSchema,
+			StateUpgrader: r.upgradeStateV0,
+		},
+	}
+}
+
+// simpleIamRoleBindingResourceSchemaV0 is the version 0 schema, kept as it was
+// so later schema changes do not affect how version 0 state is decoded.
+func simpleIamRoleBindingResourceSchemaV0() schema.Schema {
+	return schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+			"tenant_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+			},
+			"group_id": schema.StringAttribute{
+				Required: true,
+			},
+			"role_id": schema.StringAttribute{
+				Required: true,
+			},
+			"is_custom": schema.BoolAttribute{
+				Required: true,
+			},
+			"bindings": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+			},
+			"condition": schema.StringAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (r *SimpleIamRoleBindingResource) upgradeStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	var data SimpleRoleBindingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	oldId := data.ID.ValueString()
+	newId, err := UpgradeResourceId(oldId, data.TenantID.ValueString(), roleBindingIDDelimiter(r.iamService))
+	if err != nil {
+		// Leave unparseable IDs untouched; Read reports them with full context.
+		tflog.Warn(ctx, "Unable to upgrade role binding ID, keeping existing value", map[string]interface{}{
+			"id":    oldId,
+			"error": err.Error(),
+		})
+		newId = oldId
+	}
+	data.ID = types.StringValue(newId)
+
+	tflog.Debug(ctx, "Upgraded role binding state from version 0", map[string]interface{}{
+		"old_id": oldId,
+		"new_id": newId,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
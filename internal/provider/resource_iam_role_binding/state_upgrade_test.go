@@ -0,0 +1,68 @@
+package resource_iam_role_binding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeResourceId(t *testing.T) {
+	legacy := GenerateResourceIdWithDelimiter("-", "tenant1", "group1", "custom.Foo-Bar")
+
+	t.Run("LegacyToDefault", func(t *testing.T) {
+		got, err := UpgradeResourceId(legacy, "tenant1", "")
+		require.NoError(t, err)
+		require.Equal(t, GenerateResourceId("tenant1", "group1", "custom.Foo-Bar"), got)
+	})
+
+	t.Run("LegacyToConfigured", func(t *testing.T) {
+		got, err := UpgradeResourceId(legacy, "tenant1", "::")
+		require.NoError(t, err)
+		require.Equal(t, GenerateResourceIdWithDelimiter("::", "tenant1", "group1", "custom.Foo-Bar"), got)
+	})
+
+	t.Run("AlreadyUpgraded", func(t *testing.T) {
+		id := GenerateResourceId("tenant1", "group1", "role1")
+		got, err := UpgradeResourceId(id, "tenant1", "")
+		require.NoError(t, err)
+		require.Equal(t, id, got)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		_, err := UpgradeResourceId("tenant1-group1", "tenant1", "")
+		require.Error(t, err)
+	})
+}
+
+func TestSimpleIamRoleBindingResource_UpgradeState(t *testing.T) {
+	ctx := context.Background()
+	r := createTestSimpleResource(t)
+
+	upgraders := r.UpgradeState(ctx)
+	upgrader, ok := upgraders[0]
+	require.True(t, ok, "expected a version 0 upgrader")
+	require.Equal(t, int64(1), SimpleIamRoleBindingResourceSchema(ctx).Version)
+	require.Equal(t, int64(0), upgrader.PriorSchema.Version)
+
+	model := createTestSimpleModel("group1", "custom.Foo-Bar", true, []string{"bu:001"})
+	model.TenantID = types.StringValue("testtenant")
+	model.ID = types.StringValue(GenerateResourceIdWithDelimiter("-", "testtenant", "group1", "custom.Foo-Bar"))
+
+	prior := tfsdk.State{Schema: *upgrader.PriorSchema}
+	require.False(t, prior.Set(ctx, &model).HasError())
+
+	req := resource.UpgradeStateRequest{State: &prior}
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: SimpleIamRoleBindingResourceSchema(ctx)}}
+	upgrader.StateUpgrader(ctx, req, resp)
+	require.False(t, resp.Diagnostics.HasError(), "upgrade diagnostics: %v", resp.Diagnostics)
+
+	var out SimpleRoleBindingResourceModel
+	require.False(t, resp.State.Get(ctx, &out).HasError())
+	require.Equal(t, GenerateResourceId("testtenant", "group1", "custom.Foo-Bar"), out.ID.ValueString())
+	require.Equal(t, "group1", out.GroupID.ValueString())
+	require.Equal(t, "custom.Foo-Bar", out.RoleID.ValueString())
+}
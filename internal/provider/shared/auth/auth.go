@@ -99,14 +99,25 @@ type Config struct {
 	APIURL      string `json:"api_url,omitempty"`
 
 	// OAuth2 settings
-	Scopes  []string      `json:"scopes,omitempty"`
-	Timeout time.Duration `json:"timeout,omitempty"`
+	Scopes   []string      `json:"scopes,omitempty"`
+	Audience string        `json:"audience,omitempty"` // Empty uses DefaultAudience
+	Timeout  time.Duration `json:"timeout,omitempty"`
 
 	// Advanced options
 	MaxRetries       int    `json:"max_retries,omitempty"`
 	DisableDiscovery bool   `json:"disable_discovery,omitempty"`
 	SkipTLS          bool   `json:"skip_tls,omitempty"`   // For testing only
 	TestToken        string `json:"test_token,omitempty"` // For contract tests only
+
+	// TLS options for an internal CA or mutual TLS
+	CACertPEM     string `json:"-"`
+	ClientCertPEM string `json:"-"`
+	ClientKeyPEM  string `json:"-"`
+
+	// Keep-alive tuning for the OAuth2 transport, see ApplyConnectionPool
+	MaxIdleConns        int           `json:"max_idle_conns,omitempty"`
+	MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host,omitempty"`
+	IdleConnTimeout     time.Duration `json:"idle_conn_timeout,omitempty"`
 }
 
 // Client provides OAuth2 authentication for HiiRetail IAM APIs
@@ -233,9 +244,19 @@ func NewClient(config *Config) (Client, error) {
 		ClientSecret:     config.ClientSecret,
 		TokenURL:         config.AuthURL,
 		Scopes:           config.Scopes,
+		Audience:         config.Audience,
 		Timeout:          config.Timeout,
 		MaxRetries:       config.MaxRetries,
 		DisableDiscovery: config.DisableDiscovery,
+
+		CACertPEM:          config.CACertPEM,
+		ClientCertPEM:      config.ClientCertPEM,
+		ClientKeyPEM:       config.ClientKeyPEM,
+		InsecureSkipVerify: config.SkipTLS,
+
+		MaxIdleConns:        config.MaxIdleConns,
+		MaxIdleConnsPerHost: config.MaxIdleConnsPerHost,
+		IdleConnTimeout:     config.IdleConnTimeout,
 	}
 
 	// Resolve endpoints if not provided
@@ -316,7 +337,16 @@ func ValidateConfig(config *Config) error {
 //	fmt.Printf("Auth URL: %s\n", authURL)
 //	fmt.Printf("API URL: %s\n", apiURL)
 func ResolveEndpoints(tenantID, environment string) (authURL, apiURL string, err error) {
+	return ResolveEndpointsWithOverrides(tenantID, environment, nil)
+}
+
+// ResolveEndpointsWithOverrides resolves endpoints like ResolveEndpoints, but
+// consults the given environment→endpoint mappings before the built-in
+// dev, test, staging and production environments. Unknown environments
+// return an error.
+func ResolveEndpointsWithOverrides(tenantID, environment string, overrides map[string]EndpointMapping) (authURL, apiURL string, err error) {
 	resolver := NewEndpointResolver(tenantID, environment)
+	resolver.Overrides = overrides
 
 	authURL, err = resolver.ResolveAuthURL()
 	if err != nil {
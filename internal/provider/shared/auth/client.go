@@ -2,16 +2,23 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 )
 
+// DefaultAudience is the audience sent in token requests when
+// AuthClientConfig.Audience is not set
+const DefaultAudience = "https://hiiretail.com"
+
 // AuthClientConfig contains configuration for OAuth2 authentication client
 type AuthClientConfig struct {
 	// Required authentication parameters
@@ -24,6 +31,10 @@ type AuthClientConfig struct {
 	TokenURL string
 	Scopes   []string
 
+	// Audience is sent as the audience parameter of token requests, either a
+	// URL or an opaque identifier; empty uses DefaultAudience
+	Audience string
+
 	// Timeout and retry configuration
 	Timeout    time.Duration
 	MaxRetries int
@@ -31,6 +42,23 @@ type AuthClientConfig struct {
 	// Advanced configuration
 	DisableDiscovery bool
 	CustomHeaders    map[string]string
+
+	// RevocationURL overrides the revocation_endpoint from discovery, see
+	// RevokeToken. RevokeOnClose revokes the cached tokens in Close.
+	RevocationURL string
+	RevokeOnClose bool
+
+	// Keep-alive tuning for the transport, see ApplyConnectionPool; zero
+	// values use the defaults
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+
+	// TLS configuration, see NewTLSConfig
+	CACertPEM          string
+	ClientCertPEM      string
+	ClientKeyPEM       string
+	InsecureSkipVerify bool // For testing only
 }
 
 // AuthClient manages OAuth2 authentication and token lifecycle
@@ -41,12 +69,21 @@ type AuthClient struct {
 	tokenSource  oauth2.TokenSource
 	httpClient   *http.Client
 
+	// clientSecret holds the secret until the token source is created; it is
+	// overwritten with zeros by Close
+	clientSecret []byte
+	closed       bool
+
 	// Discovery integration
 	discoveryClient *DiscoveryClient
 
 	// Token management
 	tokenCache *TokenCache
 
+	// scopedTokens caches tokens for scope sets other than the configured
+	// one, keyed by scopeKey; see GetTokenForScopes
+	scopedTokens map[string]*scopedToken
+
 	// Retry configuration
 	retryConfig *RetryConfig
 
@@ -76,21 +113,29 @@ func NewAuthClient(config *AuthClientConfig) (*AuthClient, error) {
 		retryConfig: DefaultRetryConfig(),
 	}
 
+	tlsConfig, err := NewTLSConfig(config.CACertPEM, config.ClientCertPEM, config.ClientKeyPEM, config.InsecureSkipVerify)
+	if err != nil {
+		return nil, NewConfigurationError("invalid TLS configuration", err)
+	}
+
 	// Initialize discovery client if not disabled
 	if !config.DisableDiscovery && config.BaseURL != "" {
 		client.discoveryClient = NewDiscoveryClient(config.BaseURL, config.Timeout)
+		if tlsConfig != nil {
+			client.discoveryClient.httpClient.Transport.(*http.Transport).TLSClientConfig = tlsConfig
+		}
 	}
 
 	// Set up HTTP client with timeout
+	transport := &http.Transport{
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: config.Timeout,
+		TLSClientConfig:       tlsConfig,
+	}
+	ApplyConnectionPool(transport, config.MaxIdleConns, config.MaxIdleConnsPerHost, config.IdleConnTimeout)
 	client.httpClient = &http.Client{
-		Timeout: config.Timeout,
-		Transport: &http.Transport{
-			MaxIdleConns:          100,
-			MaxIdleConnsPerHost:   10,
-			IdleConnTimeout:       90 * time.Second,
-			TLSHandshakeTimeout:   10 * time.Second,
-			ResponseHeaderTimeout: config.Timeout,
-		},
+		Timeout:   config.Timeout,
+		Transport: transport,
 	}
 
 	// Initialize OAuth2 configuration
@@ -131,22 +176,23 @@ func (c *AuthClient) initializeOAuth2Config() error {
 		}
 	}
 
-	// Create OAuth2 client credentials configuration
+	// Create OAuth2 client credentials configuration; the secret is kept in
+	// clientSecret until the token source is first needed
+	c.clientSecret = []byte(c.config.ClientSecret)
+	audience := c.config.Audience
+	if audience == "" {
+		audience = DefaultAudience
+	}
 	c.oauth2Config = &clientcredentials.Config{
-		ClientID:     c.config.ClientID,
-		ClientSecret: c.config.ClientSecret,
-		TokenURL:     tokenURL,
-		Scopes:       c.config.Scopes,
-		AuthStyle:    oauth2.AuthStyleInHeader, // Use Basic authentication in header
+		ClientID:  c.config.ClientID,
+		TokenURL:  tokenURL,
+		Scopes:    c.config.Scopes,
+		AuthStyle: oauth2.AuthStyleInHeader, // Use Basic authentication in header
 		EndpointParams: url.Values{
-			"audience": {"https://hiiretail.com"}, // Required audience parameter
+			"audience": {audience}, // Required audience parameter
 		},
 	}
 
-	// Create token source with custom HTTP client
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, c.httpClient)
-	c.tokenSource = c.oauth2Config.TokenSource(ctx)
-
 	return nil
 }
 
@@ -155,6 +201,10 @@ func (c *AuthClient) GetToken(ctx context.Context) (*oauth2.Token, error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	if c.closed {
+		return nil, NewClientClosedError()
+	}
+
 	// Check if we have a valid cached token
 	if token := c.tokenCache.getValidToken(); token != nil {
 		c.tokenCache.updateLastUsed()
@@ -164,11 +214,70 @@ func (c *AuthClient) GetToken(ctx context.Context) (*oauth2.Token, error) {
 	// Acquire new token with retry logic
 	token, err := c.acquireTokenWithRetry(ctx)
 	if err != nil {
+		c.logTokenError(ctx, tokenEventAcquired, err)
 		return nil, err
 	}
 
 	// Cache the new token
 	c.tokenCache.setToken(token)
+	c.logTokenLifecycle(ctx, tokenEventAcquired, token)
+
+	return token, nil
+}
+
+// GetTokenWithRetry acquires a token, retrying only retryable errors up to
+// maxAttempts times. It waits for the error's RetryAfter when set and uses
+// exponential backoff with jitter otherwise. The last error is returned once
+// attempts are exhausted or ctx is done.
+func (c *AuthClient) GetTokenWithRetry(ctx context.Context, maxAttempts int) (*oauth2.Token, error) {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		token, err := c.getTokenOnce(ctx)
+		if err == nil {
+			return token, nil
+		}
+		lastErr = err
+
+		var authErr *AuthError
+		if !isAuthError(err, &authErr) || !authErr.Retryable || attempt == maxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, lastErr
+		case <-time.After(c.retryConfig.GetDelay(attempt, err)):
+		}
+	}
+
+	return nil, lastErr
+}
+
+// getTokenOnce returns the cached token or makes a single acquisition attempt
+func (c *AuthClient) getTokenOnce(ctx context.Context) (*oauth2.Token, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return nil, NewClientClosedError()
+	}
+
+	if token := c.tokenCache.getValidToken(); token != nil {
+		c.tokenCache.updateLastUsed()
+		return token, nil
+	}
+
+	token, err := c.acquireToken(ctx)
+	if err != nil {
+		c.logTokenError(ctx, tokenEventAcquired, err)
+		return nil, err
+	}
+	c.tokenCache.setToken(token)
+	c.logTokenLifecycle(ctx, tokenEventAcquired, token)
 
 	return token, nil
 }
@@ -205,6 +314,15 @@ func (c *AuthClient) acquireTokenWithRetry(ctx context.Context) (*oauth2.Token,
 
 // acquireToken performs a single token acquisition attempt
 func (c *AuthClient) acquireToken(ctx context.Context) (*oauth2.Token, error) {
+	if c.tokenSource == nil {
+		credentials := *c.oauth2Config
+		credentials.ClientSecret = string(c.clientSecret)
+
+		// Create token source with custom HTTP client
+		sourceCtx := context.WithValue(context.Background(), oauth2.HTTPClient, c.httpClient)
+		c.tokenSource = credentials.TokenSource(sourceCtx)
+	}
+
 	token, err := c.tokenSource.Token()
 	if err != nil {
 		return nil, c.mapOAuth2Error(err)
@@ -244,10 +362,11 @@ func (c *AuthClient) HTTPClient(ctx context.Context) (*http.Client, error) {
 
 	// Create transport that adds authentication headers
 	transport := &AuthenticatedTransport{
-		Base:     c.httpClient.Transport,
-		Token:    token,
-		TenantID: c.config.TenantID,
-		Headers:  c.config.CustomHeaders,
+		Base:       c.httpClient.Transport,
+		Token:      token,
+		TenantID:   c.config.TenantID,
+		Headers:    c.config.CustomHeaders,
+		AuthClient: c,
 	}
 
 	// Return client with authenticated transport
@@ -286,33 +405,72 @@ func (c *AuthClient) RefreshToken(ctx context.Context) (*oauth2.Token, error) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	if c.closed {
+		return nil, NewClientClosedError()
+	}
+
 	// Clear cached token to force refresh
 	c.tokenCache.clearToken()
 
 	// Acquire new token
 	token, err := c.acquireTokenWithRetry(ctx)
 	if err != nil {
+		c.logTokenError(ctx, tokenEventRefreshed, err)
 		return nil, err
 	}
 
 	// Cache the new token
 	c.tokenCache.setToken(token)
+	c.logTokenLifecycle(ctx, tokenEventRefreshed, token)
 
 	return token, nil
 }
 
-// Close cleans up resources and clears sensitive data
+// renewToken refreshes the token after the API rejected it. Unlike
+// RefreshToken, which may return the token still held by the oauth2 token
+// source, it recreates the token source so a new token is always requested.
+func (c *AuthClient) renewToken(ctx context.Context) (*oauth2.Token, error) {
+	c.mutex.Lock()
+	c.tokenSource = nil
+	c.mutex.Unlock()
+
+	return c.RefreshToken(ctx)
+}
+
+// Close clears the cached token and overwrites the client secret in memory.
+// Subsequent token requests fail with an AuthErrorClientClosed error. Close is
+// safe to call concurrently and more than once.
+//
+// With RevokeOnClose set, the cached tokens are revoked first; a revocation
+// failure is returned but does not stop the client from closing.
 func (c *AuthClient) Close() error {
+	var revokeErr error
+	if c.config.RevokeOnClose {
+		revokeErr = c.revokeCachedTokens()
+	}
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	// Clear cached token
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	// Clear cached tokens
 	c.tokenCache.clearToken()
+	c.clearScopedTokens()
 
-	// Clear sensitive configuration
+	// Zero the secret bytes; Go strings cannot be overwritten, so the token
+	// source and config copies are released instead
+	for i := range c.clientSecret {
+		c.clientSecret[i] = 0
+	}
+	c.clientSecret = nil
+	c.tokenSource = nil
 	c.config.ClientSecret = ""
 
-	return nil
+	return revokeErr
 }
 
 // TokenCache methods
@@ -367,22 +525,97 @@ func (tc *TokenCache) clearToken() {
 	tc.hash = ""
 }
 
-// AuthenticatedTransport adds OAuth2 authentication headers to HTTP requests
+// AuthenticatedTransport adds OAuth2 authentication headers to HTTP requests.
+// When AuthClient is set, a 401 response forces a token refresh and the
+// request is replayed once if that is safe, see canReplayAfterUnauthorized.
 type AuthenticatedTransport struct {
-	Base     http.RoundTripper
-	Token    *oauth2.Token
-	TenantID string
-	Headers  map[string]string
+	Base       http.RoundTripper
+	Token      *oauth2.Token
+	TenantID   string
+	Headers    map[string]string
+	AuthClient *AuthClient
+	// ReadScopes, when set together with AuthClient, is the scope set
+	// requested for GET, HEAD and OPTIONS requests; other methods keep the
+	// configured scopes
+	ReadScopes []string
+
+	tokenMutex sync.RWMutex
 }
 
 // RoundTrip implements the http.RoundTripper interface
 func (t *AuthenticatedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.AuthClient != nil && len(t.ReadScopes) > 0 && isReadRequest(req) {
+		return t.roundTripScoped(req, t.ReadScopes)
+	}
+
+	token := t.currentToken()
+	resp, err := t.roundTrip(req, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || t.AuthClient == nil {
+		return resp, err
+	}
+	if !canReplayAfterUnauthorized(req, resp) {
+		return resp, nil
+	}
+
+	// Another request may already have refreshed the token
+	newToken := t.currentToken()
+	if newToken == token {
+		newToken, err = t.AuthClient.renewToken(req.Context())
+		if err != nil {
+			// Surface the original 401 rather than the refresh failure
+			return resp, nil
+		}
+		t.setToken(newToken)
+	}
+
+	return t.replay(req, resp, newToken)
+}
+
+// roundTripScoped sends req with a token for scopes, renewing that token and
+// replaying once on a 401
+func (t *AuthenticatedTransport) roundTripScoped(req *http.Request, scopes []string) (*http.Response, error) {
+	token, err := t.AuthClient.GetTokenForScopes(req.Context(), scopes)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := t.roundTrip(req, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized || !canReplayAfterUnauthorized(req, resp) {
+		return resp, err
+	}
+
+	newToken, err := t.AuthClient.renewScopedToken(req.Context(), scopes, token)
+	if err != nil {
+		// Surface the original 401 rather than the refresh failure
+		return resp, nil
+	}
+	return t.replay(req, resp, newToken)
+}
+
+// replay resends req with token after resp was rejected, or returns resp if
+// the body cannot be re-read
+func (t *AuthenticatedTransport) replay(req *http.Request, resp *http.Response, token *oauth2.Token) (*http.Response, error) {
+	replay := req
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		replay = req.Clone(req.Context())
+		replay.Body = body
+	}
+	resp.Body.Close()
+
+	return t.roundTrip(replay, token)
+}
+
+// roundTrip sends req with token and the configured headers
+func (t *AuthenticatedTransport) roundTrip(req *http.Request, token *oauth2.Token) (*http.Response, error) {
 	// Clone the request to avoid modifying the original
 	newReq := req.Clone(req.Context())
 
 	// Add OAuth2 authorization header
-	if t.Token != nil && t.Token.AccessToken != "" {
-		newReq.Header.Set("Authorization", "Bearer "+t.Token.AccessToken)
+	if token != nil && token.AccessToken != "" {
+		newReq.Header.Set("Authorization", "Bearer "+token.AccessToken)
 	}
 
 	// Add tenant ID header
@@ -404,6 +637,55 @@ func (t *AuthenticatedTransport) RoundTrip(req *http.Request) (*http.Response, e
 	return base.RoundTrip(newReq)
 }
 
+func (t *AuthenticatedTransport) currentToken() *oauth2.Token {
+	t.tokenMutex.RLock()
+	defer t.tokenMutex.RUnlock()
+	return t.Token
+}
+
+func (t *AuthenticatedTransport) setToken(token *oauth2.Token) {
+	t.tokenMutex.Lock()
+	defer t.tokenMutex.Unlock()
+	t.Token = token
+}
+
+// canReplayAfterUnauthorized reports whether req may be sent again after a
+// 401. Idempotent methods always may. Other methods, such as POST, are only
+// replayed when the server reports the bearer token itself as invalid
+// (RFC 6750 invalid_token), meaning the request was rejected before it was
+// processed. The body must be re-readable via GetBody.
+func canReplayAfterUnauthorized(req *http.Request, resp *http.Response) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch requestMethod(req) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	challenge := strings.ToLower(resp.Header.Get("WWW-Authenticate"))
+	return strings.Contains(challenge, "invalid_token")
+}
+
+// requestMethod returns the method of req, honoring a method override
+// header that tunnels the real method through POST
+func requestMethod(req *http.Request) string {
+	if override := req.Header.Get("X-HTTP-Method-Override"); override != "" {
+		return override
+	}
+	return req.Method
+}
+
+// isReadRequest reports whether req only reads, see ReadScopes
+func isReadRequest(req *http.Request) bool {
+	switch requestMethod(req) {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
 // RetryTransport provides automatic token refresh on authentication errors
 type RetryTransport struct {
 	Base       http.RoundTripper
@@ -503,6 +785,10 @@ func validateAuthConfig(config *AuthClientConfig) error {
 		return NewConfigValidationError("client_secret", "minimum length 8 characters", "use a stronger client secret", "[REDACTED]")
 	}
 
+	if err := validateAudience(config.Audience); err != nil {
+		return err
+	}
+
 	if config.Timeout <= 0 {
 		config.Timeout = 30 * time.Second // Default timeout
 	}
@@ -518,12 +804,43 @@ func validateAuthConfig(config *AuthClientConfig) error {
 	return nil
 }
 
+// validateAudience checks that a configured audience is an absolute URL or
+// an opaque identifier without whitespace; empty is allowed
+func validateAudience(audience string) error {
+	if audience == "" {
+		return nil
+	}
+	if strings.IndexFunc(audience, func(r rune) bool { return unicode.IsSpace(r) || unicode.IsControl(r) }) >= 0 {
+		return NewConfigValidationError("audience", "must not contain whitespace", "use the audience URL or identifier expected by the token endpoint", audience)
+	}
+	if strings.Contains(audience, "://") {
+		parsed, err := url.Parse(audience)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return NewConfigValidationError("audience", "must be an absolute URL with a host", "use a URL such as https://iam-api.retailsvc.com or an opaque identifier", audience)
+		}
+	}
+	return nil
+}
+
 // mapOAuth2Error converts golang.org/x/oauth2 errors to AuthError types
 func (c *AuthClient) mapOAuth2Error(err error) error {
 	if err == nil {
 		return nil
 	}
 
+	// Classify by HTTP status when the token endpoint responded
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) && retrieveErr.Response != nil {
+		switch status := retrieveErr.Response.StatusCode; {
+		case status == http.StatusTooManyRequests:
+			rateErr := NewRateLimitError("OAuth2 token endpoint rate limited", ParseRetryAfterHeader(retrieveErr.Response.Header.Get("Retry-After")))
+			rateErr.Underlying = err
+			return rateErr
+		case status >= http.StatusInternalServerError:
+			return NewServerError("OAuth2 server temporarily unavailable", err)
+		}
+	}
+
 	errStr := err.Error()
 
 	// Check for specific OAuth2 error patterns
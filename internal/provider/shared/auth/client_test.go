@@ -356,3 +356,206 @@ func parseForm(t *testing.T, r *http.Request) url.Values {
 	require.NoError(t, err, "Should be able to parse form data")
 	return r.Form
 }
+
+// TestAuthClient_Close tests that Close clears credentials and rejects further token requests
+func TestAuthClient_Close(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "valid-token-123",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	defer server.Close()
+
+	client, err := NewAuthClient(&AuthClientConfig{
+		TenantID:     "test-tenant-123",
+		ClientID:     "test-client-123",
+		ClientSecret: "test-secret-456",
+		TokenURL:     server.URL + "/oauth2/token",
+		Timeout:      5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	_, err = client.GetToken(context.Background())
+	require.NoError(t, err)
+	secret := client.clientSecret
+
+	// Close concurrently and repeatedly
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, client.Close())
+		}()
+	}
+	wg.Wait()
+	require.NoError(t, client.Close())
+
+	assert.Equal(t, make([]byte, len("test-secret-456")), secret, "secret bytes should be zeroed")
+	assert.Empty(t, client.config.ClientSecret)
+	assert.Nil(t, client.tokenCache.getValidToken(), "cached token should be cleared")
+
+	for i := 0; i < 2; i++ {
+		token, err := client.GetToken(context.Background())
+		require.Error(t, err)
+		assert.Nil(t, token)
+
+		var authErr *AuthError
+		require.ErrorAs(t, err, &authErr)
+		assert.Equal(t, AuthErrorClientClosed, authErr.Type)
+	}
+
+	_, err = client.RefreshToken(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, requests, "no token requests should be made after Close")
+}
+
+// TestAuthClient_GetTokenWithRetry tests retry behaviour for retryable and permanent token errors
+func TestAuthClient_GetTokenWithRetry(t *testing.T) {
+	newClient := func(t *testing.T, handler func(w http.ResponseWriter, call int)) (*AuthClient, *int) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			handler(w, calls)
+		}))
+		t.Cleanup(server.Close)
+
+		client, err := NewAuthClient(&AuthClientConfig{
+			TenantID:     "test-tenant-123",
+			ClientID:     "test-client-123",
+			ClientSecret: "test-secret-456",
+			TokenURL:     server.URL + "/oauth2/token",
+			Timeout:      5 * time.Second,
+		})
+		require.NoError(t, err)
+		client.retryConfig.BaseDelay = time.Millisecond
+		return client, &calls
+	}
+
+	writeToken := func(w http.ResponseWriter) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "retried-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}
+
+	t.Run("rate_limit_honors_retry_after", func(t *testing.T) {
+		client, calls := newClient(t, func(w http.ResponseWriter, call int) {
+			if call == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			writeToken(w)
+		})
+
+		start := time.Now()
+		token, err := client.GetTokenWithRetry(context.Background(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, "retried-token", token.AccessToken)
+		assert.Equal(t, 2, *calls)
+		assert.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond, "should wait for Retry-After")
+	})
+
+	t.Run("server_error_retries_with_backoff", func(t *testing.T) {
+		client, calls := newClient(t, func(w http.ResponseWriter, call int) {
+			if call < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			writeToken(w)
+		})
+
+		token, err := client.GetTokenWithRetry(context.Background(), 3)
+		require.NoError(t, err)
+		assert.Equal(t, "retried-token", token.AccessToken)
+		assert.Equal(t, 3, *calls)
+	})
+
+	t.Run("server_error_exhausts_attempts", func(t *testing.T) {
+		client, calls := newClient(t, func(w http.ResponseWriter, call int) {
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		_, err := client.GetTokenWithRetry(context.Background(), 2)
+		var authErr *AuthError
+		require.ErrorAs(t, err, &authErr)
+		assert.Equal(t, AuthErrorServerError, authErr.Type)
+		assert.Equal(t, 2, *calls)
+	})
+
+	t.Run("bad_credentials_fail_immediately", func(t *testing.T) {
+		client, calls := newClient(t, func(w http.ResponseWriter, call int) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+		})
+
+		_, err := client.GetTokenWithRetry(context.Background(), 5)
+		var authErr *AuthError
+		require.ErrorAs(t, err, &authErr)
+		assert.Equal(t, AuthErrorCredentials, authErr.Type)
+		assert.Equal(t, 1, *calls)
+	})
+}
+
+func TestAuthClient_Audience(t *testing.T) {
+	tokenRequestAudience := func(t *testing.T, audience string) string {
+		var got string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = parseForm(t, r).Get("audience")
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": "audience-token",
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+		}))
+		defer server.Close()
+
+		client, err := NewAuthClient(&AuthClientConfig{
+			TenantID:     "test-tenant-123",
+			ClientID:     "test-client-123",
+			ClientSecret: "test-secret-456",
+			TokenURL:     server.URL + "/oauth2/token",
+			Audience:     audience,
+			Timeout:      5 * time.Second,
+		})
+		require.NoError(t, err)
+		_, err = client.GetToken(context.Background())
+		require.NoError(t, err)
+		return got
+	}
+
+	t.Run("configured_audience_is_sent", func(t *testing.T) {
+		assert.Equal(t, "https://iam-api.retailsvc.com", tokenRequestAudience(t, "https://iam-api.retailsvc.com"))
+	})
+
+	t.Run("opaque_audience_is_sent", func(t *testing.T) {
+		assert.Equal(t, "iam-api", tokenRequestAudience(t, "iam-api"))
+	})
+
+	t.Run("omitted_audience_uses_default", func(t *testing.T) {
+		assert.Equal(t, DefaultAudience, tokenRequestAudience(t, ""))
+	})
+
+	t.Run("invalid_audience_is_rejected", func(t *testing.T) {
+		for _, audience := range []string{"iam api", "https://", "://iam-api"} {
+			_, err := NewAuthClient(&AuthClientConfig{
+				TenantID:     "test-tenant-123",
+				ClientID:     "test-client-123",
+				ClientSecret: "test-secret-456",
+				TokenURL:     "https://auth.example.com/oauth2/token",
+				Audience:     audience,
+			})
+			assert.Error(t, err, "audience %q", audience)
+		}
+	})
+}
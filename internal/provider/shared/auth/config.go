@@ -64,11 +64,11 @@ func (c *OAuth2Configuration) LoadFromEnvironment() {
 		c.Environment = environment
 	}
 
-	if authURL := os.Getenv("HIIRETAIL_AUTH_URL"); authURL != "" {
+	if authURL := os.Getenv(EnvAuthURL); authURL != "" {
 		c.AuthURL = authURL
 	}
 
-	if apiURL := os.Getenv("HIIRETAIL_API_URL"); apiURL != "" {
+	if apiURL := os.Getenv(EnvAPIURL); apiURL != "" {
 		c.APIURL = apiURL
 	}
 }
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+)
+
+// Keep-alive defaults for the HTTP transports. The provider talks to a single
+// API host, so idle connections per host are allowed up to the overall limit
+// instead of the net/http default of 2, which forces new connections as soon
+// as Terraform runs more than two requests in parallel.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 100
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+// ApplyConnectionPool sets the idle connection limits on transport. Zero or
+// negative values use DefaultMaxIdleConns, DefaultMaxIdleConnsPerHost and
+// DefaultIdleConnTimeout.
+func ApplyConnectionPool(transport *http.Transport, maxIdleConns, maxIdleConnsPerHost int, idleConnTimeout time.Duration) {
+	if maxIdleConns <= 0 {
+		maxIdleConns = DefaultMaxIdleConns
+	}
+	if maxIdleConnsPerHost <= 0 {
+		maxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if idleConnTimeout <= 0 {
+		idleConnTimeout = DefaultIdleConnTimeout
+	}
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.IdleConnTimeout = idleConnTimeout
+}
@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuthClient_ConnectionPool(t *testing.T) {
+	newTransport := func(t *testing.T, config *AuthClientConfig) *http.Transport {
+		t.Helper()
+		config.TenantID = "test-tenant-123"
+		config.ClientID = "test-client-123"
+		config.ClientSecret = "test-secret-456"
+		config.TokenURL = "https://auth.example.com/oauth2/token"
+		config.DisableDiscovery = true
+
+		client, err := NewAuthClient(config)
+		require.NoError(t, err)
+		transport, ok := client.httpClient.Transport.(*http.Transport)
+		require.True(t, ok)
+		return transport
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		transport := newTransport(t, &AuthClientConfig{})
+		require.Equal(t, DefaultMaxIdleConns, transport.MaxIdleConns)
+		require.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+		require.Equal(t, DefaultIdleConnTimeout, transport.IdleConnTimeout)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		transport := newTransport(t, &AuthClientConfig{MaxIdleConns: 8, MaxIdleConnsPerHost: 4, IdleConnTimeout: time.Second})
+		require.Equal(t, 8, transport.MaxIdleConns)
+		require.Equal(t, 4, transport.MaxIdleConnsPerHost)
+		require.Equal(t, time.Second, transport.IdleConnTimeout)
+	})
+}
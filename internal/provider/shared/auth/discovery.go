@@ -3,9 +3,12 @@ package auth
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
@@ -21,24 +24,37 @@ type OIDCDiscoveryResponse struct {
 	TokenEndpointAuthMethods []string `json:"token_endpoint_auth_methods_supported"`
 	ResponseTypesSupported   []string `json:"response_types_supported"`
 	ScopesSupported          []string `json:"scopes_supported"`
+	RevocationEndpoint       string   `json:"revocation_endpoint,omitempty"`
 }
 
-// Validate validates the OIDC discovery response according to OAuth2 specifications
+// DiscoveryFieldError reports a required discovery document field that is
+// absent or invalid
+type DiscoveryFieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *DiscoveryFieldError) Error() string {
+	return e.Reason
+}
+
+// Validate validates the OIDC discovery response according to OAuth2
+// specifications. Failures are *DiscoveryFieldError naming the field.
 func (d *OIDCDiscoveryResponse) Validate() error {
 	if d.Issuer == "" {
-		return fmt.Errorf("missing required field: issuer")
+		return &DiscoveryFieldError{Field: "issuer", Reason: "missing required field: issuer"}
 	}
 
 	if d.TokenEndpoint == "" {
-		return fmt.Errorf("missing required field: token_endpoint")
+		return &DiscoveryFieldError{Field: "token_endpoint", Reason: "missing required field: token_endpoint"}
 	}
 
 	if !contains(d.GrantTypesSupported, "client_credentials") {
-		return fmt.Errorf("client_credentials grant type not supported")
+		return &DiscoveryFieldError{Field: "grant_types_supported", Reason: "client_credentials grant type not supported"}
 	}
 
 	if !isValidURL(d.TokenEndpoint) {
-		return fmt.Errorf("invalid token_endpoint URL")
+		return &DiscoveryFieldError{Field: "token_endpoint", Reason: "invalid token_endpoint URL"}
 	}
 
 	return nil
@@ -101,6 +117,7 @@ type DiscoveryClient struct {
 	timeout    time.Duration
 	httpClient *http.Client
 	cache      *DiscoveryCache
+	cacheTTL   time.Duration
 }
 
 const (
@@ -128,11 +145,16 @@ func NewDiscoveryClient(baseURL string, timeout time.Duration) *DiscoveryClient
 				TLSHandshakeTimeout: 10 * time.Second,
 			},
 		},
-		cache: &DiscoveryCache{},
+		cache:    &DiscoveryCache{},
+		cacheTTL: DiscoveryCacheTTL,
 	}
 }
 
-// FetchDiscovery retrieves OAuth2 discovery information from the OCMS discovery endpoint
+// FetchDiscovery retrieves OAuth2 discovery information from the OCMS
+// discovery endpoint. Errors are *AuthError naming the discovery URL
+// attempted, with credentials stripped from it, in the message and in the
+// "discovery_url" context; a malformed document also names the offending
+// field in the "field" context.
 func (c *DiscoveryClient) FetchDiscovery(ctx context.Context) (*OIDCDiscoveryResponse, error) {
 	discoveryURL := c.buildDiscoveryURL()
 
@@ -144,12 +166,12 @@ func (c *DiscoveryClient) FetchDiscovery(ctx context.Context) (*OIDCDiscoveryRes
 	// Create HTTP request
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
 	if err != nil {
-		return nil, &AuthError{
+		return nil, discoveryURLError(discoveryURL, &AuthError{
 			Type:       AuthErrorConfiguration,
-			Message:    fmt.Sprintf("failed to create discovery request: %v", err),
+			Message:    "failed to create discovery request",
 			Underlying: err,
 			Retryable:  false,
-		}
+		})
 	}
 
 	// Set appropriate headers
@@ -159,12 +181,12 @@ func (c *DiscoveryClient) FetchDiscovery(ctx context.Context) (*OIDCDiscoveryRes
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, &AuthError{
+		return nil, discoveryURLError(discoveryURL, &AuthError{
 			Type:       AuthErrorNetwork,
-			Message:    fmt.Sprintf("failed to fetch discovery endpoint: %v", err),
+			Message:    "failed to fetch discovery endpoint",
 			Underlying: err,
 			Retryable:  true,
-		}
+		})
 	}
 	defer resp.Body.Close()
 
@@ -173,64 +195,100 @@ func (c *DiscoveryClient) FetchDiscovery(ctx context.Context) (*OIDCDiscoveryRes
 	case http.StatusOK:
 		// Continue processing
 	case http.StatusNotFound:
-		return nil, &AuthError{
+		return nil, discoveryURLError(discoveryURL, &AuthError{
 			Type:       AuthErrorDiscovery,
 			Message:    "discovery endpoint not available - OCMS service may not support OAuth2 discovery",
 			Underlying: fmt.Errorf("discovery endpoint returned 404"),
 			Retryable:  false,
-		}
+		}).WithContext("status", resp.StatusCode)
 	case http.StatusTooManyRequests:
-		retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"))
-		return nil, &AuthError{
+		retryAfter := ParseRetryAfterHeader(resp.Header.Get("Retry-After"))
+		return nil, discoveryURLError(discoveryURL, &AuthError{
 			Type:       AuthErrorRateLimit,
 			Message:    "discovery endpoint rate limited",
 			Underlying: fmt.Errorf("discovery rate limited, retry after %v", retryAfter),
 			Retryable:  true,
 			RetryAfter: retryAfter,
-		}
+		}).WithContext("status", resp.StatusCode)
 	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
-		return nil, &AuthError{
+		return nil, discoveryURLError(discoveryURL, &AuthError{
 			Type:       AuthErrorServerError,
 			Message:    "OCMS service unavailable",
 			Underlying: fmt.Errorf("discovery endpoint returned status %d", resp.StatusCode),
 			Retryable:  true,
-		}
+		}).WithContext("status", resp.StatusCode)
 	default:
-		return nil, &AuthError{
+		return nil, discoveryURLError(discoveryURL, &AuthError{
 			Type:       AuthErrorDiscovery,
 			Message:    fmt.Sprintf("discovery endpoint returned unexpected status: %d", resp.StatusCode),
 			Underlying: fmt.Errorf("unexpected HTTP status %d", resp.StatusCode),
 			Retryable:  false,
-		}
+		}).WithContext("status", resp.StatusCode)
 	}
 
 	// Parse JSON response
 	var discoveryResponse OIDCDiscoveryResponse
 	if err := json.NewDecoder(resp.Body).Decode(&discoveryResponse); err != nil {
-		return nil, &AuthError{
+		message := "failed to parse discovery response - invalid JSON format"
+		if contentType := resp.Header.Get("Content-Type"); contentType != "" && !strings.Contains(contentType, "json") {
+			message = fmt.Sprintf("failed to parse discovery response - expected JSON, got %s", contentType)
+		}
+		return nil, discoveryURLError(discoveryURL, &AuthError{
 			Type:       AuthErrorDiscovery,
-			Message:    "failed to parse discovery response - invalid JSON format",
+			Message:    message,
 			Underlying: fmt.Errorf("JSON decode error: %w", err),
 			Retryable:  false,
-		}
+		})
 	}
 
 	// Validate response
 	if err := discoveryResponse.Validate(); err != nil {
-		return nil, &AuthError{
+		authErr := discoveryURLError(discoveryURL, &AuthError{
 			Type:       AuthErrorDiscovery,
 			Message:    fmt.Sprintf("invalid discovery response: %v", err),
 			Underlying: err,
 			Retryable:  false,
+		})
+		var fieldErr *DiscoveryFieldError
+		if errors.As(err, &fieldErr) {
+			authErr.WithContext("field", fieldErr.Field)
 		}
+		return nil, authErr
 	}
 
-	// Cache the response
-	c.cache.Set(discoveryURL, &discoveryResponse, DiscoveryCacheTTL)
+	// Cache the response, honoring a shorter lifetime requested by the server
+	if ttl := serverCacheTTL(resp.Header, c.cacheTTL, time.Now()); ttl > 0 {
+		c.cache.Set(discoveryURL, &discoveryResponse, ttl)
+	}
 
 	return &discoveryResponse, nil
 }
 
+// discoveryURLError adds the discovery URL, without credentials, to err and
+// strips credentials from the URL of an underlying *url.Error
+func discoveryURLError(discoveryURL string, err *AuthError) *AuthError {
+	var urlErr *url.Error
+	if errors.As(err.Underlying, &urlErr) {
+		urlErr.URL = redactURL(urlErr.URL)
+	}
+	safeURL := redactURL(discoveryURL)
+	err.Message = fmt.Sprintf("%s (discovery URL: %s)", err.Message, safeURL)
+	return err.WithContext("discovery_url", safeURL)
+}
+
+// redactURL strips user info and query parameters, which may carry
+// credentials, from rawURL
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "(invalid URL)"
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
 // GetTokenEndpoint retrieves the OAuth2 token endpoint URL with fallback support
 func (c *DiscoveryClient) GetTokenEndpoint(ctx context.Context, fallbackURL string) (string, error) {
 	// Try discovery first
@@ -309,6 +367,46 @@ func (c *DiscoveryClient) buildDiscoveryURL() string {
 
 // Helper functions
 
+// serverCacheTTL returns the smaller of the configured TTL and the lifetime
+// advertised by the Cache-Control or Expires response headers. Cache-Control
+// takes precedence over Expires, and no-store or no-cache disable caching.
+func serverCacheTTL(header http.Header, ttl time.Duration, now time.Time) time.Duration {
+	if cacheControl := header.Get("Cache-Control"); cacheControl != "" {
+		for _, directive := range strings.Split(cacheControl, ",") {
+			directive = strings.ToLower(strings.TrimSpace(directive))
+			switch {
+			case directive == "no-store" || directive == "no-cache":
+				return 0
+			case strings.HasPrefix(directive, "max-age="):
+				seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age="))
+				if err != nil || seconds < 0 {
+					continue
+				}
+				return minDuration(time.Duration(seconds)*time.Second, ttl)
+			}
+		}
+	}
+
+	if expires := header.Get("Expires"); expires != "" {
+		expiresAt, err := http.ParseTime(expires)
+		if err != nil {
+			// Invalid Expires values mean already expired per RFC 9111
+			return 0
+		}
+		return minDuration(expiresAt.Sub(now), ttl)
+	}
+
+	return ttl
+}
+
+// minDuration returns the smaller of two durations
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
 // contains checks if a slice contains a specific string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -332,18 +430,3 @@ func isValidURL(urlStr string) bool {
 
 	return parsedURL.Scheme == "http" || parsedURL.Scheme == "https"
 }
-
-// parseRetryAfter parses the Retry-After header value
-func parseRetryAfter(retryAfter string) time.Duration {
-	if retryAfter == "" {
-		return 60 * time.Second // Default retry after 60 seconds
-	}
-
-	// Try to parse as seconds
-	if duration, err := time.ParseDuration(retryAfter + "s"); err == nil {
-		return duration
-	}
-
-	// Default fallback
-	return 60 * time.Second
-}
@@ -137,3 +137,82 @@ func TestDiscoveryClient_FetchDiscovery(t *testing.T) {
 		assert.Contains(t, err.Error(), "discovery endpoint not available")
 	})
 }
+
+func TestDiscoveryClient_FetchDiscovery_CacheTTL(t *testing.T) {
+	validDiscoveryResponse := &OIDCDiscoveryResponse{
+		Issuer:              "https://auth.retailsvc-test.com",
+		TokenEndpoint:       "https://auth.retailsvc-test.com/oauth2/token",
+		GrantTypesSupported: []string{"client_credentials"},
+	}
+
+	tests := []struct {
+		name        string
+		headers     map[string]string
+		expectedTTL time.Duration
+	}{
+		{
+			name:        "server_max_age_shorter_than_ttl",
+			headers:     map[string]string{"Cache-Control": "public, max-age=60"},
+			expectedTTL: 60 * time.Second,
+		},
+		{
+			name:        "server_max_age_longer_than_ttl",
+			headers:     map[string]string{"Cache-Control": "max-age=86400"},
+			expectedTTL: DiscoveryCacheTTL,
+		},
+		{
+			name:        "no_cache_headers_uses_ttl",
+			expectedTTL: DiscoveryCacheTTL,
+		},
+		{
+			name:        "expires_shorter_than_ttl",
+			headers:     map[string]string{"Expires": time.Now().Add(10 * time.Minute).UTC().Format(http.TimeFormat)},
+			expectedTTL: 10 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(validDiscoveryResponse)
+			}))
+			defer server.Close()
+
+			client := NewDiscoveryClient(server.URL, 30*time.Second)
+			start := time.Now()
+
+			_, err := client.FetchDiscovery(context.Background())
+			require.NoError(t, err)
+			_, err = client.FetchDiscovery(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 1, requests, "second fetch should be served from cache")
+
+			entry := client.cache.cache[client.buildDiscoveryURL()]
+			require.NotNil(t, entry)
+			assert.WithinDuration(t, start.Add(tt.expectedTTL), entry.expiresAt, 2*time.Second)
+		})
+	}
+
+	t.Run("no_store_disables_cache", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Header().Set("Cache-Control", "no-store")
+			json.NewEncoder(w).Encode(validDiscoveryResponse)
+		}))
+		defer server.Close()
+
+		client := NewDiscoveryClient(server.URL, 30*time.Second)
+		for i := 0; i < 2; i++ {
+			_, err := client.FetchDiscovery(context.Background())
+			require.NoError(t, err)
+		}
+		assert.Equal(t, 2, requests)
+	})
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables for optional auth settings shared by the provider and the demo
+const (
+	EnvScopes         = "HIIRETAIL_SCOPES"
+	EnvTimeoutSeconds = "HIIRETAIL_TIMEOUT_SECONDS"
+	EnvMaxRetries     = "HIIRETAIL_MAX_RETRIES"
+
+	// Endpoint overrides for the OAuth2 token endpoint and the IAM API base URL
+	EnvAuthURL = "HIIRETAIL_AUTH_URL"
+	EnvAPIURL  = "HIIRETAIL_API_URL"
+
+	// Deprecated spellings of EnvAuthURL and EnvAPIURL, read only when those
+	// are not set
+	EnvTokenURL = "HIIRETAIL_TOKEN_URL"
+	EnvBaseURL  = "HIIRETAIL_BASE_URL"
+
+	// Optional write credential used for mutating requests only
+	EnvWriteClientID     = "HIIRETAIL_WRITE_CLIENT_ID"
+	EnvWriteClientSecret = "HIIRETAIL_WRITE_CLIENT_SECRET"
+	EnvWriteScopes       = "HIIRETAIL_WRITE_SCOPES"
+)
+
+// ParseScopes splits a comma-separated scope list, trimming whitespace and
+// dropping empty entries
+func ParseScopes(value string) []string {
+	parts := strings.Split(value, ",")
+	scopes := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if scope := strings.TrimSpace(part); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// ParseTimeoutSeconds parses an integer number of seconds and checks it against
+// the timeout bounds in DefaultValidationRules
+func ParseTimeoutSeconds(name, value string) (time.Duration, error) {
+	rules := DefaultValidationRules()
+	seconds, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer number of seconds, got %q", name, value)
+	}
+	if seconds < rules.MinTimeoutSeconds || seconds > rules.MaxTimeoutSeconds {
+		return 0, fmt.Errorf("%s must be between %d and %d seconds, got %d", name, rules.MinTimeoutSeconds, rules.MaxTimeoutSeconds, seconds)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// ParseMaxRetries parses an integer retry count and checks it against the
+// retry bounds in DefaultValidationRules
+func ParseMaxRetries(name, value string) (int, error) {
+	rules := DefaultValidationRules()
+	retries, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%s must be an integer, got %q", name, value)
+	}
+	if retries < 0 || retries > rules.MaxRetries {
+		return 0, fmt.Errorf("%s must be between 0 and %d, got %d", name, rules.MaxRetries, retries)
+	}
+	return retries, nil
+}
+
+// ParseEndpointURL parses an absolute HTTPS URL with a host. Surrounding
+// whitespace and trailing slashes are removed.
+func ParseEndpointURL(name, value string) (string, error) {
+	value = strings.TrimRight(strings.TrimSpace(value), "/")
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("%s is not a valid URL: %v", name, err)
+	}
+	if parsed.Scheme != "https" {
+		return "", fmt.Errorf("%s must use https, got %q", name, value)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("%s must include a host, got %q", name, value)
+	}
+	return value, nil
+}
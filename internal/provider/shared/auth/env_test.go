@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScopes(t *testing.T) {
+	assert.Equal(t, []string{"iam:read", "iam:write"}, ParseScopes("iam:read, iam:write"))
+	assert.Equal(t, []string{"iam:read"}, ParseScopes(" iam:read,,"))
+	assert.Empty(t, ParseScopes(""))
+}
+
+func TestParseTimeoutSeconds(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      time.Duration
+		expectedError string
+	}{
+		{name: "valid", value: "45", expected: 45 * time.Second},
+		{name: "lower_bound", value: "5", expected: 5 * time.Second},
+		{name: "upper_bound", value: "300", expected: 300 * time.Second},
+		{name: "too_small", value: "4", expectedError: "between 5 and 300"},
+		{name: "too_large", value: "301", expectedError: "between 5 and 300"},
+		{name: "malformed", value: "30s", expectedError: "must be an integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTimeoutSeconds(EnvTimeoutSeconds, tt.value)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Contains(t, err.Error(), EnvTimeoutSeconds)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParseMaxRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      int
+		expectedError string
+	}{
+		{name: "valid", value: "3", expected: 3},
+		{name: "zero", value: "0", expected: 0},
+		{name: "upper_bound", value: "10", expected: 10},
+		{name: "negative", value: "-1", expectedError: "between 0 and 10"},
+		{name: "too_large", value: "11", expectedError: "between 0 and 10"},
+		{name: "malformed", value: "three", expectedError: "must be an integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseMaxRetries(EnvMaxRetries, tt.value)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParseEndpointURL(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         string
+		expected      string
+		expectedError string
+	}{
+		{name: "valid", value: "https://auth.example.com/oauth2/token", expected: "https://auth.example.com/oauth2/token"},
+		{name: "trailing_slash", value: " https://iam.example.com/ ", expected: "https://iam.example.com"},
+		{name: "http", value: "http://iam.example.com", expectedError: "must use https"},
+		{name: "malformed", value: "https://iam example.com:port", expectedError: "not a valid URL"},
+		{name: "missing_host", value: "https:///oauth2/token", expectedError: "must include a host"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseEndpointURL(EnvAPIURL, tt.value)
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Contains(t, err.Error(), EnvAPIURL)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package auth
 
 import (
+	"errors"
 	"fmt"
+	"math"
+	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -33,6 +37,27 @@ const (
 
 	// AuthErrorTokenExpired represents token expiration during operation
 	AuthErrorTokenExpired
+
+	// AuthErrorClientClosed represents use of a client after Close
+	AuthErrorClientClosed
+
+	// AuthErrorTokenInvalid represents a token rejected as malformed or revoked
+	AuthErrorTokenInvalid
+)
+
+// Sentinel errors for each AuthErrorType. errors.Is matches any *AuthError of
+// the same type against them, e.g. errors.Is(err, ErrRateLimit).
+var (
+	ErrUnknown       = &AuthError{Type: AuthErrorUnknown}
+	ErrConfiguration = &AuthError{Type: AuthErrorConfiguration}
+	ErrDiscovery     = &AuthError{Type: AuthErrorDiscovery}
+	ErrCredentials   = &AuthError{Type: AuthErrorCredentials}
+	ErrNetwork       = &AuthError{Type: AuthErrorNetwork}
+	ErrServer        = &AuthError{Type: AuthErrorServerError}
+	ErrRateLimit     = &AuthError{Type: AuthErrorRateLimit}
+	ErrTokenExpired  = &AuthError{Type: AuthErrorTokenExpired}
+	ErrClientClosed  = &AuthError{Type: AuthErrorClientClosed}
+	ErrTokenInvalid  = &AuthError{Type: AuthErrorTokenInvalid}
 )
 
 // String returns a human-readable string representation of the error type
@@ -52,6 +77,10 @@ func (t AuthErrorType) String() string {
 		return "Rate Limit Error"
 	case AuthErrorTokenExpired:
 		return "Token Expired Error"
+	case AuthErrorClientClosed:
+		return "Client Closed Error"
+	case AuthErrorTokenInvalid:
+		return "Token Invalid Error"
 	default:
 		return "Unknown Error"
 	}
@@ -80,6 +109,9 @@ type AuthError struct {
 
 // Error implements the error interface
 func (e *AuthError) Error() string {
+	if e.Message == "" && e.Underlying == nil {
+		return e.Type.String()
+	}
 	if e.Underlying != nil {
 		return fmt.Sprintf("%s: %s (caused by: %v)", e.Type.String(), e.Message, e.Underlying)
 	}
@@ -91,6 +123,16 @@ func (e *AuthError) Unwrap() error {
 	return e.Underlying
 }
 
+// Is reports whether target is the sentinel for e's type, such as
+// ErrCredentials, so errors.Is can classify wrapped auth errors
+func (e *AuthError) Is(target error) bool {
+	t, ok := target.(*AuthError)
+	if !ok || t.Message != "" || t.Underlying != nil {
+		return false
+	}
+	return t.Type == e.Type
+}
+
 // IsRetryable returns true if the error indicates a retryable condition
 func (e *AuthError) IsRetryable() bool {
 	return e.Retryable
@@ -301,6 +343,26 @@ func NewTokenExpiredError(message string) *AuthError {
 	}
 }
 
+// NewClientClosedError creates an error for token requests on a closed client
+func NewClientClosedError() *AuthError {
+	return &AuthError{
+		Type:       AuthErrorClientClosed,
+		Message:    "auth client is closed",
+		Underlying: fmt.Errorf("client credentials were cleared by Close"),
+		Retryable:  false,
+	}
+}
+
+// NewTokenInvalidError creates an error for a token rejected as malformed or revoked
+func NewTokenInvalidError(message string, underlying error) *AuthError {
+	return &AuthError{
+		Type:       AuthErrorTokenInvalid,
+		Message:    message,
+		Underlying: underlying,
+		Retryable:  false, // A new token has to be requested instead
+	}
+}
+
 // Helper functions
 
 // isSensitiveField checks if a configuration field contains sensitive information
@@ -325,19 +387,9 @@ func isSensitiveField(field string) bool {
 	return false
 }
 
-// isAuthError checks if an error is an AuthError and extracts it
+// isAuthError checks if an error is or wraps an AuthError and extracts it
 func isAuthError(err error, authErr **AuthError) bool {
-	if err == nil {
-		return false
-	}
-
-	switch e := err.(type) {
-	case *AuthError:
-		*authErr = e
-		return true
-	default:
-		return false
-	}
+	return errors.As(err, authErr)
 }
 
 // pow calculates base^exp for floating point numbers
@@ -357,27 +409,35 @@ func random() float64 {
 	return float64((seed*1103515245+12345)&0x7fffffff) / float64(0x7fffffff)
 }
 
-// ParseRetryAfterHeader parses the Retry-After header value from HTTP responses
+// ParseRetryAfterHeader parses a Retry-After header value, given either as
+// delay seconds or as an HTTP-date, into the wait before retrying. A date is
+// taken relative to now and clamped to zero once it has passed. Past dates,
+// empty and malformed values all return zero, so callers fall back to their
+// default backoff rather than retrying immediately.
 func ParseRetryAfterHeader(retryAfter string) time.Duration {
+	return parseRetryAfterAt(retryAfter, time.Now())
+}
+
+// parseRetryAfterAt is ParseRetryAfterHeader with an explicit current time
+func parseRetryAfterAt(retryAfter string, now time.Time) time.Duration {
+	retryAfter = strings.TrimSpace(retryAfter)
 	if retryAfter == "" {
 		return 0
 	}
 
-	// Try to parse as seconds (integer)
-	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+	if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil {
+		if seconds < 0 || seconds > int64(math.MaxInt64/time.Second) {
+			return 0
+		}
 		return time.Duration(seconds) * time.Second
 	}
 
-	// Try to parse as HTTP date (not commonly used for OAuth2)
-	if parsedTime, err := time.Parse(time.RFC1123, retryAfter); err == nil {
-		duration := time.Until(parsedTime)
-		if duration > 0 {
-			return duration
-		}
+	// http.ParseTime accepts the IMF-fixdate, RFC 850 and ANSI C forms
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		return max(date.Sub(now), 0)
 	}
 
-	// Default fallback
-	return 60 * time.Second
+	return 0
 }
 
 // ErrorCode returns a standardized error code for API responses
@@ -397,6 +457,8 @@ func (e *AuthError) ErrorCode() string {
 		return "rate_limited"
 	case AuthErrorTokenExpired:
 		return "token_expired"
+	case AuthErrorTokenInvalid:
+		return "invalid_token"
 	default:
 		return "unknown_error"
 	}
@@ -419,6 +481,8 @@ func (e *AuthError) TroubleshootingGuidance() string {
 		return "Too many requests to OCMS. Wait before retrying or check if your application is making excessive token requests."
 	case AuthErrorTokenExpired:
 		return "Token expired during operation. This should be handled automatically by token refresh."
+	case AuthErrorTokenInvalid:
+		return "The token was rejected as malformed or revoked. Check that the OAuth2 client is still active in OCMS and request a new token."
 	default:
 		return "Check the error details and logs for more information."
 	}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthErrorType_String(t *testing.T) {
+	tests := map[AuthErrorType]string{
+		AuthErrorUnknown:       "Unknown Error",
+		AuthErrorConfiguration: "Configuration Error",
+		AuthErrorDiscovery:     "Discovery Error",
+		AuthErrorCredentials:   "Credentials Error",
+		AuthErrorNetwork:       "Network Error",
+		AuthErrorServerError:   "Server Error",
+		AuthErrorRateLimit:     "Rate Limit Error",
+		AuthErrorTokenExpired:  "Token Expired Error",
+		AuthErrorClientClosed:  "Client Closed Error",
+		AuthErrorTokenInvalid:  "Token Invalid Error",
+		AuthErrorType(99):      "Unknown Error",
+	}
+	for errType, want := range tests {
+		assert.Equal(t, want, errType.String())
+		assert.Equal(t, want, fmt.Sprint(errType), "fmt should use String rather than the numeric code")
+	}
+}
+
+func TestAuthError_Wrapping(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := fmt.Errorf("failed to get authentication token: %w", NewNetworkError("token endpoint unreachable", cause))
+
+	var authErr *AuthError
+	require.True(t, errors.As(err, &authErr), "errors.As should extract the wrapped *AuthError")
+	assert.Equal(t, AuthErrorNetwork, authErr.Type)
+	assert.True(t, authErr.Retryable)
+
+	assert.True(t, errors.Is(err, cause), "Unwrap should expose the underlying error")
+	assert.True(t, errors.Is(err, ErrNetwork), "errors.Is should match the sentinel of the same type")
+	assert.False(t, errors.Is(err, ErrCredentials), "errors.Is should not match the sentinel of another type")
+	assert.False(t, errors.Is(err, NewNetworkError("other", nil)), "non-sentinel auth errors only match themselves")
+}
+
+func TestAuthError_Sentinels(t *testing.T) {
+	tests := []struct {
+		err      *AuthError
+		sentinel error
+	}{
+		{NewCredentialsError("bad secret", nil), ErrCredentials},
+		{NewRateLimitError("slow down", 0), ErrRateLimit},
+		{NewTokenExpiredError("expired"), ErrTokenExpired},
+		{NewClientClosedError(), ErrClientClosed},
+		{NewTokenInvalidError("revoked", nil), ErrTokenInvalid},
+	}
+	for _, tt := range tests {
+		assert.ErrorIs(t, tt.err, tt.sentinel, "%s", tt.err.Type)
+	}
+	assert.Equal(t, "Token Invalid Error", ErrTokenInvalid.Error())
+	assert.Equal(t, "invalid_token", NewTokenInvalidError("revoked", nil).ErrorCode())
+}
+
+func TestRetryConfig_ShouldRetryWrappedAuthError(t *testing.T) {
+	rc := DefaultRetryConfig()
+	err := fmt.Errorf("token request: %w", NewServerError("unavailable", nil))
+	assert.True(t, rc.ShouldRetry(err, 0), "wrapped retryable auth errors should be retried")
+	assert.False(t, rc.ShouldRetry(fmt.Errorf("token request: %w", NewCredentialsError("denied", nil)), 0))
+}
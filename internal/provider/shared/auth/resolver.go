@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -9,8 +10,14 @@ import (
 type EndpointResolver struct {
 	TenantID    string
 	Environment string
+	// Overrides maps environment names to custom endpoints, for customers with
+	// vanity domains. Entries take precedence over the built-in environments.
+	Overrides map[string]EndpointMapping
 }
 
+// builtinEnvironments lists the environment names resolved without overrides
+var builtinEnvironments = []string{"dev", "development", "production", "staging", "test"}
+
 // EndpointMapping defines the mapping of environments to endpoints
 type EndpointMapping struct {
 	AuthBaseURL string
@@ -49,6 +56,14 @@ func (r *EndpointResolver) getEndpointMapping() (*EndpointMapping, error) {
 	// Determine environment from tenant ID if not explicitly set
 	effectiveEnv := r.determineEffectiveEnvironment()
 
+	for name, mapping := range r.Overrides {
+		if strings.ToLower(name) == effectiveEnv {
+			mapping.AuthBaseURL = strings.TrimSuffix(mapping.AuthBaseURL, "/")
+			mapping.APIBaseURL = strings.TrimSuffix(mapping.APIBaseURL, "/")
+			return &mapping, nil
+		}
+	}
+
 	switch effectiveEnv {
 	case "production":
 		return &EndpointMapping{
@@ -75,8 +90,18 @@ func (r *EndpointResolver) getEndpointMapping() (*EndpointMapping, error) {
 		}, nil
 
 	default:
-		return nil, fmt.Errorf("unsupported environment: %s", effectiveEnv)
+		return nil, fmt.Errorf("unsupported environment: %s (supported: %s)", effectiveEnv, strings.Join(r.knownEnvironments(), ", "))
+	}
+}
+
+// knownEnvironments returns the sorted built-in and override environment names
+func (r *EndpointResolver) knownEnvironments() []string {
+	names := append([]string{}, builtinEnvironments...)
+	for name := range r.Overrides {
+		names = append(names, strings.ToLower(name))
 	}
+	sort.Strings(names)
+	return names
 }
 
 // determineEffectiveEnvironment determines the effective environment based on tenant ID and explicit environment
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveEndpoints_KnownEnvironments(t *testing.T) {
+	tests := []struct {
+		environment string
+		authURL     string
+		apiURL      string
+	}{
+		{"dev", "https://auth.retailsvc-dev.com/oauth2/token", "https://iam-api.retailsvc-dev.com"},
+		{"test", "https://auth.retailsvc-test.com/oauth2/token", "https://iam-api.retailsvc-test.com"},
+		{"production", "https://auth.retailsvc.com/oauth2/token", "https://iam-api.retailsvc.com"},
+		{"Production", "https://auth.retailsvc.com/oauth2/token", "https://iam-api.retailsvc.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.environment, func(t *testing.T) {
+			authURL, apiURL, err := ResolveEndpoints("acme", tt.environment)
+			require.NoError(t, err)
+			assert.Equal(t, tt.authURL, authURL)
+			assert.Equal(t, tt.apiURL, apiURL)
+		})
+	}
+}
+
+func TestResolveEndpoints_UnknownEnvironment(t *testing.T) {
+	_, _, err := ResolveEndpoints("acme", "qa")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported environment: qa")
+	assert.Contains(t, err.Error(), "production")
+}
+
+func TestResolveEndpointsWithOverrides(t *testing.T) {
+	overrides := map[string]EndpointMapping{
+		"ACME": {AuthBaseURL: "https://auth.acme.example/", APIBaseURL: "https://iam.acme.example"},
+		"test": {AuthBaseURL: "https://auth.custom-test.example", APIBaseURL: "https://iam.custom-test.example"},
+	}
+
+	authURL, apiURL, err := ResolveEndpointsWithOverrides("acme", "acme", overrides)
+	require.NoError(t, err)
+	assert.Equal(t, "https://auth.acme.example/oauth2/token", authURL)
+	assert.Equal(t, "https://iam.acme.example", apiURL)
+
+	// Overrides take precedence over built-in environments
+	authURL, _, err = ResolveEndpointsWithOverrides("acme", "test", overrides)
+	require.NoError(t, err)
+	assert.Equal(t, "https://auth.custom-test.example/oauth2/token", authURL)
+
+	// Built-ins still resolve when no override matches
+	_, apiURL, err = ResolveEndpointsWithOverrides("acme", "dev", overrides)
+	require.NoError(t, err)
+	assert.Equal(t, "https://iam-api.retailsvc-dev.com", apiURL)
+
+	_, _, err = ResolveEndpointsWithOverrides("acme", "qa", overrides)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "acme")
+}
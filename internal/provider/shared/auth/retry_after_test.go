@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfterHeader(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value string
+		want  time.Duration
+	}{
+		{"integer seconds", "120", 2 * time.Minute},
+		{"zero seconds", "0", 0},
+		{"future HTTP-date", now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second},
+		{"future RFC 850 date", now.Add(time.Minute).Format(time.RFC850), time.Minute},
+		{"past HTTP-date", now.Add(-time.Hour).Format(http.TimeFormat), 0},
+		{"empty", "", 0},
+		{"garbage", "soon", 0},
+		{"negative seconds", "-5", 0},
+		{"fractional seconds", "1.5", 0},
+		{"overflowing seconds", "99999999999999999", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRetryAfterAt(tt.value, now); got != tt.want {
+				t.Errorf("parseRetryAfterAt(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryConfig_GetDelay_MalformedRetryAfter(t *testing.T) {
+	rc := DefaultRetryConfig()
+	rc.Jitter = false
+	want := rc.GetDelay(0, NewRateLimitError("rate limited", 0))
+	for _, value := range []string{"garbage", time.Now().Add(-time.Hour).Format(http.TimeFormat)} {
+		err := NewRateLimitError("rate limited", ParseRetryAfterHeader(value))
+		if got := rc.GetDelay(0, err); got != want {
+			t.Errorf("GetDelay() for Retry-After %q = %v, want the default backoff %v", value, got, want)
+		}
+	}
+}
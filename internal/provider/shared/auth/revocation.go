@@ -0,0 +1,143 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultRevocationTimeout bounds token revocation in Close when no client
+// timeout is configured
+const defaultRevocationTimeout = 10 * time.Second
+
+// RevokeToken revokes an access token at the authorization server as defined
+// in RFC 7009, so it stops being accepted before it expires. The endpoint is
+// AuthClientConfig.RevocationURL or the revocation_endpoint advertised by
+// discovery; without either a configuration error is returned. A 200
+// response, and a 404 for a token the server no longer knows, count as
+// success. A revoked token is dropped from the token caches.
+func (c *AuthClient) RevokeToken(ctx context.Context, token string) error {
+	if token == "" {
+		return nil
+	}
+
+	c.mutex.RLock()
+	closed := c.closed
+	secret := string(c.clientSecret)
+	c.mutex.RUnlock()
+	if closed {
+		return NewClientClosedError()
+	}
+
+	endpoint, err := c.revocationEndpoint(ctx)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{
+		"token":           {token},
+		"token_type_hint": {"access_token"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return NewConfigurationError("failed to create revocation request", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(c.config.ClientID), url.QueryEscape(secret))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return NewNetworkError("token revocation request failed", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNotFound:
+	case resp.StatusCode >= 500:
+		return NewServerError(fmt.Sprintf("token revocation failed with status %d", resp.StatusCode), nil)
+	default:
+		return NewCredentialsError(fmt.Sprintf("token revocation rejected with status %d", resp.StatusCode), nil)
+	}
+
+	c.forgetToken(token)
+	return nil
+}
+
+// revocationEndpoint returns the configured or discovered revocation endpoint
+func (c *AuthClient) revocationEndpoint(ctx context.Context) (string, error) {
+	if c.config.RevocationURL != "" {
+		return c.config.RevocationURL, nil
+	}
+	if c.discoveryClient != nil {
+		discovery, err := c.discoveryClient.FetchDiscovery(ctx)
+		if err != nil {
+			return "", NewDiscoveryError("failed to discover revocation endpoint", err)
+		}
+		if discovery.RevocationEndpoint != "" {
+			return discovery.RevocationEndpoint, nil
+		}
+	}
+	return "", NewConfigurationError("no token revocation endpoint advertised by discovery and no revocation URL configured", nil)
+}
+
+// forgetToken drops token from the token caches so it is not handed out
+// after revocation
+func (c *AuthClient) forgetToken(token string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if cached := c.tokenCache.getValidToken(); cached != nil && cached.AccessToken == token {
+		c.tokenCache.clearToken()
+		c.tokenSource = nil
+	}
+	for _, entry := range c.scopedTokens {
+		if cached := entry.cache.getValidToken(); cached != nil && cached.AccessToken == token {
+			entry.cache.clearToken()
+			entry.source = nil
+		}
+	}
+}
+
+// revokeCachedTokens revokes every valid cached token, see RevokeOnClose
+func (c *AuthClient) revokeCachedTokens() error {
+	c.mutex.RLock()
+	if c.closed {
+		c.mutex.RUnlock()
+		return nil
+	}
+	var tokens []string
+	if cached := c.tokenCache.getValidToken(); cached != nil {
+		tokens = append(tokens, cached.AccessToken)
+	}
+	for _, entry := range c.scopedTokens {
+		if cached := entry.cache.getValidToken(); cached != nil {
+			tokens = append(tokens, cached.AccessToken)
+		}
+	}
+	c.mutex.RUnlock()
+
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	timeout := c.config.Timeout
+	if timeout <= 0 {
+		timeout = defaultRevocationTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var errs []error
+	for _, token := range tokens {
+		if err := c.RevokeToken(ctx, token); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
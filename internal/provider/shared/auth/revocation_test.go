@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// revocationServer serves discovery, numbered tokens and an RFC 7009
+// revocation endpoint
+type revocationServer struct {
+	mu               sync.Mutex
+	issued           int
+	revoked          []string
+	revokeStatus     int
+	advertiseRevoke  bool
+	revocationClient string
+}
+
+func (s *revocationServer) handler(serverURL *string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		switch r.URL.Path {
+		case DiscoveryEndpointPath:
+			doc := OIDCDiscoveryResponse{
+				Issuer:              *serverURL,
+				TokenEndpoint:       *serverURL + "/oauth2/token",
+				GrantTypesSupported: []string{"client_credentials"},
+			}
+			if s.advertiseRevoke {
+				doc.RevocationEndpoint = *serverURL + "/oauth2/revoke"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(doc)
+		case "/oauth2/token":
+			s.issued++
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": fmt.Sprintf("token-%d", s.issued),
+				"token_type":   "Bearer",
+				"expires_in":   3600,
+			})
+		case "/oauth2/revoke":
+			r.ParseForm()
+			s.revoked = append(s.revoked, r.PostForm.Get("token"))
+			s.revocationClient, _, _ = r.BasicAuth()
+			w.WriteHeader(s.revokeStatus)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func newRevocationClient(t *testing.T, state *revocationServer, configure func(*AuthClientConfig)) *AuthClient {
+	t.Helper()
+	var serverURL string
+	server := httptest.NewServer(state.handler(&serverURL))
+	t.Cleanup(server.Close)
+	serverURL = server.URL
+
+	config := &AuthClientConfig{
+		TenantID:     "test-tenant-123",
+		ClientID:     "test-client-123",
+		ClientSecret: "test-secret-456",
+		BaseURL:      server.URL,
+		Timeout:      5 * time.Second,
+	}
+	if configure != nil {
+		configure(config)
+	}
+	client, err := NewAuthClient(config)
+	require.NoError(t, err)
+	return client
+}
+
+func TestAuthClient_RevokeToken(t *testing.T) {
+	for _, status := range []int{http.StatusOK, http.StatusNotFound} {
+		t.Run(fmt.Sprintf("status %d", status), func(t *testing.T) {
+			state := &revocationServer{revokeStatus: status, advertiseRevoke: true}
+			client := newRevocationClient(t, state, nil)
+			ctx := context.Background()
+
+			token, err := client.GetToken(ctx)
+			require.NoError(t, err)
+			require.NoError(t, client.RevokeToken(ctx, token.AccessToken))
+
+			assert.Equal(t, []string{"token-1"}, state.revoked)
+			assert.Equal(t, "test-client-123", state.revocationClient)
+
+			// The revoked token is not handed out again
+			next, err := client.GetToken(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, "token-2", next.AccessToken)
+		})
+	}
+}
+
+func TestAuthClient_RevokeToken_Failure(t *testing.T) {
+	state := &revocationServer{revokeStatus: http.StatusServiceUnavailable, advertiseRevoke: true}
+	client := newRevocationClient(t, state, nil)
+
+	err := client.RevokeToken(context.Background(), "token-x")
+	var authErr *AuthError
+	require.True(t, errors.As(err, &authErr), "got %v", err)
+	assert.Equal(t, AuthErrorServerError, authErr.Type)
+}
+
+func TestAuthClient_RevokeToken_MissingEndpoint(t *testing.T) {
+	t.Run("not advertised", func(t *testing.T) {
+		state := &revocationServer{revokeStatus: http.StatusOK}
+		client := newRevocationClient(t, state, nil)
+
+		err := client.RevokeToken(context.Background(), "token-x")
+		var authErr *AuthError
+		require.True(t, errors.As(err, &authErr), "got %v", err)
+		assert.Equal(t, AuthErrorConfiguration, authErr.Type)
+		assert.Empty(t, state.revoked)
+	})
+
+	t.Run("discovery disabled", func(t *testing.T) {
+		state := &revocationServer{revokeStatus: http.StatusOK, advertiseRevoke: true}
+		client := newRevocationClient(t, state, func(config *AuthClientConfig) {
+			config.TokenURL = config.BaseURL + "/oauth2/token"
+			config.DisableDiscovery = true
+		})
+
+		err := client.RevokeToken(context.Background(), "token-x")
+		var authErr *AuthError
+		require.True(t, errors.As(err, &authErr), "got %v", err)
+		assert.Equal(t, AuthErrorConfiguration, authErr.Type)
+	})
+
+	t.Run("explicit URL", func(t *testing.T) {
+		state := &revocationServer{revokeStatus: http.StatusOK}
+		client := newRevocationClient(t, state, func(config *AuthClientConfig) {
+			config.RevocationURL = config.BaseURL + "/oauth2/revoke"
+		})
+
+		require.NoError(t, client.RevokeToken(context.Background(), "token-x"))
+		assert.Equal(t, []string{"token-x"}, state.revoked)
+	})
+}
+
+func TestAuthClient_RevokeOnClose(t *testing.T) {
+	t.Run("enabled", func(t *testing.T) {
+		state := &revocationServer{revokeStatus: http.StatusOK, advertiseRevoke: true}
+		client := newRevocationClient(t, state, func(config *AuthClientConfig) {
+			config.RevokeOnClose = true
+		})
+		ctx := context.Background()
+
+		_, err := client.GetToken(ctx)
+		require.NoError(t, err)
+		_, err = client.GetTokenForScopes(ctx, []string{"iam:read"})
+		require.NoError(t, err)
+
+		require.NoError(t, client.Close())
+		assert.ElementsMatch(t, []string{"token-1", "token-2"}, state.revoked)
+
+		// A second Close does not revoke again
+		require.NoError(t, client.Close())
+		assert.Len(t, state.revoked, 2)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		state := &revocationServer{revokeStatus: http.StatusOK, advertiseRevoke: true}
+		client := newRevocationClient(t, state, nil)
+
+		_, err := client.GetToken(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, client.Close())
+		assert.Empty(t, state.revoked)
+	})
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// scopedToken is a token cache and token source for one scope set
+type scopedToken struct {
+	cache  *TokenCache
+	source oauth2.TokenSource
+}
+
+// scopeKey returns a cache key for a scope set that is independent of order
+// and duplicates.
+func scopeKey(scopes []string) string {
+	set := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		set = append(set, scope)
+	}
+	sort.Strings(set)
+	return strings.Join(set, " ")
+}
+
+// GetTokenForScopes acquires or returns a cached OAuth2 access token for the
+// given scope set. Tokens are cached per sorted scope set, so alternating
+// between e.g. read-only and read-write scopes keeps one valid token for each
+// instead of refetching. Requesting the configured scopes is the same as
+// GetToken.
+func (c *AuthClient) GetTokenForScopes(ctx context.Context, scopes []string) (*oauth2.Token, error) {
+	key := scopeKey(scopes)
+	if key == scopeKey(c.config.Scopes) {
+		return c.GetToken(ctx)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.closed {
+		return nil, NewClientClosedError()
+	}
+
+	entry, ok := c.scopedTokens[key]
+	if !ok {
+		entry = &scopedToken{cache: &TokenCache{}}
+		if c.scopedTokens == nil {
+			c.scopedTokens = make(map[string]*scopedToken)
+		}
+		c.scopedTokens[key] = entry
+	}
+
+	if token := entry.cache.getValidToken(); token != nil {
+		entry.cache.updateLastUsed()
+		return token, nil
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < c.retryConfig.MaxAttempts; attempt++ {
+		token, err := c.acquireScopedToken(entry, strings.Fields(key))
+		if err == nil {
+			entry.cache.setToken(token)
+			return token, nil
+		}
+		lastErr = err
+
+		if !c.retryConfig.ShouldRetry(err, attempt) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, NewNetworkError("context canceled during token acquisition retry", ctx.Err())
+		case <-time.After(c.retryConfig.GetDelay(attempt, err)):
+		}
+	}
+
+	return nil, lastErr
+}
+
+// acquireScopedToken performs a single token acquisition for a scope set
+func (c *AuthClient) acquireScopedToken(entry *scopedToken, scopes []string) (*oauth2.Token, error) {
+	if entry.source == nil {
+		credentials := *c.oauth2Config
+		credentials.ClientSecret = string(c.clientSecret)
+		credentials.Scopes = scopes
+
+		sourceCtx := context.WithValue(context.Background(), oauth2.HTTPClient, c.httpClient)
+		entry.source = credentials.TokenSource(sourceCtx)
+	}
+
+	token, err := entry.source.Token()
+	if err != nil {
+		return nil, c.mapOAuth2Error(err)
+	}
+
+	if !token.Valid() {
+		return nil, NewCredentialsError("received invalid token from OAuth2 server", nil)
+	}
+
+	return token, nil
+}
+
+// clearScopedTokens drops every per-scope token and token source
+func (c *AuthClient) clearScopedTokens() {
+	for _, entry := range c.scopedTokens {
+		entry.cache.clearToken()
+		entry.source = nil
+	}
+	c.scopedTokens = nil
+}
+
+// renewScopedToken discards stale and fetches a new token for scopes, unless
+// another request has already replaced it
+func (c *AuthClient) renewScopedToken(ctx context.Context, scopes []string, stale *oauth2.Token) (*oauth2.Token, error) {
+	key := scopeKey(scopes)
+	if key == scopeKey(c.config.Scopes) {
+		return c.renewToken(ctx)
+	}
+
+	c.mutex.Lock()
+	if entry, ok := c.scopedTokens[key]; ok {
+		token := entry.cache.getValidToken()
+		if token != nil && (stale == nil || token.AccessToken != stale.AccessToken) {
+			c.mutex.Unlock()
+			return token, nil
+		}
+		entry.cache.clearToken()
+		entry.source = nil
+	}
+	c.mutex.Unlock()
+
+	return c.GetTokenForScopes(ctx, scopes)
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newScopedTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *int32) {
+	t.Helper()
+	var fetches int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&fetches, 1)
+		form := parseForm(t, r)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-" + form.Get("scope") + "-" + string(rune('0'+n)),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &fetches
+}
+
+func newScopedTokenClient(t *testing.T, tokenURL string) *AuthClient {
+	t.Helper()
+	client, err := NewAuthClient(&AuthClientConfig{
+		TenantID:     "test-tenant-123",
+		ClientID:     "test-client-123",
+		ClientSecret: "test-secret-456",
+		TokenURL:     tokenURL,
+		Scopes:       []string{"iam:read"},
+		Timeout:      5 * time.Second,
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestAuthClient_GetTokenForScopes_AlternatingScopes(t *testing.T) {
+	server, fetches := newScopedTokenServer(t, 3600)
+	client := newScopedTokenClient(t, server.URL+"/oauth2/token")
+
+	readOnly := []string{"iam:read"}
+	readWrite := []string{"iam:write", "iam:read"}
+
+	var readToken, writeToken string
+	for i := 0; i < 10; i++ {
+		token, err := client.GetTokenForScopes(context.Background(), readOnly)
+		require.NoError(t, err)
+		if readToken == "" {
+			readToken = token.AccessToken
+		}
+		assert.Equal(t, readToken, token.AccessToken)
+
+		token, err = client.GetTokenForScopes(context.Background(), readWrite)
+		require.NoError(t, err)
+		if writeToken == "" {
+			writeToken = token.AccessToken
+		}
+		assert.Equal(t, writeToken, token.AccessToken)
+	}
+
+	assert.NotEqual(t, readToken, writeToken)
+	assert.Equal(t, int32(2), atomic.LoadInt32(fetches), "each scope set should be fetched once")
+
+	// Scope order and duplicates do not create new cache entries
+	token, err := client.GetTokenForScopes(context.Background(), []string{"iam:read", "iam:write", "iam:read"})
+	require.NoError(t, err)
+	assert.Equal(t, writeToken, token.AccessToken)
+
+	// The configured scopes share the GetToken cache
+	token, err = client.GetToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, readToken, token.AccessToken)
+	assert.Equal(t, int32(2), atomic.LoadInt32(fetches))
+}
+
+func TestAuthClient_GetTokenForScopes_Concurrent(t *testing.T) {
+	server, fetches := newScopedTokenServer(t, 3600)
+	client := newScopedTokenClient(t, server.URL+"/oauth2/token")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			scopes := []string{"iam:read"}
+			if i%2 == 0 {
+				scopes = []string{"iam:read", "iam:write"}
+			}
+			_, err := client.GetTokenForScopes(context.Background(), scopes)
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(fetches))
+}
+
+func TestAuthClient_GetTokenForScopes_Expiry(t *testing.T) {
+	server, fetches := newScopedTokenServer(t, 3600)
+	client := newScopedTokenClient(t, server.URL+"/oauth2/token")
+
+	writeScopes := []string{"iam:write"}
+	adminScopes := []string{"iam:admin"}
+	_, err := client.GetTokenForScopes(context.Background(), writeScopes)
+	require.NoError(t, err)
+	admin, err := client.GetTokenForScopes(context.Background(), adminScopes)
+	require.NoError(t, err)
+
+	// Expire only the write entry
+	client.scopedTokens[scopeKey(writeScopes)].cache.setToken(&oauth2.Token{
+		AccessToken: "expired",
+		Expiry:      time.Now().Add(-time.Minute),
+	})
+	client.scopedTokens[scopeKey(writeScopes)].source = nil
+
+	token, err := client.GetTokenForScopes(context.Background(), writeScopes)
+	require.NoError(t, err)
+	assert.NotEqual(t, "expired", token.AccessToken)
+
+	token, err = client.GetTokenForScopes(context.Background(), adminScopes)
+	require.NoError(t, err)
+	assert.Equal(t, admin.AccessToken, token.AccessToken, "unexpired entries are kept")
+	assert.Equal(t, int32(3), atomic.LoadInt32(fetches))
+}
+
+func TestAuthClient_GetTokenForScopes_Closed(t *testing.T) {
+	server, fetches := newScopedTokenServer(t, 3600)
+	client := newScopedTokenClient(t, server.URL+"/oauth2/token")
+
+	_, err := client.GetTokenForScopes(context.Background(), []string{"iam:write"})
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+	assert.Nil(t, client.scopedTokens)
+
+	_, err = client.GetTokenForScopes(context.Background(), []string{"iam:write"})
+	var authErr *AuthError
+	require.ErrorAs(t, err, &authErr)
+	assert.Equal(t, AuthErrorClientClosed, authErr.Type)
+	assert.Equal(t, int32(1), atomic.LoadInt32(fetches))
+}
+
+func TestScopeKey(t *testing.T) {
+	assert.Equal(t, "a b", scopeKey([]string{"b", "a", " a ", ""}))
+	assert.Equal(t, "", scopeKey(nil))
+}
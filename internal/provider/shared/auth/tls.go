@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+)
+
+// NewTLSConfig builds a TLS configuration from PEM encoded certificates.
+//
+// caCertPEM is added to a copy of the system roots so an internal CA can be
+// trusted alongside public ones. clientCertPEM and clientKeyPEM enable mutual
+// TLS and must be given together. insecureSkipVerify disables server
+// certificate verification and is meant for tests only.
+//
+// It returns nil when no option is set, so the default transport settings apply.
+func NewTLSConfig(caCertPEM, clientCertPEM, clientKeyPEM string, insecureSkipVerify bool) (*tls.Config, error) {
+	if caCertPEM == "" && clientCertPEM == "" && clientKeyPEM == "" && !insecureSkipVerify {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caCertPEM)) {
+			return nil, NewConfigValidationError("ca_cert_pem", "valid PEM encoded certificate", "provide the CA certificate in PEM format", "[PEM]")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCertPEM != "" || clientKeyPEM != "" {
+		if clientCertPEM == "" || clientKeyPEM == "" {
+			return nil, NewConfigValidationError("client_cert_pem", "client certificate and key must be set together", "provide both client_cert_pem and client_key_pem", "[PEM]")
+		}
+		cert, err := tls.X509KeyPair([]byte(clientCertPEM), []byte(clientKeyPEM))
+		if err != nil {
+			return nil, NewConfigurationError("invalid client certificate or key", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if insecureSkipVerify {
+		WarnWithFields("TLS certificate verification is disabled; use only for testing", map[string]interface{}{
+			"insecure_skip_verify": true,
+		})
+		tlsConfig.InsecureSkipVerify = true // #nosec G402 -- opt-in, for tests only
+	}
+
+	return tlsConfig, nil
+}
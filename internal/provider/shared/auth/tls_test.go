@@ -0,0 +1,77 @@
+package auth
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewTLSConfig(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	t.Run("no options", func(t *testing.T) {
+		cfg, err := NewTLSConfig("", "", "", false)
+		if err != nil || cfg != nil {
+			t.Fatalf("expected nil config, got %v, %v", cfg, err)
+		}
+	})
+
+	t.Run("custom CA", func(t *testing.T) {
+		cfg, err := NewTLSConfig(caPEM, "", "", false)
+		if err != nil {
+			t.Fatalf("NewTLSConfig failed: %v", err)
+		}
+		if cfg.MinVersion != tls.VersionTLS12 {
+			t.Errorf("MinVersion = %x, want TLS 1.2", cfg.MinVersion)
+		}
+
+		httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+		resp, err := httpClient.Get(server.URL)
+		if err != nil {
+			t.Fatalf("request with custom CA failed: %v", err)
+		}
+		resp.Body.Close()
+	})
+
+	t.Run("insecure skip verify", func(t *testing.T) {
+		cfg, err := NewTLSConfig("", "", "", true)
+		if err != nil {
+			t.Fatalf("NewTLSConfig failed: %v", err)
+		}
+		if !cfg.InsecureSkipVerify {
+			t.Error("expected InsecureSkipVerify to be set")
+		}
+	})
+
+	errCases := map[string][3]string{
+		"malformed CA":       {"not a certificate", "", ""},
+		"certificate only":   {"", caPEM, ""},
+		"key only":           {"", "", "not a key"},
+		"malformed key pair": {"", caPEM, "not a key"},
+	}
+	for name, pems := range errCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewTLSConfig(pems[0], pems[1], pems[2], false); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestNewAuthClient_InvalidTLS(t *testing.T) {
+	_, err := NewAuthClient(&AuthClientConfig{
+		TenantID:         "tenant",
+		ClientID:         "client",
+		ClientSecret:     "secret-value",
+		TokenURL:         "https://auth.example.com/token",
+		DisableDiscovery: true,
+		CACertPEM:        "not a certificate",
+	})
+	if err == nil {
+		t.Fatal("expected malformed CA certificate to be rejected")
+	}
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"golang.org/x/oauth2"
+)
+
+// Token lifecycle events logged by logTokenLifecycle
+const (
+	tokenEventAcquired  = "acquired"
+	tokenEventRefreshed = "refreshed"
+)
+
+// tokenErrorMessages are the log messages of failed token lifecycle events
+var tokenErrorMessages = map[string]string{
+	tokenEventAcquired:  "OAuth2 token acquisition failed",
+	tokenEventRefreshed: "OAuth2 token refresh failed",
+}
+
+// logTokenLifecycle logs at debug level that a token was acquired or
+// refreshed, with its scopes, type and expiry. The token value is never a
+// field, and it is masked in case another field happens to contain it.
+func (c *AuthClient) logTokenLifecycle(ctx context.Context, event string, token *oauth2.Token) {
+	ctx = maskToken(ctx, token)
+	fields := map[string]interface{}{
+		"event":      event,
+		"scopes":     c.config.Scopes,
+		"token_type": token.Type(),
+	}
+	if !token.Expiry.IsZero() {
+		fields["expires_at"] = token.Expiry.UTC().Format(time.RFC3339)
+		fields["expires_in_seconds"] = int64(time.Until(token.Expiry).Round(time.Second).Seconds())
+	}
+	tflog.Debug(ctx, "OAuth2 token "+event, fields)
+}
+
+// logTokenError logs at debug level that acquiring or refreshing a token
+// failed. The error is redacted and the cached token, if any, masked.
+func (c *AuthClient) logTokenError(ctx context.Context, event string, err error) {
+	c.tokenCache.mutex.RLock()
+	cached := c.tokenCache.token
+	c.tokenCache.mutex.RUnlock()
+
+	ctx = maskToken(ctx, cached)
+	tflog.Debug(ctx, tokenErrorMessages[event], map[string]interface{}{
+		"event":  event,
+		"scopes": c.config.Scopes,
+		"error":  RedactString(err.Error()),
+	})
+}
+
+// maskToken masks the access and refresh token of token in messages and
+// field values logged with the returned context
+func maskToken(ctx context.Context, token *oauth2.Token) context.Context {
+	if token == nil {
+		return ctx
+	}
+	for _, secret := range []string{token.AccessToken, token.RefreshToken} {
+		if secret != "" {
+			ctx = tflog.MaskMessageStrings(ctx, secret)
+			ctx = tflog.MaskAllFieldValuesStrings(ctx, secret)
+		}
+	}
+	return ctx
+}
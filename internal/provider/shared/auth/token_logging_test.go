@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const loggedTestToken = "eyJhbGciOiJSUzI1NiJ9.lifecycle-secret-token"
+
+// tokenLoggingClient returns a client whose token endpoint answers with
+// loggedTestToken, or fails with a body echoing it when fail is set
+func tokenLoggingClient(t *testing.T, fail bool) *AuthClient {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if fail {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error":             "invalid_grant",
+				"error_description": "access_token=" + loggedTestToken + " was rejected",
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": loggedTestToken,
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewAuthClient(&AuthClientConfig{
+		TenantID:     "test-tenant-123",
+		ClientID:     "test-client-123",
+		ClientSecret: "test-secret-456",
+		TokenURL:     server.URL + "/oauth2/token",
+		Scopes:       []string{"iam:read"},
+		Timeout:      5 * time.Second,
+	})
+	require.NoError(t, err)
+	client.retryConfig.MaxAttempts = 1
+	return client
+}
+
+func TestAuthClient_TokenLifecycleLogging(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := tokenLoggingClient(t, false)
+
+	token, err := client.GetToken(ctx)
+	require.NoError(t, err)
+	_, err = client.RefreshToken(ctx)
+	require.NoError(t, err)
+
+	assert.NotContains(t, output.String(), loggedTestToken, "the token must never be logged")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+	events := map[string]map[string]interface{}{}
+	for _, entry := range entries {
+		if event, ok := entry["event"].(string); ok {
+			events[event] = entry
+		}
+	}
+	for _, event := range []string{tokenEventAcquired, tokenEventRefreshed} {
+		entry, ok := events[event]
+		require.True(t, ok, "expected a %s log entry, got %v", event, entries)
+		assert.Equal(t, "debug", entry["@level"])
+		assert.Equal(t, "Bearer", entry["token_type"])
+		assert.Equal(t, []interface{}{"iam:read"}, entry["scopes"])
+		assert.Equal(t, token.Expiry.UTC().Format(time.RFC3339), entry["expires_at"])
+		assert.InDelta(t, 3600, entry["expires_in_seconds"], 5)
+	}
+}
+
+func TestAuthClient_TokenLifecycleLogging_Error(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+	client := tokenLoggingClient(t, true)
+
+	_, err := client.GetToken(ctx)
+	require.Error(t, err)
+
+	logs := output.String()
+	assert.Contains(t, logs, "OAuth2 token acquisition failed")
+	assert.Contains(t, logs, "invalid_grant", "the error should still explain the failure")
+	assert.NotContains(t, logs, loggedTestToken, "the token must not leak through error logs")
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// JitterStrategy selects how Backoff randomizes retry delays
+type JitterStrategy string
+
+const (
+	// JitterNone waits the exact exponential delay, for deterministic tests
+	JitterNone JitterStrategy = "none"
+	// JitterFull waits a random duration between zero and the exponential delay
+	JitterFull JitterStrategy = "full"
+	// JitterEqual waits half the exponential delay plus a random duration up
+	// to the other half
+	JitterEqual JitterStrategy = "equal"
+)
+
+// DefaultBackoffMultiplier is the growth factor used when Backoff.Multiplier
+// is not above 1
+const DefaultBackoffMultiplier = 2.0
+
+// Backoff computes the delay before each retry: Base * Multiplier^(n-1) for
+// the nth retry, capped at Max and then jittered.
+type Backoff struct {
+	// Base is the delay before the first retry; zero uses Config.RetryWaitMin
+	Base time.Duration
+	// Max caps the delay before jitter; zero uses Config.RetryWaitMax
+	Max time.Duration
+	// Multiplier is the growth factor per retry; DefaultBackoffMultiplier
+	// when below 1
+	Multiplier float64
+	// Jitter is the randomization strategy; empty uses JitterEqual
+	Jitter JitterStrategy
+	// Rand returns a value in [0, 1) for the jitter; nil uses math/rand/v2.
+	// Tests inject a fixed source to assert exact delays.
+	Rand func() float64
+}
+
+// Delay returns the wait before the given retry, counting from 1
+func (b Backoff) Delay(retry int) time.Duration {
+	if retry < 1 {
+		retry = 1
+	}
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = DefaultBackoffMultiplier
+	}
+
+	// Grow in floating point so large retry counts saturate instead of overflowing
+	delay := float64(b.Base) * math.Pow(multiplier, float64(retry-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+	if delay > math.MaxInt64 {
+		delay = math.MaxInt64
+	}
+
+	switch b.Jitter {
+	case JitterNone:
+	case JitterFull:
+		delay *= b.random()
+	default:
+		delay = delay/2 + delay/2*b.random()
+	}
+	return time.Duration(delay)
+}
+
+// random returns the next jitter value in [0, 1)
+func (b Backoff) random() float64 {
+	if b.Rand == nil {
+		return rand.Float64()
+	}
+	return b.Rand()
+}
+
+// backoff returns Config.Backoff with unset durations taken from
+// RetryWaitMin and RetryWaitMax
+func (c *Client) backoff() Backoff {
+	var b Backoff
+	if c.config.Backoff != nil {
+		b = *c.config.Backoff
+	}
+	if b.Base <= 0 {
+		b.Base = c.config.RetryWaitMin
+	}
+	if b.Max <= 0 {
+		b.Max = c.config.RetryWaitMax
+	}
+	return b
+}
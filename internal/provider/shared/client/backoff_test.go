@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fixedRand returns a Backoff.Rand that always yields v
+func fixedRand(v float64) func() float64 {
+	return func() float64 { return v }
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	base := Backoff{Base: 100 * time.Millisecond, Max: time.Second, Multiplier: 2, Rand: fixedRand(0.5)}
+
+	tests := []struct {
+		name   string
+		jitter JitterStrategy
+		want   []time.Duration // delays for retries 1..n
+	}{
+		{"none", JitterNone, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}},
+		{"full", JitterFull, []time.Duration{50 * time.Millisecond, 100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond}},
+		{"equal", JitterEqual, []time.Duration{75 * time.Millisecond, 150 * time.Millisecond, 300 * time.Millisecond, 600 * time.Millisecond, 750 * time.Millisecond, 750 * time.Millisecond}},
+		{"empty is equal", "", []time.Duration{75 * time.Millisecond, 150 * time.Millisecond, 300 * time.Millisecond, 600 * time.Millisecond, 750 * time.Millisecond, 750 * time.Millisecond}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := base
+			b.Jitter = tt.jitter
+			for i, want := range tt.want {
+				if got := b.Delay(i + 1); got != want {
+					t.Errorf("Delay(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBackoff_Delay_Bounds(t *testing.T) {
+	b := Backoff{Base: time.Second, Max: 30 * time.Second, Jitter: JitterNone}
+
+	// Multiplier below 1 uses the default
+	if got := b.Delay(3); got != 4*time.Second {
+		t.Errorf("Delay(3) = %v, want 4s", got)
+	}
+	// Max caps growth however many retries are made
+	if got := b.Delay(1000); got != 30*time.Second {
+		t.Errorf("Delay(1000) = %v, want 30s", got)
+	}
+
+	b.Multiplier = 1
+	if got := b.Delay(5); got != time.Second {
+		t.Errorf("constant Delay(5) = %v, want 1s", got)
+	}
+
+	// Full jitter never exceeds the capped delay
+	b = Backoff{Base: time.Second, Max: 2 * time.Second, Jitter: JitterFull}
+	for retry := 1; retry <= 10; retry++ {
+		if got := b.Delay(retry); got < 0 || got > 2*time.Second {
+			t.Errorf("Delay(%d) = %v, want within [0, 2s]", retry, got)
+		}
+	}
+}
+
+func TestClient_Backoff_Defaults(t *testing.T) {
+	c := newTestClient(t, "http://example.invalid", &Config{})
+	c.config.RetryWaitMin = 10 * time.Millisecond
+	c.config.RetryWaitMax = 50 * time.Millisecond
+	if got := c.backoff(); got.Base != 10*time.Millisecond || got.Max != 50*time.Millisecond || got.Jitter != "" {
+		t.Errorf("backoff() = %+v, want RetryWaitMin and RetryWaitMax with the default jitter", got)
+	}
+
+	c.config.Backoff = &Backoff{Max: time.Second, Multiplier: 3, Jitter: JitterNone}
+	got := c.backoff()
+	if got.Base != 10*time.Millisecond || got.Max != time.Second || got.Multiplier != 3 || got.Jitter != JitterNone {
+		t.Errorf("backoff() = %+v, want Base from RetryWaitMin and the configured fields", got)
+	}
+}
+
+func TestClient_Backoff_RetryLoop(t *testing.T) {
+	server, calls := flakyServer(t, http.StatusServiceUnavailable, 2)
+	c := newTestClient(t, server.URL, &Config{
+		Backoff: &Backoff{Base: 20 * time.Millisecond, Max: 30 * time.Millisecond, Jitter: JitterNone},
+	})
+	c.config.MaxRetries = 2
+
+	start := time.Now()
+	resp, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Fatalf("got status %d after %d attempts, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+	// 20ms then 30ms (capped from 40ms)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("retries finished after %v, want at least the 50ms of backoff", elapsed)
+	}
+}
@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+// DefaultMaxLogBodyBytes caps logged bodies when Config.MaxLogBodyBytes is not set
+const DefaultMaxLogBodyBytes = 4096
+
+// redactedBodyFields are JSON keys whose values are never written to logs
+var redactedBodyFields = map[string]bool{
+	"access_token":  true,
+	"api_key":       true,
+	"authorization": true,
+	"client_secret": true,
+	"password":      true,
+	"refresh_token": true,
+	"secret":        true,
+	"token":         true,
+}
+
+// logBody writes a redacted, size-capped body at debug level when body logging is enabled
+func (c *Client) logBody(ctx context.Context, message string, body []byte, fields map[string]interface{}) {
+	if c.config == nil || !c.config.LogBodies || len(body) == 0 {
+		return
+	}
+
+	maxBytes := c.config.MaxLogBodyBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxLogBodyBytes
+	}
+
+	fields["body"] = truncateBody(redactBodyWith(body, c.isRedactedField), maxBytes)
+	tflog.Debug(ctx, message, fields)
+}
+
+// redactBody replaces the values of known secret fields in a JSON body.
+// Bodies that are not JSON fall back to pattern-based redaction.
+func redactBody(body []byte) string {
+	return redactBodyWith(body, isDefaultRedactedField)
+}
+
+// redactBodyWith is redactBody with a custom set of redacted fields
+func redactBodyWith(body []byte, redact func(key string) bool) string {
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return auth.RedactString(string(body))
+	}
+
+	redacted, err := json.Marshal(redactJSONValue(decoded, redact))
+	if err != nil {
+		return auth.RedactString(string(body))
+	}
+	return string(redacted)
+}
+
+// RedactFields replaces the values of known secret fields in fields, including nested
+// maps and slices, for structured output other than logs. fields is modified in place.
+func RedactFields(fields map[string]interface{}) map[string]interface{} {
+	redactJSONValue(fields, isDefaultRedactedField)
+	return fields
+}
+
+// isDefaultRedactedField reports whether key is one of redactedBodyFields
+func isDefaultRedactedField(key string) bool {
+	return redactedBodyFields[strings.ToLower(key)]
+}
+
+// isRedactedField reports whether key is a default secret field or listed in
+// Config.LogRedactFields
+func (c *Client) isRedactedField(key string) bool {
+	if isDefaultRedactedField(key) {
+		return true
+	}
+	if c.config == nil {
+		return false
+	}
+	for _, field := range c.config.LogRedactFields {
+		if strings.EqualFold(field, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSONValue walks a decoded JSON value and redacts matching fields in place
+func redactJSONValue(value interface{}, redact func(key string) bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if redact(key) {
+				v[key] = "[REDACTED]"
+				continue
+			}
+			v[key] = redactJSONValue(field, redact)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = redactJSONValue(v[i], redact)
+		}
+	}
+	return value
+}
+
+// truncateBody cuts a body to maxBytes and notes how much was dropped
+func truncateBody(body string, maxBytes int) string {
+	if len(body) <= maxBytes {
+		return body
+	}
+	return fmt.Sprintf("%s...[truncated %d bytes]", body[:maxBytes], len(body)-maxBytes)
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func doWithBodyLogging(t *testing.T, cfg *Config, responseBody string) string {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responseBody))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c := newTestClient(t, server.URL, cfg)
+	body := map[string]string{"name": "group", "client_secret": "super-secret-value"}
+	if _, err := c.Do(ctx, &Request{Method: http.MethodPost, Path: "/api/v1/tenants/t/groups", Body: body}); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	return output.String()
+}
+
+func TestClient_LogBodies_Enabled(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LogBodies = true
+	cfg.MaxLogBodyBytes = 40
+
+	logs := doWithBodyLogging(t, cfg, `{"id":"g1","access_token":"abc","description":"`+strings.Repeat("x", 100)+`"}`)
+
+	if !strings.Contains(logs, "API request body") || !strings.Contains(logs, "API response body") {
+		t.Fatalf("expected request and response bodies to be logged, got:\n%s", logs)
+	}
+	if strings.Contains(logs, "super-secret-value") || strings.Contains(logs, `"access_token\":\"abc`) {
+		t.Errorf("secret values were logged:\n%s", logs)
+	}
+	if !strings.Contains(logs, "[REDACTED]") {
+		t.Errorf("expected redaction marker in logs:\n%s", logs)
+	}
+	if !strings.Contains(logs, "[truncated ") {
+		t.Errorf("expected response body to be truncated:\n%s", logs)
+	}
+	if strings.Contains(logs, strings.Repeat("x", 100)) {
+		t.Errorf("response body was not capped:\n%s", logs)
+	}
+}
+
+func TestClient_LogBodies_Disabled(t *testing.T) {
+	logs := doWithBodyLogging(t, DefaultConfig(), `{"id":"g1"}`)
+
+	if strings.Contains(logs, "API request body") || strings.Contains(logs, "API response body") {
+		t.Errorf("bodies should not be logged when disabled, got:\n%s", logs)
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	got := redactBody([]byte(`{"items":[{"password":"p"}],"Token":"t","name":"n"}`))
+	if strings.Contains(got, `"p"`) || strings.Contains(got, `"t"`) || !strings.Contains(got, `"name":"n"`) {
+		t.Errorf("unexpected redaction result: %s", got)
+	}
+
+	if got := redactBody([]byte("client_secret=abcdefghijklmnop")); strings.Contains(got, "abcdefghijklmnop") {
+		t.Errorf("non-JSON body was not redacted: %s", got)
+	}
+}
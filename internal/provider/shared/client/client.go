@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -9,6 +10,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
@@ -16,7 +18,11 @@ import (
 
 // Config holds the configuration for the API client
 type Config struct {
-	BaseURL      string
+	BaseURL string
+	// BasePath is the deployment-specific API prefix, e.g. /api/v1 or /iam/v1.
+	// Relative request paths are resolved against it.
+	BasePath string
+	// IAMEndpoint overrides the IAM service prefix; empty uses BasePath
 	IAMEndpoint  string
 	CCCEndpoint  string
 	UserAgent    string
@@ -24,19 +30,117 @@ type Config struct {
 	MaxRetries   int
 	RetryWaitMin time.Duration
 	RetryWaitMax time.Duration
+	// Backoff tunes the delay between retries; nil grows from RetryWaitMin
+	// to RetryWaitMax with equal jitter. See Backoff.
+	Backoff *Backoff
+	// MethodOverride sends PUT, PATCH and DELETE requests as POST with the
+	// X-HTTP-Method-Override header set, for proxies that block those methods
+	MethodOverride bool
+	// LogBodies writes redacted request and response bodies at debug level,
+	// truncated to MaxLogBodyBytes (DefaultMaxLogBodyBytes when zero)
+	LogBodies       bool
+	MaxLogBodyBytes int
+	// LogRedactFields lists additional JSON keys, such as "members", whose
+	// values are redacted from logged bodies on top of the built-in secrets
+	LogRedactFields []string
+	// MaxResponseBytes caps how much of a response body is read;
+	// DefaultMaxResponseBytes when zero or negative
+	MaxResponseBytes int64
+	// TLS options for API requests, see auth.NewTLSConfig. The OAuth2 token
+	// endpoint uses the equivalent options on auth.Config.
+	CACertPEM          string
+	ClientCertPEM      string
+	ClientKeyPEM       string
+	InsecureSkipVerify bool // For testing only
+	// DefaultHeaders are sent with every request; Request.Headers win on
+	// conflict. Headers the client manages itself, such as Authorization and
+	// Content-Type, are ignored.
+	DefaultHeaders map[string]string
+	// Observer is notified of every HTTP attempt, including retries; nil
+	// observes nothing
+	Observer Observer
+	// ScopeDownReads requests a token limited to ReadScopes (DefaultReadScopes
+	// when empty) for GET requests. Writes keep the token for the configured
+	// OAuth2 scopes. Has no effect with a test token.
+	ScopeDownReads bool
+	ReadScopes     []string
+	// RetryBudget caps the retries made across all requests of the client to
+	// this many per RetryBudgetInterval (DefaultRetryBudgetInterval when
+	// zero). Once spent, failures are returned without retrying until the
+	// budget refills. Zero leaves retries limited only by MaxRetries.
+	RetryBudget         int
+	RetryBudgetInterval time.Duration
+	// RequestInterceptors and ResponseInterceptors run in order around every
+	// HTTP attempt, inside the retry loop, so an interceptor that injects a
+	// failure exercises retries. See RequestInterceptor and ResponseInterceptor.
+	RequestInterceptors  []RequestInterceptor
+	ResponseInterceptors []ResponseInterceptor
+	// DisablePreferRepresentation stops sending "Prefer: return=representation"
+	// on POST, PUT and PATCH requests. The header asks the API to return the
+	// written entity instead of a 204, saving the follow-up GET.
+	DisablePreferRepresentation bool
+	// RetryPolicy decides which attempts are retried and how long to wait,
+	// overriding the built-in classification; nil uses DefaultRetryPolicy
+	RetryPolicy RetryPolicy
+	// MaxIdleConns, MaxIdleConnsPerHost and IdleConnTimeout tune keep-alive
+	// on the transport for API requests; zero values use
+	// auth.DefaultMaxIdleConns, auth.DefaultMaxIdleConnsPerHost and
+	// auth.DefaultIdleConnTimeout, tuned for the single API host
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	// TraceFile, when set, receives a JSON line per request made by Do with
+	// the method, templated path, status, duration and bodies, with
+	// credentials and member ids redacted, for attaching to support tickets.
+	// The file is opened by New and appended to; see Client.Close.
+	TraceFile string
+	// ResponseCacheTTL, when positive, keeps successful GET responses in
+	// memory for this long, keyed by method, path and query, so repeated
+	// reads during a plan do not hit the API. Responses sent with
+	// Cache-Control: no-store are not kept, and any other request invalidates
+	// cached responses for its path, the collections above it and the
+	// entities below it. ResponseCacheSize bounds the number of responses
+	// kept (DefaultResponseCacheSize when zero).
+	ResponseCacheTTL  time.Duration
+	ResponseCacheSize int
+	// MaxConcurrentRequests caps the requests Do has in flight at once,
+	// whatever Terraform's -parallelism, counting the write client too.
+	// Further requests wait for a free slot or for their context to end.
+	// Zero leaves concurrency uncapped.
+	MaxConcurrentRequests int
+	// TraceParent, when set, is the W3C traceparent of the caller's span.
+	// Every request carries a traceparent with its trace ID and flags and a
+	// new span ID, plus TraceState as tracestate when set. Empty sends no
+	// trace context.
+	TraceParent string
+	TraceState  string
 }
 
+// DefaultBasePath is the API prefix used when Config.BasePath is empty
+const DefaultBasePath = "/api/v1"
+
+// DefaultMaxResponseBytes is the response body limit used when Config.MaxResponseBytes is not set
+const DefaultMaxResponseBytes int64 = 10 << 20
+
+// DefaultReadScopes are the scopes requested for reads when ScopeDownReads is set
+var DefaultReadScopes = []string{"iam:read"}
+
+// MethodOverrideHeader is the header carrying the real method when MethodOverride is enabled
+const MethodOverrideHeader = "X-HTTP-Method-Override"
+
 // DefaultConfig returns a default client configuration
 func DefaultConfig() *Config {
 	return &Config{
 		BaseURL:      "https://iam-api.retailsvc.com",
-		IAMEndpoint:  "/api/v1", // V1 API prefix for most IAM resources (groups, roles, custom roles, resources)
+		BasePath:     DefaultBasePath, // V1 API prefix for most IAM resources (groups, roles, custom roles, resources)
 		CCCEndpoint:  "/ccc/v1",
 		UserAgent:    "terraform-provider-hiiretail/1.0.0",
 		Timeout:      30 * time.Second,
 		MaxRetries:   3,
 		RetryWaitMin: 1 * time.Second,
 		RetryWaitMax: 30 * time.Second,
+
+		MaxResponseBytes: DefaultMaxResponseBytes,
 	}
 }
 
@@ -47,6 +151,19 @@ type Client struct {
 	auth       *auth.Config
 	baseURL    *url.URL
 	tenantID   string
+	metrics    *RetryMetrics
+	budget     *retryBudget
+	cache      *responseCache // Shared with writer, nil unless Config.ResponseCacheTTL is set
+	slots      requestSlots   // Shared with writer, nil unless Config.MaxConcurrentRequests is set
+	traceCtx   *traceContext  // Parsed Config.TraceParent, nil when no trace context is propagated
+
+	deprecations *deprecationLog
+	tracer       *traceWriter // Writes Config.TraceFile, nil when tracing is off
+	// writer performs mutating requests with a separate credential, see SetWriteAuth
+	writer *Client
+
+	iamOnce   sync.Once
+	iamClient *ServiceClient // Shared by every IAM service, see IAMClient
 }
 
 // New creates a new HiiRetail API client
@@ -60,30 +177,111 @@ func New(authConfig *auth.Config, clientConfig *Config) (*Client, error) {
 		return nil, fmt.Errorf("invalid base URL: %w", err)
 	}
 
-	var httpClient *http.Client
-	if authConfig != nil && authConfig.TestToken != "" {
-		// Use basic http.Client for contract tests with dummy token
-		httpClient = &http.Client{Timeout: clientConfig.Timeout}
-	} else {
-		// Create OAuth2 HTTP client
-		var err error
-		httpClient, err = auth.NewHTTPClient(context.Background(), authConfig)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create OAuth2 client: %w", err)
-		}
-		// Set timeout
-		httpClient.Timeout = clientConfig.Timeout
+	httpClient, err := newHTTPClient(authConfig, clientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	traceCtx, err := newTraceContext(clientConfig.TraceParent, clientConfig.TraceState)
+	if err != nil {
+		return nil, err
+	}
+
+	tracer, err := openTraceWriter(clientConfig.TraceFile)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Client{
-		config:     clientConfig,
-		httpClient: httpClient,
-		auth:       authConfig,
-		baseURL:    baseURL,
-		tenantID:   authConfig.TenantID,
+		config:       clientConfig,
+		httpClient:   httpClient,
+		auth:         authConfig,
+		baseURL:      baseURL,
+		tenantID:     authConfig.TenantID,
+		metrics:      &RetryMetrics{},
+		budget:       newRetryBudget(clientConfig.RetryBudget, clientConfig.RetryBudgetInterval),
+		cache:        newResponseCache(clientConfig.ResponseCacheTTL, clientConfig.ResponseCacheSize),
+		slots:        newRequestSlots(clientConfig.MaxConcurrentRequests),
+		traceCtx:     traceCtx,
+		deprecations: &deprecationLog{},
+		tracer:       tracer,
 	}, nil
 }
 
+// newHTTPClient builds the HTTP client for authConfig, applying the timeout and TLS options
+func newHTTPClient(authConfig *auth.Config, clientConfig *Config) (*http.Client, error) {
+	tlsConfig, err := auth.NewTLSConfig(clientConfig.CACertPEM, clientConfig.ClientCertPEM, clientConfig.ClientKeyPEM, clientConfig.InsecureSkipVerify)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration: %w", err)
+	}
+
+	if authConfig != nil && authConfig.TestToken != "" {
+		// Use basic http.Client for contract tests with dummy token
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = tlsConfig
+		applyConnectionPool(transport, clientConfig)
+		return &http.Client{Timeout: clientConfig.Timeout, Transport: transport}, nil
+	}
+
+	// Create OAuth2 HTTP client
+	httpClient, err := auth.NewHTTPClient(context.Background(), authConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OAuth2 client: %w", err)
+	}
+	// Set timeout
+	httpClient.Timeout = clientConfig.Timeout
+	if tlsConfig != nil {
+		applyTLSConfig(httpClient, tlsConfig)
+	}
+	if authTransport, ok := httpClient.Transport.(*auth.AuthenticatedTransport); ok {
+		base := baseTransport(authTransport).Clone()
+		applyConnectionPool(base, clientConfig)
+		authTransport.Base = base
+	}
+	if clientConfig.ScopeDownReads {
+		applyReadScopes(httpClient, clientConfig.ReadScopes)
+	}
+	return httpClient, nil
+}
+
+// applyReadScopes makes the OAuth2 transport use a read-only token for reads
+func applyReadScopes(httpClient *http.Client, scopes []string) {
+	authTransport, ok := httpClient.Transport.(*auth.AuthenticatedTransport)
+	if !ok {
+		return
+	}
+	if len(scopes) == 0 {
+		scopes = DefaultReadScopes
+	}
+	authTransport.ReadScopes = scopes
+}
+
+// applyTLSConfig sets tlsConfig on the transport underneath the OAuth2 transport
+func applyTLSConfig(httpClient *http.Client, tlsConfig *tls.Config) {
+	authTransport, ok := httpClient.Transport.(*auth.AuthenticatedTransport)
+	if !ok {
+		return
+	}
+	base := baseTransport(authTransport).Clone()
+	base.TLSClientConfig = tlsConfig
+	authTransport.Base = base
+}
+
+// baseTransport returns the transport underneath the OAuth2 transport, or
+// http.DefaultTransport when it is not an *http.Transport
+func baseTransport(authTransport *auth.AuthenticatedTransport) *http.Transport {
+	base, ok := authTransport.Base.(*http.Transport)
+	if !ok || base == nil {
+		return http.DefaultTransport.(*http.Transport)
+	}
+	return base
+}
+
+// applyConnectionPool sets the Config keep-alive tuning on transport
+func applyConnectionPool(transport *http.Transport, clientConfig *Config) {
+	auth.ApplyConnectionPool(transport, clientConfig.MaxIdleConns, clientConfig.MaxIdleConnsPerHost, clientConfig.IdleConnTimeout)
+}
+
 // Request represents an API request
 type Request struct {
 	Method  string
@@ -102,8 +300,182 @@ type Response struct {
 
 // Do executes an API request
 func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
+	httpReq, err := c.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Serve repeated reads from the response cache, and drop what a write may change
+	cacheKey := responseCacheKey(req.Method, httpReq)
+	if req.Method == http.MethodGet {
+		if cached, ok := c.cache.get(cacheKey); ok {
+			return cached, nil
+		}
+	} else {
+		defer c.cache.invalidate(httpReq.URL.Path)
+	}
+
+	// Wait for a free slot when concurrency is capped
+	release, err := c.slots.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Execute request with retries
+	start := time.Now()
+	resp, err := c.doWithRetry(ctx, req, httpReq)
+	if err != nil {
+		c.logRequest(ctx, httpReq, 0, start, err)
+		c.trace(req.Method, httpReq, req.Body, 0, nil, start, err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	c.logRequest(ctx, httpReq, resp.StatusCode, start, nil)
+
+	// Read response body, refusing anything over the configured limit
+	limit := c.maxResponseBytes()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		err = fmt.Errorf("failed to read response body: %w", err)
+		c.trace(req.Method, httpReq, req.Body, resp.StatusCode, nil, start, err)
+		return nil, err
+	}
+	if int64(len(respBody)) > limit {
+		err := &ResponseTooLargeError{Method: req.Method, Path: httpReq.URL.Path, Limit: limit}
+		c.trace(req.Method, httpReq, req.Body, resp.StatusCode, nil, start, err)
+		return nil, err
+	}
+	c.trace(req.Method, httpReq, req.Body, resp.StatusCode, respBody, start, nil)
+	c.logBody(ctx, "API response body", respBody, map[string]interface{}{
+		"method": req.Method,
+		"path":   httpReq.URL.Path,
+		"status": resp.StatusCode,
+	})
+	c.recordDeprecation(ctx, req.Method, httpReq.URL.Path, resp.Header)
+
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Body:       respBody,
+		Headers:    resp.Header,
+	}
+	if req.Method == http.MethodGet {
+		c.cache.set(cacheKey, httpReq.URL.Path, response)
+	}
+	return response, nil
+}
+
+// StreamResponse is an API response whose body is read incrementally.
+// Callers must close Body.
+type StreamResponse struct {
+	StatusCode int
+	Body       io.ReadCloser
+	Headers    http.Header
+}
+
+// DoStream executes an API request like Do but returns the body as a stream,
+// for large list endpoints that should be decoded without buffering.
+// Reading past Config.MaxResponseBytes fails with a ResponseTooLargeError.
+// The request holds its concurrency slot until Body is closed, and is traced
+// at that point; streamed responses are never cached.
+func (c *Client) DoStream(ctx context.Context, req *Request) (*StreamResponse, error) {
+	httpReq, err := c.newHTTPRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop cached reads a write may change
+	if req.Method != http.MethodGet {
+		defer c.cache.invalidate(httpReq.URL.Path)
+	}
+
+	// Wait for a free slot when concurrency is capped
+	release, err := c.slots.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	resp, err := c.doWithRetry(ctx, req, httpReq)
+	if err != nil {
+		release()
+		c.logRequest(ctx, httpReq, 0, start, err)
+		c.trace(req.Method, httpReq, req.Body, 0, nil, start, err)
+		return nil, err
+	}
+	c.logRequest(ctx, httpReq, resp.StatusCode, start, nil)
+	c.recordDeprecation(ctx, req.Method, httpReq.URL.Path, resp.Header)
+
+	limit := c.maxResponseBytes()
+	return &StreamResponse{
+		StatusCode: resp.StatusCode,
+		Body: &limitedBody{
+			body:      resp.Body,
+			remaining: limit,
+			tooLarge:  &ResponseTooLargeError{Method: req.Method, Path: httpReq.URL.Path, Limit: limit},
+			onClose: func(readErr error) {
+				release()
+				c.trace(req.Method, httpReq, req.Body, resp.StatusCode, nil, start, readErr)
+			},
+		},
+		Headers: resp.Header,
+	}, nil
+}
+
+// maxResponseBytes returns the effective response body limit
+func (c *Client) maxResponseBytes() int64 {
+	if c.config == nil || c.config.MaxResponseBytes <= 0 {
+		return DefaultMaxResponseBytes
+	}
+	return c.config.MaxResponseBytes
+}
+
+// limitedBody wraps a response body and fails once more than remaining bytes
+// are read. onClose, when set, runs once on the first Close with the error
+// that ended reading, if any other than io.EOF.
+type limitedBody struct {
+	body      io.ReadCloser
+	remaining int64
+	tooLarge  error
+	readErr   error
+	onClose   func(readErr error)
+	closeOnce sync.Once
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.remaining < 0 {
+		return 0, b.tooLarge
+	}
+	// Read one byte past the limit so an oversized body is detected
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.body.Read(p)
+	b.remaining -= int64(n)
+	if b.remaining < 0 {
+		b.readErr = b.tooLarge
+		return n + int(b.remaining), b.tooLarge
+	}
+	if err != nil && err != io.EOF && b.readErr == nil {
+		b.readErr = err
+	}
+	return n, err
+}
+
+func (b *limitedBody) Close() error {
+	err := b.body.Close()
+	b.closeOnce.Do(func() {
+		if b.onClose != nil {
+			b.onClose(b.readErr)
+		}
+	})
+	return err
+}
+
+// newHTTPRequest builds the HTTP request for req, including headers and body
+func (c *Client) newHTTPRequest(ctx context.Context, req *Request) (*http.Request, error) {
 	// Build URL
-	reqURL := c.buildURL(req.Path)
+	reqURL := c.buildURL(c.resolvePath(req.Path))
 	if len(req.Query) > 0 {
 		q := reqURL.Query()
 		for key, value := range req.Query {
@@ -121,10 +493,21 @@ func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
 		}
 
 		body = strings.NewReader(string(bodyBytes))
+		c.logBody(ctx, "API request body", bodyBytes, map[string]interface{}{
+			"method": req.Method,
+			"path":   reqURL.Path,
+		})
+	}
+
+	// Tunnel mutating methods through POST when method override is enabled
+	method := req.Method
+	overridden := c.config.MethodOverride && isOverridableMethod(method)
+	if overridden {
+		method = http.MethodPost
 	}
 
 	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, req.Method, reqURL.String(), body)
+	httpReq, err := http.NewRequestWithContext(ctx, method, reqURL.String(), body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -136,50 +519,63 @@ func (c *Client) Do(ctx context.Context, req *Request) (*Response, error) {
 		httpReq.Header.Set("Content-Type", "application/json")
 	}
 
-	for key, value := range req.Headers {
-		httpReq.Header.Set(key, value)
+	if err := c.applyHeaders(httpReq, req); err != nil {
+		return nil, err
+	}
+	if overridden {
+		httpReq.Header.Set(MethodOverrideHeader, req.Method)
 	}
 	// If TestToken is set, use it for Authorization and skip real OAuth2
 	if c.auth != nil && c.auth.TestToken != "" {
 		httpReq.Header.Set("Authorization", "Bearer "+c.auth.TestToken)
 	}
 
-	// Execute request with retries
-	resp, err := c.doWithRetry(ctx, httpReq)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+	return httpReq, nil
+}
 
-	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+// isOverridableMethod reports whether a method is tunneled through POST in method override mode
+func isOverridableMethod(method string) bool {
+	switch method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
 	}
-
-	return &Response{
-		StatusCode: resp.StatusCode,
-		Body:       respBody,
-		Headers:    resp.Header,
-	}, nil
+	return false
 }
 
 // buildURL constructs the full URL for a request path
 func (c *Client) buildURL(path string) *url.URL {
 	u := *c.baseURL // Copy
-	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + strings.TrimPrefix(path, "/")
+	// path is in escaped form, so callers can path-escape IDs containing
+	// reserved characters such as "/"
+	rawPath := strings.TrimSuffix(u.EscapedPath(), "/") + "/" + strings.TrimPrefix(path, "/")
+	if unescaped, err := url.PathUnescape(rawPath); err == nil {
+		u.Path, u.RawPath = unescaped, rawPath
+	} else {
+		u.Path, u.RawPath = rawPath, ""
+	}
 	fmt.Fprintf(os.Stderr, "[DEBUG buildURL] Input path: '%s', BaseURL: '%s', Final URL: '%s'\n", path, c.baseURL.String(), u.String())
 	return &u
 }
 
-// doWithRetry executes HTTP request with retry logic
-func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Response, error) {
+// doWithRetry executes HTTP request with retry logic, classifying each
+// attempt with the configured RetryPolicy
+func (c *Client) doWithRetry(ctx context.Context, apiReq *Request, req *http.Request) (*http.Response, error) {
 	var lastErr error
+	var retryAfter time.Duration
+	policy := c.retryPolicy()
+	backoff := c.backoff()
 
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
 		if attempt > 0 {
-			// Calculate backoff delay
-			delay := c.calculateBackoff(attempt)
+			if !c.budget.allow() {
+				return nil, fmt.Errorf("request failed after %d attempts: %w: %w", attempt, ErrRetryBudgetExhausted, lastErr)
+			}
+
+			// Calculate backoff delay unless the policy asked for one
+			delay := retryAfter
+			if delay <= 0 {
+				delay = backoff.Delay(attempt)
+			}
 
 			select {
 			case <-ctx.Done():
@@ -188,62 +584,55 @@ func (c *Client) doWithRetry(ctx context.Context, req *http.Request) (*http.Resp
 			}
 		}
 
-		resp, err := c.httpClient.Do(req)
+		c.metrics.recordAttempt()
+		start := time.Now()
+		resp, err := c.send(req)
 		if err != nil {
+			c.observeAttempt(req, 0, start, err)
+			retry, after := policy(apiReq, nil, err)
+			if !retry {
+				return nil, err
+			}
 			lastErr = err
+			retryAfter = after
 			continue
 		}
+		c.observeAttempt(req, resp.StatusCode, start, nil)
 
-		// Check if we should retry based on status code
-		if c.shouldRetry(resp.StatusCode) {
+		// Check if we should retry based on the response
+		retry, after := policy(apiReq, &Response{StatusCode: resp.StatusCode, Headers: resp.Header}, nil)
+		if retry {
 			resp.Body.Close()
 			lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+			retryAfter = after
 			continue
 		}
 
+		if attempt > 0 && resp.StatusCode < 400 {
+			c.metrics.recordSuccessAfterRetry()
+		}
 		return resp, nil
 	}
 
+	c.metrics.recordExhausted()
 	return nil, fmt.Errorf("request failed after %d attempts: %w", c.config.MaxRetries+1, lastErr)
 }
 
-// shouldRetry determines if a request should be retried based on status code
-func (c *Client) shouldRetry(statusCode int) bool {
-	// Retry on server errors and rate limiting
-	return statusCode >= 500 || statusCode == 429
-}
-
-// calculateBackoff calculates exponential backoff with jitter
-func (c *Client) calculateBackoff(attempt int) time.Duration {
-	min := c.config.RetryWaitMin
-	max := c.config.RetryWaitMax
-
-	// Exponential backoff: min * 2^(attempt-1)
-	backoff := min * time.Duration(1<<uint(attempt-1))
-
-	if backoff > max {
-		backoff = max
-	}
-
-	// Add jitter (±25%)
-	jitter := time.Duration(float64(backoff) * 0.25)
-	jitterMultiplier := (2*float64(time.Now().UnixNano()%1000)/1000.0 - 1)
-	backoff += time.Duration(float64(jitter) * jitterMultiplier)
-
-	if backoff < min {
-		backoff = min
-	}
-
-	return backoff
-}
-
-// IAMClient returns a client configured for IAM service endpoints
+// IAMClient returns a client configured for IAM service endpoints.
+// It is created once and shared, so every service uses the same instance.
 func (c *Client) IAMClient() *ServiceClient {
-	return &ServiceClient{
-		client:   c,
-		endpoint: c.config.IAMEndpoint,
-		service:  "iam",
-	}
+	c.iamOnce.Do(func() {
+		endpoint := c.config.IAMEndpoint
+		if endpoint == "" {
+			endpoint = c.BasePath()
+		}
+		c.iamClient = &ServiceClient{
+			client:   c,
+			endpoint: endpoint,
+			service:  "iam",
+		}
+	})
+	return c.iamClient
 }
 
 // CCCClient returns a client configured for CCC service endpoints
@@ -323,11 +712,46 @@ func (c *Client) TenantID() string {
 	return c.tenantID
 }
 
+// Credential describes the OAuth2 credential the client authenticates with
+type Credential struct {
+	ClientID string
+	Scopes   []string // Requested scopes; the token may be granted fewer
+}
+
+// Credential returns the configured OAuth2 credential. It is empty for a
+// client using a test token.
+func (c *Client) Credential() Credential {
+	if c.auth == nil || c.auth.TestToken != "" {
+		return Credential{}
+	}
+	return Credential{
+		ClientID: c.auth.ClientID,
+		Scopes:   append([]string(nil), c.auth.Scopes...),
+	}
+}
+
 // HTTPClient returns the underlying HTTP client
 func (c *Client) HTTPClient() *http.Client {
 	return c.httpClient
 }
 
+// BasePath returns the API prefix configured for this client
+func (c *Client) BasePath() string {
+	if c.config == nil || c.config.BasePath == "" {
+		return DefaultBasePath
+	}
+	return "/" + strings.Trim(c.config.BasePath, "/")
+}
+
+// resolvePath resolves a relative request path against the base path.
+// Absolute paths are used as-is so callers can target other API versions.
+func (c *Client) resolvePath(path string) string {
+	if strings.HasPrefix(path, "/") {
+		return path
+	}
+	return c.BasePath() + "/" + path
+}
+
 // BaseURL returns the base URL configured for this client
 func (c *Client) BaseURL() string {
 	return c.baseURL.String()
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+// keepAliveServer counts the TCP connections opened to it. Responses are
+// delayed so parallel requests overlap.
+func keepAliveServer(t *testing.T, conns *atomic.Int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	t.Cleanup(server.Close)
+	return server
+}
+
+// runParallel makes rounds of parallel requests and waits for each round
+func runParallel(t *testing.T, c *Client, parallel, rounds int) {
+	t.Helper()
+	for round := 0; round < rounds; round++ {
+		var wg sync.WaitGroup
+		for i := 0; i < parallel; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"}); err != nil {
+					t.Errorf("Do failed: %v", err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+func TestClient_ConnectionReuse(t *testing.T) {
+	const parallel, rounds = 8, 10
+
+	var conns atomic.Int32
+	server := keepAliveServer(t, &conns)
+	c := newTestClient(t, server.URL, nil)
+
+	runParallel(t, c, parallel, rounds)
+
+	// Every round reuses the connections left idle by the previous one
+	if got := conns.Load(); got > parallel {
+		t.Errorf("opened %d connections for %d requests, want at most %d", got, parallel*rounds, parallel)
+	}
+}
+
+func TestClient_ConnectionPoolLimits(t *testing.T) {
+	const parallel, rounds = 8, 10
+
+	var conns atomic.Int32
+	server := keepAliveServer(t, &conns)
+	cfg := DefaultConfig()
+	cfg.MaxIdleConnsPerHost = 1
+	cfg.IdleConnTimeout = time.Minute
+	c := newTestClient(t, server.URL, cfg)
+
+	transport := c.HTTPClient().Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 1 || transport.IdleConnTimeout != time.Minute || transport.MaxIdleConns != auth.DefaultMaxIdleConns {
+		t.Fatalf("transport pool = %d/%d/%v, want 1 per host, default total and 1m",
+			transport.MaxIdleConnsPerHost, transport.MaxIdleConns, transport.IdleConnTimeout)
+	}
+
+	runParallel(t, c, parallel, rounds)
+
+	// Keeping a single idle connection forces new ones every round
+	if got := conns.Load(); got <= parallel {
+		t.Errorf("opened %d connections, want more than %d with one idle connection per host", got, parallel)
+	}
+}
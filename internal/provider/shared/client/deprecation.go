@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DeprecationNotice describes deprecation signals returned by the API for an endpoint
+type DeprecationNotice struct {
+	Method      string
+	Path        string   // Endpoint path with IDs replaced by ":id", see templatePath
+	Deprecation string   // Deprecation header value, e.g. "true" or a date
+	Sunset      string   // Sunset header value, the date the endpoint goes away
+	Warnings    []string // Warning header texts
+}
+
+// String renders the notice as a human readable sentence
+func (n DeprecationNotice) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "The HiiRetail API reported that %s %s is deprecated", n.Method, n.Path)
+	if n.Deprecation != "" && !strings.EqualFold(n.Deprecation, "true") {
+		fmt.Fprintf(&b, " since %s", n.Deprecation)
+	}
+	if n.Sunset != "" {
+		fmt.Fprintf(&b, " and will be removed on %s", n.Sunset)
+	}
+	b.WriteString(".")
+	for _, w := range n.Warnings {
+		fmt.Fprintf(&b, " %s", w)
+	}
+	return b.String()
+}
+
+// parseDeprecationNotice extracts Deprecation, Sunset and Warning headers.
+// It returns nil when the response carries none of them.
+func parseDeprecationNotice(method, path string, header http.Header) *DeprecationNotice {
+	notice := &DeprecationNotice{
+		Method:      method,
+		Path:        templatePath(path),
+		Deprecation: header.Get("Deprecation"),
+		Sunset:      header.Get("Sunset"),
+	}
+	for _, value := range header.Values("Warning") {
+		notice.Warnings = append(notice.Warnings, warningText(value))
+	}
+
+	if notice.Deprecation == "" && notice.Sunset == "" && len(notice.Warnings) == 0 {
+		return nil
+	}
+	return notice
+}
+
+// warningText returns the quoted text of an RFC 7234 Warning header value,
+// e.g. `299 - "Use /api/v2/groups"`, or the raw value when it is not in that form
+func warningText(value string) string {
+	start := strings.Index(value, `"`)
+	end := strings.LastIndex(value, `"`)
+	if start >= 0 && end > start {
+		return value[start+1 : end]
+	}
+	return strings.TrimSpace(value)
+}
+
+// deprecationLog collects deprecation notices, reporting each distinct notice
+// once per client. Notices are keyed by their text, which holds the templated
+// endpoint and the header values, so every instance of a resource shares one
+// warning while a changed message is reported again.
+type deprecationLog struct {
+	mu      sync.Mutex
+	seen    map[string]bool
+	pending []DeprecationNotice
+}
+
+// recordDeprecation logs and queues a notice if the response carries deprecation headers
+func (c *Client) recordDeprecation(ctx context.Context, method, path string, header http.Header) {
+	notice := parseDeprecationNotice(method, path, header)
+	if notice == nil || c.deprecations == nil {
+		return
+	}
+
+	c.deprecations.mu.Lock()
+	defer c.deprecations.mu.Unlock()
+
+	key := notice.String()
+	if c.deprecations.seen[key] {
+		return
+	}
+	if c.deprecations.seen == nil {
+		c.deprecations.seen = make(map[string]bool)
+	}
+	c.deprecations.seen[key] = true
+	c.deprecations.pending = append(c.deprecations.pending, *notice)
+
+	tflog.Warn(ctx, "API endpoint is deprecated", map[string]interface{}{
+		"method":      method,
+		"path":        notice.Path,
+		"deprecation": notice.Deprecation,
+		"sunset":      notice.Sunset,
+		"warnings":    notice.Warnings,
+	})
+}
+
+// DeprecationNotices returns the notices recorded since the last call and clears them
+func (c *Client) DeprecationNotices() []DeprecationNotice {
+	if c == nil || c.deprecations == nil {
+		return nil
+	}
+
+	c.deprecations.mu.Lock()
+	defer c.deprecations.mu.Unlock()
+
+	notices := c.deprecations.pending
+	c.deprecations.pending = nil
+	return notices
+}
+
+// AppendDeprecationWarnings adds a warning diagnostic for every pending deprecation notice.
+// Resources defer it from their CRUD methods so users see deprecations during plan and apply.
+func (c *Client) AppendDeprecationWarnings(diags *diag.Diagnostics) {
+	for _, notice := range c.DeprecationNotices() {
+		diags.AddWarning("Deprecated API Endpoint", notice.String())
+	}
+}
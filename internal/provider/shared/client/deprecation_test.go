@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestClient_DeprecationWarnings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/old" {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Sunset", "Wed, 01 Jul 2026 00:00:00 GMT")
+			w.Header().Add("Warning", `299 - "Use /api/v2/new instead"`)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, nil)
+	ctx := context.Background()
+
+	t.Run("without deprecation headers", func(t *testing.T) {
+		if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "current"}); err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+		var diags diag.Diagnostics
+		c.AppendDeprecationWarnings(&diags)
+		if len(diags) != 0 {
+			t.Fatalf("expected no diagnostics, got %v", diags)
+		}
+	})
+
+	t.Run("with deprecation headers", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "old"}); err != nil {
+				t.Fatalf("Do failed: %v", err)
+			}
+		}
+
+		var diags diag.Diagnostics
+		c.AppendDeprecationWarnings(&diags)
+		if len(diags) != 1 || diags.WarningsCount() != 1 {
+			t.Fatalf("expected one warning for the endpoint, got %v", diags)
+		}
+		detail := diags[0].Detail()
+		for _, want := range []string{"GET /api/v1/old", "Wed, 01 Jul 2026 00:00:00 GMT", "Use /api/v2/new instead"} {
+			if !strings.Contains(detail, want) {
+				t.Errorf("warning %q missing %q", detail, want)
+			}
+		}
+
+		// Notices are drained and each endpoint is only reported once
+		if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "old"}); err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+		if notices := c.DeprecationNotices(); len(notices) != 0 {
+			t.Fatalf("expected no new notices, got %+v", notices)
+		}
+	})
+}
+
+func TestClient_DeprecationWarnings_DedupedByEndpointAndMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Add("Warning", `299 - "`+r.URL.Query().Get("warning")+`"`)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, nil)
+	ctx := context.Background()
+
+	requests := []struct{ path, warning string }{
+		{"tenants/t/groups/g1", "Use /api/v2/groups"},
+		{"tenants/t/groups/g2", "Use /api/v2/groups"},
+		{"tenants/t/groups", "Use /api/v2/groups"},
+		{"tenants/t/groups/g3", "Groups are read-only from 2027"},
+	}
+	for _, req := range requests {
+		if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: req.path, Query: map[string]string{"warning": req.warning}}); err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+	}
+
+	var diags diag.Diagnostics
+	c.AppendDeprecationWarnings(&diags)
+	var details []string
+	for _, d := range diags {
+		details = append(details, d.Detail())
+	}
+	want := []string{
+		"The HiiRetail API reported that GET /api/v1/tenants/:id/groups/:id is deprecated. Use /api/v2/groups",
+		"The HiiRetail API reported that GET /api/v1/tenants/:id/groups is deprecated. Use /api/v2/groups",
+		"The HiiRetail API reported that GET /api/v1/tenants/:id/groups/:id is deprecated. Groups are read-only from 2027",
+	}
+	if strings.Join(details, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("warnings = %q, want %q", details, want)
+	}
+}
+
+func TestParseDeprecationNotice(t *testing.T) {
+	if n := parseDeprecationNotice("GET", "/x", http.Header{}); n != nil {
+		t.Fatalf("expected nil notice, got %+v", n)
+	}
+
+	h := http.Header{}
+	h.Set("Deprecation", "@1719792000")
+	h.Add("Warning", "plain text warning")
+	n := parseDeprecationNotice("DELETE", "/x", h)
+	if n == nil {
+		t.Fatal("expected notice")
+	}
+	if got, want := n.String(), "The HiiRetail API reported that DELETE /x is deprecated since @1719792000. plain text warning"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+
+	var nilClient *Client
+	if notices := nilClient.DeprecationNotices(); notices != nil {
+		t.Errorf("nil client should have no notices, got %+v", notices)
+	}
+}
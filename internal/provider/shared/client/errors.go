@@ -1,11 +1,32 @@
 package client
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
 	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
+// maxErrorSnippetRunes bounds the body excerpt kept in an Error for
+// error responses without a message
+const maxErrorSnippetRunes = 200
+
+// htmlTagPattern matches HTML tags, comments and doctypes in error pages
+var htmlTagPattern = regexp.MustCompile(`(?s)<!--.*?-->|<[^>]*>`)
+
+// htmlSkippedElementPattern matches elements whose text is not shown in an error page
+var htmlSkippedElementPattern = regexp.MustCompile(`(?is)<(script|style)\b[^>]*>.*?</(script|style)>`)
+
+// ErrNotFound is wrapped by every 404 Error, so wrapped API errors can be
+// recognized with errors.Is(err, ErrNotFound)
+var ErrNotFound = errors.New("not found")
+
 // Error represents an API error response
 type Error struct {
 	StatusCode int    `json:"status_code"`
@@ -19,6 +40,14 @@ func (e *Error) Error() string {
 	return fmt.Sprintf("API error %d: %s", e.StatusCode, e.Message)
 }
 
+// Unwrap returns ErrNotFound for 404 errors
+func (e *Error) Unwrap() error {
+	if e.IsNotFound() {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // IsNotFound returns true if the error is a 404 Not Found error
 func (e *Error) IsNotFound() bool {
 	return e.StatusCode == http.StatusNotFound
@@ -63,35 +92,34 @@ func CheckResponse(resp *Response) error {
 		Message:    http.StatusText(resp.StatusCode),
 	}
 
-	// Try to parse error response body
-	if len(resp.Body) > 0 {
-		var errorResp struct {
-			Error   string `json:"error"`
-			Message string `json:"message"`
-			Code    string `json:"code"`
-			Details string `json:"details"`
+	// Gateways and proxies answer with HTML or plain text; keep an excerpt
+	// of the body instead of decoding it
+	if len(resp.Body) > 0 && !json.Valid(resp.Body) {
+		if snippet := errorBodySnippet(resp.Body); snippet != "" {
+			apiError.Message = fmt.Sprintf("%s: %s", apiError.Message, snippet)
 		}
+		return apiError
+	}
 
-		if err := json.Unmarshal(resp.Body, &errorResp); err == nil {
-			if errorResp.Message != "" {
-				apiError.Message = errorResp.Message
-			} else if errorResp.Error != "" {
-				apiError.Message = errorResp.Error
-			}
-			apiError.Code = errorResp.Code
-			apiError.Details = errorResp.Details
+	// Use the message the API gave, or an excerpt of the body when it has none
+	if len(resp.Body) > 0 {
+		message, code, details := errorBodyFields(resp.Body)
+		if message != "" {
+			apiError.Message = message
+		} else if snippet := errorBodySnippet(resp.Body); snippet != "" {
+			apiError.Message = fmt.Sprintf("%s: %s", apiError.Message, snippet)
 		}
+		apiError.Code = code
+		apiError.Details = details
 	}
 
+	// 404 errors wrap ErrNotFound through Error.Unwrap
 	return apiError
 }
 
-// IsNotFoundError returns true if the error is a 404 Not Found error
+// IsNotFoundError returns true if err is or wraps a 404 Not Found error
 func IsNotFoundError(err error) bool {
-	if apiErr, ok := err.(*Error); ok {
-		return apiErr.IsNotFound()
-	}
-	return false
+	return errors.Is(err, ErrNotFound)
 }
 
 // IsUnauthorizedError returns true if the error is a 401 Unauthorized error
@@ -133,3 +161,99 @@ func IsServerError(err error) bool {
 	}
 	return false
 }
+
+// ResponseTooLargeError is returned when a response body exceeds Config.MaxResponseBytes
+type ResponseTooLargeError struct {
+	Method string
+	Path   string
+	Limit  int64
+}
+
+// Error implements the error interface
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("response body for %s %s exceeds the %d byte limit", e.Method, e.Path, e.Limit)
+}
+
+// IsResponseTooLargeError returns true if the error is a ResponseTooLargeError
+func IsResponseTooLargeError(err error) bool {
+	var tooLarge *ResponseTooLargeError
+	return errors.As(err, &tooLarge)
+}
+
+// errorBodyFields extracts the message, code and details of a JSON error
+// body. The message is taken from "message", the OAuth2 "error_description"
+// or "error", which may itself be an object with a message; a body that is a
+// JSON string is the message. Numeric codes are kept as their JSON text.
+func errorBodyFields(body []byte) (message, code, details string) {
+	var text string
+	if json.Unmarshal(body, &text) == nil {
+		return strings.TrimSpace(text), "", ""
+	}
+
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(body, &fields) != nil {
+		return "", "", ""
+	}
+	code = jsonScalarText(fields["code"])
+	details = jsonScalarText(fields["details"])
+
+	errorCode := jsonScalarText(fields["error"])
+	for _, candidate := range []string{jsonScalarText(fields["message"]), jsonScalarText(fields["error_description"]), errorCode} {
+		if candidate != "" {
+			message = candidate
+			break
+		}
+	}
+	if message == "" && len(fields["error"]) > 0 {
+		// {"error": {"message": "...", "code": ...}}
+		nestedMessage, nestedCode, nestedDetails := errorBodyFields(fields["error"])
+		message = nestedMessage
+		if code == "" {
+			code = nestedCode
+		}
+		if details == "" {
+			details = nestedDetails
+		}
+	}
+	// The OAuth2 error code accompanies its description
+	if code == "" && errorCode != "" && message != errorCode {
+		code = errorCode
+	}
+	return message, code, details
+}
+
+// jsonScalarText returns a JSON string without quotes and a number or
+// boolean as written, or "" for anything else
+func jsonScalarText(raw json.RawMessage) string {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if len(raw) == 0 || decoder.Decode(&value) != nil {
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	return ""
+}
+
+// errorBodySnippet reduces an error body without a message to a short single line of
+// text: HTML markup is dropped, whitespace collapsed and the result cut to
+// maxErrorSnippetRunes
+func errorBodySnippet(body []byte) string {
+	text := strings.ToValidUTF8(string(body), "\uFFFD")
+	if strings.Contains(text, "<") {
+		text = htmlSkippedElementPattern.ReplaceAllString(text, " ")
+		text = html.UnescapeString(htmlTagPattern.ReplaceAllString(text, " "))
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxErrorSnippetRunes {
+		return text
+	}
+	return string([]rune(text)[:maxErrorSnippetRunes]) + "..."
+}
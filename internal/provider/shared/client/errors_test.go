@@ -0,0 +1,154 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestIsNotFoundError(t *testing.T) {
+	notFound := CheckResponse(&Response{StatusCode: http.StatusNotFound, Body: []byte(`{"message":"group g1 not found"}`)})
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"404 from CheckResponse", notFound, true},
+		{"wrapped 404", fmt.Errorf("failed to delete group g1: %w", notFound), true},
+		{"constructed 404", &Error{StatusCode: http.StatusNotFound, Message: "role binding not found"}, true},
+		{"sentinel", ErrNotFound, true},
+		{"other status mentioning not found", &Error{StatusCode: http.StatusBadRequest, Message: "role not found in request"}, false},
+		{"plain error mentioning not found", errors.New("group not found"), false},
+		{"plain error mentioning 404", errors.New("upstream returned 404"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsNotFoundError(tt.err); got != tt.want {
+				t.Errorf("IsNotFoundError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+
+	if !errors.Is(notFound, ErrNotFound) {
+		t.Error("CheckResponse 404 should wrap ErrNotFound")
+	}
+}
+
+func TestCheckResponse_NonJSONBody(t *testing.T) {
+	t.Run("HTML 502", func(t *testing.T) {
+		body := `<!DOCTYPE html>
+<html><head><title>502 Bad Gateway</title><style>body { color: red; }</style></head>
+<body><center><h1>502 Bad Gateway</h1></center><hr><center>nginx</center>
+<script>console.log("ignored")</script></body></html>`
+		err := CheckResponse(&Response{StatusCode: http.StatusBadGateway, Body: []byte(body)})
+
+		var apiErr *Error
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("expected *Error, got %T: %v", err, err)
+		}
+		if apiErr.StatusCode != http.StatusBadGateway || !IsServerError(err) {
+			t.Errorf("status = %d, want 502", apiErr.StatusCode)
+		}
+		if want := "Bad Gateway: 502 Bad Gateway 502 Bad Gateway nginx"; apiErr.Message != want {
+			t.Errorf("message = %q, want %q", apiErr.Message, want)
+		}
+	})
+
+	t.Run("plain text 503", func(t *testing.T) {
+		err := CheckResponse(&Response{StatusCode: http.StatusServiceUnavailable, Body: []byte("upstream connect error\nor disconnect/reset before headers\n")})
+
+		if got, want := err.Error(), "API error 503: Service Unavailable: upstream connect error or disconnect/reset before headers"; got != want {
+			t.Errorf("error = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("long body is truncated", func(t *testing.T) {
+		err := CheckResponse(&Response{StatusCode: http.StatusServiceUnavailable, Body: []byte(strings.Repeat("é", 1000))})
+
+		apiErr := err.(*Error)
+		snippet := strings.TrimPrefix(apiErr.Message, "Service Unavailable: ")
+		if want := strings.Repeat("é", maxErrorSnippetRunes) + "..."; snippet != want {
+			t.Errorf("snippet has %d bytes, want %d runes and an ellipsis", len(snippet), maxErrorSnippetRunes)
+		}
+	})
+
+	t.Run("JSON body is still decoded", func(t *testing.T) {
+		err := CheckResponse(&Response{StatusCode: http.StatusBadGateway, Body: []byte(`{"message":"upstream timeout","code":"GATEWAY"}`)})
+
+		apiErr := err.(*Error)
+		if apiErr.Message != "upstream timeout" || apiErr.Code != "GATEWAY" {
+			t.Errorf("error = %+v, want the JSON message and code", apiErr)
+		}
+	})
+}
+
+func TestCheckResponse_ErrorBodyMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantMessage string
+		wantCode    string
+	}{
+		{
+			name:        "500 with a JSON message",
+			status:      http.StatusInternalServerError,
+			body:        `{"message":"database unavailable, retry later","code":500}`,
+			wantMessage: "database unavailable, retry later",
+			wantCode:    "500",
+		},
+		{
+			name:        "500 with a plain body",
+			status:      http.StatusInternalServerError,
+			body:        "java.lang.NullPointerException at GroupService.java:42",
+			wantMessage: "Internal Server Error: java.lang.NullPointerException at GroupService.java:42",
+		},
+		{
+			name:        "OAuth2 error description",
+			status:      http.StatusBadRequest,
+			body:        `{"error":"invalid_request","error_description":"tenant header is missing"}`,
+			wantMessage: "tenant header is missing",
+			wantCode:    "invalid_request",
+		},
+		{
+			name:        "nested error object",
+			status:      http.StatusInternalServerError,
+			body:        `{"error":{"message":"role store timed out","code":"TIMEOUT"}}`,
+			wantMessage: "role store timed out",
+			wantCode:    "TIMEOUT",
+		},
+		{
+			name:        "JSON string body",
+			status:      http.StatusServiceUnavailable,
+			body:        `"maintenance in progress"`,
+			wantMessage: "maintenance in progress",
+		},
+		{
+			name:        "JSON without a message",
+			status:      http.StatusInternalServerError,
+			body:        `{"status":500,"path":"/api/v1/tenants/t/groups"}`,
+			wantMessage: `Internal Server Error: {"status":500,"path":"/api/v1/tenants/t/groups"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckResponse(&Response{StatusCode: tt.status, Body: []byte(tt.body)})
+
+			var apiErr *Error
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("expected *Error, got %T: %v", err, err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if apiErr.Message != tt.wantMessage || apiErr.Code != tt.wantCode {
+				t.Errorf("message, code = %q, %q, want %q, %q", apiErr.Message, apiErr.Code, tt.wantMessage, tt.wantCode)
+			}
+		})
+	}
+}
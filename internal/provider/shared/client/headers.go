@@ -0,0 +1,95 @@
+package client
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RequestIDHeader carries a per-request ID; one is generated for every
+// request that does not set it
+const RequestIDHeader = "X-Request-ID"
+
+// PreferHeader and PreferRepresentation ask the API to return the written
+// entity in the response to a write (RFC 7240), see
+// Config.DisablePreferRepresentation
+const (
+	PreferHeader         = "Prefer"
+	PreferRepresentation = "return=representation"
+)
+
+// reservedHeaders are set by the client itself and cannot be supplied
+// through Config.DefaultHeaders
+var reservedHeaders = map[string]bool{
+	"Authorization":  true,
+	"Content-Type":   true,
+	"Content-Length": true,
+	"Host":           true,
+	http.CanonicalHeaderKey(MethodOverrideHeader): true,
+}
+
+// isReservedHeader reports whether key is managed by the client
+func isReservedHeader(key string) bool {
+	return reservedHeaders[http.CanonicalHeaderKey(key)]
+}
+
+// applyHeaders sets the configured default headers followed by the request
+// headers, which win on conflict, and generates a request ID when neither
+// provides one. A configured trace context is propagated as a new span
+// unless the request carries its own traceparent.
+func (c *Client) applyHeaders(httpReq *http.Request, req *Request) error {
+	for key, value := range c.config.DefaultHeaders {
+		if isReservedHeader(key) {
+			continue
+		}
+		httpReq.Header.Set(key, value)
+	}
+	for key, value := range req.Headers {
+		httpReq.Header.Set(key, value)
+	}
+	if !c.config.DisablePreferRepresentation && isWriteMethod(req.Method) && httpReq.Header.Get(PreferHeader) == "" {
+		httpReq.Header.Set(PreferHeader, PreferRepresentation)
+	}
+
+	if httpReq.Header.Get(RequestIDHeader) == "" {
+		id, err := newRequestID()
+		if err != nil {
+			return err
+		}
+		httpReq.Header.Set(RequestIDHeader, id)
+	}
+
+	if c.traceCtx != nil && httpReq.Header.Get(TraceParentHeader) == "" {
+		parent, err := c.traceCtx.traceParent()
+		if err != nil {
+			return err
+		}
+		httpReq.Header.Set(TraceParentHeader, parent)
+		if c.traceCtx.state != "" {
+			httpReq.Header.Set(TraceStateHeader, c.traceCtx.state)
+		}
+	}
+	return nil
+}
+
+// isWriteMethod reports whether a request method returns the written entity
+// when asked to with PreferRepresentation
+func isWriteMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+// newRequestID returns a random (version 4) UUID
+func newRequestID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("failed to generate request ID: %w", err)
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
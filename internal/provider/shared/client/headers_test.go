@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+func TestClient_DefaultHeaders_Precedence(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.DefaultHeaders = map[string]string{
+		"X-Correlation-ID": "default-correlation",
+		"X-Gateway-Key":    "gw",
+		"Authorization":    "Bearer stolen",
+		"content-type":     "text/plain",
+	}
+	c := newTestClient(t, server.URL, cfg)
+
+	_, err := c.Do(context.Background(), &Request{
+		Method:  http.MethodPost,
+		Path:    "groups",
+		Body:    map[string]string{"name": "g"},
+		Headers: map[string]string{"X-Correlation-ID": "request-correlation"},
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if v := got.Get("X-Correlation-ID"); v != "request-correlation" {
+		t.Errorf("X-Correlation-ID = %q, request header should win", v)
+	}
+	if v := got.Get("X-Gateway-Key"); v != "gw" {
+		t.Errorf("X-Gateway-Key = %q, want default header", v)
+	}
+	if v := got.Get("Authorization"); v != "Bearer test-token" {
+		t.Errorf("Authorization = %q, default headers must not override it", v)
+	}
+	if v := got.Get("Content-Type"); v != "application/json" {
+		t.Errorf("Content-Type = %q, default headers must not override it", v)
+	}
+}
+
+func TestClient_RequestIDGeneration(t *testing.T) {
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids = append(ids, r.Header.Get(RequestIDHeader))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, nil)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"}); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	}
+	if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups", Headers: map[string]string{RequestIDHeader: "caller-id"}}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	uuid := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	for _, id := range ids[:2] {
+		if !uuid.MatchString(id) {
+			t.Errorf("generated request ID %q is not a v4 UUID", id)
+		}
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("request IDs should be unique, got %q twice", ids[0])
+	}
+	if ids[2] != "caller-id" {
+		t.Errorf("request ID = %q, caller-supplied ID should be kept", ids[2])
+	}
+}
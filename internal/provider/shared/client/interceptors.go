@@ -0,0 +1,40 @@
+package client
+
+import "net/http"
+
+// RequestInterceptor is called before each HTTP attempt made by Client.Do,
+// including retries. It may modify req, for example its headers, or
+// short-circuit the attempt by returning a canned response or an error, in
+// which case no request is sent and later request interceptors are skipped.
+// Returning (nil, nil) passes the attempt on.
+type RequestInterceptor func(req *http.Request) (*http.Response, error)
+
+// ResponseInterceptor is called after each HTTP attempt with its response or
+// error, whether sent or short-circuited, and returns the response and error
+// to use instead. Returning resp and err unchanged only observes the attempt.
+type ResponseInterceptor func(req *http.Request, resp *http.Response, err error) (*http.Response, error)
+
+// send performs a single HTTP attempt through the configured interceptors.
+// Interceptors run in the order they are configured.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+	for _, intercept := range c.config.RequestInterceptors {
+		if resp, err = intercept(req); resp != nil || err != nil {
+			break
+		}
+	}
+	if resp == nil && err == nil {
+		resp, err = c.httpClient.Do(req)
+	}
+
+	for _, intercept := range c.config.ResponseInterceptors {
+		resp, err = intercept(req, resp, err)
+	}
+
+	// Canned responses may omit the body; callers always close it
+	if resp != nil && resp.Body == nil {
+		resp.Body = http.NoBody
+	}
+	return resp, err
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RequestInterceptor_InjectsRetriedFault(t *testing.T) {
+	var serverCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverCalls.Add(1)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var injected atomic.Bool
+	cfg := DefaultConfig()
+	cfg.RequestInterceptors = []RequestInterceptor{func(req *http.Request) (*http.Response, error) {
+		if injected.CompareAndSwap(false, true) {
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}, nil
+		}
+		return nil, nil
+	}}
+	c := newTestClient(t, server.URL, cfg)
+	c.config.MaxRetries = 2
+	c.config.RetryWaitMin = time.Millisecond
+	c.config.RetryWaitMax = 2 * time.Millisecond
+
+	resp, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || string(resp.Body) != `{"ok":true}` {
+		t.Fatalf("got %d %s, want the passed-through response", resp.StatusCode, resp.Body)
+	}
+	if got := serverCalls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+	if got, want := c.RetryStats(), (RetryStats{Attempts: 2, SucceededAfterRetry: 1}); got != want {
+		t.Errorf("RetryStats = %+v, want %+v", got, want)
+	}
+}
+
+func TestClient_Interceptors_ComposeInOrder(t *testing.T) {
+	var gotHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Trace")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var calls []string
+	cfg := DefaultConfig()
+	cfg.RequestInterceptors = []RequestInterceptor{
+		func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, "request 1")
+			req.Header.Set("X-Trace", "first")
+			return nil, nil
+		},
+		func(req *http.Request) (*http.Response, error) {
+			calls = append(calls, "request 2")
+			req.Header.Set("X-Trace", req.Header.Get("X-Trace")+",second")
+			return nil, nil
+		},
+	}
+	cfg.ResponseInterceptors = []ResponseInterceptor{
+		func(req *http.Request, resp *http.Response, err error) (*http.Response, error) {
+			calls = append(calls, "response 1")
+			return resp, err
+		},
+		func(req *http.Request, resp *http.Response, err error) (*http.Response, error) {
+			calls = append(calls, "response 2")
+			return resp, err
+		},
+	}
+	c := newTestClient(t, server.URL, cfg)
+
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"}); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if want := []string{"request 1", "request 2", "response 1", "response 2"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("interceptor calls = %v, want %v", calls, want)
+	}
+	if gotHeader != "first,second" {
+		t.Errorf("X-Trace = %q, want %q", gotHeader, "first,second")
+	}
+}
+
+func TestClient_Interceptors_ShortCircuit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("short-circuited request reached the server")
+	}))
+	defer server.Close()
+
+	t.Run("canned response", func(t *testing.T) {
+		secondCalled := false
+		cfg := DefaultConfig()
+		cfg.RequestInterceptors = []RequestInterceptor{
+			func(req *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": []string{"application/json"}},
+					Body:       io.NopCloser(strings.NewReader(`{"id":"canned"}`)),
+				}, nil
+			},
+			func(req *http.Request) (*http.Response, error) {
+				secondCalled = true
+				return nil, nil
+			},
+		}
+		c := newTestClient(t, server.URL, cfg)
+
+		resp, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups/g1"})
+		if err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+		if string(resp.Body) != `{"id":"canned"}` {
+			t.Errorf("body = %s, want the canned body", resp.Body)
+		}
+		if secondCalled {
+			t.Error("interceptors after a short-circuit should not run")
+		}
+	})
+
+	t.Run("error replaced by response interceptor", func(t *testing.T) {
+		injected := errors.New("injected")
+		cfg := DefaultConfig()
+		cfg.RequestInterceptors = []RequestInterceptor{func(req *http.Request) (*http.Response, error) {
+			return nil, injected
+		}}
+		var seen error
+		cfg.ResponseInterceptors = []ResponseInterceptor{func(req *http.Request, resp *http.Response, err error) (*http.Response, error) {
+			seen = err
+			return &http.Response{StatusCode: http.StatusNoContent}, nil
+		}}
+		c := newTestClient(t, server.URL, cfg)
+
+		resp, err := c.Do(context.Background(), &Request{Method: http.MethodDelete, Path: "groups/g1"})
+		if err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+		}
+		if seen != injected {
+			t.Errorf("response interceptor saw %v, want the injected error", seen)
+		}
+	})
+}
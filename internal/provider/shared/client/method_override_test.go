@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+func newTestClient(t *testing.T, serverURL string, cfg *Config) *Client {
+	t.Helper()
+	if cfg == nil {
+		cfg = DefaultConfig()
+	}
+	cfg.BaseURL = serverURL
+	cfg.MaxRetries = 0
+	c, err := New(&auth.Config{TestToken: "test-token", TenantID: "t"}, cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return c
+}
+
+func TestClient_MethodOverride(t *testing.T) {
+	tests := []struct {
+		name           string
+		override       bool
+		method         string
+		wantMethod     string
+		wantOverrideTo string
+	}{
+		{name: "delete with override", override: true, method: http.MethodDelete, wantMethod: http.MethodPost, wantOverrideTo: http.MethodDelete},
+		{name: "put with override", override: true, method: http.MethodPut, wantMethod: http.MethodPost, wantOverrideTo: http.MethodPut},
+		{name: "get with override is untouched", override: true, method: http.MethodGet, wantMethod: http.MethodGet},
+		{name: "delete without override", override: false, method: http.MethodDelete, wantMethod: http.MethodDelete},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotOverride string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotMethod = r.Method
+				gotOverride = r.Header.Get(MethodOverrideHeader)
+				w.WriteHeader(http.StatusNoContent)
+			}))
+			defer server.Close()
+
+			cfg := DefaultConfig()
+			cfg.MethodOverride = tt.override
+			c := newTestClient(t, server.URL, cfg)
+
+			if _, err := c.Do(context.Background(), &Request{Method: tt.method, Path: "/api/v1/tenants/t/groups/g1"}); err != nil {
+				t.Fatalf("Do failed: %v", err)
+			}
+			if gotMethod != tt.wantMethod {
+				t.Errorf("method = %s, want %s", gotMethod, tt.wantMethod)
+			}
+			if gotOverride != tt.wantOverrideTo {
+				t.Errorf("%s = %q, want %q", MethodOverrideHeader, gotOverride, tt.wantOverrideTo)
+			}
+		})
+	}
+}
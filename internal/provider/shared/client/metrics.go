@@ -0,0 +1,56 @@
+package client
+
+import "sync/atomic"
+
+// RetryMetrics aggregates retry behaviour across all requests made by a Client.
+// It is safe for concurrent use; a nil *RetryMetrics records nothing.
+type RetryMetrics struct {
+	attempts            atomic.Int64
+	succeededAfterRetry atomic.Int64
+	exhausted           atomic.Int64
+}
+
+// RetryStats is a point-in-time snapshot of RetryMetrics.
+type RetryStats struct {
+	// Attempts is the total number of HTTP attempts, including the first try.
+	Attempts int64
+	// SucceededAfterRetry counts operations that only succeeded on a retry.
+	SucceededAfterRetry int64
+	// Exhausted counts operations that failed after using every retry.
+	Exhausted int64
+}
+
+// Snapshot returns the current counter values.
+func (m *RetryMetrics) Snapshot() RetryStats {
+	if m == nil {
+		return RetryStats{}
+	}
+	return RetryStats{
+		Attempts:            m.attempts.Load(),
+		SucceededAfterRetry: m.succeededAfterRetry.Load(),
+		Exhausted:           m.exhausted.Load(),
+	}
+}
+
+func (m *RetryMetrics) recordAttempt() {
+	if m != nil {
+		m.attempts.Add(1)
+	}
+}
+
+func (m *RetryMetrics) recordSuccessAfterRetry() {
+	if m != nil {
+		m.succeededAfterRetry.Add(1)
+	}
+}
+
+func (m *RetryMetrics) recordExhausted() {
+	if m != nil {
+		m.exhausted.Add(1)
+	}
+}
+
+// RetryStats returns a snapshot of the retry counters for this client.
+func (c *Client) RetryStats() RetryStats {
+	return c.metrics.Snapshot()
+}
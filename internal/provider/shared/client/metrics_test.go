@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryingTestClient(t *testing.T, serverURL string) *Client {
+	t.Helper()
+	c := newTestClient(t, serverURL, nil)
+	c.config.MaxRetries = 2
+	c.config.RetryWaitMin = time.Millisecond
+	c.config.RetryWaitMax = 2 * time.Millisecond
+	return c
+}
+
+func TestClient_RetryStats(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/flaky":
+			// Fail the first attempt only
+			if calls.Add(1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/api/v1/ok":
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	c := newRetryingTestClient(t, server.URL)
+	ctx := context.Background()
+
+	if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "flaky"}); err != nil {
+		t.Fatalf("transient failure should succeed after retry: %v", err)
+	}
+	if got, want := c.RetryStats(), (RetryStats{Attempts: 2, SucceededAfterRetry: 1}); got != want {
+		t.Fatalf("after transient failure: got %+v, want %+v", got, want)
+	}
+
+	if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "ok"}); err != nil {
+		t.Fatalf("first-try success failed: %v", err)
+	}
+	if got, want := c.RetryStats(), (RetryStats{Attempts: 3, SucceededAfterRetry: 1}); got != want {
+		t.Fatalf("after first-try success: got %+v, want %+v", got, want)
+	}
+
+	if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "broken"}); err == nil {
+		t.Fatal("persistent failure should exhaust retries")
+	}
+	if got, want := c.RetryStats(), (RetryStats{Attempts: 6, SucceededAfterRetry: 1, Exhausted: 1}); got != want {
+		t.Fatalf("after persistent failure: got %+v, want %+v", got, want)
+	}
+}
+
+func TestRetryMetrics_NilSafe(t *testing.T) {
+	var m *RetryMetrics
+	m.recordAttempt()
+	m.recordSuccessAfterRetry()
+	m.recordExhausted()
+	if got := m.Snapshot(); got != (RetryStats{}) {
+		t.Fatalf("nil metrics should report zero stats, got %+v", got)
+	}
+}
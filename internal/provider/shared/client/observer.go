@@ -0,0 +1,68 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Observer receives one call per HTTP attempt made by Client.Do, including
+// retries, for exporting request counts, latencies and status codes to a
+// metrics backend such as Prometheus. Implementations must be safe for
+// concurrent use.
+type Observer interface {
+	// ObserveRequest is called after each attempt. path has IDs replaced by
+	// ":id" to keep label cardinality low. status is 0 when no response was
+	// received, in which case err holds the transport error.
+	ObserveRequest(method, path string, status int, dur time.Duration, err error)
+}
+
+// NoopObserver discards all observations. It is used when Config.Observer is nil.
+type NoopObserver struct{}
+
+// ObserveRequest implements Observer.
+func (NoopObserver) ObserveRequest(method, path string, status int, dur time.Duration, err error) {}
+
+// collectionSegments are API path segments that are followed by an ID
+var collectionSegments = map[string]bool{
+	"tenants":       true,
+	"groups":        true,
+	"roles":         true,
+	"custom-roles":  true,
+	"resources":     true,
+	"permissions":   true,
+	"members":       true,
+	"users":         true,
+	"role-bindings": true,
+	"bindings":      true,
+}
+
+// templatePath replaces the ID segments of an API path with ":id", e.g.
+// /api/v1/tenants/acme/groups/g1 becomes /api/v1/tenants/:id/groups/:id.
+func templatePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i] != "" && collectionSegments[segments[i-1]] && !collectionSegments[segments[i]] {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// observer returns the configured Observer or a NoopObserver
+func (c *Client) observer() Observer {
+	if c.config == nil || c.config.Observer == nil {
+		return NoopObserver{}
+	}
+	return c.config.Observer
+}
+
+// observeAttempt reports a single HTTP attempt to the configured Observer,
+// using the original method when it was tunneled through MethodOverride
+func (c *Client) observeAttempt(req *http.Request, status int, start time.Time, err error) {
+	method := req.Method
+	if override := req.Header.Get(MethodOverrideHeader); override != "" {
+		method = override
+	}
+	c.observer().ObserveRequest(method, templatePath(req.URL.Path), status, time.Since(start), err)
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type observation struct {
+	method string
+	path   string
+	status int
+	err    error
+}
+
+type recordingObserver struct {
+	mu           sync.Mutex
+	observations []observation
+}
+
+func (o *recordingObserver) ObserveRequest(method, path string, status int, dur time.Duration, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.observations = append(o.observations, observation{method: method, path: path, status: status, err: err})
+}
+
+func TestClient_Observer_RetrySequence(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	obs := &recordingObserver{}
+	c := newRetryingTestClient(t, server.URL)
+	c.config.Observer = obs
+
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodDelete, Path: "tenants/acme/groups/g-123"}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	want := []observation{
+		{method: http.MethodDelete, path: "/api/v1/tenants/:id/groups/:id", status: http.StatusServiceUnavailable},
+		{method: http.MethodDelete, path: "/api/v1/tenants/:id/groups/:id", status: http.StatusTooManyRequests},
+		{method: http.MethodDelete, path: "/api/v1/tenants/:id/groups/:id", status: http.StatusOK},
+	}
+	if len(obs.observations) != len(want) {
+		t.Fatalf("got %d observations, want %d: %+v", len(obs.observations), len(want), obs.observations)
+	}
+	for i, got := range obs.observations {
+		if got != want[i] {
+			t.Errorf("observation %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestClient_Observer_TransportError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	obs := &recordingObserver{}
+	c := newTestClient(t, url, nil)
+	c.config.Observer = obs
+
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "tenants/acme/roles"}); err == nil {
+		t.Fatal("expected error for closed server")
+	}
+	if len(obs.observations) != 1 {
+		t.Fatalf("got %d observations, want 1", len(obs.observations))
+	}
+	got := obs.observations[0]
+	if got.status != 0 || got.err == nil || got.path != "/api/v1/tenants/:id/roles" {
+		t.Errorf("unexpected observation %+v", got)
+	}
+}
+
+func TestClient_Observer_DefaultNoop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, nil)
+	if _, ok := c.observer().(NoopObserver); !ok {
+		t.Fatalf("default observer = %T, want NoopObserver", c.observer())
+	}
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "ok"}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+}
+
+func TestTemplatePath(t *testing.T) {
+	cases := map[string]string{
+		"/api/v1/tenants/acme/groups":                       "/api/v1/tenants/:id/groups",
+		"/api/v2/tenants/acme/groups/g1/roles/custom.admin": "/api/v2/tenants/:id/groups/:id/roles/:id",
+		"/api/v1/roles/iam.admin":                           "/api/v1/roles/:id",
+		"/api/v1/tenants/acme/resources/bu:1/":              "/api/v1/tenants/:id/resources/:id/",
+		"/health":                                           "/health",
+	}
+	for in, want := range cases {
+		if got := templatePath(in); got != want {
+			t.Errorf("templatePath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
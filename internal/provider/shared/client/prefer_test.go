@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClient_PreferRepresentation(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(PreferHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		disable bool
+		method  string
+		headers map[string]string
+		want    string
+	}{
+		{name: "POST", method: http.MethodPost, want: PreferRepresentation},
+		{name: "PUT", method: http.MethodPut, want: PreferRepresentation},
+		{name: "PATCH", method: http.MethodPatch, want: PreferRepresentation},
+		{name: "GET", method: http.MethodGet},
+		{name: "DELETE", method: http.MethodDelete},
+		{name: "disabled", disable: true, method: http.MethodPut},
+		{name: "request header wins", method: http.MethodPost, headers: map[string]string{PreferHeader: "return=minimal"}, want: "return=minimal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = ""
+			c := newTestClient(t, server.URL, &Config{DisablePreferRepresentation: tt.disable})
+			if _, err := c.Do(context.Background(), &Request{Method: tt.method, Path: "groups/g1", Headers: tt.headers}); err != nil {
+				t.Fatalf("Do failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Prefer = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedHeaders are request headers whose values are never written to logs
+var redactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+}
+
+// logRequest writes the method, path, status and duration of a completed
+// request at debug level, together with its headers with credentials
+// redacted. status is 0 when no response was received.
+func (c *Client) logRequest(ctx context.Context, httpReq *http.Request, status int, start time.Time, err error) {
+	fields := map[string]interface{}{
+		"method":      httpReq.Method,
+		"path":        httpReq.URL.Path,
+		"status":      status,
+		"duration_ms": time.Since(start).Milliseconds(),
+		"headers":     redactHeaders(httpReq.Header),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	}
+	tflog.Debug(ctx, "API request", fields)
+}
+
+// redactHeaders flattens headers for logging, replacing credential values
+func redactHeaders(headers http.Header) map[string]string {
+	flat := make(map[string]string, len(headers))
+	for key, values := range headers {
+		if redactedHeaders[http.CanonicalHeaderKey(key)] {
+			flat[key] = "[REDACTED]"
+			continue
+		}
+		flat[key] = strings.Join(values, ", ")
+	}
+	return flat
+}
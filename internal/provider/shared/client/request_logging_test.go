@@ -0,0 +1,86 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestClient_LogRequest_RedactsAuthorization(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c := newTestClient(t, server.URL, nil)
+	if _, err := c.Do(ctx, &Request{Method: http.MethodPost, Path: "tenants/t/groups", Body: map[string]string{"name": "g"}}); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("decoding logs: %v", err)
+	}
+	var entry map[string]interface{}
+	for _, e := range entries {
+		if e["@message"] == "API request" {
+			entry = e
+		}
+	}
+	if entry == nil {
+		t.Fatalf("expected an API request log entry, got %v", entries)
+	}
+
+	if entry["method"] != http.MethodPost || entry["path"] != "/api/v1/tenants/t/groups" || entry["status"] != float64(http.StatusCreated) {
+		t.Errorf("unexpected request fields: %v", entry)
+	}
+	if _, ok := entry["duration_ms"]; !ok {
+		t.Errorf("expected duration_ms in %v", entry)
+	}
+	headers, _ := entry["headers"].(map[string]interface{})
+	if headers["Authorization"] != "[REDACTED]" {
+		t.Errorf("Authorization header = %v, want [REDACTED]", headers["Authorization"])
+	}
+	if raw, _ := json.Marshal(entries); strings.Contains(string(raw), "test-token") {
+		t.Errorf("bearer token leaked into logs: %s", raw)
+	}
+}
+
+func TestClient_LogRedactFields(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.LogBodies = true
+	cfg.LogRedactFields = []string{"Members"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"g1","members":["bob@example.com"]}`))
+	}))
+	defer server.Close()
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	c := newTestClient(t, server.URL, cfg)
+	body := map[string]interface{}{"name": "ops", "members": []string{"alice@example.com"}, "client_secret": "s3cret"}
+	if _, err := c.Do(ctx, &Request{Method: http.MethodPost, Path: "tenants/t/groups", Body: body}); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+
+	logs := output.String()
+	for _, leaked := range []string{"alice@example.com", "bob@example.com", "s3cret"} {
+		if strings.Contains(logs, leaked) {
+			t.Errorf("%q leaked into logs:\n%s", leaked, logs)
+		}
+	}
+	if !strings.Contains(logs, "ops") {
+		t.Errorf("non-sensitive fields should still be logged:\n%s", logs)
+	}
+}
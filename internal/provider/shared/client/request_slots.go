@@ -0,0 +1,33 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// requestSlots caps the requests in flight across the client and its write
+// client, see Config.MaxConcurrentRequests. A nil requestSlots is unlimited.
+type requestSlots chan struct{}
+
+// newRequestSlots returns slots for max concurrent requests, or nil when max
+// is not positive
+func newRequestSlots(max int) requestSlots {
+	if max <= 0 {
+		return nil
+	}
+	return make(requestSlots, max)
+}
+
+// acquire blocks until a slot is free or ctx is done. The returned function
+// releases the slot.
+func (s requestSlots) acquire(ctx context.Context) (func(), error) {
+	if s == nil {
+		return func() {}, nil
+	}
+	select {
+	case s <- struct{}{}:
+		return func() { <-s }, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for a free request slot: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_MaxConcurrentRequests(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		for {
+			prev := peak.Load()
+			if current <= prev || peak.CompareAndSwap(prev, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		inFlight.Add(-1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.MaxConcurrentRequests = 2
+	c := newTestClient(t, server.URL, cfg)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"})
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("request %d failed: %v", i, err)
+		}
+	}
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak in-flight requests = %d, want 2", got)
+	}
+}
+
+func TestClient_MaxConcurrentRequests_ContextDone(t *testing.T) {
+	c := newTestClient(t, "http://example.invalid", &Config{MaxConcurrentRequests: 1})
+	release, err := c.slots.acquire(context.Background())
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to end with the context, got %v", err)
+	}
+}
+
+func TestClient_MaxConcurrentRequests_SharedWithWriteClient(t *testing.T) {
+	c := newTestClient(t, "http://example.invalid", &Config{MaxConcurrentRequests: 1})
+	if err := c.SetWriteAuth(c.auth); err != nil {
+		t.Fatalf("SetWriteAuth failed: %v", err)
+	}
+	if c.WriteClient().slots != c.slots {
+		t.Fatal("write client should share the request slots")
+	}
+}
+
+func TestClient_MaxConcurrentRequests_StreamHoldsSlotUntilClosed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, &Config{MaxConcurrentRequests: 1})
+	resp, err := c.DoStream(context.Background(), &Request{Method: http.MethodGet, Path: "groups"})
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "roles"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the open stream to hold the only slot, got %v", err)
+	}
+
+	resp.Body.Close()
+	resp.Body.Close()
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "roles"}); err != nil {
+		t.Fatalf("Do after closing the stream failed: %v", err)
+	}
+}
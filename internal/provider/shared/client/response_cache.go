@@ -0,0 +1,138 @@
+package client
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultResponseCacheSize bounds the response cache when Config.ResponseCacheSize is not set
+const DefaultResponseCacheSize = 256
+
+// responseCache holds successful GET responses for a short TTL, shared by
+// the client and its write client. It is safe for concurrent use; a nil
+// *responseCache caches nothing.
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	size    int
+	entries map[string]cachedResponse
+	now     func() time.Time
+}
+
+type cachedResponse struct {
+	path     string // URL path, used to invalidate entries on writes
+	response Response
+	expires  time.Time
+}
+
+// newResponseCache returns a cache of up to size responses kept for ttl, or
+// nil when ttl is not positive
+func newResponseCache(ttl time.Duration, size int) *responseCache {
+	if ttl <= 0 {
+		return nil
+	}
+	if size <= 0 {
+		size = DefaultResponseCacheSize
+	}
+	return &responseCache{ttl: ttl, size: size, entries: make(map[string]cachedResponse), now: time.Now}
+}
+
+// responseCacheKey identifies a request by method, path and encoded query
+func responseCacheKey(method string, httpReq *http.Request) string {
+	return method + " " + httpReq.URL.RequestURI()
+}
+
+// get returns a copy of the unexpired response cached under key
+func (c *responseCache) get(key string) (*Response, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return copyResponse(&entry.response), true
+}
+
+// set caches a copy of resp under key, unless the response forbids storing
+// it. When full, expired entries and then the oldest entry are evicted.
+func (c *responseCache) set(key, path string, resp *Response) {
+	if c == nil || resp.StatusCode < 200 || resp.StatusCode > 299 || noStore(resp.Headers) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict(now)
+	}
+	c.entries[key] = cachedResponse{path: path, response: *copyResponse(resp), expires: now.Add(c.ttl)}
+}
+
+// evict removes expired entries, or the entry closest to expiry when none
+// have expired. Entries share one TTL, so that is the oldest. Callers hold mu.
+func (c *responseCache) evict(now time.Time) {
+	var oldestKey string
+	var oldest time.Time
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.expires.Before(oldest) {
+			oldestKey, oldest = key, entry.expires
+		}
+	}
+	if len(c.entries) >= c.size {
+		delete(c.entries, oldestKey)
+	}
+}
+
+// invalidate drops cached responses a write to path may have changed: the
+// path itself, the collections above it and the entities below it
+func (c *responseCache) invalidate(path string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path = strings.TrimSuffix(path, "/")
+	for key, entry := range c.entries {
+		cached := strings.TrimSuffix(entry.path, "/")
+		if cached == path || strings.HasPrefix(cached, path+"/") || strings.HasPrefix(path, cached+"/") {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// noStore reports whether the Cache-Control header includes no-store
+func noStore(headers http.Header) bool {
+	for _, value := range headers.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// copyResponse copies resp so callers cannot modify a cached body or headers
+func copyResponse(resp *Response) *Response {
+	return &Response{
+		StatusCode: resp.StatusCode,
+		Body:       append([]byte(nil), resp.Body...),
+		Headers:    resp.Headers.Clone(),
+	}
+}
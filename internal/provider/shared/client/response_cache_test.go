@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingServer answers every request with a JSON body and counts the GET
+// requests per path
+func countingServer(t *testing.T, header http.Header) (*httptest.Server, func(path string) int32) {
+	t.Helper()
+	var mu sync.Mutex
+	counts := map[string]*atomic.Int32{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			mu.Lock()
+			if counts[r.URL.Path] == nil {
+				counts[r.URL.Path] = &atomic.Int32{}
+			}
+			counts[r.URL.Path].Add(1)
+			mu.Unlock()
+		}
+		for key, values := range header {
+			w.Header()[key] = values
+		}
+		w.Write([]byte(`{"id":"g1"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, func(path string) int32 {
+		mu.Lock()
+		defer mu.Unlock()
+		if counts[path] == nil {
+			return 0
+		}
+		return counts[path].Load()
+	}
+}
+
+func newCachingClient(t *testing.T, serverURL string) *Client {
+	t.Helper()
+	cfg := DefaultConfig()
+	cfg.ResponseCacheTTL = time.Minute
+	return newTestClient(t, serverURL, cfg)
+}
+
+func get(t *testing.T, c *Client, path string, query map[string]string) *Response {
+	t.Helper()
+	resp, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: path, Query: query})
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", path, err)
+	}
+	return resp
+}
+
+func TestClient_ResponseCache_ServesRepeatedGets(t *testing.T) {
+	server, count := countingServer(t, nil)
+	c := newCachingClient(t, server.URL)
+
+	first := get(t, c, "groups/g1", nil)
+	first.Body[0] = 'x' // Callers cannot modify the cached body
+	second := get(t, c, "groups/g1", nil)
+	if got := count("/api/v1/groups/g1"); got != 1 {
+		t.Fatalf("got %d requests, want the second GET served from cache", got)
+	}
+	if string(second.Body) != `{"id":"g1"}` {
+		t.Errorf("cached body = %s", second.Body)
+	}
+
+	// A different query is a different entry
+	get(t, c, "groups/g1", map[string]string{"expand": "members"})
+	if got := count("/api/v1/groups/g1"); got != 2 {
+		t.Errorf("got %d requests, want a miss for a new query", got)
+	}
+}
+
+func TestClient_ResponseCache_Expires(t *testing.T) {
+	server, count := countingServer(t, nil)
+	c := newCachingClient(t, server.URL)
+	now := time.Now()
+	c.cache.now = func() time.Time { return now }
+
+	get(t, c, "groups/g1", nil)
+	now = now.Add(30 * time.Second)
+	get(t, c, "groups/g1", nil)
+	if got := count("/api/v1/groups/g1"); got != 1 {
+		t.Fatalf("got %d requests within the TTL, want 1", got)
+	}
+
+	now = now.Add(time.Minute)
+	get(t, c, "groups/g1", nil)
+	if got := count("/api/v1/groups/g1"); got != 2 {
+		t.Errorf("got %d requests after the TTL, want 2", got)
+	}
+}
+
+func TestClient_ResponseCache_WritesInvalidate(t *testing.T) {
+	server, count := countingServer(t, nil)
+	c := newCachingClient(t, server.URL)
+	if err := c.SetWriteAuth(c.auth); err != nil {
+		t.Fatalf("SetWriteAuth failed: %v", err)
+	}
+
+	for _, path := range []string{"groups", "groups/g1", "groups/g1/roles", "roles"} {
+		get(t, c, path, nil)
+	}
+
+	// Writes go through the write client, which shares the cache
+	if _, err := c.WriteClient().Do(context.Background(), &Request{Method: http.MethodPut, Path: "groups/g1", Body: map[string]string{"name": "ops"}}); err != nil {
+		t.Fatalf("PUT failed: %v", err)
+	}
+
+	for _, path := range []string{"groups", "groups/g1", "groups/g1/roles", "roles"} {
+		get(t, c, path, nil)
+	}
+	for path, want := range map[string]int32{
+		"/api/v1/groups":          2, // Collection above the write
+		"/api/v1/groups/g1":       2, // The written entity
+		"/api/v1/groups/g1/roles": 2, // Below the write
+		"/api/v1/roles":           1, // Unrelated, still cached
+	} {
+		if got := count(path); got != want {
+			t.Errorf("%s: got %d GETs, want %d", path, got, want)
+		}
+	}
+}
+
+func TestClient_ResponseCache_StreamedWritesInvalidate(t *testing.T) {
+	server, count := countingServer(t, nil)
+	c := newCachingClient(t, server.URL)
+
+	get(t, c, "groups/g1", nil)
+	resp, err := c.DoStream(context.Background(), &Request{Method: http.MethodDelete, Path: "groups/g1"})
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	resp.Body.Close()
+	get(t, c, "groups/g1", nil)
+
+	if got := count("/api/v1/groups/g1"); got != 2 {
+		t.Errorf("got %d GETs, want 2 after the streamed write", got)
+	}
+}
+
+func TestClient_ResponseCache_NoStore(t *testing.T) {
+	server, count := countingServer(t, http.Header{"Cache-Control": {"private, no-store"}})
+	c := newCachingClient(t, server.URL)
+
+	get(t, c, "groups/g1", nil)
+	get(t, c, "groups/g1", nil)
+	if got := count("/api/v1/groups/g1"); got != 2 {
+		t.Errorf("got %d requests, want no-store responses not cached", got)
+	}
+}
+
+func TestClient_ResponseCache_DisabledByDefault(t *testing.T) {
+	server, count := countingServer(t, nil)
+	c := newTestClient(t, server.URL, nil)
+
+	get(t, c, "groups/g1", nil)
+	get(t, c, "groups/g1", nil)
+	if got := count("/api/v1/groups/g1"); got != 2 {
+		t.Errorf("got %d requests, want every GET sent without a TTL", got)
+	}
+}
+
+func TestResponseCache_Bounded(t *testing.T) {
+	cache := newResponseCache(time.Minute, 2)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for _, key := range []string{"a", "b", "c"} {
+		cache.set(key, "/"+key, &Response{StatusCode: 200})
+		now = now.Add(time.Second)
+	}
+	if len(cache.entries) != 2 {
+		t.Fatalf("cache holds %d entries, want 2", len(cache.entries))
+	}
+	if _, ok := cache.get("a"); ok {
+		t.Error("the oldest entry should have been evicted")
+	}
+	if _, ok := cache.get("c"); !ok {
+		t.Error("the newest entry should be cached")
+	}
+}
+
+func TestResponseCache_Concurrent(t *testing.T) {
+	cache := newResponseCache(time.Minute, 8)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			key := string(rune('a' + i%10))
+			cache.set(key, "/groups/"+key, &Response{StatusCode: 200, Body: []byte(key)})
+			cache.get(key)
+			cache.invalidate("/groups")
+		}()
+	}
+	wg.Wait()
+	if len(cache.entries) > 8 {
+		t.Errorf("cache holds %d entries, want at most 8", len(cache.entries))
+	}
+}
@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_MaxResponseBytes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch r.URL.Path {
+		case "/api/v1/small":
+			_, _ = w.Write([]byte(strings.Repeat("a", 16)))
+		case "/api/v1/large":
+			_, _ = w.Write([]byte(strings.Repeat("a", 64)))
+		}
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, nil)
+	c.config.MaxResponseBytes = 32
+	ctx := context.Background()
+
+	t.Run("under limit", func(t *testing.T) {
+		resp, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "small"})
+		if err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+		if len(resp.Body) != 16 {
+			t.Fatalf("expected 16 byte body, got %d", len(resp.Body))
+		}
+	})
+
+	t.Run("over limit", func(t *testing.T) {
+		_, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "large"})
+		if !IsResponseTooLargeError(err) {
+			t.Fatalf("expected ResponseTooLargeError, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "GET /api/v1/large") {
+			t.Errorf("error %q should name the request", err)
+		}
+	})
+
+	t.Run("stream under limit", func(t *testing.T) {
+		resp, err := c.DoStream(ctx, &Request{Method: http.MethodGet, Path: "small"})
+		if err != nil {
+			t.Fatalf("DoStream failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("reading stream failed: %v", err)
+		}
+		if len(body) != 16 {
+			t.Fatalf("expected 16 byte body, got %d", len(body))
+		}
+	})
+
+	t.Run("stream over limit", func(t *testing.T) {
+		resp, err := c.DoStream(ctx, &Request{Method: http.MethodGet, Path: "large"})
+		if err != nil {
+			t.Fatalf("DoStream failed: %v", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if !IsResponseTooLargeError(err) {
+			t.Fatalf("expected ResponseTooLargeError, got %v", err)
+		}
+		if len(body) != 32 {
+			t.Errorf("expected reads to stop at the limit, got %d bytes", len(body))
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDefaultRetryPolicy_RetryAfter(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		retryAfter string
+		wantRetry  bool
+		wantMin    time.Duration
+		wantMax    time.Duration
+	}{
+		{"429 seconds", http.StatusTooManyRequests, "7", true, 7 * time.Second, 7 * time.Second},
+		{"503 HTTP-date", http.StatusServiceUnavailable, time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), true, 58 * time.Second, time.Minute},
+		{"429 past HTTP-date", http.StatusTooManyRequests, time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), true, 0, 0},
+		{"429 garbage uses the backoff", http.StatusTooManyRequests, "later", true, 0, 0},
+		{"500 ignores Retry-After", http.StatusInternalServerError, "7", true, 0, 0},
+		{"409 is not retried", http.StatusConflict, "7", false, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &Response{StatusCode: tt.status, Headers: http.Header{"Retry-After": []string{tt.retryAfter}}}
+			retry, after := DefaultRetryPolicy(&Request{Method: http.MethodGet, Path: "groups"}, resp, nil)
+			if retry != tt.wantRetry {
+				t.Errorf("retry = %v, want %v", retry, tt.wantRetry)
+			}
+			if after < tt.wantMin || after > tt.wantMax {
+				t.Errorf("after = %v, want between %v and %v", after, tt.wantMin, tt.wantMax)
+			}
+		})
+	}
+}
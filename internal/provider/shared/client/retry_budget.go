@@ -0,0 +1,69 @@
+package client
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRetryBudgetExhausted is wrapped by errors from requests that stopped
+// retrying because the client's shared retry budget ran out
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// DefaultRetryBudgetInterval is the refill period used when
+// Config.RetryBudgetInterval is not set
+const DefaultRetryBudgetInterval = time.Minute
+
+// retryBudget is a token bucket of retries shared by every request of a
+// Client. It holds up to capacity tokens and refills capacity tokens per
+// interval. It is safe for concurrent use; a nil *retryBudget allows every
+// retry.
+type retryBudget struct {
+	mu       sync.Mutex
+	capacity float64
+	tokens   float64
+	rate     float64 // tokens per second
+	last     time.Time
+	now      func() time.Time
+}
+
+// newRetryBudget returns a budget of retries per interval, or nil when
+// retries is not positive
+func newRetryBudget(retries int, interval time.Duration) *retryBudget {
+	if retries <= 0 {
+		return nil
+	}
+	if interval <= 0 {
+		interval = DefaultRetryBudgetInterval
+	}
+	b := &retryBudget{
+		capacity: float64(retries),
+		tokens:   float64(retries),
+		rate:     float64(retries) / interval.Seconds(),
+		now:      time.Now,
+	}
+	b.last = b.now()
+	return b
+}
+
+// allow takes one retry from the budget and reports whether one was left
+func (b *retryBudget) allow() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
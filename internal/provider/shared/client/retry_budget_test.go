@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClient_RetryBudget(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.RetryBudget = 3
+	cfg.RetryBudgetInterval = time.Hour
+	c := newTestClient(t, server.URL, cfg)
+	c.config.MaxRetries = 2
+	c.config.RetryWaitMin = time.Millisecond
+	c.config.RetryWaitMax = 2 * time.Millisecond
+	ctx := context.Background()
+
+	// First request: 1 attempt + 2 retries, budget 3 -> 1
+	_, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"})
+	if err == nil || errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("first request: want retries exhausted within budget, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("first request: got %d attempts, want 3", got)
+	}
+
+	// Second request: 1 attempt + 1 retry, budget 1 -> 0
+	_, err = c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"})
+	if !errors.Is(err, ErrRetryBudgetExhausted) {
+		t.Fatalf("second request: want ErrRetryBudgetExhausted, got %v", err)
+	}
+	if got := calls.Load(); got != 5 {
+		t.Fatalf("second request: got %d attempts, want 5", got)
+	}
+
+	// Budget is drained: later failures fail fast after one attempt
+	for i := 0; i < 3; i++ {
+		_, err = c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"})
+		if !errors.Is(err, ErrRetryBudgetExhausted) {
+			t.Fatalf("drained request %d: want ErrRetryBudgetExhausted, got %v", i, err)
+		}
+	}
+	if got := calls.Load(); got != 8 {
+		t.Fatalf("drained requests: got %d attempts, want 8", got)
+	}
+}
+
+func TestClient_RetryBudget_SharedWithWriteClient(t *testing.T) {
+	c := newTestClient(t, "http://example.invalid", &Config{RetryBudget: 1})
+	if err := c.SetWriteAuth(c.auth); err != nil {
+		t.Fatalf("SetWriteAuth failed: %v", err)
+	}
+	if c.WriteClient().budget != c.budget {
+		t.Fatal("write client should share the retry budget")
+	}
+}
+
+func TestRetryBudget_Refill(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := newRetryBudget(2, time.Minute)
+	b.now = func() time.Time { return now }
+	b.last = now
+
+	if !b.allow() || !b.allow() {
+		t.Fatal("full budget should allow 2 retries")
+	}
+	if b.allow() {
+		t.Fatal("drained budget should deny retries")
+	}
+
+	// One retry refills every 30s
+	now = now.Add(30 * time.Second)
+	if !b.allow() {
+		t.Fatal("budget should refill one retry after 30s")
+	}
+	if b.allow() {
+		t.Fatal("budget should only have refilled one retry")
+	}
+
+	// Refill is capped at the capacity
+	now = now.Add(time.Hour)
+	for i := 0; i < 2; i++ {
+		if !b.allow() {
+			t.Fatalf("retry %d after long idle should be allowed", i)
+		}
+	}
+	if b.allow() {
+		t.Fatal("refill should be capped at capacity")
+	}
+}
+
+func TestRetryBudget_Unlimited(t *testing.T) {
+	b := newRetryBudget(0, 0)
+	for i := 0; i < 100; i++ {
+		if !b.allow() {
+			t.Fatal("nil budget should allow every retry")
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+// RetryPolicy classifies the outcome of an HTTP attempt made by Client.Do.
+// It receives the request and either the response, whose Body is not read,
+// or the transport error, and reports whether to retry. A positive after
+// replaces the exponential backoff before the next attempt; zero keeps it.
+// Retries stay limited by MaxRetries and RetryBudget.
+type RetryPolicy func(req *Request, resp *Response, err error) (retry bool, after time.Duration)
+
+// DefaultRetryPolicy retries transport errors, 5xx responses and 429 Too
+// Many Requests. A 429 or 503 waits for its Retry-After header, in seconds or
+// as an HTTP-date; other failures and malformed headers use the exponential
+// backoff. It is used when Config.RetryPolicy is nil.
+func DefaultRetryPolicy(req *Request, resp *Response, err error) (bool, time.Duration) {
+	if err != nil {
+		return true, 0
+	}
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+		return true, auth.ParseRetryAfterHeader(resp.Headers.Get("Retry-After"))
+	case resp.StatusCode >= 500:
+		return true, 0
+	}
+	return false, 0
+}
+
+// retryPolicy returns the configured retry policy, DefaultRetryPolicy when unset
+func (c *Client) retryPolicy() RetryPolicy {
+	if c.config == nil || c.config.RetryPolicy == nil {
+		return DefaultRetryPolicy
+	}
+	return c.config.RetryPolicy
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer answers the first failures requests with status, then 200
+func flakyServer(t *testing.T, status int, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func newRetryClient(t *testing.T, serverURL string, policy RetryPolicy) *Client {
+	t.Helper()
+	c := newTestClient(t, serverURL, &Config{RetryPolicy: policy})
+	c.config.MaxRetries = 2
+	c.config.RetryWaitMin = time.Millisecond
+	c.config.RetryWaitMax = 2 * time.Millisecond
+	return c
+}
+
+func TestClient_RetryPolicy_Default(t *testing.T) {
+	// 409 is not retried by default
+	server, calls := flakyServer(t, http.StatusConflict, 1)
+	resp, err := newRetryClient(t, server.URL, nil).Do(context.Background(), &Request{Method: http.MethodPost, Path: "groups"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusConflict || calls.Load() != 1 {
+		t.Errorf("got status %d after %d attempts, want 409 after 1", resp.StatusCode, calls.Load())
+	}
+
+	// 503 is
+	server, calls = flakyServer(t, http.StatusServiceUnavailable, 1)
+	resp, err = newRetryClient(t, server.URL, nil).Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || calls.Load() != 2 {
+		t.Errorf("got status %d after %d attempts, want 200 after 2", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestClient_RetryPolicy_RetriesConflict(t *testing.T) {
+	server, calls := flakyServer(t, http.StatusConflict, 2)
+
+	var seen []string
+	policy := func(req *Request, resp *Response, err error) (bool, time.Duration) {
+		seen = append(seen, req.Method+" "+req.Path)
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return true, time.Millisecond
+		}
+		return DefaultRetryPolicy(req, resp, err)
+	}
+
+	resp, err := newRetryClient(t, server.URL, policy).Do(context.Background(), &Request{Method: http.MethodPost, Path: "groups"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 {
+		t.Errorf("got status %d after %d attempts, want 200 after 3", resp.StatusCode, calls.Load())
+	}
+	if len(seen) != 3 || seen[0] != "POST groups" {
+		t.Errorf("policy saw %q, want the API request on each of 3 attempts", seen)
+	}
+}
+
+func TestClient_RetryPolicy_AfterOverridesBackoff(t *testing.T) {
+	server, _ := flakyServer(t, http.StatusConflict, 1)
+	c := newRetryClient(t, server.URL, func(req *Request, resp *Response, err error) (bool, time.Duration) {
+		return resp != nil && resp.StatusCode == http.StatusConflict, 50 * time.Millisecond
+	})
+	// The built-in backoff would wait about a millisecond
+	start := time.Now()
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"}); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("retried after %v, want the policy delay of 50ms", elapsed)
+	}
+}
+
+func TestClient_RetryPolicy_DisablesRetries(t *testing.T) {
+	never := func(req *Request, resp *Response, err error) (bool, time.Duration) {
+		return false, 0
+	}
+
+	server, calls := flakyServer(t, http.StatusServiceUnavailable, 1)
+	resp, err := newRetryClient(t, server.URL, never).Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable || calls.Load() != 1 {
+		t.Errorf("got status %d after %d attempts, want 503 after 1", resp.StatusCode, calls.Load())
+	}
+
+	// Transport errors are returned as-is, without retrying
+	sendErr := errors.New("connection reset")
+	attempts := 0
+	c := newRetryClient(t, server.URL, never)
+	c.config.RequestInterceptors = []RequestInterceptor{func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, sendErr
+	}}
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"}); err == nil || attempts != 1 {
+		t.Errorf("got error %v after %d attempts, want the transport error after 1", err, attempts)
+	}
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+// scopedTokenServer issues tokens named after the requested scope and
+// records the token each API request carried, keyed by method
+type scopedTokenServer struct {
+	mu        sync.Mutex
+	issued    map[string]int
+	authByReq map[string]string
+}
+
+func (s *scopedTokenServer) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/oauth2/token" {
+		r.ParseForm()
+		scope := r.PostForm.Get("scope")
+		s.issued[scope]++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "token-" + strings.ReplaceAll(scope, " ", "+"),
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+		return
+	}
+
+	method := r.Method
+	if override := r.Header.Get(MethodOverrideHeader); override != "" {
+		method = override
+	}
+	s.authByReq[method] = r.Header.Get("Authorization")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{}`))
+}
+
+func newScopedTokenClient(t *testing.T, configure func(*Config)) (*Client, *scopedTokenServer) {
+	t.Helper()
+	state := &scopedTokenServer{issued: map[string]int{}, authByReq: map[string]string{}}
+	server := httptest.NewServer(http.HandlerFunc(state.handler))
+	t.Cleanup(server.Close)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.MaxRetries = 0
+	configure(cfg)
+	c, err := New(&auth.Config{
+		ClientID:         "test-client-id",
+		ClientSecret:     "test-client-secret",
+		TenantID:         "test-tenant",
+		AuthURL:          server.URL + "/oauth2/token",
+		APIURL:           server.URL,
+		Scopes:           []string{"iam:read", "iam:write"},
+		DisableDiscovery: true,
+	}, cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return c, state
+}
+
+func TestClient_ScopeDownReads(t *testing.T) {
+	const (
+		readToken  = "Bearer token-iam:read"
+		writeToken = "Bearer token-iam:read+iam:write"
+	)
+
+	tests := []struct {
+		name      string
+		configure func(*Config)
+		want      map[string]string
+	}{
+		{
+			name:      "disabled",
+			configure: func(cfg *Config) {},
+			want: map[string]string{
+				http.MethodGet:    writeToken,
+				http.MethodPost:   writeToken,
+				http.MethodPut:    writeToken,
+				http.MethodDelete: writeToken,
+			},
+		},
+		{
+			name:      "enabled",
+			configure: func(cfg *Config) { cfg.ScopeDownReads = true },
+			want: map[string]string{
+				http.MethodGet:    readToken,
+				http.MethodPost:   writeToken,
+				http.MethodPut:    writeToken,
+				http.MethodDelete: writeToken,
+			},
+		},
+		{
+			name: "enabled with method override",
+			configure: func(cfg *Config) {
+				cfg.ScopeDownReads = true
+				cfg.MethodOverride = true
+			},
+			want: map[string]string{
+				http.MethodGet:    readToken,
+				http.MethodPost:   writeToken,
+				http.MethodPut:    writeToken,
+				http.MethodDelete: writeToken,
+			},
+		},
+		{
+			name: "custom read scopes",
+			configure: func(cfg *Config) {
+				cfg.ScopeDownReads = true
+				cfg.ReadScopes = []string{"iam:groups:read"}
+			},
+			want: map[string]string{
+				http.MethodGet:    "Bearer token-iam:groups:read",
+				http.MethodPost:   writeToken,
+				http.MethodPut:    writeToken,
+				http.MethodDelete: writeToken,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, state := newScopedTokenClient(t, tt.configure)
+
+			for method := range tt.want {
+				req := &Request{Method: method, Path: "/groups"}
+				if method == http.MethodPost || method == http.MethodPut {
+					req.Body = map[string]string{"name": "g"}
+				}
+				if _, err := c.Do(context.Background(), req); err != nil {
+					t.Fatalf("%s failed: %v", method, err)
+				}
+			}
+
+			state.mu.Lock()
+			defer state.mu.Unlock()
+			for method, want := range tt.want {
+				if got := state.authByReq[method]; got != want {
+					t.Errorf("%s Authorization = %q, want %q", method, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_ScopeDownReads_CachesTokenPerScope(t *testing.T) {
+	c, state := newScopedTokenClient(t, func(cfg *Config) { cfg.ScopeDownReads = true })
+
+	for i := 0; i < 3; i++ {
+		for _, method := range []string{http.MethodGet, http.MethodDelete} {
+			if _, err := c.Do(context.Background(), &Request{Method: method, Path: "/groups/g1"}); err != nil {
+				t.Fatalf("%s failed: %v", method, err)
+			}
+		}
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	for scope, count := range state.issued {
+		if count != 1 {
+			t.Errorf("token for %q fetched %d times, want 1", scope, count)
+		}
+	}
+	if len(state.issued) != 2 {
+		t.Errorf("fetched tokens for %d scope sets, want 2: %v", len(state.issued), state.issued)
+	}
+}
@@ -0,0 +1,31 @@
+package client
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestClient_IAMClient_Shared(t *testing.T) {
+	c := newTestClient(t, "http://example.invalid", nil)
+
+	first := c.IAMClient()
+	if second := c.IAMClient(); first != second {
+		t.Fatal("IAMClient should return the same instance on every call")
+	}
+
+	var wg sync.WaitGroup
+	clients := make([]*ServiceClient, 16)
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			clients[i] = c.IAMClient()
+		}()
+	}
+	wg.Wait()
+	for i, sc := range clients {
+		if sc != first {
+			t.Fatalf("concurrent call %d returned a different instance", i)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newClientCertPEM returns a self-signed client certificate and its key in PEM form
+func newClientCertPEM(t *testing.T) (certPEM, keyPEM string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform-test-client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshalling key: %v", err)
+	}
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	return certPEM, keyPEM
+}
+
+func TestClient_TLS(t *testing.T) {
+	clientCertPEM, clientKeyPEM := newClientCertPEM(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(clientCertPEM))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs}
+	server.StartTLS()
+	defer server.Close()
+
+	// The httptest certificate is self-signed, so it serves as the custom CA
+	caPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	ctx := context.Background()
+
+	t.Run("custom CA and client certificate", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.CACertPEM = caPEM
+		cfg.ClientCertPEM = clientCertPEM
+		cfg.ClientKeyPEM = clientKeyPEM
+		c := newTestClient(t, server.URL, cfg)
+
+		if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"}); err != nil {
+			t.Fatalf("Do failed: %v", err)
+		}
+	})
+
+	t.Run("missing client certificate", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.CACertPEM = caPEM
+		c := newTestClient(t, server.URL, cfg)
+
+		if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"}); err == nil {
+			t.Fatal("expected handshake failure without a client certificate")
+		}
+	})
+
+	t.Run("unknown CA", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ClientCertPEM = clientCertPEM
+		cfg.ClientKeyPEM = clientKeyPEM
+		c := newTestClient(t, server.URL, cfg)
+
+		if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"}); err == nil {
+			t.Fatal("expected certificate verification failure")
+		}
+	})
+
+	t.Run("malformed PEM", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.BaseURL = server.URL
+		cfg.CACertPEM = "not a certificate"
+		if _, err := New(nil, cfg); err == nil {
+			t.Fatal("expected malformed CA certificate to be rejected")
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+// tokenRefreshServer issues numbered tokens and rejects API requests made
+// with any token but the latest one
+type tokenRefreshServer struct {
+	mu         sync.Mutex
+	issued     int
+	apiCalls   map[string]int
+	lastBodies []string
+	challenge  string
+}
+
+func (s *tokenRefreshServer) handler(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.URL.Path == "/oauth2/token" {
+		s.issued++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": fmt.Sprintf("token-%d", s.issued),
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+		})
+		return
+	}
+
+	s.apiCalls[r.Method]++
+	body, _ := io.ReadAll(r.Body)
+	s.lastBodies = append(s.lastBodies, string(body))
+	if r.Header.Get("Authorization") != fmt.Sprintf("Bearer token-%d", s.issued) || s.issued < 2 {
+		if s.challenge != "" {
+			w.Header().Set("WWW-Authenticate", s.challenge)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{}`))
+}
+
+func newTokenRefreshClient(t *testing.T, challenge string) (*Client, *tokenRefreshServer) {
+	t.Helper()
+	state := &tokenRefreshServer{apiCalls: map[string]int{}, challenge: challenge}
+	server := httptest.NewServer(http.HandlerFunc(state.handler))
+	t.Cleanup(server.Close)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = server.URL
+	cfg.MaxRetries = 0
+	c, err := New(&auth.Config{
+		ClientID:         "test-client-id",
+		ClientSecret:     "test-client-secret",
+		TenantID:         "test-tenant",
+		AuthURL:          server.URL + "/oauth2/token",
+		APIURL:           server.URL,
+		DisableDiscovery: true,
+	}, cfg)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return c, state
+}
+
+func TestClient_RefreshesTokenOn401(t *testing.T) {
+	c, state := newTokenRefreshClient(t, "")
+
+	resp, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200 after token refresh", resp.StatusCode)
+	}
+	if state.issued != 2 || state.apiCalls[http.MethodGet] != 2 {
+		t.Fatalf("expected 2 tokens and 2 GETs, got %d tokens and %d GETs", state.issued, state.apiCalls[http.MethodGet])
+	}
+
+	// The refreshed token is reused by later requests
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodPut, Path: "groups/g1", Body: map[string]string{"name": "g"}}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if state.issued != 2 || state.apiCalls[http.MethodPut] != 1 {
+		t.Fatalf("expected no further refresh, got %d tokens and %d PUTs", state.issued, state.apiCalls[http.MethodPut])
+	}
+}
+
+func TestClient_PostNotReplayedOnPlain401(t *testing.T) {
+	c, state := newTokenRefreshClient(t, "")
+
+	resp, err := c.Do(context.Background(), &Request{Method: http.MethodPost, Path: "groups", Body: map[string]string{"name": "g"}})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status = %d, want the original 401", resp.StatusCode)
+	}
+	if state.apiCalls[http.MethodPost] != 1 || state.issued != 1 {
+		t.Fatalf("POST should not be replayed, got %d POSTs and %d tokens", state.apiCalls[http.MethodPost], state.issued)
+	}
+}
+
+func TestClient_PostReplayedOnInvalidToken(t *testing.T) {
+	c, state := newTokenRefreshClient(t, `Bearer error="invalid_token", error_description="expired"`)
+
+	resp, err := c.Do(context.Background(), &Request{Method: http.MethodPost, Path: "groups", Body: map[string]string{"name": "g"}})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200 after replay", resp.StatusCode)
+	}
+	if state.apiCalls[http.MethodPost] != 2 {
+		t.Fatalf("expected POST to be replayed once, got %d", state.apiCalls[http.MethodPost])
+	}
+	if len(state.lastBodies) != 2 || state.lastBodies[1] != state.lastBodies[0] || state.lastBodies[1] == "" {
+		t.Fatalf("replayed body should match the original, got %q", state.lastBodies)
+	}
+}
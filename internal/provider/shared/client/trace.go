@@ -0,0 +1,142 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// traceMemberFields are JSON keys holding member ids, redacted from trace
+// files on top of the fields redacted from logs
+var traceMemberFields = map[string]bool{
+	"member":    true,
+	"members":   true,
+	"member_id": true,
+	"memberid":  true,
+	"user_id":   true,
+	"userid":    true,
+}
+
+// TraceEntry is one line of a trace file written when Config.TraceFile is set
+type TraceEntry struct {
+	Timestamp    string            `json:"timestamp"`
+	Method       string            `json:"method"`
+	Path         string            `json:"path"`
+	Status       int               `json:"status"`
+	DurationMs   int64             `json:"duration_ms"`
+	Headers      map[string]string `json:"headers,omitempty"`
+	RequestBody  string            `json:"request_body,omitempty"`
+	ResponseBody string            `json:"response_body,omitempty"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// traceWriter appends TraceEntry values to a file as JSON lines. Writes are
+// serialized so concurrent requests do not interleave.
+type traceWriter struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// openTraceWriter opens path for appending, creating it readable only by the
+// current user, or returns nil when path is empty
+func openTraceWriter(path string) (*traceWriter, error) {
+	if path == "" {
+		return nil, nil
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trace file: %w", err)
+	}
+	return &traceWriter{file: file}, nil
+}
+
+// write appends entry as a single line. A nil writer discards the entry.
+func (w *traceWriter) write(entry TraceEntry) error {
+	if w == nil {
+		return nil
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return os.ErrClosed
+	}
+	_, err = w.file.Write(line)
+	return err
+}
+
+// close closes the trace file; later writes fail with os.ErrClosed
+func (w *traceWriter) close() error {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
+
+// Close releases the trace file opened for Config.TraceFile. It is safe to
+// call on a client without tracing and more than once.
+func (c *Client) Close() error {
+	return c.tracer.close()
+}
+
+// trace writes a redacted entry for a completed request when tracing is
+// enabled. method is the method the caller asked for, before any
+// MethodOverride tunneling; status is 0 when no response was received.
+// Failures to write the trace never fail the request.
+func (c *Client) trace(method string, httpReq *http.Request, reqBody interface{}, status int, respBody []byte, start time.Time, err error) {
+	if c.tracer == nil {
+		return
+	}
+
+	entry := TraceEntry{
+		Timestamp:  start.UTC().Format(time.RFC3339Nano),
+		Method:     method,
+		Path:       templatePath(httpReq.URL.Path),
+		Status:     status,
+		DurationMs: time.Since(start).Milliseconds(),
+		Headers:    redactHeaders(httpReq.Header),
+	}
+	if reqBody != nil {
+		if encoded, marshalErr := json.Marshal(reqBody); marshalErr == nil {
+			entry.RequestBody = c.traceBody(encoded)
+		}
+	}
+	if len(respBody) > 0 {
+		entry.ResponseBody = c.traceBody(respBody)
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	_ = c.tracer.write(entry)
+}
+
+// traceBody redacts credentials and member ids from body and caps its size
+// like logged bodies
+func (c *Client) traceBody(body []byte) string {
+	maxBytes := DefaultMaxLogBodyBytes
+	if c.config != nil && c.config.MaxLogBodyBytes > 0 {
+		maxBytes = c.config.MaxLogBodyBytes
+	}
+	return truncateBody(redactBodyWith(body, c.isTraceRedactedField), maxBytes)
+}
+
+// isTraceRedactedField reports whether key is redacted from logs or holds member ids
+func (c *Client) isTraceRedactedField(key string) bool {
+	return c.isRedactedField(key) || traceMemberFields[strings.ToLower(key)]
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// W3C Trace Context headers propagated when Config.TraceParent is set
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+)
+
+// EnvTraceParent and EnvTraceState carry an incoming trace context in the
+// environment, following the OpenTelemetry convention for processes
+const (
+	EnvTraceParent = "TRACEPARENT"
+	EnvTraceState  = "TRACESTATE"
+)
+
+// maxTraceStateLength is the longest tracestate the W3C spec allows
+const maxTraceStateLength = 512
+
+// traceContext is the parsed incoming trace context. Every request is sent
+// as a new child span of it, sharing the trace ID and flags.
+type traceContext struct {
+	traceID string
+	flags   string
+	state   string
+}
+
+// newTraceContext parses a W3C traceparent and tracestate, or returns nil
+// when parent is empty
+func newTraceContext(parent, state string) (*traceContext, error) {
+	parent = strings.TrimSpace(parent)
+	state = strings.TrimSpace(state)
+	if parent == "" {
+		if state != "" {
+			return nil, fmt.Errorf("invalid trace context: tracestate is set without traceparent")
+		}
+		return nil, nil
+	}
+
+	fields := strings.Split(parent, "-")
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("invalid traceparent %q: want version-traceid-parentid-flags", parent)
+	}
+	version, traceID, parentID, flags := fields[0], fields[1], fields[2], fields[3]
+	switch {
+	case !isLowerHex(version, 2) || version == "ff":
+		return nil, fmt.Errorf("invalid traceparent %q: version must be two lowercase hex digits other than ff", parent)
+	case version == "00" && len(fields) != 4:
+		return nil, fmt.Errorf("invalid traceparent %q: version 00 has exactly four fields", parent)
+	case !isLowerHex(traceID, 32) || isAllZero(traceID):
+		return nil, fmt.Errorf("invalid traceparent %q: trace ID must be 32 lowercase hex digits, not all zero", parent)
+	case !isLowerHex(parentID, 16) || isAllZero(parentID):
+		return nil, fmt.Errorf("invalid traceparent %q: parent ID must be 16 lowercase hex digits, not all zero", parent)
+	case !isLowerHex(flags, 2):
+		return nil, fmt.Errorf("invalid traceparent %q: flags must be two lowercase hex digits", parent)
+	}
+
+	if len(state) > maxTraceStateLength {
+		return nil, fmt.Errorf("invalid tracestate: longer than %d characters", maxTraceStateLength)
+	}
+	for _, r := range state {
+		if r < 0x20 || r > 0x7e {
+			return nil, fmt.Errorf("invalid tracestate: contains characters outside printable ASCII")
+		}
+	}
+
+	return &traceContext{traceID: traceID, flags: flags, state: state}, nil
+}
+
+// ValidateTraceContext reports why parent and state cannot be used as
+// Config.TraceParent and Config.TraceState
+func ValidateTraceContext(parent, state string) error {
+	_, err := newTraceContext(parent, state)
+	return err
+}
+
+// traceParent returns a version 00 traceparent for a new span of the trace
+func (t *traceContext) traceParent() (string, error) {
+	var spanID [8]byte
+	for isAllZero(hex.EncodeToString(spanID[:])) {
+		if _, err := rand.Read(spanID[:]); err != nil {
+			return "", fmt.Errorf("failed to generate span ID: %w", err)
+		}
+	}
+	return fmt.Sprintf("00-%s-%s-%s", t.traceID, hex.EncodeToString(spanID[:]), t.flags), nil
+}
+
+// isLowerHex reports whether s is n lowercase hex digits
+func isLowerHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// isAllZero reports whether the hex string s is all zeros
+func isAllZero(s string) bool {
+	return strings.Trim(s, "0") == ""
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+var traceParentPattern = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})$`)
+
+func TestClient_TraceContextPropagation(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.TraceParent = testTraceParent
+	cfg.TraceState = "vendor=opaque,other=1"
+	c := newTestClient(t, server.URL, cfg)
+
+	ctx := context.Background()
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		if _, err := c.Do(ctx, &Request{Method: method, Path: "groups/g1"}); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+	}
+
+	spans := map[string]bool{}
+	for i, h := range headers {
+		match := traceParentPattern.FindStringSubmatch(h.Get(TraceParentHeader))
+		if match == nil {
+			t.Fatalf("request %d traceparent = %q, want a version 00 traceparent", i, h.Get(TraceParentHeader))
+		}
+		if match[1] != "4bf92f3577b34da6a3ce929d0e0e4736" || match[3] != "01" {
+			t.Errorf("request %d traceparent = %q, want the configured trace ID and flags", i, match[0])
+		}
+		if match[2] == "00f067aa0ba902b7" || match[2] == "0000000000000000" {
+			t.Errorf("request %d span ID = %s, want a new non-zero span", i, match[2])
+		}
+		spans[match[2]] = true
+		if got := h.Get(TraceStateHeader); got != "vendor=opaque,other=1" {
+			t.Errorf("request %d tracestate = %q, want the configured tracestate", i, got)
+		}
+	}
+	if len(spans) != len(headers) {
+		t.Errorf("got %d distinct span IDs for %d requests, want one per request", len(spans), len(headers))
+	}
+}
+
+func TestClient_TraceContextRequestHeaderWins(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get(TraceParentHeader)
+	}))
+	defer server.Close()
+
+	cfg := DefaultConfig()
+	cfg.TraceParent = testTraceParent
+	c := newTestClient(t, server.URL, cfg)
+
+	own := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-00"
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups", Headers: map[string]string{TraceParentHeader: own}}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got != own {
+		t.Errorf("traceparent = %q, want the request's own %q", got, own)
+	}
+}
+
+func TestClient_NoTraceContext(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, nil)
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups"}); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if got.Get(TraceParentHeader) != "" || got.Get(TraceStateHeader) != "" {
+		t.Errorf("trace headers = %q / %q, want none without a trace context", got.Get(TraceParentHeader), got.Get(TraceStateHeader))
+	}
+}
+
+func TestNew_InvalidTraceContext(t *testing.T) {
+	tests := []struct {
+		name, parent, state, wantErr string
+	}{
+		{"too few fields", "00-4bf92f3577b34da6a3ce929d0e0e4736-01", "", "want version-traceid-parentid-flags"},
+		{"version ff", "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "", "version"},
+		{"extra field on version 00", testTraceParent + "-extra", "", "exactly four fields"},
+		{"uppercase trace ID", "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", "", "trace ID"},
+		{"zero trace ID", "00-00000000000000000000000000000000-00f067aa0ba902b7-01", "", "trace ID"},
+		{"zero parent ID", "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", "", "parent ID"},
+		{"bad flags", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-1", "", "flags"},
+		{"tracestate without traceparent", "", "vendor=1", "without traceparent"},
+		{"tracestate too long", testTraceParent, "v=" + strings.Repeat("a", maxTraceStateLength), "longer than"},
+		{"tracestate control character", testTraceParent, "v=1\nx=2", "printable ASCII"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.TraceParent = tt.parent
+			cfg.TraceState = tt.state
+			_, err := New(&auth.Config{TestToken: "test-token", TenantID: "t"}, cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("New() error = %v, want it to mention %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewTraceContext_FutureVersion(t *testing.T) {
+	tc, err := newTraceContext("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", "")
+	if err != nil {
+		t.Fatalf("newTraceContext() error = %v", err)
+	}
+	parent, err := tc.traceParent()
+	if err != nil {
+		t.Fatalf("traceParent() error = %v", err)
+	}
+	if !traceParentPattern.MatchString(parent) {
+		t.Errorf("traceparent = %q, want it downgraded to version 00", parent)
+	}
+}
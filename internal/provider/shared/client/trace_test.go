@@ -0,0 +1,171 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+func readTraceEntries(t *testing.T, path string) []TraceEntry {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open trace file: %v", err)
+	}
+	defer f.Close()
+
+	var entries []TraceEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry TraceEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("trace line is not JSON: %q: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestClient_TraceFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"g1","members":["user:alice"],"access_token":"abc"}`))
+	}))
+	defer server.Close()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	cfg := DefaultConfig()
+	cfg.TraceFile = tracePath
+	c := newTestClient(t, server.URL, cfg)
+
+	_, err := c.Do(context.Background(), &Request{
+		Method: http.MethodPost,
+		Path:   "tenants/acme/groups/g1/members",
+		Body:   map[string]interface{}{"members": []string{"user:bob"}, "client_secret": "s3cret"},
+	})
+	if err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	entries := readTraceEntries(t, tracePath)
+	if len(entries) != 1 {
+		t.Fatalf("got %d trace lines, want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Method != http.MethodPost || entry.Status != http.StatusCreated {
+		t.Errorf("entry = %+v, want POST with status 201", entry)
+	}
+	if entry.Path != "/api/v1/tenants/:id/groups/:id/members" {
+		t.Errorf("Path = %q, want the templated path", entry.Path)
+	}
+	if entry.Timestamp == "" {
+		t.Error("Timestamp is empty")
+	}
+	if got := entry.Headers["Authorization"]; got != "[REDACTED]" {
+		t.Errorf("Authorization = %q, want [REDACTED]", got)
+	}
+
+	raw, err := os.ReadFile(tracePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"test-token", "s3cret", "abc", "user:alice", "user:bob", "acme"} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("trace file contains %q: %s", secret, raw)
+		}
+	}
+
+	if info, err := os.Stat(tracePath); err == nil && info.Mode().Perm() != 0o600 {
+		t.Errorf("trace file mode = %v, want 0600", info.Mode().Perm())
+	}
+}
+
+func TestClient_TraceFile_ConcurrentWritesAndErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	cfg := DefaultConfig()
+	cfg.TraceFile = tracePath
+	c := newTestClient(t, server.URL, cfg)
+	defer c.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups/g1"})
+		}()
+	}
+	wg.Wait()
+
+	// A transport failure is traced with status 0 and the error
+	server.Close()
+	if _, err := c.Do(context.Background(), &Request{Method: http.MethodGet, Path: "groups/g1"}); err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+
+	entries := readTraceEntries(t, tracePath)
+	if len(entries) != 21 {
+		t.Fatalf("got %d trace lines, want 21", len(entries))
+	}
+	last := entries[len(entries)-1]
+	if last.Status != 0 || last.Error == "" {
+		t.Errorf("last entry = %+v, want status 0 with an error", last)
+	}
+}
+
+func TestClient_TraceFile_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("a", 64)))
+	}))
+	defer server.Close()
+
+	tracePath := filepath.Join(t.TempDir(), "trace.jsonl")
+	cfg := DefaultConfig()
+	cfg.TraceFile = tracePath
+	cfg.MaxResponseBytes = 32
+	c := newTestClient(t, server.URL, cfg)
+	defer c.Close()
+
+	resp, err := c.DoStream(context.Background(), &Request{Method: http.MethodGet, Path: "groups"})
+	if err != nil {
+		t.Fatalf("DoStream failed: %v", err)
+	}
+	if entries := readTraceEntries(t, tracePath); len(entries) != 0 {
+		t.Fatalf("got %d trace lines before the stream was closed, want 0", len(entries))
+	}
+
+	// The entry is written on close, with the error that ended reading
+	io.ReadAll(resp.Body)
+	resp.Body.Close()
+	entries := readTraceEntries(t, tracePath)
+	if len(entries) != 1 {
+		t.Fatalf("got %d trace lines, want 1", len(entries))
+	}
+	if entry := entries[0]; entry.Status != http.StatusOK || !strings.Contains(entry.Error, "exceeds") {
+		t.Errorf("entry = %+v, want status 200 with the size limit error", entry)
+	}
+}
+
+func TestNew_TraceFileUnwritable(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.TraceFile = filepath.Join(t.TempDir(), "missing", "trace.jsonl")
+	if _, err := New(&auth.Config{TestToken: "test-token", TenantID: "t"}, cfg); err == nil {
+		t.Fatal("expected an error for a trace file in a missing directory")
+	}
+}
@@ -44,3 +44,50 @@ func TestBuildURL(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_ResolvePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		path     string
+		want     string
+	}{
+		{"relative with default base path", "", "tenants/t/groups", "/api/v1/tenants/t/groups"},
+		{"relative with custom base path", "/iam/v1/", "tenants/t/groups", "/iam/v1/tenants/t/groups"},
+		{"absolute path is unchanged", "/iam/v1", "/api/v2/tenants/t/groups/g/roles", "/api/v2/tenants/t/groups/g/roles"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{config: &Config{BasePath: tt.basePath}}
+			if got := c.resolvePath(tt.path); got != tt.want {
+				t.Errorf("resolvePath(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_IAMClientUsesBasePath(t *testing.T) {
+	c := &Client{config: &Config{BasePath: "/iam/v1"}}
+	if got := c.IAMClient().endpoint; got != "/iam/v1" {
+		t.Errorf("IAMClient endpoint = %q, want %q", got, "/iam/v1")
+	}
+
+	c = &Client{config: &Config{BasePath: "/iam/v1", IAMEndpoint: "/custom"}}
+	if got := c.IAMClient().endpoint; got != "/custom" {
+		t.Errorf("IAMClient endpoint = %q, want %q", got, "/custom")
+	}
+}
+
+func TestBuildURL_EscapedSegments(t *testing.T) {
+	baseURL, _ := url.Parse("https://iam-api.retailsvc.com")
+	c := &Client{baseURL: baseURL}
+
+	u := c.buildURL("/api/v1/tenants/t/groups/ops%2Fteam%20a")
+	if got, want := u.EscapedPath(), "/api/v1/tenants/t/groups/ops%2Fteam%20a"; got != want {
+		t.Errorf("escaped path = %s, want %s", got, want)
+	}
+	if got, want := u.Path, "/api/v1/tenants/t/groups/ops/team a"; got != want {
+		t.Errorf("path = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+// SetWriteAuth configures a separate credential for mutating requests, so the
+// credential passed to New can be limited to read scopes. The write client
+// shares the configuration, retry metrics, retry budget, deprecation notices,
+// trace context and trace file of c.
+func (c *Client) SetWriteAuth(writeAuth *auth.Config) error {
+	if writeAuth == nil {
+		c.writer = nil
+		return nil
+	}
+
+	httpClient, err := newHTTPClient(writeAuth, c.config)
+	if err != nil {
+		return fmt.Errorf("failed to create write client: %w", err)
+	}
+
+	c.writer = &Client{
+		config:       c.config,
+		httpClient:   httpClient,
+		auth:         writeAuth,
+		baseURL:      c.baseURL,
+		tenantID:     c.tenantID,
+		metrics:      c.metrics,
+		budget:       c.budget,
+		cache:        c.cache,
+		slots:        c.slots,
+		traceCtx:     c.traceCtx,
+		deprecations: c.deprecations,
+		tracer:       c.tracer,
+	}
+	return nil
+}
+
+// WriteClient returns the client to use for mutating requests.
+// It is c itself when no separate write credential is configured.
+func (c *Client) WriteClient() *Client {
+	if c.writer != nil {
+		return c.writer
+	}
+	return c
+}
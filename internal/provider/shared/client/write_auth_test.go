@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/extenda/hiiretail-terraform-providers/internal/provider/shared/auth"
+)
+
+func TestClient_WriteAuth(t *testing.T) {
+	var mu sync.Mutex
+	tokens := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		tokens[r.Method] = r.Header.Get("Authorization")
+		mu.Unlock()
+		w.Header().Set("Deprecation", "true")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := newTestClient(t, server.URL, nil)
+	if c.WriteClient() != c {
+		t.Fatal("WriteClient should return the client itself without a write credential")
+	}
+	if err := c.SetWriteAuth(&auth.Config{TestToken: "write-token", TenantID: "t"}); err != nil {
+		t.Fatalf("SetWriteAuth failed: %v", err)
+	}
+
+	ctx := context.Background()
+	if _, err := c.Do(ctx, &Request{Method: http.MethodGet, Path: "groups"}); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if _, err := c.WriteClient().Do(ctx, &Request{Method: http.MethodPost, Path: "groups"}); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	if got := tokens[http.MethodGet]; got != "Bearer test-token" {
+		t.Errorf("read used %q, want the read credential", got)
+	}
+	if got := tokens[http.MethodPost]; got != "Bearer write-token" {
+		t.Errorf("write used %q, want the write credential", got)
+	}
+	// Notices from the write client are reported through the read client
+	if notices := c.DeprecationNotices(); len(notices) != 2 {
+		t.Errorf("expected notices from both clients, got %+v", notices)
+	}
+
+	if err := c.SetWriteAuth(nil); err != nil || c.WriteClient() != c {
+		t.Errorf("SetWriteAuth(nil) should remove the write client, got %v", err)
+	}
+}
@@ -0,0 +1,429 @@
+// Package jsonschema validates JSON documents against a subset of JSON
+// Schema (draft 2020-12) without external dependencies.
+//
+// Supported keywords: type, enum, const, properties, required,
+// additionalProperties, items, minItems, maxItems, minLength, maxLength,
+// pattern, minimum, maximum, exclusiveMinimum and exclusiveMaximum.
+// Annotations ($schema, $id, $comment, title, description, default, examples,
+// format) are accepted and ignored. Any other keyword, such as $ref or
+// anyOf, is rejected by Compile so a schema is never silently half-applied.
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Violation is a single way a document does not match a schema
+type Violation struct {
+	// Path is the JSON Pointer of the offending value, "" for the document root
+	Path    string
+	Message string
+}
+
+func (v Violation) String() string {
+	path := v.Path
+	if path == "" {
+		path = "(root)"
+	}
+	return path + ": " + v.Message
+}
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	types                []string
+	enum                 []interface{}
+	constValue           interface{}
+	hasConst             bool
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	noAdditional         bool
+	items                *Schema
+	minItems, maxItems   *int
+	minLength, maxLength *int
+	pattern              *regexp.Regexp
+	minimum, maximum     *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+}
+
+var annotationKeywords = map[string]bool{
+	"$schema": true, "$id": true, "$comment": true, "title": true,
+	"description": true, "default": true, "examples": true, "format": true,
+}
+
+var simpleTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+// Compile parses a JSON Schema document
+func Compile(doc string) (*Schema, error) {
+	value, err := Decode([]byte(doc))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema JSON: %w", err)
+	}
+	return compile(value, "")
+}
+
+// Decode decodes a JSON document keeping numbers as json.Number, the form
+// Validate expects
+func Decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("unexpected data after the document")
+	}
+	return value, nil
+}
+
+func compile(value interface{}, at string) (*Schema, error) {
+	if b, ok := value.(bool); ok {
+		// true accepts everything, false nothing
+		if b {
+			return &Schema{}, nil
+		}
+		return &Schema{types: []string{}}, nil
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema at %q must be an object or boolean", at)
+	}
+
+	s := &Schema{}
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		raw := obj[key]
+		keyAt := at + "/" + key
+		var err error
+		switch key {
+		case "type":
+			s.types, err = compileTypes(raw, keyAt)
+		case "enum":
+			values, ok := raw.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s must be an array", keyAt)
+			}
+			s.enum = values
+		case "const":
+			s.constValue, s.hasConst = raw, true
+		case "properties":
+			props, ok := raw.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s must be an object", keyAt)
+			}
+			s.properties = make(map[string]*Schema, len(props))
+			for name, sub := range props {
+				if s.properties[name], err = compile(sub, keyAt+"/"+escape(name)); err != nil {
+					return nil, err
+				}
+			}
+		case "required":
+			names, ok := raw.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s must be an array of strings", keyAt)
+			}
+			for _, name := range names {
+				str, ok := name.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s must be an array of strings", keyAt)
+				}
+				s.required = append(s.required, str)
+			}
+		case "additionalProperties":
+			if b, ok := raw.(bool); ok && !b {
+				s.noAdditional = true
+			} else if !ok {
+				s.additionalProperties, err = compile(raw, keyAt)
+			}
+		case "items":
+			s.items, err = compile(raw, keyAt)
+		case "minItems":
+			s.minItems, err = compileCount(raw, keyAt)
+		case "maxItems":
+			s.maxItems, err = compileCount(raw, keyAt)
+		case "minLength":
+			s.minLength, err = compileCount(raw, keyAt)
+		case "maxLength":
+			s.maxLength, err = compileCount(raw, keyAt)
+		case "pattern":
+			str, ok := raw.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must be a string", keyAt)
+			}
+			if s.pattern, err = regexp.Compile(str); err != nil {
+				return nil, fmt.Errorf("%s: %w", keyAt, err)
+			}
+		case "minimum":
+			s.minimum, err = compileNumber(raw, keyAt)
+		case "maximum":
+			s.maximum, err = compileNumber(raw, keyAt)
+		case "exclusiveMinimum":
+			s.exclusiveMinimum, err = compileNumber(raw, keyAt)
+		case "exclusiveMaximum":
+			s.exclusiveMaximum, err = compileNumber(raw, keyAt)
+		default:
+			if !annotationKeywords[key] {
+				return nil, fmt.Errorf("unsupported schema keyword %q at %q", key, at)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func compileTypes(raw interface{}, at string) ([]string, error) {
+	var names []interface{}
+	switch v := raw.(type) {
+	case string:
+		names = []interface{}{v}
+	case []interface{}:
+		names = v
+	default:
+		return nil, fmt.Errorf("%s must be a string or an array of strings", at)
+	}
+	types := make([]string, 0, len(names))
+	for _, name := range names {
+		str, ok := name.(string)
+		if !ok || !simpleTypes[str] {
+			return nil, fmt.Errorf("%s: unknown type %v", at, name)
+		}
+		types = append(types, str)
+	}
+	return types, nil
+}
+
+func compileCount(raw interface{}, at string) (*int, error) {
+	n, ok := raw.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a non-negative integer", at)
+	}
+	i, err := strconv.Atoi(string(n))
+	if err != nil || i < 0 {
+		return nil, fmt.Errorf("%s must be a non-negative integer", at)
+	}
+	return &i, nil
+}
+
+func compileNumber(raw interface{}, at string) (*float64, error) {
+	n, ok := raw.(json.Number)
+	if !ok {
+		return nil, fmt.Errorf("%s must be a number", at)
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", at)
+	}
+	return &f, nil
+}
+
+// Validate reports every way value, as returned by Decode, violates the
+// schema, ordered by path
+func (s *Schema) Validate(value interface{}) []Violation {
+	var violations []Violation
+	s.validate(value, "", &violations)
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Path < violations[j].Path })
+	return violations
+}
+
+func (s *Schema) validate(value interface{}, at string, out *[]Violation) {
+	fail := func(format string, args ...interface{}) {
+		*out = append(*out, Violation{Path: at, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.types != nil && !matchesType(value, s.types) {
+		if len(s.types) == 0 {
+			fail("no value is allowed here")
+		} else {
+			fail("expected %s, got %s", strings.Join(s.types, " or "), typeName(value))
+		}
+		return
+	}
+	if s.hasConst && !equal(value, s.constValue) {
+		fail("must be %s", encode(s.constValue))
+	}
+	if s.enum != nil && !containsValue(s.enum, value) {
+		allowed := make([]string, len(s.enum))
+		for i, v := range s.enum {
+			allowed[i] = encode(v)
+		}
+		fail("must be one of %s", strings.Join(allowed, ", "))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				fail("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			childAt := at + "/" + escape(name)
+			if sub, ok := s.properties[name]; ok {
+				sub.validate(v[name], childAt, out)
+			} else if s.noAdditional {
+				*out = append(*out, Violation{Path: childAt, Message: "property is not allowed"})
+			} else if s.additionalProperties != nil {
+				s.additionalProperties.validate(v[name], childAt, out)
+			}
+		}
+	case []interface{}:
+		if s.minItems != nil && len(v) < *s.minItems {
+			fail("must have at least %d items, got %d", *s.minItems, len(v))
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			fail("must have at most %d items, got %d", *s.maxItems, len(v))
+		}
+		if s.items != nil {
+			for i, item := range v {
+				s.items.validate(item, at+"/"+strconv.Itoa(i), out)
+			}
+		}
+	case string:
+		length := len([]rune(v))
+		if s.minLength != nil && length < *s.minLength {
+			fail("must be at least %d characters, got %d", *s.minLength, length)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			fail("must be at most %d characters, got %d", *s.maxLength, length)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			fail("must match pattern %q", s.pattern.String())
+		}
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return
+		}
+		if s.minimum != nil && f < *s.minimum {
+			fail("must be >= %v", *s.minimum)
+		}
+		if s.maximum != nil && f > *s.maximum {
+			fail("must be <= %v", *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && f <= *s.exclusiveMinimum {
+			fail("must be > %v", *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && f >= *s.exclusiveMaximum {
+			fail("must be < %v", *s.exclusiveMaximum)
+		}
+	}
+}
+
+func matchesType(value interface{}, types []string) bool {
+	actual := typeName(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName returns the JSON Schema type of a decoded value; numbers without
+// a fractional part are "integer"
+func typeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if equal(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// equal compares decoded values, treating numbers by value
+func equal(a, b interface{}) bool {
+	if an, ok := a.(json.Number); ok {
+		bn, ok := b.(json.Number)
+		if !ok {
+			return false
+		}
+		af, aerr := an.Float64()
+		bf, berr := bn.Float64()
+		return aerr == nil && berr == nil && af == bf
+	}
+	switch av := a.(type) {
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func encode(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(encoded)
+}
+
+// escape encodes a property name as a JSON Pointer reference token
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,122 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+const storeSchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["region", "store"],
+  "additionalProperties": false,
+  "properties": {
+    "region": {"type": "string", "enum": ["north", "south"]},
+    "store": {
+      "type": "object",
+      "required": ["id"],
+      "properties": {
+        "id": {"type": "integer", "minimum": 1},
+        "name": {"type": "string", "minLength": 2, "pattern": "^[A-Z]"}
+      }
+    },
+    "tags": {"type": "array", "maxItems": 2, "items": {"type": "string"}},
+    "weight": {"type": "number", "exclusiveMaximum": 1}
+  }
+}`
+
+func TestSchema_Validate(t *testing.T) {
+	schema, err := Compile(storeSchema)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{
+			name: "valid",
+			doc:  `{"region":"north","store":{"id":7,"name":"Main"},"tags":["a"],"weight":0.5}`,
+		},
+		{
+			name: "missing required field",
+			doc:  `{"store":{"id":7}}`,
+			want: []string{`(root): missing required property "region"`},
+		},
+		{
+			name: "nested violations",
+			doc:  `{"region":"east","store":{"id":0,"name":"m"},"tags":["a",1,"c"],"weight":1,"extra":true}`,
+			want: []string{
+				`/extra: property is not allowed`,
+				`/region: must be one of "north", "south"`,
+				`/store/id: must be >= 1`,
+				`/store/name: must be at least 2 characters, got 1`,
+				`/store/name: must match pattern "^[A-Z]"`,
+				`/tags: must have at most 2 items, got 3`,
+				`/tags/1: expected string, got integer`,
+				`/weight: must be < 1`,
+			},
+		},
+		{
+			name: "integer type",
+			doc:  `{"region":"north","store":{"id":1.5}}`,
+			want: []string{`/store/id: expected integer, got number`},
+		},
+		{
+			name: "wrong root type",
+			doc:  `[1]`,
+			want: []string{`(root): expected object, got array`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := Decode([]byte(tt.doc))
+			if err != nil {
+				t.Fatalf("Decode failed: %v", err)
+			}
+			var got []string
+			for _, v := range schema.Validate(value) {
+				got = append(got, v.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompile_Errors(t *testing.T) {
+	for _, doc := range []string{
+		`not json`,
+		`"string"`,
+		`{"$ref":"#/defs/x"}`,
+		`{"type":"decimal"}`,
+		`{"required":"id"}`,
+		`{"properties":{"a":{"anyOf":[]}}}`,
+		`{"pattern":"("}`,
+		`{"minLength":-1}`,
+	} {
+		if _, err := Compile(doc); err == nil {
+			t.Errorf("Compile(%s) succeeded, want an error", doc)
+		}
+	}
+}
+
+func TestSchema_BooleanSchemas(t *testing.T) {
+	schema, err := Compile(`{"properties":{"any":true,"none":false},"additionalProperties":{"type":"string"}}`)
+	if err != nil {
+		t.Fatalf("Compile failed: %v", err)
+	}
+	value, _ := Decode([]byte(`{"any":[1],"none":1,"other":2}`))
+	var got []string
+	for _, v := range schema.Validate(value) {
+		got = append(got, v.String())
+	}
+	want := []string{"/none: no value is allowed here", "/other: expected string, got integer"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("violations = %q, want %q", got, want)
+	}
+}
@@ -3,12 +3,15 @@ package testutils
 // Package testutils provides common utilities for testing the IAM provider
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestEnvironment holds configuration for test execution
@@ -827,25 +830,101 @@ func (env *TestEnvironment) generateAlias(permId string) string {
 	return action + " " + resource
 }
 
+// readinessPaths are the IAM endpoints WaitUntilReady polls after the OAuth2
+// token endpoint
+var readinessPaths = []string{"/iam/v1/groups", "/iam/v1/custom-roles"}
+
+const (
+	// DefaultReadyTimeout bounds ValidateMockServerReady
+	DefaultReadyTimeout = 10 * time.Second
+
+	readyBackoffMin = 10 * time.Millisecond
+	readyBackoffMax = 500 * time.Millisecond
+)
+
 // ValidateMockServerReady ensures the mock server is fully operational before running tests
 func (env *TestEnvironment) ValidateMockServerReady(t *testing.T) {
 	if env.MockServer == nil {
 		t.Fatal("Mock server not initialized")
 	}
 
-	// Test OAuth2 endpoint to ensure it's responsive
-	resp, err := http.Post(env.BaseURL+"/oauth2/token", "application/x-www-form-urlencoded",
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultReadyTimeout)
+	defer cancel()
+	if err := env.WaitUntilReady(ctx); err != nil {
+		t.Fatalf("Mock server not ready: %v", err)
+	}
+
+	t.Logf("Mock server validated and ready at %s", env.BaseURL)
+}
+
+// WaitUntilReady polls the OAuth2 token endpoint, then the groups and custom
+// roles endpoints with the issued token, until each answers 200 OK. Failed
+// probes are retried with exponential backoff. When ctx ends first the error
+// names the endpoint that was not ready and its last failure.
+func (env *TestEnvironment) WaitUntilReady(ctx context.Context) error {
+	backoff := readyBackoffMin
+	for {
+		endpoint, err := env.probe(ctx)
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%s not ready: %w", endpoint, err)
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, readyBackoffMax)
+	}
+}
+
+// probe requests a token and each readiness path once, returning the first
+// endpoint that failed
+func (env *TestEnvironment) probe(ctx context.Context) (string, error) {
+	tokenURL := env.BaseURL + "/oauth2/token"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL,
 		strings.NewReader("grant_type=client_credentials&client_id=test&client_secret=test"))
 	if err != nil {
-		t.Fatalf("Mock server OAuth2 endpoint not responsive: %v", err)
+		return tokenURL, err
 	}
-	resp.Body.Close()
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	if resp.StatusCode != http.StatusOK {
-		t.Fatalf("Mock server OAuth2 endpoint returned unexpected status: %d", resp.StatusCode)
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := doReadinessRequest(req, &token); err != nil {
+		return tokenURL, err
 	}
 
-	t.Logf("Mock server validated and ready at %s", env.BaseURL)
+	for _, path := range readinessPaths {
+		endpoint := env.BaseURL + path
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+		if err != nil {
+			return endpoint, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token.AccessToken)
+		if err := doReadinessRequest(req, nil); err != nil {
+			return endpoint, err
+		}
+	}
+	return "", nil
+}
+
+// doReadinessRequest sends req and requires a 200 OK, decoding the body into
+// out when it is not nil
+func doReadinessRequest(req *http.Request, out interface{}) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
 }
 
 // SimulateError configures the mock server to return specific error responses
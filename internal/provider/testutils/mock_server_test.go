@@ -0,0 +1,58 @@
+package testutils
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitUntilReady_ServerUp(t *testing.T) {
+	env := SetupTestEnvironment(t)
+	env.SetupMockServer(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	start := time.Now()
+	if err := env.WaitUntilReady(ctx); err != nil {
+		t.Fatalf("WaitUntilReady() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("WaitUntilReady() took %v, want it to return promptly", elapsed)
+	}
+}
+
+func TestWaitUntilReady_WedgedEndpoint(t *testing.T) {
+	env := SetupTestEnvironment(t)
+	env.SetupMockServer(t)
+
+	// Front the mock server with a custom roles endpoint that never answers
+	upstream := env.MockServer.Config.Handler
+	wedged := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/iam/v1/custom-roles") {
+			<-r.Context().Done()
+			return
+		}
+		upstream.ServeHTTP(w, r)
+	}))
+	t.Cleanup(wedged.Close)
+	env.BaseURL = wedged.URL
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := env.WaitUntilReady(ctx)
+	if err == nil {
+		t.Fatal("WaitUntilReady() succeeded, want a timeout")
+	}
+	if !strings.Contains(err.Error(), "/iam/v1/custom-roles not ready") {
+		t.Errorf("WaitUntilReady() error = %v, want it to name the custom roles endpoint", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("WaitUntilReady() took %v, want it to stop at the deadline", elapsed)
+	}
+}